@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 	"time-tracker/internal/domain"
 	"time-tracker/internal/errors"
 	"time-tracker/internal/repository/sqlite"
@@ -17,6 +18,33 @@ type DashboardData = services.DashboardData
 type DayStatistics = services.DayStatistics
 type TimeRange = services.TimeRange
 type TimeEntryWithTask = services.TimeEntryWithTask
+type TimeEntryUpdate = services.TimeEntryUpdate
+type TimeEntryEdit = services.TimeEntryEdit
+type TaskMerge = services.TaskMerge
+type ImportEntry = services.ImportEntry
+type ImportResult = services.ImportResult
+type ImportSkip = services.ImportSkip
+type ProjectInfo = services.ProjectInfo
+type ProjectTotal = services.ProjectTotal
+type StartOptions = services.StartOptions
+type TimeEntryFilter = services.TimeEntryFilter
+type Operation = services.Operation
+type Event = services.Event
+type ReportOptions = services.ReportOptions
+type Report = services.Report
+type ReportRow = services.ReportRow
+type Timeline = services.Timeline
+type TimelineRow = services.TimelineRow
+type TimelineSegment = services.TimelineSegment
+type ForgottenTimer = services.ForgottenTimer
+type ForgottenTimerResolution = services.ForgottenTimerResolution
+type DoctorOptions = services.DoctorOptions
+type DoctorProblem = services.DoctorProblem
+type DoctorReport = services.DoctorReport
+type BackupInfo = services.BackupInfo
+type BackupRetention = services.BackupRetention
+type AutoBackup = services.AutoBackup
+type BackupRestore = services.BackupRestore
 
 // Re-export constants from services
 const (
@@ -24,8 +52,41 @@ const (
 	SortByOldestFirst = services.SortByOldestFirst
 	SortByName        = services.SortByName
 	SortByDuration    = services.SortByDuration
+
+	EventCurrent     = services.EventCurrent
+	EventStarted     = services.EventStarted
+	EventStopped     = services.EventStopped
+	EventResumed     = services.EventResumed
+	EventTaskRenamed = services.EventTaskRenamed
+	EventDeleted     = services.EventDeleted
+	EventUndone      = services.EventUndone
+
+	ReportPeriodDay      = services.ReportPeriodDay
+	ReportPeriodWeek     = services.ReportPeriodWeek
+	ReportPeriodMonth    = services.ReportPeriodMonth
+	ReportGroupByTask    = services.ReportGroupByTask
+	ReportGroupByProject = services.ReportGroupByProject
+	ReportGroupByTag     = services.ReportGroupByTag
+	ReportGroupByDay     = services.ReportGroupByDay
+
+	ForgottenTimerStop = services.ForgottenTimerStop
+	ForgottenTimerCap  = services.ForgottenTimerCap
+	ForgottenTimerKeep = services.ForgottenTimerKeep
+
+	ProblemOverlap         = services.ProblemOverlap
+	ProblemMultipleRunning = services.ProblemMultipleRunning
+	ProblemEndBeforeStart  = services.ProblemEndBeforeStart
+	ProblemTooLong         = services.ProblemTooLong
+	ProblemOrphanedEntry   = services.ProblemOrphanedEntry
+	ProblemDuplicateTask   = services.ProblemDuplicateTask
+	FixTrimOverlaps        = services.FixTrimOverlaps
+	FixStopExtras          = services.FixStopExtras
+	FixMergeDuplicates     = services.FixMergeDuplicates
 )
 
+// FixStrategies lists every fix strategy CheckConsistency can apply, in the order it applies them
+var FixStrategies = services.FixStrategies
+
 // BusinessAPI defines the business-logic-only interface for time tracking operations
 type BusinessAPI interface {
 	// ========== Task Management Workflows ==========
@@ -33,18 +94,104 @@ type BusinessAPI interface {
 	// StartNewTask creates a new task and starts tracking time, stopping any running tasks
 	StartNewTask(ctx context.Context, taskName string) (*TaskSession, error)
 
+	// StartNewTaskWithOptions starts a task like StartNewTask, filing it under the given project
+	StartNewTaskWithOptions(ctx context.Context, taskName string, opts StartOptions) (*TaskSession, error)
+
 	// ResumeTask starts a new time entry for an existing task, stopping running tasks
 	ResumeTask(ctx context.Context, taskID int64) (*TaskSession, error)
 
+	// ResumeTaskAt resumes a task like ResumeTask, as of the given time rather than now
+	ResumeTaskAt(ctx context.Context, taskID int64, at time.Time) (*TaskSession, error)
+
+	// AddTimeEntry records a completed time entry for a task after the fact, rejecting overlaps
+	AddTimeEntry(ctx context.Context, taskName string, start time.Time, end time.Time) (*TaskSession, error)
+
+	// EditTimeEntry changes the times or task of an existing time entry, rejecting overlaps
+	EditTimeEntry(ctx context.Context, entryID int64, update TimeEntryUpdate) (*TimeEntryEdit, error)
+
 	// StopAllRunningTasks stops all currently running time entries
 	StopAllRunningTasks(ctx context.Context) ([]*domain.TimeEntry, error)
 
+	// StopAllRunningTasksAt stops all running time entries at the given time, which may not be before they started
+	StopAllRunningTasksAt(ctx context.Context, at time.Time) ([]*domain.TimeEntry, error)
+
+	// FindForgottenTimer returns the running entry that has gone on for longer than after, or nil if there is none
+	FindForgottenTimer(ctx context.Context, after time.Duration) (*ForgottenTimer, error)
+
+	// ResolveForgottenTimer stops, caps or keeps running a forgotten entry, journaling the decision
+	ResolveForgottenTimer(ctx context.Context, entryID int64, resolution ForgottenTimerResolution) (*TimeEntryWithTask, error)
+
+	// PauseTask ends the running entry as a pause that ContinueTask can pick up again
+	PauseTask(ctx context.Context) (*TaskSession, error)
+
+	// ContinueTask starts a new segment of the paused task, linked to the paused entry
+	ContinueTask(ctx context.Context) (*TaskSession, error)
+
 	// DeleteTaskWithEntries deletes a task and all its time entries (safe cascade delete)
 	DeleteTaskWithEntries(ctx context.Context, taskID int64) error
 
 	// UpdateTaskName safely updates a task name with validation
 	UpdateTaskName(ctx context.Context, taskID int64, newName string) (*domain.Task, error)
 
+	// MergeTasks moves all time entries of one task into another and deletes the emptied task
+	MergeTasks(ctx context.Context, fromID int64, intoID int64) (*TaskMerge, error)
+
+	// ImportTimeEntries inserts entries from an export in a single transaction, creating missing tasks and skipping duplicates
+	ImportTimeEntries(ctx context.Context, entries []ImportEntry, dryRun bool) (*ImportResult, error)
+
+	// ArchiveTask hides a task from task searches and menus while its time still counts in reports
+	ArchiveTask(ctx context.Context, taskID int64) (*domain.Task, error)
+
+	// UnarchiveTask brings an archived task back into task searches and menus
+	UnarchiveTask(ctx context.Context, taskID int64) (*domain.Task, error)
+
+	// ArchiveInactiveTasks archives every task not worked on since before, returning the archived tasks
+	ArchiveInactiveTasks(ctx context.Context, before time.Time) ([]*domain.Task, error)
+
+	// ========== Project Management ==========
+
+	// CreateProject creates a project such as "acme/website", creating its client if needed
+	CreateProject(ctx context.Context, path string) (*ProjectInfo, error)
+
+	// ListProjects returns projects sorted by path, including archived ones only when asked
+	ListProjects(ctx context.Context, includeArchived bool) ([]*ProjectInfo, error)
+
+	// ArchiveProject hides a project and its sub-projects from listings and new tasks
+	ArchiveProject(ctx context.Context, path string) (*ProjectInfo, error)
+
+	// ========== Operations Journal ==========
+
+	// ListOperations returns the most recent journaled operations, newest first, including undone ones
+	ListOperations(ctx context.Context, limit int) ([]*Operation, error)
+
+	// UndoOperations reverts the last count operations that have not been undone, restoring deleted rows with their IDs
+	UndoOperations(ctx context.Context, count int) ([]*Operation, error)
+
+	// ========== Events ==========
+
+	// SubscribeEvents streams the running session, then started, stopped, resumed, task_renamed, deleted and
+	// undone events, including changes made by other tt processes, until ctx is done
+	SubscribeEvents(ctx context.Context) (<-chan *Event, error)
+
+	// ========== Maintenance ==========
+
+	// CheckConsistency scans the database for overlapping, concurrently running, inverted, overlong and orphaned
+	// entries and duplicate task names, applying the chosen fix strategies in a single journaled operation
+	CheckConsistency(ctx context.Context, opts DoctorOptions) (*DoctorReport, error)
+
+	// BackupDatabase copies the database to path with VACUUM INTO, or to a timestamped file in dir when path is empty
+	BackupDatabase(ctx context.Context, dir string, path string) (*BackupInfo, error)
+
+	// AutoBackupDatabase takes today's automatic backup in dir unless it exists, pruning those outside the retention
+	AutoBackupDatabase(ctx context.Context, dir string, retention BackupRetention) (*AutoBackup, error)
+
+	// ListBackups returns the backups in dir, newest first
+	ListBackups(ctx context.Context, dir string) ([]*BackupInfo, error)
+
+	// RestoreDatabase replaces the database with a backup after checking its schema version, first backing
+	// the database up to dir
+	RestoreDatabase(ctx context.Context, dir string, path string) (*BackupRestore, error)
+
 	// ========== Query Operations ==========
 
 	// GetCurrentSession returns the currently running task session, if any
@@ -53,20 +200,32 @@ type BusinessAPI interface {
 	// GetTask returns a single task by ID
 	GetTask(ctx context.Context, id int64) (*domain.Task, error)
 
+	// GetTaskByName returns a single task by its exact name
+	GetTaskByName(ctx context.Context, name string) (*domain.Task, error)
+
+	// GetTimeEntry returns a single time entry with its task
+	GetTimeEntry(ctx context.Context, id int64) (*TimeEntryWithTask, error)
+
 	// GetTaskSummary returns comprehensive summary for a specific task
 	GetTaskSummary(ctx context.Context, taskID int64) (*TaskSummary, error)
 
 	// ========== Search and Discovery Operations ==========
 
-	// ParseTimeRange converts time shorthand ("30m", "2h", "1d") to actual time range
+	// ParseTimeRange converts a time range expression ("30m", "3d", "today", "2026-10-01..2026-10-15") to an actual time range
 	ParseTimeRange(ctx context.Context, timeStr string) (*TimeRange, error)
 
 	// SearchTasks finds tasks by name and/or time range with rich metadata and configurable sorting
 	SearchTasks(ctx context.Context, timeRange string, textFilter string, sortOrder SortOrder) ([]*TaskActivity, error)
 
+	// SearchTasksWithFilter finds tasks with entries matching a filter that may also name a project or tags
+	SearchTasksWithFilter(ctx context.Context, filter TimeEntryFilter, sortOrder SortOrder) ([]*TaskActivity, error)
+
 	// SearchTimeEntries returns detailed time entries with task information for analysis
 	SearchTimeEntries(ctx context.Context, timeRange string, textFilter string) ([]*TimeEntryWithTask, error)
 
+	// SearchTimeEntriesWithFilter returns time entries matching a filter that may also name a project or tags
+	SearchTimeEntriesWithFilter(ctx context.Context, filter TimeEntryFilter) ([]*TimeEntryWithTask, error)
+
 	// ========== Dashboard and Analytics ==========
 
 	// GetDashboardData returns all data needed for a dashboard view
@@ -74,14 +233,28 @@ type BusinessAPI interface {
 
 	// GetTodayStatistics returns summary statistics for today's work
 	GetTodayStatistics(ctx context.Context) (*DayStatistics, error)
+
+	// GetProjectTotals returns the time spent per project on the matching entries, rolled up to parent projects
+	GetProjectTotals(ctx context.Context, filter TimeEntryFilter) ([]*ProjectTotal, error)
+
+	// GetReport totals the time per task, project, tag or day over the calendar day, week or month around a date
+	GetReport(ctx context.Context, opts ReportOptions) (*Report, error)
+
+	// GetTimeline lays out the sessions of the day containing date per task
+	GetTimeline(ctx context.Context, date time.Time) (*Timeline, error)
 }
 
 // businessAPIImpl implements the BusinessAPI interface
 type businessAPIImpl struct {
 	timeService      services.TimeService
 	taskService      services.TaskService
+	projectService   services.ProjectService
 	searchService    services.SearchService
 	reportingService services.ReportingService
+	journalService   services.JournalService
+	eventService     services.EventService
+	doctorService    services.DoctorService
+	backupService    services.BackupService
 }
 
 // NewBusinessAPI creates a new BusinessAPI instance
@@ -89,14 +262,24 @@ func NewBusinessAPI(repo sqlite.Repository) BusinessAPI {
 	// Create services
 	timeService := services.NewTimeService(repo)
 	taskService := services.NewTaskService(repo, timeService)
+	projectService := services.NewProjectService(repo)
 	searchService := services.NewSearchService(repo, timeService, taskService)
 	reportingService := services.NewReportingService(repo, timeService, taskService, searchService)
+	journalService := services.NewJournalService(repo)
+	eventService := services.NewEventService(repo, taskService)
+	doctorService := services.NewDoctorService(repo)
+	backupService := services.NewBackupService(repo)
 
 	return &businessAPIImpl{
 		timeService:      timeService,
 		taskService:      taskService,
+		projectService:   projectService,
 		searchService:    searchService,
 		reportingService: reportingService,
+		journalService:   journalService,
+		eventService:     eventService,
+		doctorService:    doctorService,
+		backupService:    backupService,
 	}
 }
 
@@ -106,14 +289,50 @@ func (b *businessAPIImpl) StartNewTask(ctx context.Context, taskName string) (*T
 	return b.taskService.StartNewTask(ctx, taskName)
 }
 
+func (b *businessAPIImpl) StartNewTaskWithOptions(ctx context.Context, taskName string, opts StartOptions) (*TaskSession, error) {
+	return b.taskService.StartNewTaskWithOptions(ctx, taskName, opts)
+}
+
 func (b *businessAPIImpl) ResumeTask(ctx context.Context, taskID int64) (*TaskSession, error) {
 	return b.taskService.ResumeTask(ctx, taskID)
 }
 
+func (b *businessAPIImpl) ResumeTaskAt(ctx context.Context, taskID int64, at time.Time) (*TaskSession, error) {
+	return b.taskService.ResumeTaskAt(ctx, taskID, at)
+}
+
+func (b *businessAPIImpl) AddTimeEntry(ctx context.Context, taskName string, start time.Time, end time.Time) (*TaskSession, error) {
+	return b.taskService.AddTimeEntry(ctx, taskName, start, end)
+}
+
+func (b *businessAPIImpl) EditTimeEntry(ctx context.Context, entryID int64, update TimeEntryUpdate) (*TimeEntryEdit, error) {
+	return b.taskService.EditTimeEntry(ctx, entryID, update)
+}
+
 func (b *businessAPIImpl) StopAllRunningTasks(ctx context.Context) ([]*domain.TimeEntry, error) {
 	return b.taskService.StopAllRunningTasks(ctx)
 }
 
+func (b *businessAPIImpl) StopAllRunningTasksAt(ctx context.Context, at time.Time) ([]*domain.TimeEntry, error) {
+	return b.taskService.StopAllRunningTasksAt(ctx, at)
+}
+
+func (b *businessAPIImpl) FindForgottenTimer(ctx context.Context, after time.Duration) (*ForgottenTimer, error) {
+	return b.taskService.FindForgottenTimer(ctx, after)
+}
+
+func (b *businessAPIImpl) ResolveForgottenTimer(ctx context.Context, entryID int64, resolution ForgottenTimerResolution) (*TimeEntryWithTask, error) {
+	return b.taskService.ResolveForgottenTimer(ctx, entryID, resolution)
+}
+
+func (b *businessAPIImpl) PauseTask(ctx context.Context) (*TaskSession, error) {
+	return b.taskService.PauseTask(ctx)
+}
+
+func (b *businessAPIImpl) ContinueTask(ctx context.Context) (*TaskSession, error) {
+	return b.taskService.ContinueTask(ctx)
+}
+
 func (b *businessAPIImpl) DeleteTaskWithEntries(ctx context.Context, taskID int64) error {
 	return b.taskService.DeleteTaskWithEntries(ctx, taskID)
 }
@@ -122,6 +341,78 @@ func (b *businessAPIImpl) UpdateTaskName(ctx context.Context, taskID int64, newN
 	return b.taskService.UpdateTask(ctx, taskID, newName)
 }
 
+func (b *businessAPIImpl) MergeTasks(ctx context.Context, fromID int64, intoID int64) (*TaskMerge, error) {
+	return b.taskService.MergeTasks(ctx, fromID, intoID)
+}
+
+func (b *businessAPIImpl) ImportTimeEntries(ctx context.Context, entries []ImportEntry, dryRun bool) (*ImportResult, error) {
+	return b.taskService.ImportTimeEntries(ctx, entries, dryRun)
+}
+
+func (b *businessAPIImpl) ArchiveTask(ctx context.Context, taskID int64) (*domain.Task, error) {
+	return b.taskService.ArchiveTask(ctx, taskID)
+}
+
+func (b *businessAPIImpl) UnarchiveTask(ctx context.Context, taskID int64) (*domain.Task, error) {
+	return b.taskService.UnarchiveTask(ctx, taskID)
+}
+
+func (b *businessAPIImpl) ArchiveInactiveTasks(ctx context.Context, before time.Time) ([]*domain.Task, error) {
+	return b.taskService.ArchiveInactiveTasks(ctx, before)
+}
+
+// ========== Project Management ==========
+
+func (b *businessAPIImpl) CreateProject(ctx context.Context, path string) (*ProjectInfo, error) {
+	return b.projectService.CreateProject(ctx, path)
+}
+
+func (b *businessAPIImpl) ListProjects(ctx context.Context, includeArchived bool) ([]*ProjectInfo, error) {
+	return b.projectService.ListProjects(ctx, includeArchived)
+}
+
+func (b *businessAPIImpl) ArchiveProject(ctx context.Context, path string) (*ProjectInfo, error) {
+	return b.projectService.ArchiveProject(ctx, path)
+}
+
+// ========== Operations Journal ==========
+
+func (b *businessAPIImpl) ListOperations(ctx context.Context, limit int) ([]*Operation, error) {
+	return b.journalService.ListOperations(ctx, limit)
+}
+
+func (b *businessAPIImpl) UndoOperations(ctx context.Context, count int) ([]*Operation, error) {
+	return b.journalService.UndoOperations(ctx, count)
+}
+
+// ========== Events ==========
+
+func (b *businessAPIImpl) SubscribeEvents(ctx context.Context) (<-chan *Event, error) {
+	return b.eventService.Subscribe(ctx)
+}
+
+// ========== Maintenance ==========
+
+func (b *businessAPIImpl) CheckConsistency(ctx context.Context, opts DoctorOptions) (*DoctorReport, error) {
+	return b.doctorService.CheckConsistency(ctx, opts)
+}
+
+func (b *businessAPIImpl) BackupDatabase(ctx context.Context, dir string, path string) (*BackupInfo, error) {
+	return b.backupService.CreateBackup(ctx, dir, path)
+}
+
+func (b *businessAPIImpl) AutoBackupDatabase(ctx context.Context, dir string, retention BackupRetention) (*AutoBackup, error) {
+	return b.backupService.AutoBackup(ctx, dir, retention)
+}
+
+func (b *businessAPIImpl) ListBackups(ctx context.Context, dir string) ([]*BackupInfo, error) {
+	return b.backupService.ListBackups(ctx, dir)
+}
+
+func (b *businessAPIImpl) RestoreDatabase(ctx context.Context, dir string, path string) (*BackupRestore, error) {
+	return b.backupService.RestoreBackup(ctx, dir, path)
+}
+
 // ========== Query Operations ==========
 
 func (b *businessAPIImpl) GetCurrentSession(ctx context.Context) (*TaskSession, error) {
@@ -139,6 +430,26 @@ func (b *businessAPIImpl) GetTask(ctx context.Context, id int64) (*domain.Task,
 	return b.taskService.GetTask(ctx, id)
 }
 
+func (b *businessAPIImpl) GetTaskByName(ctx context.Context, name string) (*domain.Task, error) {
+	return b.taskService.GetTaskByName(ctx, name)
+}
+
+func (b *businessAPIImpl) GetTimeEntry(ctx context.Context, id int64) (*TimeEntryWithTask, error) {
+	entry, err := b.timeService.GetTimeEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	task, err := b.taskService.GetTask(ctx, entry.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	return &TimeEntryWithTask{
+		TimeEntry: entry,
+		Task:      task,
+		Duration:  b.timeService.CalculateDuration(entry.StartTime, entry.EndTime),
+	}, nil
+}
+
 func (b *businessAPIImpl) GetTaskSummary(ctx context.Context, taskID int64) (*TaskSummary, error) {
 	return b.reportingService.GetTaskSummary(ctx, taskID)
 }
@@ -150,20 +461,13 @@ func (b *businessAPIImpl) ParseTimeRange(ctx context.Context, timeStr string) (*
 }
 
 func (b *businessAPIImpl) SearchTasks(ctx context.Context, timeRange string, textFilter string, sortOrder SortOrder) ([]*TaskActivity, error) {
-	// Parse time range only if provided
-	var timeRangeObj *services.TimeRange
-	var err error
-	if timeRange != "" {
-		timeRangeObj, err = b.timeService.ParseTimeRange(timeRange)
-		if err != nil {
-			return nil, err
-		}
-	}
-	
-	// Create search criteria
-	criteria := services.SearchCriteria{
-		TimeRange:  timeRangeObj,
-		TextFilter: textFilter,
+	return b.SearchTasksWithFilter(ctx, TimeEntryFilter{TimeRange: timeRange, Text: textFilter}, sortOrder)
+}
+
+func (b *businessAPIImpl) SearchTasksWithFilter(ctx context.Context, filter TimeEntryFilter, sortOrder SortOrder) ([]*TaskActivity, error) {
+	criteria, err := b.buildSearchCriteria(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
 	
 	// Get tasks and then sort them
@@ -176,23 +480,47 @@ func (b *businessAPIImpl) SearchTasks(ctx context.Context, timeRange string, tex
 }
 
 func (b *businessAPIImpl) SearchTimeEntries(ctx context.Context, timeRange string, textFilter string) ([]*TimeEntryWithTask, error) {
+	return b.SearchTimeEntriesWithFilter(ctx, TimeEntryFilter{TimeRange: timeRange, Text: textFilter})
+}
+
+func (b *businessAPIImpl) SearchTimeEntriesWithFilter(ctx context.Context, filter TimeEntryFilter) ([]*TimeEntryWithTask, error) {
+	criteria, err := b.buildSearchCriteria(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	
+	return b.searchService.SearchTimeEntries(ctx, criteria)
+}
+
+// buildSearchCriteria resolves the time range and project of a user filter into search criteria
+func (b *businessAPIImpl) buildSearchCriteria(ctx context.Context, filter TimeEntryFilter) (services.SearchCriteria, error) {
 	// Parse time range only if provided
 	var timeRangeObj *services.TimeRange
 	var err error
-	if timeRange != "" {
-		timeRangeObj, err = b.timeService.ParseTimeRange(timeRange)
+	if filter.TimeRange != "" {
+		timeRangeObj, err = b.timeService.ParseTimeRange(filter.TimeRange)
 		if err != nil {
-			return nil, err
+			return services.SearchCriteria{}, err
 		}
 	}
 	
-	// Create search criteria
-	criteria := services.SearchCriteria{
-		TimeRange:  timeRangeObj,
-		TextFilter: textFilter,
+	// Resolve the project and its sub-projects only if provided
+	var projectIDs []int64
+	if filter.Project != "" {
+		projectIDs, err = b.projectService.ResolveProjectIDs(ctx, filter.Project)
+		if err != nil {
+			return services.SearchCriteria{}, err
+		}
 	}
 	
-	return b.searchService.SearchTimeEntries(ctx, criteria)
+	return services.SearchCriteria{
+		TimeRange:       timeRangeObj,
+		TextFilter:      filter.Text,
+		ProjectIDs:      projectIDs,
+		Tags:            filter.Tags,
+		ExcludeTags:     filter.ExcludeTags,
+		IncludeArchived: filter.IncludeArchived,
+	}, nil
 }
 
 // ========== Dashboard and Analytics ==========
@@ -203,4 +531,20 @@ func (b *businessAPIImpl) GetDashboardData(ctx context.Context, timeRange string
 
 func (b *businessAPIImpl) GetTodayStatistics(ctx context.Context) (*DayStatistics, error) {
 	return b.reportingService.GetTodayStatistics(ctx)
-}
\ No newline at end of file
+}
+
+func (b *businessAPIImpl) GetProjectTotals(ctx context.Context, filter TimeEntryFilter) ([]*ProjectTotal, error) {
+	entries, err := b.SearchTimeEntriesWithFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return b.reportingService.RollupByProject(entries), nil
+}
+
+func (b *businessAPIImpl) GetReport(ctx context.Context, opts ReportOptions) (*Report, error) {
+	return b.reportingService.GetReport(ctx, opts)
+}
+
+func (b *businessAPIImpl) GetTimeline(ctx context.Context, date time.Time) (*Timeline, error) {
+	return b.reportingService.GetTimeline(ctx, date)
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// AddOptions holds the flags accepted by the add command
+type AddOptions struct {
+	From     string        // Start time (e.g. "09:00")
+	To       string        // End time (e.g. "10:30")
+	Duration time.Duration // Length of the entry, used when --from or --to is missing
+	Ago      time.Duration // How long ago the entry ended, used with --duration
+}
+
+// AddCommand handles the add command
+type AddCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	options      AddOptions
+}
+
+// NewAddCommand creates a new add command handler
+func NewAddCommand(app *App) *AddCommand {
+	return NewAddCommandWithOptions(app, AddOptions{})
+}
+
+// NewAddCommandWithOptions creates a new add command handler with the given flag values
+func NewAddCommandWithOptions(app *App, options AddOptions) *AddCommand {
+	return &AddCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		options:      options,
+	}
+}
+
+// Execute runs the add command
+func (c *AddCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errors.NewInvalidInputError("command", "add", "usage: tt add \"task name\" --from 09:00 --to 10:30 or --duration 45m [--ago 2h]")
+	}
+	taskName := strings.Join(args, " ")
+
+	start, end, err := c.resolveTimeRange(timeNow())
+	if err != nil {
+		return err
+	}
+
+	return c.addTimeEntry(ctx, taskName, start, end)
+}
+
+// resolveTimeRange works out the start and end of the entry from the flag combination
+func (c *AddCommand) resolveTimeRange(now time.Time) (time.Time, time.Time, error) {
+	opts := c.options
+
+	if opts.From != "" && opts.Ago > 0 {
+		return time.Time{}, time.Time{}, errors.NewInvalidInputError("ago", opts.Ago, "cannot be combined with --from")
+	}
+
+	if opts.From != "" {
+		start, err := newTimeParser(now).Parse(opts.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+
+		switch {
+		case opts.To != "":
+			end, err := newTimeParser(now).Parse(opts.To)
+			if err != nil {
+				return time.Time{}, time.Time{}, err
+			}
+			return start, end, nil
+		case opts.Duration > 0:
+			return start, start.Add(opts.Duration), nil
+		default:
+			return time.Time{}, time.Time{}, errors.NewInvalidInputError("to", "", "--from requires --to or --duration")
+		}
+	}
+
+	if opts.Duration <= 0 {
+		return time.Time{}, time.Time{}, errors.NewInvalidInputError("duration", opts.Duration, "specify --from/--to or a positive --duration")
+	}
+
+	end := now.Add(-opts.Ago)
+	if opts.To != "" {
+		if opts.Ago > 0 {
+			return time.Time{}, time.Time{}, errors.NewInvalidInputError("ago", opts.Ago, "cannot be combined with --to")
+		}
+		parsedEnd, err := newTimeParser(now).Parse(opts.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = parsedEnd
+	}
+
+	return end.Add(-opts.Duration), end, nil
+}
+
+// addTimeEntry records the completed entry and reports the result
+func (c *AddCommand) addTimeEntry(ctx context.Context, taskName string, start, end time.Time) error {
+	session, err := c.businessAPI.AddTimeEntry(ctx, taskName, start, end)
+	if err != nil {
+		return c.errorHandler.Handle("add time entry", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newEntryRecord(session.TimeEntry, session.Task))
+	}
+
+	fmt.Printf("Added entry for %s: %s - %s (%s)\n",
+		session.Task.TaskName,
+		session.TimeEntry.StartTime.Format("2006-01-02 15:04:05"),
+		session.TimeEntry.EndTime.Format("2006-01-02 15:04:05"),
+		session.Duration)
+	return nil
+}
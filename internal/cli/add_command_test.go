@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCommand_ResolveTimeRange(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name          string
+		options       AddOptions
+		expectedStart time.Time
+		expectedEnd   time.Time
+		expectError   string
+	}{
+		{
+			name:          "from and to clock times",
+			options:       AddOptions{From: "09:00", To: "10:30"},
+			expectedStart: time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local),
+			expectedEnd:   time.Date(2026, 10, 16, 10, 30, 0, 0, time.Local),
+		},
+		{
+			name:          "from with duration",
+			options:       AddOptions{From: "14:00", Duration: 45 * time.Minute},
+			expectedStart: time.Date(2026, 10, 16, 14, 0, 0, 0, time.Local),
+			expectedEnd:   time.Date(2026, 10, 16, 14, 45, 0, 0, time.Local),
+		},
+		{
+			name:          "duration ending ago",
+			options:       AddOptions{Duration: 45 * time.Minute, Ago: 2 * time.Hour},
+			expectedStart: time.Date(2026, 10, 16, 9, 15, 0, 0, time.Local),
+			expectedEnd:   time.Date(2026, 10, 16, 10, 0, 0, 0, time.Local),
+		},
+		{
+			name:          "duration ending now",
+			options:       AddOptions{Duration: 30 * time.Minute},
+			expectedStart: time.Date(2026, 10, 16, 11, 30, 0, 0, time.Local),
+			expectedEnd:   now,
+		},
+		{
+			name:          "duration ending at explicit time",
+			options:       AddOptions{To: "2026-10-15 17:00", Duration: time.Hour},
+			expectedStart: time.Date(2026, 10, 15, 16, 0, 0, 0, time.Local),
+			expectedEnd:   time.Date(2026, 10, 15, 17, 0, 0, 0, time.Local),
+		},
+		{
+			name:        "from without end",
+			options:     AddOptions{From: "09:00"},
+			expectError: "--from requires --to or --duration",
+		},
+		{
+			name:        "from with ago",
+			options:     AddOptions{From: "09:00", Ago: time.Hour},
+			expectError: "cannot be combined with --from",
+		},
+		{
+			name:        "no flags",
+			options:     AddOptions{},
+			expectError: "positive --duration",
+		},
+		{
+			name:        "invalid time format",
+			options:     AddOptions{From: "nine", To: "10:00"},
+			expectError: "expected a time such as",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, cleanup := setupTestAppWithMockBusinessAPI(t)
+			defer cleanup()
+
+			cmd := NewAddCommandWithOptions(app, tt.options)
+			start, end, err := cmd.resolveTimeRange(now)
+
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expectedStart.Equal(start), "start = %v, want %v", start, tt.expectedStart)
+			assert.True(t, tt.expectedEnd.Equal(end), "end = %v, want %v", end, tt.expectedEnd)
+		})
+	}
+}
+
+func TestAddCommand_Execute(t *testing.T) {
+	app, cleanup := setupTestAppWithMockBusinessAPI(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	t.Run("adds completed entry", func(t *testing.T) {
+		cmd := NewAddCommandWithOptions(app, AddOptions{Duration: 45 * time.Minute, Ago: 2 * time.Hour})
+		err := cmd.Execute(ctx, []string{"Team", "meeting"})
+		require.NoError(t, err)
+
+		entries, err := app.businessAPI.SearchTimeEntries(ctx, "", "Team meeting")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.NotNil(t, entries[0].TimeEntry.EndTime)
+
+		// Adding an entry must not start a running task
+		_, err = app.businessAPI.GetCurrentSession(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("reports entries the service rejects", func(t *testing.T) {
+		mockAPI(app).failures["AddTimeEntry"] = errors.NewValidationError("time entry overlaps with existing entry 1", nil)
+		defer delete(mockAPI(app).failures, "AddTimeEntry")
+
+		cmd := NewAddCommandWithOptions(app, AddOptions{Duration: time.Hour, Ago: 90 * time.Minute})
+		err := cmd.Execute(ctx, []string{"Overlapping"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "overlaps")
+	})
+
+	t.Run("requires task name", func(t *testing.T) {
+		cmd := NewAddCommandWithOptions(app, AddOptions{Duration: time.Hour})
+		err := cmd.Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "usage: tt add")
+	})
+}
@@ -3,14 +3,17 @@ package cli
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"time-tracker/internal/api"
 	"time-tracker/internal/config"
 	"time-tracker/internal/errors"
 	"time-tracker/internal/repository/sqlite"
+	"time-tracker/internal/timeparse"
 )
 
 // timeNow is a variable that can be replaced in tests
@@ -54,6 +57,12 @@ func NewAppWithDefaultRepository() (*App, error) {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	return NewAppWithDefaultRepositoryAndConfig(cfg)
+}
+
+// NewAppWithDefaultRepositoryAndConfig creates a new CLI application instance with the default SQLite
+// repository using an already loaded configuration, so command-line flag overrides are kept
+func NewAppWithDefaultRepositoryAndConfig(cfg *config.Config) (*App, error) {
 	// Get database path from configuration
 	dbPath := cfg.GetDatabasePath()
 
@@ -66,6 +75,9 @@ func NewAppWithDefaultRepository() (*App, error) {
 	// Create BusinessAPI instance
 	businessAPI := api.NewBusinessAPI(repo)
 
+	// Back up before the command or its housekeeping changes the database
+	autoBackup(context.Background(), businessAPI, cfg, os.Stderr)
+
 	app := &App{
 		businessAPI: businessAPI,
 		config:      cfg,
@@ -74,6 +86,88 @@ func NewAppWithDefaultRepository() (*App, error) {
 	return app, nil
 }
 
+// autoArchiveStamp names the file next to the database holding the day tasks were last archived automatically
+const autoArchiveStamp = ".auto-archive"
+
+// startupChecks selects the housekeeping done before a command runs
+type startupChecks struct {
+	autoArchive    bool // Archive the tasks nobody has worked on, at most once a day
+	forgottenTimer bool // Look for a task left running by mistake
+}
+
+// runStartupChecks does the selected housekeeping, when the app has a configuration. Questions and
+// notices are written to out.
+func (a *App) runStartupChecks(ctx context.Context, checks startupChecks, out io.Writer) error {
+	if a.config == nil {
+		return nil
+	}
+	if checks.autoArchive {
+		if err := autoArchiveTasks(ctx, a.businessAPI, a.config); err != nil {
+			return err
+		}
+	}
+	if checks.forgottenTimer {
+		if err := checkForgottenTimer(ctx, a.businessAPI, a.config, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// autoArchiveTasks archives the tasks nobody has worked on for the configured number of days. Finding them
+// scans every task and entry, so like the automatic backup this is only done once a day.
+func autoArchiveTasks(ctx context.Context, businessAPI api.BusinessAPI, cfg *config.Config) error {
+	if cfg.Tasks.AutoArchiveDays <= 0 {
+		return nil
+	}
+
+	stamp := filepath.Join(cfg.Database.Dir, autoArchiveStamp)
+	today := timeNow().Format("2006-01-02")
+	if last, err := os.ReadFile(stamp); err == nil && strings.TrimSpace(string(last)) == today {
+		return nil
+	}
+
+	before := timeNow().AddDate(0, 0, -cfg.Tasks.AutoArchiveDays)
+	if _, err := businessAPI.ArchiveInactiveTasks(ctx, before); err != nil {
+		return fmt.Errorf("failed to archive inactive tasks: %w", err)
+	}
+
+	// A stamp that cannot be written only means the next command archives again
+	os.WriteFile(stamp, []byte(today+"\n"), 0600)
+	return nil
+}
+
+// autoBackup takes the day's automatic backup on the first command of the day and prunes the old ones.
+// A failed backup is reported on out but never stops the command.
+func autoBackup(ctx context.Context, businessAPI api.BusinessAPI, cfg *config.Config, out io.Writer) {
+	if !cfg.Database.AutoBackup {
+		return
+	}
+	retention := api.BackupRetention{KeepDaily: cfg.Database.BackupKeepDaily, KeepWeekly: cfg.Database.BackupKeepWeekly}
+	if _, err := businessAPI.AutoBackupDatabase(ctx, cfg.GetBackupDir(), retention); err != nil {
+		fmt.Fprintf(out, "Warning: automatic backup failed: %v\n", err)
+	}
+}
+
+// newPrinter creates a printer for the configured output format
+func (a *App) newPrinter() *Printer {
+	format := FormatTable
+	if a.config != nil {
+		if parsed, err := ParseOutputFormat(a.config.Commands.ListDefaultFormat); err == nil {
+			format = parsed
+		}
+	}
+	return NewPrinter(format)
+}
+
+// displayConfig returns the display settings, or the defaults when the app was created without configuration
+func (a *App) displayConfig() config.DisplayConfig {
+	if a.config != nil {
+		return a.config.Display
+	}
+	return config.NewConfig().Display
+}
+
 // Run executes the CLI application with the given arguments
 func (a *App) Run(ctx context.Context, args []string) error {
 	if len(args) == 0 {
@@ -86,38 +180,32 @@ func (a *App) Run(ctx context.Context, args []string) error {
 	return a.registry.Execute(ctx, commandName, commandArgs)
 }
 
-// parseTimeShorthand parses time shorthand like "30m", "2h", "1d", etc.
-func parseTimeShorthand(shorthand string) (time.Duration, error) {
-	re := regexp.MustCompile(`^(\d+)(m|h|d|w|mo|y)$`)
-	matches := re.FindStringSubmatch(shorthand)
-	if matches == nil {
-		return 0, errors.NewInvalidInputError("time_format", shorthand, "invalid time format")
-	}
+// newTimeParser returns the parser for times and dates given on the command line, relative to now
+// and in now's timezone
+func newTimeParser(now time.Time) *timeparse.Parser {
+	return timeparse.NewParser(now, now.Location())
+}
 
-	value, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return 0, errors.NewInvalidInputError("time_number", shorthand, "invalid number in time format")
-	}
-
-	unit := matches[2]
-	var duration time.Duration
-
-	switch unit {
-	case "m":
-		duration = time.Duration(value) * time.Minute
-	case "h":
-		duration = time.Duration(value) * time.Hour
-	case "d":
-		duration = time.Duration(value) * 24 * time.Hour
-	case "w":
-		duration = time.Duration(value) * 7 * 24 * time.Hour
-	case "mo":
-		duration = time.Duration(value) * 30 * 24 * time.Hour
-	case "y":
-		duration = time.Duration(value) * 365 * 24 * time.Hour
-	default:
-		return 0, errors.NewInvalidInputError("time_unit", unit, "invalid time unit")
-	}
-
-	return duration, nil
+// resolveAt turns the --at and --ago flags into the time a start, stop or resume takes effect,
+// returning nil when neither is given so the change happens now
+func resolveAt(at, ago string, now time.Time) (*time.Time, error) {
+	switch {
+	case at != "" && ago != "":
+		return nil, errors.NewInvalidInputError("at", at, "--at and --ago cannot be used together")
+	case at != "":
+		t, err := newTimeParser(now).Parse(at)
+		if err != nil {
+			return nil, err
+		}
+		return &t, nil
+	case ago != "":
+		d, err := time.ParseDuration(ago)
+		if err != nil || d <= 0 {
+			return nil, errors.NewInvalidInputError("ago", ago, "expected a positive duration such as 10m or 1h30m")
+		}
+		t := now.Add(-d)
+		return &t, nil
+	}
+	return nil, nil
 }
+
@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
 
+	"time-tracker/internal/api"
+	"time-tracker/internal/config"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -140,95 +144,104 @@ func TestApp_RunErrorPropagation(t *testing.T) {
 	})
 }
 
-func TestParseTimeShorthand(t *testing.T) {
+func TestResolveAt(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+
 	tests := []struct {
-		name        string
-		input       string
-		expectError bool
-		expected    time.Duration
+		name     string
+		at       string
+		ago      string
+		expected *time.Time
+		errorMsg string
 	}{
-		{
-			name:        "minutes",
-			input:       "30m",
-			expectError: false,
-			expected:    30 * time.Minute,
-		},
-		{
-			name:        "hours", 
-			input:       "2h",
-			expectError: false,
-			expected:    2 * time.Hour,
-		},
-		{
-			name:        "days",
-			input:       "1d",
-			expectError: false,
-			expected:    24 * time.Hour,
-		},
-		{
-			name:        "weeks",
-			input:       "2w",
-			expectError: false,
-			expected:    14 * 24 * time.Hour,
-		},
-		{
-			name:        "months",
-			input:       "1mo",
-			expectError: false,
-			expected:    30 * 24 * time.Hour,
-		},
-		{
-			name:        "years",
-			input:       "1y",
-			expectError: false,
-			expected:    365 * 24 * time.Hour,
-		},
-		{
-			name:        "invalid format",
-			input:       "invalid",
-			expectError: true,
-		},
-		{
-			name:        "empty string",
-			input:       "",
-			expectError: true,
-		},
-		{
-			name:        "no number",
-			input:       "h",
-			expectError: true,
-		},
-		{
-			name:        "no unit",
-			input:       "5",
-			expectError: true,
-		},
-		{
-			name:        "invalid unit",
-			input:       "5x",
-			expectError: true,
-		},
-		{
-			name:        "negative number",
-			input:       "-5h",
-			expectError: true,
-		},
+		{name: "neither flag means now"},
+		{name: "clock time today", at: "09:15", expected: timePtr(time.Date(2026, 10, 16, 9, 15, 0, 0, time.Local))},
+		{name: "clock time yesterday", at: "yesterday 17:00", expected: timePtr(time.Date(2026, 10, 15, 17, 0, 0, 0, time.Local))},
+		{name: "date and time", at: "2026-10-14 08:30", expected: timePtr(time.Date(2026, 10, 14, 8, 30, 0, 0, time.Local))},
+		{name: "duration ago", ago: "10m", expected: timePtr(now.Add(-10 * time.Minute))},
+		{name: "both flags", at: "09:15", ago: "10m", errorMsg: "cannot be used together"},
+		{name: "invalid time", at: "quarter past nine", errorMsg: "expected a time such as"},
+		{name: "negative duration", ago: "-5m", errorMsg: "expected a positive duration"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			duration, err := parseTimeShorthand(tt.input)
-			
-			if tt.expectError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.expected, duration)
+			at, err := resolveAt(tt.at, tt.ago, now)
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
 			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, at)
 		})
 	}
 }
 
+func TestRootCommand_StartupChecks(t *testing.T) {
+	// checksFor parses a command line the way tt does and returns the housekeeping selected for it
+	checksFor := func(t *testing.T, args ...string) startupChecks {
+		root := NewRootCommand(config.NewConfig())
+		root.cmd.InitDefaultCompletionCmd() // Added by cobra when tt runs
+		cmd, flags, err := root.cmd.Find(args)
+		require.NoError(t, err)
+		require.NoError(t, cmd.ParseFlags(flags))
+		root.running = cmd
+		return root.startupChecks()
+	}
+
+	t.Run("archives before everyday commands", func(t *testing.T) {
+		for _, args := range [][]string{{"current"}, {"start", "Email"}, {"serve"}, {"project", "list"}} {
+			assert.True(t, checksFor(t, args...).autoArchive, args)
+		}
+	})
+
+	t.Run("changes nothing before repairing or undoing", func(t *testing.T) {
+		for _, args := range [][]string{{"db", "restore", "tt-2026-10-14.db", "--yes"}, {"db", "backup"}, {"doctor", "--fix"}, {"undo"}} {
+			assert.False(t, checksFor(t, args...).autoArchive, args)
+		}
+	})
+
+	t.Run("looks for a forgotten timer before everyday commands", func(t *testing.T) {
+		for _, args := range [][]string{{"current"}, {"start", "Email"}, {"stop"}, {"pause"}, {"list"}, {"report"}, {"project", "list"}, {"task", "list"}} {
+			assert.True(t, checksFor(t, args...).forgottenTimer, args)
+		}
+	})
+
+	t.Run("never looks for a forgotten timer before repairs, the server, completion or backdated stops", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"db", "restore", "tt-2026-10-14.db", "--yes"}, {"doctor"}, {"serve"}, {"watch"}, {"undo"},
+			{"completion", "bash"}, {"stop", "--at", "17:00"}, {"stop", "--ago", "2h"},
+		} {
+			assert.False(t, checksFor(t, args...).forgottenTimer, args)
+		}
+	})
+
+	t.Run("db restore never resolves the forgotten timer current stops", func(t *testing.T) {
+		stubStdin(t, false, "")
+		for _, test := range []struct {
+			args     []string
+			resolved int
+		}{
+			{[]string{"db", "restore", "tt-2026-10-14.db", "--yes"}, 0},
+			{[]string{"current"}, 1},
+		} {
+			app, cleanup := setupTestAppWithMockBusinessAPI(t)
+			t.Cleanup(cleanup)
+			app.config = config.NewConfig()
+			app.config.Database.Dir = t.TempDir()
+			app.config.Tasks.ForgottenPolicy = config.ForgottenPolicyStop
+			_, err := app.businessAPI.StartNewTaskWithOptions(context.Background(), "Email",
+				api.StartOptions{At: timePtr(time.Now().Add(-30 * time.Hour))})
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, app.runStartupChecks(context.Background(), checksFor(t, test.args...), &out))
+			assert.Equal(t, test.resolved, app.businessAPI.(*mockBusinessAPI).resolutions, test.args)
+		}
+	})
+}
+
 func TestTimeNow(t *testing.T) {
 	// Test that timeNow can be overridden for testing
 	originalTimeNow := timeNow
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/errors"
+)
+
+// ArchiveCommand handles the archive and unarchive commands. Archived tasks are left out of
+// task searches and menus, while their time still counts in reports.
+type ArchiveCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	unarchive    bool // Bring archived tasks back instead of archiving
+}
+
+// NewArchiveCommand creates a new archive command handler
+func NewArchiveCommand(app *App) *ArchiveCommand {
+	return &ArchiveCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+	}
+}
+
+// NewUnarchiveCommand creates a new unarchive command handler
+func NewUnarchiveCommand(app *App) *ArchiveCommand {
+	command := NewArchiveCommand(app)
+	command.unarchive = true
+	return command
+}
+
+// Execute runs the archive or unarchive command on every task given by ID or exact name
+func (c *ArchiveCommand) Execute(ctx context.Context, args []string) error {
+	name, operation := "archive", "archive task"
+	if c.unarchive {
+		name, operation = "unarchive", "unarchive task"
+	}
+	if len(args) == 0 {
+		return errors.NewInvalidInputError("command", name, fmt.Sprintf("usage: tt %s <id|name>...", name))
+	}
+
+	var records []*domain.Task
+	for _, ref := range args {
+		task, err := resolveTask(ctx, c.businessAPI, ref)
+		if err != nil {
+			return c.errorHandler.Handle(operation, err)
+		}
+
+		var changed *domain.Task
+		if c.unarchive {
+			changed, err = c.businessAPI.UnarchiveTask(ctx, task.ID)
+		} else {
+			changed, err = c.businessAPI.ArchiveTask(ctx, task.ID)
+		}
+		if err != nil {
+			return c.errorHandler.Handle(operation, err)
+		}
+
+		if c.printer.IsStructured() {
+			records = append(records, changed)
+			continue
+		}
+		if c.unarchive {
+			fmt.Printf("Unarchived task %d: %s\n", changed.ID, changed.TaskName)
+		} else {
+			fmt.Printf("Archived task %d: %s\n", changed.ID, changed.TaskName)
+		}
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(records)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"time-tracker/internal/config"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(-5 * time.Hour)
+
+	setup := func(t *testing.T) (*App, map[string]int64) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+
+		ids := make(map[string]int64)
+		for i, name := range []string{"Old experiment", "Write report"} {
+			from := start.Add(time.Duration(i) * time.Hour)
+			entry, err := app.businessAPI.AddTimeEntry(ctx, name, from, from.Add(30*time.Minute))
+			require.NoError(t, err)
+			ids[name] = entry.Task.ID
+		}
+		return app, ids
+	}
+
+	t.Run("archives tasks by name and ID", func(t *testing.T) {
+		app, ids := setup(t)
+
+		var out bytes.Buffer
+		cmd := NewArchiveCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{"Old experiment", "2"}))
+
+		var records []domain.Task
+		require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+		require.Len(t, records, 2)
+		assert.Equal(t, ids["Old experiment"], records[0].ID)
+		assert.NotNil(t, records[0].ArchivedAt)
+		assert.Equal(t, ids["Write report"], records[1].ID)
+
+		tasks, err := app.businessAPI.SearchTasks(ctx, "", "", "")
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("unarchives tasks", func(t *testing.T) {
+		app, ids := setup(t)
+		_, err := app.businessAPI.ArchiveTask(ctx, ids["Old experiment"])
+		require.NoError(t, err)
+
+		require.NoError(t, NewUnarchiveCommand(app).Execute(ctx, []string{"Old experiment"}))
+
+		task, err := app.businessAPI.GetTask(ctx, ids["Old experiment"])
+		require.NoError(t, err)
+		assert.False(t, task.IsArchived())
+	})
+
+	t.Run("rejects missing arguments and unknown tasks", func(t *testing.T) {
+		app, _ := setup(t)
+
+		assert.Error(t, NewArchiveCommand(app).Execute(ctx, []string{}))
+		assert.Error(t, NewArchiveCommand(app).Execute(ctx, []string{"No such task"}))
+
+		mockAPI(app).failures["UnarchiveTask"] = errors.NewValidationError(`task "Write report" is not archived`, nil)
+		err := NewUnarchiveCommand(app).Execute(ctx, []string{"Write report"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not archived")
+	})
+
+	t.Run("resume only offers archived tasks with --include-archived", func(t *testing.T) {
+		app, ids := setup(t)
+		_, err := app.businessAPI.ArchiveTask(ctx, ids["Write report"])
+		require.NoError(t, err)
+
+		var info bytes.Buffer
+		stubStdin(t, true, "1\n")
+		cmd := NewResumeCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatTable, &info, &info)
+		require.NoError(t, cmd.Execute(ctx, []string{"1d"}))
+		assert.NotContains(t, info.String(), "Write report")
+
+		_, err = app.businessAPI.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+
+		// Resuming made "Old experiment" the most recent task, so the archived one comes second
+		info.Reset()
+		stubStdin(t, true, "2\n")
+		cmd = NewResumeCommandWithOptions(app, ResumeOptions{IncludeArchived: true})
+		cmd.printer = newPrinterWithWriters(FormatTable, &info, &info)
+		require.NoError(t, cmd.Execute(ctx, []string{"1d"}))
+		assert.Regexp(t, `2\. Write report .*\[archived\]`, info.String())
+
+		session, err := app.businessAPI.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Write report", session.Task.TaskName)
+		assert.False(t, session.Task.IsArchived())
+	})
+}
+
+func TestAutoArchiveTasks(t *testing.T) {
+	ctx := context.Background()
+	app, cleanup := setupTestAppWithMockBusinessAPI(t)
+	defer cleanup()
+
+	old := time.Now().AddDate(0, 0, -40)
+	stale, err := app.businessAPI.AddTimeEntry(ctx, "Old experiment", old, old.Add(time.Hour))
+	require.NoError(t, err)
+	recent := time.Now().Add(-2 * time.Hour)
+	fresh, err := app.businessAPI.AddTimeEntry(ctx, "Write report", recent, recent.Add(time.Hour))
+	require.NoError(t, err)
+
+	cfg := config.NewConfig()
+	cfg.Database.Dir = t.TempDir()
+	require.NoError(t, autoArchiveTasks(ctx, app.businessAPI, cfg))
+	task, err := app.businessAPI.GetTask(ctx, stale.Task.ID)
+	require.NoError(t, err)
+	assert.False(t, task.IsArchived(), "auto-archiving is off by default")
+
+	cfg.Tasks.AutoArchiveDays = 30
+	require.NoError(t, autoArchiveTasks(ctx, app.businessAPI, cfg))
+	task, err = app.businessAPI.GetTask(ctx, stale.Task.ID)
+	require.NoError(t, err)
+	assert.True(t, task.IsArchived())
+	task, err = app.businessAPI.GetTask(ctx, fresh.Task.ID)
+	require.NoError(t, err)
+	assert.False(t, task.IsArchived())
+
+	// Later commands the same day leave the tasks alone, the first command of the next day archives again
+	staler, err := app.businessAPI.AddTimeEntry(ctx, "Older experiment", old.Add(-2*time.Hour), old.Add(-time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, autoArchiveTasks(ctx, app.businessAPI, cfg))
+	task, err = app.businessAPI.GetTask(ctx, staler.Task.ID)
+	require.NoError(t, err)
+	assert.False(t, task.IsArchived(), "tasks are archived once a day")
+
+	originalTimeNow := timeNow
+	defer func() { timeNow = originalTimeNow }()
+	timeNow = func() time.Time { return originalTimeNow().AddDate(0, 0, 1) }
+	require.NoError(t, autoArchiveTasks(ctx, app.businessAPI, cfg))
+	task, err = app.businessAPI.GetTask(ctx, staler.Task.ID)
+	require.NoError(t, err)
+	assert.True(t, task.IsArchived())
+}
@@ -3,16 +3,21 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"time-tracker/internal/api"
 	"time-tracker/internal/config"
+	"time-tracker/internal/errors"
 )
 
 // RootCommand represents the base command when called without any subcommands
 type RootCommand struct {
-	cmd    *cobra.Command
-	config *config.Config
+	cmd     *cobra.Command
+	config  *config.Config
+	running *cobra.Command // The command being run, known once its flags are parsed
 }
 
 // NewRootCommand creates the root cobra command with global flags
@@ -27,22 +32,57 @@ func NewRootCommand(cfg *config.Config) *RootCommand {
 		Long: `Time Tracker (tt) is a command-line application for tracking time spent on tasks.
 
 FEATURES:
-  • Start and stop time tracking for named tasks
+  • Start and stop time tracking for named tasks, now or backdated with --at/--ago
+  • Catch tasks left running overnight and stop, cap or keep them
+  • Add forgotten sessions after the fact and edit existing entries
+  • Rename tasks and merge duplicates
+  • Group tasks into projects and clients with per-project totals
+  • Tag sessions with +billable or #meeting and filter on tags
+  • Attach a note to any session describing what was done
+  • Pause and continue a session without switching tasks
   • List and filter time entries by time range or task name  
-  • Export data to CSV format
+  • Export data to CSV, JSON, iCalendar, Markdown or a timesheet
+  • Import entries from tt, Toggl or Clockify exports
+  • JSON and NDJSON output from every command for scripting
   • Resume previous tasks from interactive menus
   • Generate detailed summaries and delete tasks
+  • Daily, weekly and monthly reports per task, project, tag or day
+  • Timeline of a day's sessions drawn in the terminal
+  • Archive old tasks to keep menus short while their time still counts
+  • Undo recent changes, including deletes, and review them in the history
+  • Check the database for overlaps and duplicates and fix them (tt doctor)
+  • Daily automatic backups, manual backups and restores (tt db)
+  • Local HTTP/JSON API and web dashboard (tt serve)
+  • Live stream of tracking changes for status bars (tt watch, SSE)
   • Fully configurable via environment variables and command-line flags
 
 EXAMPLES:
   tt start "Working on feature X"          # Start tracking a new task
+  tt add "Meeting" --from 09:00 --to 10:30 # Record time you forgot to track
+  tt edit 42 --start 09:15                 # Fix the start time of entry 42
+  tt task merge 7 3                        # Move task 7's entries into task 3
+  tt project add acme/website              # Add a project for the client acme
+  tt start "Landing page" --project acme/website
+  tt start "Standup" +meeting              # Tag the session as a meeting
+  tt note "reviewing PR 412"               # Attach a note to the running session
   tt list 2h                               # List tasks from last 2 hours
   tt list 1d "meeting"                     # List tasks from last day containing "meeting"
   tt current                               # Show currently running task
+  tt current --json                        # Show the running task as JSON for scripts
   tt stop                                  # Stop all running tasks
+  tt stop --ago 10m                        # Stop as of ten minutes ago
+  tt pause                                 # Take a break from the running task
+  tt continue                              # Pick the paused task up again
   tt resume                                # Resume a previous task (interactive)
   tt summary 1w                            # Summary of tasks from last week
+  tt summary this-month --project acme     # Time per acme project this month
+  tt report week                           # Time per task for each day of this week
+  tt timeline yesterday                    # Yesterday's sessions as a bar per task
   tt output format=csv > tasks.csv         # Export to CSV file
+  tt import tasks.csv --dry-run            # Check what an import would add
+  tt undo                                  # Revert the last change
+  tt doctor --fix                          # Find and fix overlapping entries and duplicate tasks
+  tt db backup                             # Back up the database to the backup directory
 
 CONFIGURATION:
   Configuration follows this priority order: command-line flags > environment variables > defaults
@@ -52,6 +92,10 @@ CONFIGURATION:
     TT_DB_FILENAME                         Database filename (default: tt.db)
     TT_DB_QUERY_TIMEOUT                    Query timeout (default: 10s)
     TT_DB_WRITE_TIMEOUT                    Write timeout (default: 5s)
+    TT_DB_BACKUP_DIR                       Backup directory (default: backups in TT_DB_DIR)
+    TT_DB_AUTO_BACKUP                      Back up on the first command of each day (default: true)
+    TT_DB_BACKUP_KEEP_DAILY                Daily backups to keep (default: 7)
+    TT_DB_BACKUP_KEEP_WEEKLY               Weekly backups to keep beyond those (default: 4)
   
   Display Configuration:
     TT_TIME_DISPLAY_FORMAT                 Time format (default: 2006-01-02 15:04:05)
@@ -69,12 +113,20 @@ CONFIGURATION:
     TT_APP_VERBOSE                         Enable verbose output (default: false)
   
   Command Configuration:
-    TT_LIST_DEFAULT_FORMAT                 Default list format (default: table)
+    TT_LIST_DEFAULT_FORMAT                 Output format: table, json or ndjson (default: table)
     TT_OUTPUT_DEFAULT_FORMAT               Default output format (default: csv)
+  
+  Task Configuration:
+    TT_AUTO_ARCHIVE_DAYS                   Archive tasks not worked on for this many days (default: 0, off)
+    TT_FORGOTTEN_AFTER                     Ask about a task running this long (default: 12h, 0 is off)
+    TT_FORGOTTEN_POLICY                    Without a terminal: warn, stop, cap or keep (default: warn)
 
 TIME FORMATS:
-  Use these shorthand formats for time filtering:
-    30m, 2h, 1d, 2w, 3mo, 1y              # Minutes, hours, days, weeks, months, years
+  Use these formats for time filtering:
+    30m, 2h, 3d, 2w, 3mo, 1y              # Last N minutes, hours, days, weeks, months, years
+    today, yesterday, this-week, last-week, this-month, last-month, this-year, last-year
+    2026-10-01                            # A single day
+    2026-10-01..2026-10-15                # Several days, both inclusive
 
 GETTING HELP:
   tt [command] --help                      # Get help for any specific command
@@ -83,6 +135,8 @@ GETTING HELP:
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			root.running = cmd
+
 			// Apply configuration overrides from flags before any command runs
 			return root.getConfigFromFlags()
 		},
@@ -111,6 +165,8 @@ func (r *RootCommand) addGlobalFlags() {
 	flags.String("db-filename", "", "Database filename (overrides TT_DB_FILENAME)")
 	flags.Duration("db-query-timeout", 0, "Database query timeout (overrides TT_DB_QUERY_TIMEOUT)")
 	flags.Duration("db-write-timeout", 0, "Database write timeout (overrides TT_DB_WRITE_TIMEOUT)")
+	flags.String("backup-dir", "", "Backup directory (overrides TT_DB_BACKUP_DIR)")
+	flags.Bool("no-auto-backup", false, "Skip the automatic daily backup (overrides TT_DB_AUTO_BACKUP)")
 
 	// Time configuration
 	flags.String("time-format", "", "Time display format (overrides TT_TIME_DISPLAY_FORMAT)")
@@ -130,8 +186,16 @@ func (r *RootCommand) addGlobalFlags() {
 	flags.Bool("verbose", false, "Enable verbose output (overrides TT_APP_VERBOSE)")
 
 	// Commands configuration
+	flags.String("format", "", "Output format for all commands: table, json or ndjson (overrides TT_LIST_DEFAULT_FORMAT)")
+	flags.Bool("json", false, "Shorthand for --format json")
 	flags.String("list-format", "", "Default list format (overrides TT_LIST_DEFAULT_FORMAT)")
 	flags.String("output-format", "", "Default output format (overrides TT_OUTPUT_DEFAULT_FORMAT)")
+
+	// Tasks configuration
+	flags.Int("auto-archive-days", 0, "Archive tasks not worked on for this many days (overrides TT_AUTO_ARCHIVE_DAYS)")
+	flags.Duration("forgotten-after", 0, "Ask about a task running this long (overrides TT_FORGOTTEN_AFTER)")
+	flags.String("forgotten-policy", "", "What to do with a forgotten task without a terminal: warn; stop it at the last activity tt saw, "+
+		"or cap it without one; cap it at the maximum duration; or keep it (overrides TT_FORGOTTEN_POLICY)")
 }
 
 // addSubcommands adds all CLI subcommands to the root command
@@ -140,41 +204,364 @@ func (r *RootCommand) addSubcommands() {
 	startCmd := &cobra.Command{
 		Use:   "start [task name]",
 		Short: "Start a new task",
-		Long:  "Start tracking time for a new task. If a task is already running, it will be stopped first.",
+		Long: `Start tracking time for a new task. If a task is already running, it will be stopped first.
+
+Use --project to file the task under a project created with "tt project add".
+The task stays in that project when it is started again later.
+
+Use -m to describe what you are working on; the note is shown by "tt list",
+matched by text filters and included in exports.
+
+Words starting with + or # tag the new session instead of becoming part of the
+task name. Tags are case-insensitive and must start with a letter. Quote #tags,
+since the shell treats # as the start of a comment.
+
+Use --at or --ago when you started working before running the command. The
+running task, if any, is stopped at the same moment; the new session may not
+start before the previous one ended.
+
+Examples:
+  tt start "Landing page"
+  tt start "Landing page" --project acme/website
+  tt start Standup +meeting +billable
+  tt start "Customer call #support"
+  tt start "Code review" -m "reviewing PR 412"
+  tt start "Landing page" --at 09:15
+  tt start Standup --ago 10m`,
 		Args:  cobra.MinimumNArgs(1), // Require at least one argument
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
 			defer cancel()
+
+			project, _ := cmd.Flags().GetString("project")
+			note, _ := cmd.Flags().GetString("note")
+			at, ago := getAtFlags(cmd)
 			
 			// Create app with default repository to get both API instances
-		app, err := NewAppWithDefaultRepository()
+		app, err := r.newApp()
 		if err != nil {
 			return fmt.Errorf("failed to initialize app: %w", err)
 		}
-		startHandler := NewStartCommand(app)
+		startHandler := NewStartCommandWithOptions(app, StartOptions{Project: project, Note: note, At: at, Ago: ago})
 			return startHandler.Execute(ctx, args)
 		},
 	}
+	startCmd.Flags().String("project", "", "File the task under an existing project (e.g. acme/website)")
+	startCmd.Flags().StringP("note", "m", "", "Attach a note to the new session")
+	addAtFlags(startCmd, "started")
+
+	// Note command
+	noteCmd := &cobra.Command{
+		Use:   "note [entry-id] <text>",
+		Short: "Attach a note to a time entry",
+		Long: `Attach a free-text note to the running time entry, or to an earlier entry when its
+ID is given first. Entry IDs are shown in brackets by "tt list". A new note replaces
+the existing one, and an empty note ("") removes it.
+
+Notes are shown by "tt list", matched by the text filter of list, summary and output,
+and included in CSV and JSON exports.
+
+Examples:
+  tt note "reviewing PR 412"
+  tt note 42 "customer asked about the invoice"
+  tt note 42 ""`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			noteHandler := NewNoteCommand(app)
+			return noteHandler.Execute(ctx, args)
+		},
+	}
+
+	// Add command
+	addCmd := &cobra.Command{
+		Use:   "add [task name]",
+		Short: "Add a completed time entry after the fact",
+		Long: `Record time for a task that was not tracked live, such as a forgotten meeting.
+
+Specify the period with --from and --to, or with --duration (optionally ending --ago
+some time before now). Entries overlapping existing time entries are rejected.
+
+Examples:
+  tt add "Team meeting" --from 09:00 --to 10:30
+  tt add "Code review" --from 14:00 --duration 45m
+  tt add "Support call" --duration 45m --ago 2h`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			from, _ := cmd.Flags().GetString("from")
+			to, _ := cmd.Flags().GetString("to")
+			duration, _ := cmd.Flags().GetDuration("duration")
+			ago, _ := cmd.Flags().GetDuration("ago")
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			addHandler := NewAddCommandWithOptions(app, AddOptions{
+				From:     from,
+				To:       to,
+				Duration: duration,
+				Ago:      ago,
+			})
+			return addHandler.Execute(ctx, args)
+		},
+	}
+	addCmd.Flags().String("from", "", "Start time (e.g. 09:00, 3pm, \"yesterday 14:00\", \"2 hours ago\" or 2026-10-12 09:00)")
+	addCmd.Flags().String("to", "", "End time, in the same forms as --from")
+	addCmd.Flags().Duration("duration", 0, "Length of the entry (e.g. 45m, 1h30m)")
+	addCmd.Flags().Duration("ago", 0, "How long ago the entry ended, used with --duration (e.g. 2h)")
+
+	// Edit command
+	editCmd := &cobra.Command{
+		Use:   "edit <entry-id>",
+		Short: "Edit an existing time entry",
+		Long: `Adjust the start or end time of a time entry, or reassign it to another task.
+
+Entry IDs are shown in brackets by "tt list". Times of day without a day, such as
+09:15 or 3pm, are taken to be on the same day the entry started. Changes that would overlap other entries are rejected.
+
+Examples:
+  tt edit 42 --start 09:15
+  tt edit 42 --start 09:15 --end 10:45
+  tt edit 42 --task "Code review"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			start, _ := cmd.Flags().GetString("start")
+			end, _ := cmd.Flags().GetString("end")
+			task, _ := cmd.Flags().GetString("task")
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			editHandler := NewEditCommandWithOptions(app, EditOptions{
+				Start: start,
+				End:   end,
+				Task:  task,
+			})
+			return editHandler.Execute(ctx, args)
+		},
+	}
+	editCmd.Flags().String("start", "", "New start time (e.g. 09:15, 9:15am, \"yesterday 17:00\" or 2026-10-12 09:15)")
+	editCmd.Flags().String("end", "", "New end time, in the same forms as --start")
+	editCmd.Flags().String("task", "", "Reassign the entry to this task (created if it does not exist)")
+
+	// Task command with rename and merge subcommands
+	taskCmd := &cobra.Command{
+		Use:   "task",
+		Short: "Rename or merge tasks",
+		Long: `Manage tasks directly. Tasks can be referred to by ID or by their exact name.
+
+Examples:
+  tt task rename 3 "Code review"
+  tt task rename "code reveiw" "Code review"
+  tt task merge "code reveiw" "Code review"`,
+	}
+
+	taskRenameCmd := &cobra.Command{
+		Use:   "rename <id|name> <new-name>",
+		Short: "Rename a task",
+		Long: `Rename a task. Renaming to a name already used by another task is rejected;
+use "tt task merge" to combine the two tasks instead.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			taskHandler := NewTaskCommand(app)
+			return taskHandler.Execute(ctx, append([]string{"rename"}, args...))
+		},
+	}
+
+	taskMergeCmd := &cobra.Command{
+		Use:   "merge <from> <into>",
+		Short: "Merge one task into another",
+		Long: `Move every time entry of the first task onto the second task and delete the
+now-empty first task. The change is made in a single transaction.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			taskHandler := NewTaskCommand(app)
+			return taskHandler.Execute(ctx, append([]string{"merge"}, args...))
+		},
+	}
+	taskCmd.AddCommand(taskRenameCmd, taskMergeCmd)
+
+	// Project command with add, list and archive subcommands
+	projectCmd := &cobra.Command{
+		Use:   "project",
+		Short: "Manage clients and projects",
+		Long: `Group tasks into projects, optionally under a client. Projects are named by
+their path, such as "acme/website" for the website project of the client acme.
+
+Examples:
+  tt project add acme/website
+  tt project list --all
+  tt project archive acme/website`,
+	}
+
+	projectAddCmd := &cobra.Command{
+		Use:   "add <client/project>",
+		Short: "Add a project",
+		Long:  "Add a project, creating its client (and any other missing parent) if needed.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			projectHandler := NewProjectCommand(app)
+			return projectHandler.Execute(ctx, append([]string{"add"}, args...))
+		},
+	}
+
+	projectListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List projects",
+		Long:  "List projects by path. Archived projects are only shown with --all.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			all, _ := cmd.Flags().GetBool("all")
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			projectHandler := NewProjectCommandWithOptions(app, ProjectOptions{All: all})
+			return projectHandler.Execute(ctx, []string{"list"})
+		},
+	}
+	projectListCmd.Flags().Bool("all", false, "Include archived projects")
+
+	projectArchiveCmd := &cobra.Command{
+		Use:   "archive <client/project>",
+		Short: "Archive a project",
+		Long: `Archive a project and its sub-projects. Archived projects are hidden from
+"tt project list" and cannot be used with "tt start --project", but their
+time entries are kept and still show up in reports.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			projectHandler := NewProjectCommand(app)
+			return projectHandler.Execute(ctx, append([]string{"archive"}, args...))
+		},
+	}
+	projectCmd.AddCommand(projectAddCmd, projectListCmd, projectArchiveCmd)
 
 	// Stop command
 	stopCmd := &cobra.Command{
 		Use:   "stop",
 		Short: "Stop all running tasks",
-		Long:  "Stop all currently running time tracking tasks.",
-		Args:  cobra.NoArgs,
+		Long: `Stop all currently running time tracking tasks.
+
+Use --at or --ago when you stopped working before running the command; the
+stop time may not be before the running session started.
+
+Examples:
+  tt stop
+  tt stop --at 17:30
+  tt stop --at "yesterday 18:00"
+  tt stop --ago 20m`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
 			defer cancel()
 			
 			// Create app with default repository to get both API instances
-		app, err := NewAppWithDefaultRepository()
+		app, err := r.newApp()
 		if err != nil {
 			return fmt.Errorf("failed to initialize app: %w", err)
 		}
-		stopHandler := NewStopCommand(app)
+		at, ago := getAtFlags(cmd)
+		stopHandler := NewStopCommandWithOptions(app, StopOptions{At: at, Ago: ago})
 			return stopHandler.Execute(ctx, args)
 		},
 	}
+	addAtFlags(stopCmd, "stopped")
+
+	// Pause command
+	pauseCmd := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause the running task",
+		Long: `Pause the running task. The session ends now and tt continue picks the same task
+up again as a linked segment, so the summary shows one session with its pauses.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+			
+			// Create app with default repository to get both API instances
+		app, err := r.newApp()
+		if err != nil {
+			return fmt.Errorf("failed to initialize app: %w", err)
+		}
+		pauseHandler := NewPauseCommand(app)
+			return pauseHandler.Execute(ctx, args)
+		},
+	}
+
+	// Continue command
+	continueCmd := &cobra.Command{
+		Use:   "continue",
+		Short: "Continue the paused task",
+		Long: `Continue the most recently paused task with a new segment linked to the paused one.
+The new segment keeps the tags of the paused one. Any running task is stopped first.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+			
+			// Create app with default repository to get both API instances
+		app, err := r.newApp()
+		if err != nil {
+			return fmt.Errorf("failed to initialize app: %w", err)
+		}
+		continueHandler := NewContinueCommand(app)
+			return continueHandler.Execute(ctx, args)
+		},
+	}
 
 	// List command
 	listCmd := &cobra.Command{
@@ -182,27 +569,40 @@ func (r *RootCommand) addSubcommands() {
 		Short: "List time entries",
 		Long: `List time entries with optional filtering.
 		
-Time filters support: 30m, 2h, 1d, 2w, 3mo, 1y
-Text filters search within task names (case-insensitive partial matching)
+Time filters support: 30m, 2h, 3d, 2w, 3mo, 1y, today, last-month, 2026-10-01, 2026-10-01..2026-10-15
+Text filters search within task names and notes (case-insensitive partial matching)
 
 Examples:
   tt list                    # List all entries
   tt list 1h                 # List entries from last hour
   tt list "project alpha"    # List entries containing "project alpha"
-  tt list 2d "meeting"       # List entries from last 2 days containing "meeting"`,
+  tt list 2d "meeting"       # List entries from last 2 days containing "meeting"
+  tt list 1w --project acme  # List last week's entries for acme and its projects, with totals
+  tt list today --tag meeting --exclude-tag billable`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
 			defer cancel()
+
+			project, _ := cmd.Flags().GetString("project")
+			tags, _ := cmd.Flags().GetStringSlice("tag")
+			excludeTags, _ := cmd.Flags().GetStringSlice("exclude-tag")
 			
 			// Create app with default repository to get both API instances
-		app, err := NewAppWithDefaultRepository()
+		app, err := r.newApp()
 		if err != nil {
 			return fmt.Errorf("failed to initialize app: %w", err)
 		}
-		listHandler := NewListCommand(app)
+		listHandler := NewListCommandWithOptions(app, ListOptions{
+			Project:     project,
+			Tags:        tags,
+			ExcludeTags: excludeTags,
+		})
 			return listHandler.Execute(ctx, args)
 		},
 	}
+	listCmd.Flags().String("project", "", "Only list entries of this project and its sub-projects")
+	listCmd.Flags().StringSlice("tag", nil, "Only list entries carrying this tag (repeatable, all must match)")
+	listCmd.Flags().StringSlice("exclude-tag", nil, "Leave out entries carrying this tag (repeatable)")
 
 	// Current command
 	currentCmd := &cobra.Command{
@@ -215,7 +615,7 @@ Examples:
 			defer cancel()
 			
 			// Create app with default repository to get both API instances
-		app, err := NewAppWithDefaultRepository()
+		app, err := r.newApp()
 		if err != nil {
 			return fmt.Errorf("failed to initialize app: %w", err)
 		}
@@ -226,83 +626,282 @@ Examples:
 
 	// Output command
 	outputCmd := &cobra.Command{
-		Use:   "output format=csv",
+		Use:   "output format=csv|json|ndjson|ics|md|timesheet",
 		Short: "Export data in specified format",
 		Long: `Export time tracking data in the specified format.
 		
 Supported formats:
-  csv - Comma-separated values format
+  csv       - Comma-separated values, one row per time entry
+  json      - JSON array of time entries
+  ndjson    - One JSON object per time entry per line
+  ics       - iCalendar file with one event per time entry
+  md        - Markdown table for pasting into reports
+  timesheet - CSV with one row per task and one column per day, in hours
 
-Example:
-  tt output format=csv`,
+Examples:
+  tt output format=csv
+  tt output format=ics --range this-month --out sessions.ics
+  tt output format=timesheet --range last-week --filter "acme"
+  tt output format=csv --range last-month --project acme --out acme.csv
+  tt output format=md --range 2026-10-01..2026-10-15
+  tt output format=csv --range last-month --tag billable --out billable.csv`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
 			defer cancel()
-			
+
+			timeRange, _ := cmd.Flags().GetString("range")
+			filter, _ := cmd.Flags().GetString("filter")
+			project, _ := cmd.Flags().GetString("project")
+			tags, _ := cmd.Flags().GetStringSlice("tag")
+			excludeTags, _ := cmd.Flags().GetStringSlice("exclude-tag")
+			out, _ := cmd.Flags().GetString("out")
+
 			// Create app with default repository to get both API instances
-		app, err := NewAppWithDefaultRepository()
-		if err != nil {
-			return fmt.Errorf("failed to initialize app: %w", err)
-		}
-		outputHandler := NewOutputCommand(app)
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			outputHandler := NewOutputCommandWithOptions(app, OutputOptions{
+				Range:       timeRange,
+				Filter:      filter,
+				Project:     project,
+				Tags:        tags,
+				ExcludeTags: excludeTags,
+				Out:         out,
+			})
 			return outputHandler.Execute(ctx, args)
 		},
 	}
+	outputCmd.Flags().String("range", "", "Only export entries in this time range (e.g. 2w, last-month, 2026-10-01..2026-10-15)")
+	outputCmd.Flags().String("filter", "", "Only export entries whose task name or note contains this text")
+	outputCmd.Flags().String("project", "", "Only export entries of this project and its sub-projects")
+	outputCmd.Flags().StringSlice("tag", nil, "Only export entries carrying this tag (repeatable, all must match)")
+	outputCmd.Flags().StringSlice("exclude-tag", nil, "Leave out entries carrying this tag (repeatable)")
+	outputCmd.Flags().String("out", "", "Write to this file instead of stdout")
+
+	// Import command
+	importCmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import time entries from an export file",
+		Long: `Import time entries from a file exported by tt or another time tracker.
+
+Supported formats:
+  csv      - The CSV written by "tt output format=csv"
+  json     - The JSON or NDJSON written by "tt output format=json|ndjson"
+  toggl    - Toggl Track detailed report CSV
+  clockify - Clockify detailed report CSV
+
+The format is taken from the file extension when --format is not given (.json and
+.ndjson are read as json, anything else as csv). Tasks that do not exist yet are
+created, and entries already recorded for the same task with the same start and end
+time are skipped, so importing the same file twice is safe. Everything is imported in
+a single transaction: if any entry is invalid, nothing is imported.
+
+Examples:
+  tt import backup.csv
+  tt import sessions.json --dry-run
+  tt import Toggl_time_entries.csv --format toggl`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			format, _ := cmd.Flags().GetString("format")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			importHandler := NewImportCommandWithOptions(app, ImportOptions{
+				Format: format,
+				DryRun: dryRun,
+			})
+			return importHandler.Execute(ctx, args)
+		},
+	}
+	// --format names the input format here; use --json for machine-readable results
+	importCmd.Flags().String("format", "", "Input format: csv, json, toggl or clockify (default: from the file extension)")
+	importCmd.Flags().Bool("dry-run", false, "Report what would be imported without changing the database")
 
 	// Resume command
 	resumeCmd := &cobra.Command{
 		Use:   "resume [time]",
 		Short: "Resume a previous task",
 		Long: `Resume a previous task by selecting from a list of recent tasks.
+Type to filter the list, move with the arrow keys and press Enter to select.
 		
-Time filters support: 30m, 2h, 1d, 2w, 3mo, 1y
+Time filters support: 30m, 2h, 3d, 2w, 3mo, 1y, today, last-month, 2026-10-01, 2026-10-01..2026-10-15
+
+With --id, --name or --last the task is picked without a prompt, which is
+required when stdin is not a terminal (scripts, cron jobs, editor integrations).
+
+Use --at or --ago when you went back to the task before running the command.
 
 Examples:
   tt resume      # Resume from today's tasks
-  tt resume 3d   # Resume from tasks in the last 3 days`,
+  tt resume 3d   # Resume from tasks in the last 3 days
+  tt resume --last           # Resume the most recently worked task
+  tt resume --name "Review"  # Resume the task named or uniquely matching "Review"
+  tt resume --last --ago 15m # Resume the last task as of 15 minutes ago`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Resume commands may need longer timeout for user interaction
 			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout()*2)
 			defer cancel()
 			
 			// Create app with default repository to get both API instances
-		app, err := NewAppWithDefaultRepository()
+		app, err := r.newApp()
 		if err != nil {
 			return fmt.Errorf("failed to initialize app: %w", err)
 		}
-		resumeHandler := NewResumeCommand(app)
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		at, ago := getAtFlags(cmd)
+		resumeHandler := NewResumeCommandWithOptions(app, ResumeOptions{
+			Select:          getTaskSelection(cmd),
+			IncludeArchived: includeArchived,
+			At:              at,
+			Ago:             ago,
+		})
 			return resumeHandler.Execute(ctx, args)
 		},
 	}
 
+	addTaskSelectionFlags(resumeCmd)
+	addAtFlags(resumeCmd, "resumed")
+
 	// Summary command
 	summaryCmd := &cobra.Command{
 		Use:   "summary [time] [text]",
 		Short: "Show detailed task summary",
 		Long: `Show a detailed summary for selected tasks with time breakdowns.
+When several tasks match, type to filter the list and press Enter to select.
 		
-Time filters support: 30m, 2h, 1d, 2w, 3mo, 1y
-Text filters search within task names
+Time filters support: 30m, 2h, 3d, 2w, 3mo, 1y, today, last-month, 2026-10-01, 2026-10-01..2026-10-15
+Text filters search within task names and notes
 
 Examples:
   tt summary           # Summary for all tasks
   tt summary 1w        # Summary for tasks from last week
-  tt summary "project" # Summary for tasks containing "project"`,
+  tt summary "project" # Summary for tasks containing "project"
+  tt summary 1w --project acme  # Time per project for acme last week
+  tt summary 1w --tag billable  # Only tasks with billable sessions last week
+  tt summary --id 12            # Summary for task 12 without a prompt`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Summary commands may need longer timeout for user interaction
 			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout()*2)
 			defer cancel()
+
+			project, _ := cmd.Flags().GetString("project")
+			tags, _ := cmd.Flags().GetStringSlice("tag")
+			excludeTags, _ := cmd.Flags().GetStringSlice("exclude-tag")
+			includeArchived, _ := cmd.Flags().GetBool("include-archived")
 			
 			// Create app with default repository to get both API instances
-		app, err := NewAppWithDefaultRepository()
+		app, err := r.newApp()
 		if err != nil {
 			return fmt.Errorf("failed to initialize app: %w", err)
 		}
-		summaryHandler := NewSummaryCommand(app)
+		summaryHandler := NewSummaryCommandWithOptions(app, SummaryOptions{
+			Project:         project,
+			Tags:            tags,
+			ExcludeTags:     excludeTags,
+			Select:          getTaskSelection(cmd),
+			IncludeArchived: includeArchived,
+		})
 			return summaryHandler.Execute(ctx, args)
 		},
 	}
+	summaryCmd.Flags().String("project", "", "Show time per project for this project and its sub-projects")
+	summaryCmd.Flags().StringSlice("tag", nil, "Only consider entries carrying this tag (repeatable, all must match)")
+	summaryCmd.Flags().StringSlice("exclude-tag", nil, "Leave out entries carrying this tag (repeatable)")
+	addTaskSelectionFlags(summaryCmd)
+
+	// Report command
+	reportCmd := &cobra.Command{
+		Use:   "report day|week|month [date]",
+		Short: "Report the time per task, project, tag or day over a period",
+		Long: `Report the time tracked over the calendar day, week (Monday to Sunday) or month
+containing date (e.g. 2026-10-14, yesterday or last friday; default today), with a column per day for weeks and
+months, grand totals and each row's share of the total. Entries spanning midnight
+are split across days, and the running entry counts up to now and is marked with *.
+
+Rows are tasks unless --group-by says otherwise. Grouped by tag, an entry with
+several tags counts towards each of them.
+
+Formats:
+  table  - Aligned columns in hours and minutes (default)
+  csv    - One row per group and a column per day, in hours
+  md     - Markdown table for pasting into reports
+  json   - The whole report as one JSON document
+  ndjson - The whole report as one line of JSON
+
+Examples:
+  tt report week
+  tt report day 2026-10-14
+  tt report week "last monday"
+  tt report month --group-by project
+  tt report week --group-by tag --format md
+  tt report month 2026-09-01 --format csv > september.csv`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			groupBy, _ := cmd.Flags().GetString("group-by")
+			format, _ := cmd.Flags().GetString("format")
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			reportHandler := NewReportCommandWithOptions(app, ReportOptions{
+				GroupBy: groupBy,
+				Format:  format,
+			})
+			return reportHandler.Execute(ctx, args)
+		},
+	}
+	reportCmd.Flags().String("group-by", "task", "Rows of the report: task, project, tag or day")
+	// --format also takes the export formats here; without it the global --format or --json applies
+	reportCmd.Flags().String("format", "", "Report format: table, csv, md, json or ndjson (default: the global output format)")
+
+	// Timeline command
+	timelineCmd := &cobra.Command{
+		Use:   "timeline [date]",
+		Short: "Draw a day's sessions as a bar per task",
+		Long: `Draw the sessions of a day (e.g. 2026-10-14, yesterday or monday; default today) as a
+horizontal bar per task, so gaps and switches between tasks stand out. The bars
+span the working hours from 08 to 18, widened to any session outside them, or the
+whole day with --full-day. The drawing is TT_DISPLAY_SUMMARY_WIDTH characters wide.
+
+  █ tracked  ▒ running  ▚ overlapping another entry  · idle
+
+Examples:
+  tt timeline
+  tt timeline yesterday
+  tt timeline "last friday"
+  tt timeline 2026-10-14 --full-day
+  tt timeline --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			fullDay, _ := cmd.Flags().GetBool("full-day")
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			timelineHandler := NewTimelineCommandWithOptions(app, TimelineOptions{FullDay: fullDay})
+			return timelineHandler.Execute(ctx, args)
+		},
+	}
+	timelineCmd.Flags().Bool("full-day", false, "Show all 24 hours instead of the working hours")
 
 	// Delete command
 	deleteCmd := &cobra.Command{
@@ -310,8 +909,15 @@ Examples:
 		Short: "Delete a task and all its time entries",
 		Long: `Delete a task and all its associated time entries.
 		
-This operation cannot be undone. You will be prompted to select
-which task to delete from a list of available tasks.`,
+You will be prompted to select which task to delete from a list of
+available tasks. Run tt undo right after to bring the task back.
+
+With --id, --name or --last the task is picked without the list and you are
+asked to confirm instead; --yes skips the confirmation for unattended use.
+
+Examples:
+  tt delete                  # Pick the task to delete from a list
+  tt delete --id 12 --yes    # Delete task 12 without any prompt`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Delete commands may need longer timeout for user interaction
@@ -319,28 +925,475 @@ which task to delete from a list of available tasks.`,
 			defer cancel()
 			
 			// Create app with default repository to get both API instances
-		app, err := NewAppWithDefaultRepository()
+		app, err := r.newApp()
 		if err != nil {
 			return fmt.Errorf("failed to initialize app: %w", err)
 		}
-		deleteHandler := NewDeleteCommand(app)
+		yes, _ := cmd.Flags().GetBool("yes")
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		deleteHandler := NewDeleteCommandWithOptions(app, DeleteOptions{
+			Select:          getTaskSelection(cmd),
+			Yes:             yes,
+			IncludeArchived: includeArchived,
+		})
 			return deleteHandler.Execute(ctx, args)
 		},
 	}
+	addTaskSelectionFlags(deleteCmd)
+	deleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+
+	// Archive command
+	archiveCmd := &cobra.Command{
+		Use:   "archive <id|name>...",
+		Short: "Archive tasks you no longer work on",
+		Long: `Archive tasks by ID or exact name. Archived tasks no longer appear in the resume,
+summary and delete menus, while their time still counts in lists, exports and
+project totals. Pass --include-archived to those commands to see them again.
+
+Starting or resuming an archived task brings it back automatically. Set
+TT_AUTO_ARCHIVE_DAYS to archive tasks that have not been worked on for that many days.
+
+Examples:
+  tt archive 12                       # Archive task 12
+  tt archive "Old experiment"         # Archive a task by name`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+			
+			// Create app with default repository to get both API instances
+		app, err := r.newApp()
+		if err != nil {
+			return fmt.Errorf("failed to initialize app: %w", err)
+		}
+		archiveHandler := NewArchiveCommand(app)
+			return archiveHandler.Execute(ctx, args)
+		},
+	}
+
+	// Unarchive command
+	unarchiveCmd := &cobra.Command{
+		Use:   "unarchive <id|name>...",
+		Short: "Bring archived tasks back",
+		Long: `Bring archived tasks back into the resume, summary and delete menus.
+
+Examples:
+  tt unarchive 12                     # Unarchive task 12`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+			
+			// Create app with default repository to get both API instances
+		app, err := r.newApp()
+		if err != nil {
+			return fmt.Errorf("failed to initialize app: %w", err)
+		}
+		unarchiveHandler := NewUnarchiveCommand(app)
+			return unarchiveHandler.Execute(ctx, args)
+		},
+	}
+
+	// Undo command
+	undoCmd := &cobra.Command{
+		Use:   "undo [count]",
+		Short: "Undo the last changes",
+		Long: `Undo the last change, or the last count changes, made by tt. Starting, stopping,
+adding, editing, renaming, merging, importing and deleting can all be undone;
+deleted tasks and entries come back with their original IDs.
+
+Examples:
+  tt undo                    # Undo the last change
+  tt undo 3                  # Undo the last three changes`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+			
+			// Create app with default repository to get both API instances
+		app, err := r.newApp()
+		if err != nil {
+			return fmt.Errorf("failed to initialize app: %w", err)
+		}
+		undoHandler := NewUndoCommand(app)
+			return undoHandler.Execute(ctx, args)
+		},
+	}
+
+	// History command
+	historyCmd := &cobra.Command{
+		Use:   "history [count]",
+		Short: "Show the recent changes that can be undone",
+		Long: `Show the most recent changes made by tt, newest first (10 unless count is given).
+Changes already reverted with tt undo are marked as undone.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+			
+			// Create app with default repository to get both API instances
+		app, err := r.newApp()
+		if err != nil {
+			return fmt.Errorf("failed to initialize app: %w", err)
+		}
+		historyHandler := NewHistoryCommand(app)
+			return historyHandler.Execute(ctx, args)
+		},
+	}
+
+	// Doctor command
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the time database for inconsistencies",
+		Long: `Scan every task and time entry for problems that tt itself avoids but that
+imports, crashes or hand edits of the database can leave behind:
+
+  overlap            two entries covering the same time
+  multiple_running   more than one running entry
+  end_before_start   an entry ending before it starts
+  too_long           an entry longer than TT_VALIDATION_MAX_DURATION
+  orphaned_entry     an entry whose task no longer exists
+  duplicate_task     tasks whose names differ only in case or spaces
+
+Each problem is listed with the IDs of its entries or tasks. tt doctor exits with
+an error while problems remain.
+
+--fix applies every fix strategy; --fix=strategy,... applies only those named:
+
+  stop-extras        stop all running entries but the latest, when it started
+  merge-duplicates   move the entries of duplicate tasks into the oldest one
+  trim-overlaps      end each overlapping entry when the next one starts
+
+The fixes are applied in a single transaction and can be taken back with tt undo.
+The other problems need fixing by hand with tt edit, tt task or tt delete.
+
+Examples:
+  tt doctor
+  tt doctor --fix
+  tt doctor --fix=stop-extras,merge-duplicates
+  tt doctor --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			fix, _ := cmd.Flags().GetStringSlice("fix")
+			var strategies []string
+			for _, strategy := range fix {
+				if strategy == "all" {
+					strategies = append(strategies, api.FixStrategies...)
+					continue
+				}
+				strategies = append(strategies, strategy)
+			}
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			doctorHandler := NewDoctorCommandWithOptions(app, DoctorOptions{Fix: strategies})
+			return doctorHandler.Execute(ctx, args)
+		},
+	}
+	doctorCmd.Flags().StringSlice("fix", nil, "Fix the problems found: all, or any of "+strings.Join(api.FixStrategies, ", "))
+	doctorCmd.Flags().Lookup("fix").NoOptDefVal = "all"
+
+	// Database command with backup, restore and list subcommands
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Back up and restore the time database",
+		Long: `Back up the time database and restore it from a backup.
+
+The first tt command of each day backs the database up to tt-YYYY-MM-DD.db in
+the backup directory (TT_DB_BACKUP_DIR, by default the backups directory next to
+the database). The newest TT_DB_BACKUP_KEEP_DAILY of these daily backups are kept,
+plus the newest one of each of the TT_DB_BACKUP_KEEP_WEEKLY weeks before; older
+ones are deleted. Backups taken with tt db backup are never deleted.
+
+Examples:
+  tt db backup
+  tt db backup ~/tt-before-cleanup.db
+  tt db list
+  tt db restore tt-2026-10-14.db`,
+	}
+
+	dbBackupCmd := &cobra.Command{
+		Use:   "backup [path]",
+		Short: "Back up the database",
+		Long: `Write a copy of the database to path, or to a file named after the current time
+in the backup directory. The copy is consistent even while other tt commands or
+tt serve are using the database. An existing file is never overwritten.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			dbHandler := NewDBCommand(app)
+			return dbHandler.Execute(ctx, append([]string{"backup"}, args...))
+		},
+	}
+
+	dbRestoreCmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Replace the database with a backup",
+		Long: `Replace the database with the backup in file; a file name alone is also looked up
+in the backup directory. The backup must be a tt database whose schema this
+version of tt knows; backups from older versions are upgraded once restored.
+
+The database is backed up to tt-before-restore-<time>.db first, so restoring that
+file takes the restore back.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			yes, _ := cmd.Flags().GetBool("yes")
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			dbHandler := NewDBCommandWithOptions(app, DBOptions{Yes: yes})
+			return dbHandler.Execute(ctx, append([]string{"restore"}, args...))
+		},
+	}
+	dbRestoreCmd.Flags().BoolP("yes", "y", false, "Restore without asking for confirmation")
+
+	dbListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the backups in the backup directory",
+		Long:  "List the backups in the backup directory, newest first, marking the automatic daily ones.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), r.getAppTimeout())
+			defer cancel()
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			dbHandler := NewDBCommand(app)
+			return dbHandler.Execute(ctx, []string{"list"})
+		},
+	}
+	dbCmd.AddCommand(dbBackupCmd, dbRestoreCmd, dbListCmd)
+
+	// Serve command
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the tt API over HTTP",
+		Long: `Serve a local REST API with JSON bodies, so editor plugins and menu-bar widgets
+can start, stop and query tasks through a single running tt process. The server
+runs until interrupted with Ctrl-C.
+
+Open the address in a browser for a dashboard with the running timer, today's and
+this week's totals, time per task and a daily timeline. It is built into tt and
+loads nothing from the internet.
+
+Endpoints:
+  GET  /api/current              The running task (404 when nothing is running)
+  POST /api/start                Start a task: {"task": "...", "project": "...", "tags": [...], "note": "..."}
+  POST /api/stop                 Stop all running tasks
+  POST /api/tasks/{id}/resume    Resume a task
+  GET  /api/tasks/{id}/summary   Summary of a task
+  GET  /api/tasks                Search tasks: ?range=&text=&project=&tag=&exclude_tag=&include_archived=&sort=
+  GET  /api/entries              Search time entries, with the same filters
+  GET  /api/dashboard            Running task, recent tasks and today's statistics: ?range=today
+  GET  /api/events               Server-Sent Events stream of changes, as printed by tt watch
+
+Errors are returned as {"error": {"type": ..., "code": ..., "message": ...}} with
+status 400 for invalid input, 404 when something is not found and 422 when the
+request conflicts with the stored data. The API has no authentication unless
+given a token, so tt serve refuses addresses other machines can reach, such as
+0.0.0.0:8080, unless --allow-remote and --token are given. With a token every
+request must send the header Authorization: Bearer <token>.
+
+POST requests must send Content-Type: application/json. Requests naming another
+host, or sent by pages of another origin, are refused with 403, so web pages open
+in a browser cannot use the API.
+
+Examples:
+  tt serve                        # Listen on 127.0.0.1:7070
+  tt serve --addr 127.0.0.1:8080
+  tt serve --addr 0.0.0.0:7070 --allow-remote --token "$(cat ~/.tt-token)"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// The server runs until interrupted, so it gets no timeout
+			ctx := context.Background()
+
+			addr, _ := cmd.Flags().GetString("addr")
+			allowRemote, _ := cmd.Flags().GetBool("allow-remote")
+			token, _ := cmd.Flags().GetString("token")
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			serveHandler := NewServeCommandWithOptions(app, ServeOptions{Addr: addr, AllowRemote: allowRemote, Token: token})
+			return serveHandler.Execute(ctx, args)
+		},
+	}
+
+	serveCmd.Flags().String("addr", defaultServeAddr, "Host and port to listen on")
+	serveCmd.Flags().Bool("allow-remote", false, "Allow an address other machines can reach; requires --token")
+	serveCmd.Flags().String("token", "", "Bearer token every request must send")
+
+	// Watch command
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Print changes to the tracking state as they happen",
+		Long: `Print the running task, then a line each time a task is started, stopped,
+paused, resumed, continued, renamed, merged or deleted, or a change is undone,
+by any tt process, until interrupted with Ctrl-C. Status bar widgets can read the stream instead of
+polling tt current.
+
+With --json (or --format ndjson) each change is written as one line of JSON:
+  {"id":12,"type":"started","description":"Started \"Code review\"","time":"...","current":{...}}
+
+The type is one of current, started, stopped, resumed, task_renamed, deleted
+and undone;
+current holds the session running after the change, or null.
+
+Examples:
+  tt watch
+  tt watch --json | jq -r .type`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Watching runs until interrupted, so it gets no timeout
+			ctx := context.Background()
+
+			// Create app with default repository to get both API instances
+			app, err := r.newApp()
+			if err != nil {
+				return fmt.Errorf("failed to initialize app: %w", err)
+			}
+			watchHandler := NewWatchCommand(app)
+			return watchHandler.Execute(ctx, args)
+		},
+	}
 
 	// Add all subcommands to root
 	r.cmd.AddCommand(
 		startCmd,
+		noteCmd,
+		addCmd,
+		editCmd,
+		taskCmd,
+		projectCmd,
 		stopCmd,
+		pauseCmd,
+		continueCmd,
 		listCmd,
 		currentCmd,
 		outputCmd,
+		importCmd,
 		resumeCmd,
 		summaryCmd,
+		reportCmd,
+		timelineCmd,
 		deleteCmd,
+		archiveCmd,
+		unarchiveCmd,
+		undoCmd,
+		historyCmd,
+		doctorCmd,
+		dbCmd,
+		serveCmd,
+		watchCmd,
 	)
 }
 
+// addTaskSelectionFlags adds the flags that pick a task without prompting, and --include-archived
+// to offer archived tasks too
+func addTaskSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64("id", 0, "Select the task with this ID")
+	cmd.Flags().String("name", "", "Select the task with this name, or the only task whose name contains it")
+	cmd.Flags().Bool("last", false, "Select the most recently worked task")
+	cmd.Flags().Bool("include-archived", false, "Also offer archived tasks")
+}
+
+// addAtFlags adds the --at and --ago flags that backdate a start, stop or resume
+func addAtFlags(cmd *cobra.Command, verb string) {
+	cmd.Flags().String("at", "", fmt.Sprintf("When work %s, e.g. 09:15, 3pm, \"yesterday 17:00\", \"last friday 09:30\" or \"10 minutes ago\"", verb))
+	cmd.Flags().String("ago", "", fmt.Sprintf("How long ago work %s, e.g. 10m or 1h30m", verb))
+}
+
+// getAtFlags reads the flags added by addAtFlags
+func getAtFlags(cmd *cobra.Command) (string, string) {
+	at, _ := cmd.Flags().GetString("at")
+	ago, _ := cmd.Flags().GetString("ago")
+	return at, ago
+}
+
+// getTaskSelection reads the flags added by addTaskSelectionFlags
+func getTaskSelection(cmd *cobra.Command) TaskSelection {
+	id, _ := cmd.Flags().GetInt64("id")
+	name, _ := cmd.Flags().GetString("name")
+	last, _ := cmd.Flags().GetBool("last")
+	return TaskSelection{ID: id, Name: name, Last: last}
+}
+
+// newApp creates the application with the root configuration so flag overrides reach every command,
+// then does the housekeeping selected for the command being run
+func (r *RootCommand) newApp() (*App, error) {
+	var app *App
+	var err error
+	if r.config == nil {
+		app, err = NewAppWithDefaultRepository()
+	} else {
+		app, err = NewAppWithDefaultRepositoryAndConfig(r.config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := app.runStartupChecks(context.Background(), r.startupChecks(), os.Stderr); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// repairCommands are run to repair or replace the database or to take changes back, so no housekeeping
+// changes the database before them
+var repairCommands = map[string]bool{"db": true, "doctor": true, "undo": true}
+
+// unattendedCommands run with nobody to answer a question, or only complete the command line,
+// so they never ask about a forgotten timer
+var unattendedCommands = map[string]bool{
+	"serve": true, "watch": true, "completion": true, cobra.ShellCompRequestCmd: true, cobra.ShellCompNoDescRequestCmd: true,
+}
+
+// startupChecks selects the housekeeping for the command being run
+func (r *RootCommand) startupChecks() startupChecks {
+	if r.running == nil {
+		return startupChecks{}
+	}
+	name := topLevelName(r.running)
+	// Given --at or --ago, a command says itself when the running task ended, so it is not asked
+	backdated := r.running.Flags().Changed("at") || r.running.Flags().Changed("ago")
+	return startupChecks{
+		autoArchive:    !repairCommands[name],
+		forgottenTimer: !repairCommands[name] && !unattendedCommands[name] && !backdated,
+	}
+}
+
+// topLevelName returns the name of the tt command cmd belongs to, such as "db" for tt db restore
+func topLevelName(cmd *cobra.Command) string {
+	for cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	return cmd.Name()
+}
+
 // getAppTimeout returns the configured application timeout
 func (r *RootCommand) getAppTimeout() time.Duration {
 	if r.config != nil {
@@ -370,6 +1423,12 @@ func (r *RootCommand) getConfigFromFlags() error {
 	if writeTimeout, _ := flags.GetDuration("db-write-timeout"); writeTimeout > 0 {
 		r.config.Database.WriteTimeout = writeTimeout
 	}
+	if backupDir, _ := flags.GetString("backup-dir"); backupDir != "" {
+		r.config.Database.BackupDir = backupDir
+	}
+	if noAutoBackup, _ := flags.GetBool("no-auto-backup"); noAutoBackup {
+		r.config.Database.AutoBackup = false
+	}
 
 	// Time configuration
 	if timeFormat, _ := flags.GetString("time-format"); timeFormat != "" {
@@ -413,6 +1472,31 @@ func (r *RootCommand) getConfigFromFlags() error {
 	if outputFormat, _ := flags.GetString("output-format"); outputFormat != "" {
 		r.config.Commands.OutputDefaultFormat = outputFormat
 	}
+	if format, _ := flags.GetString("format"); format != "" {
+		r.config.Commands.ListDefaultFormat = format
+	}
+	if jsonOutput, _ := flags.GetBool("json"); jsonOutput {
+		r.config.Commands.ListDefaultFormat = string(FormatJSON)
+	}
+	if _, err := ParseOutputFormat(r.config.Commands.ListDefaultFormat); err != nil {
+		return err
+	}
+
+	// Tasks configuration
+	if autoArchiveDays, _ := flags.GetInt("auto-archive-days"); autoArchiveDays > 0 {
+		r.config.Tasks.AutoArchiveDays = autoArchiveDays
+	}
+	if forgottenAfter, _ := flags.GetDuration("forgotten-after"); forgottenAfter > 0 {
+		r.config.Tasks.ForgottenAfter = forgottenAfter
+	}
+	if forgottenPolicy, _ := flags.GetString("forgotten-policy"); forgottenPolicy != "" {
+		switch forgottenPolicy {
+		case config.ForgottenPolicyWarn, config.ForgottenPolicyStop, config.ForgottenPolicyCap, config.ForgottenPolicyKeep:
+			r.config.Tasks.ForgottenPolicy = forgottenPolicy
+		default:
+			return errors.NewInvalidInputError("forgotten-policy", forgottenPolicy, "expected warn, stop, cap or keep")
+		}
+	}
 
 	return nil
 }
@@ -23,13 +23,31 @@ func NewCommandRegistry(app *App) *CommandRegistry {
 	
 	// Register all commands
 	registry.Register("start", NewStartCommand(app))
+	registry.Register("note", NewNoteCommand(app))
+	registry.Register("add", NewAddCommand(app))
+	registry.Register("edit", NewEditCommand(app))
+	registry.Register("task", NewTaskCommand(app))
+	registry.Register("project", NewProjectCommand(app))
 	registry.Register("stop", NewStopCommand(app))
+	registry.Register("pause", NewPauseCommand(app))
+	registry.Register("continue", NewContinueCommand(app))
 	registry.Register("list", NewListCommand(app))
 	registry.Register("current", NewCurrentCommand(app))
 	registry.Register("output", NewOutputCommand(app))
+	registry.Register("import", NewImportCommand(app))
 	registry.Register("resume", NewResumeCommand(app))
 	registry.Register("summary", NewSummaryCommand(app))
+	registry.Register("report", NewReportCommand(app))
+	registry.Register("timeline", NewTimelineCommand(app))
 	registry.Register("delete", NewDeleteCommand(app))
+	registry.Register("archive", NewArchiveCommand(app))
+	registry.Register("unarchive", NewUnarchiveCommand(app))
+	registry.Register("undo", NewUndoCommand(app))
+	registry.Register("history", NewHistoryCommand(app))
+	registry.Register("doctor", NewDoctorCommand(app))
+	registry.Register("db", NewDBCommand(app))
+	registry.Register("serve", NewServeCommand(app))
+	registry.Register("watch", NewWatchCommand(app))
 	
 	return registry
 }
@@ -50,5 +68,5 @@ func (r *CommandRegistry) Execute(ctx context.Context, commandName string, args
 
 // GetUsage returns the usage string for the CLI
 func (r *CommandRegistry) GetUsage() string {
-	return "usage: tt start \"your text here\" or tt stop or tt list [time] [text] or tt current or tt output format=csv or tt summary [time] [text] or tt resume or tt delete"
+	return "usage: tt start \"your text here\" [+tag] [-m note] [--at time|--ago duration] or tt note [entry-id] \"text\" or tt add \"task\" --from 09:00 --to 10:30 or tt edit <entry-id> --start 09:15 or tt task rename|merge or tt project add|list|archive or tt stop [--at time|--ago duration] or tt pause or tt continue or tt list [time] [text] [--tag tag] or tt current or tt output format=csv or tt import <file> or tt summary [time] [text] [--last] or tt report day|week|month [date] [--group-by task|project|tag|day] or tt timeline [date] [--full-day] or tt resume [--last] or tt delete [--last] [--yes] or tt archive <id|name> or tt unarchive <id|name> or tt undo [count] or tt history [count] or tt doctor [--fix[=strategy,...]] or tt db backup|restore|list or tt serve [--addr host:port] or tt watch [--json]"
 }
\ No newline at end of file
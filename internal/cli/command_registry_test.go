@@ -92,6 +92,8 @@ func TestCommandRegistry_GetUsage(t *testing.T) {
 	assert.Contains(t, usage, "current")
 	assert.Contains(t, usage, "list")
 	assert.Contains(t, usage, "output")
+	assert.Contains(t, usage, "import")
+	assert.Contains(t, usage, "project")
 	assert.Contains(t, usage, "resume")
 	assert.Contains(t, usage, "summary")
 	assert.Contains(t, usage, "delete")
@@ -110,6 +112,8 @@ func TestCommandRegistry_CommandsRegistered(t *testing.T) {
 		"current",
 		"list",
 		"output",
+		"import",
+		"project",
 		"resume",
 		"summary",
 		"delete",
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// ContinueCommand handles the continue command, which picks the paused task up again as a linked segment
+type ContinueCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+}
+
+// NewContinueCommand creates a new continue command handler
+func NewContinueCommand(app *App) *ContinueCommand {
+	return &ContinueCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+	}
+}
+
+// Execute runs the continue command
+func (c *ContinueCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return errors.NewInvalidInputError("command", "continue", "usage: tt continue")
+	}
+
+	session, err := c.businessAPI.ContinueTask(ctx)
+	if err != nil {
+		return c.errorHandler.Handle("continue task", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newEntryRecord(session.TimeEntry, session.Task))
+	}
+
+	message := "Continued: " + session.Task.TaskName
+	if session.TimeEntry.ContinuesID != nil {
+		if paused, err := c.businessAPI.GetTimeEntry(ctx, *session.TimeEntry.ContinuesID); err == nil && paused.TimeEntry.EndTime != nil {
+			message += fmt.Sprintf(" (paused %s)", formatDurationHuman(session.TimeEntry.StartTime.Sub(*paused.TimeEntry.EndTime)))
+		}
+	}
+	if len(session.TimeEntry.Tags) > 0 {
+		message += " " + formatTags(session.TimeEntry.Tags)
+	}
+	fmt.Println(message)
+	return nil
+}
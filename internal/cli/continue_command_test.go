@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"time-tracker/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContinueCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("continues the paused task as a linked segment", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		started, err := app.businessAPI.StartNewTaskWithOptions(ctx, "Write report", api.StartOptions{Tags: []string{"billable"}})
+		require.NoError(t, err)
+		require.NoError(t, NewPauseCommand(app).Execute(ctx, []string{}))
+
+		var out bytes.Buffer
+		cmd := NewContinueCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		var record entryRecord
+		require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+		assert.NotEqual(t, started.TimeEntry.ID, record.ID)
+		assert.Equal(t, started.Task.ID, record.TaskID)
+		assert.True(t, record.Running)
+		require.NotNil(t, record.ContinuesID)
+		assert.Equal(t, started.TimeEntry.ID, *record.ContinuesID)
+		assert.Equal(t, []string{"billable"}, record.Tags)
+	})
+
+	t.Run("summary counts the segments as one session", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		started, err := app.businessAPI.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			require.NoError(t, NewPauseCommand(app).Execute(ctx, []string{}))
+			require.NoError(t, NewContinueCommand(app).Execute(ctx, []string{}))
+		}
+
+		var out bytes.Buffer
+		cmd := NewSummaryCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.showTaskSummary(ctx, started.Task.ID))
+
+		var record summaryRecord
+		require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+		assert.Len(t, record.Entries, 3)
+		assert.Equal(t, 1, record.SessionCount)
+		assert.Equal(t, 2, record.PauseCount)
+		assert.Equal(t, 1, record.RunningCount)
+	})
+
+	t.Run("requires a paused task", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		_, err := app.businessAPI.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		_, err = app.businessAPI.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+
+		err = NewContinueCommand(app).Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no task is paused")
+	})
+}
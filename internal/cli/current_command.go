@@ -11,11 +11,15 @@ import (
 // CurrentCommand handles the current command
 type CurrentCommand struct {
 	businessAPI api.BusinessAPI
+	printer     *Printer
 }
 
 // NewCurrentCommand creates a new current command handler
 func NewCurrentCommand(app *App) *CurrentCommand {
-	return &CurrentCommand{businessAPI: app.businessAPI}
+	return &CurrentCommand{
+		businessAPI: app.businessAPI,
+		printer:     app.newPrinter(),
+	}
 }
 
 // Execute runs the current command
@@ -31,12 +35,19 @@ func (c *CurrentCommand) showCurrentTask(ctx context.Context) error {
 		// Check if it's a "not found" error
 		var appErr *appErrors.AppError
 		if errors.As(err, &appErr) && appErr.IsType(appErrors.ErrorTypeNotFound) {
+			if c.printer.IsStructured() {
+				return c.printer.Emit(nil)
+			}
 			fmt.Println("No task is currently running")
 			return nil
 		}
 		return fmt.Errorf("failed to get current session: %w", err)
 	}
 
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newEntryRecord(session.TimeEntry, session.Task))
+	}
+
 	fmt.Printf("Current task: %s (%s)\n", session.Task.TaskName, session.Duration)
 	return nil
 }
\ No newline at end of file
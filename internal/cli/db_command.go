@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/config"
+	"time-tracker/internal/errors"
+)
+
+// dbUsage describes the subcommands of tt db
+const dbUsage = "usage: tt db backup [path], tt db restore <file> [--yes] or tt db list"
+
+// DBOptions holds the flags accepted by the db command
+type DBOptions struct {
+	Yes bool // Restore without asking for confirmation
+}
+
+// DBCommand handles the database maintenance subcommands (backup, restore, list)
+type DBCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	backupDir    string
+	options      DBOptions
+}
+
+// NewDBCommand creates a new db command handler
+func NewDBCommand(app *App) *DBCommand {
+	return NewDBCommandWithOptions(app, DBOptions{})
+}
+
+// NewDBCommandWithOptions creates a new db command handler with the given flag values
+func NewDBCommandWithOptions(app *App, options DBOptions) *DBCommand {
+	cfg := app.config
+	if cfg == nil {
+		cfg = config.NewConfig()
+	}
+	return &DBCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		backupDir:    cfg.GetBackupDir(),
+		options:      options,
+	}
+}
+
+// Execute runs the db command, dispatching on the subcommand in args[0]
+func (c *DBCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.NewInvalidInputError("command", "db", dbUsage)
+	}
+
+	switch args[0] {
+	case "backup":
+		return c.backup(ctx, args[1:])
+	case "restore":
+		return c.restore(ctx, args[1:])
+	case "list":
+		return c.list(ctx, args[1:])
+	default:
+		return errors.NewInvalidInputError("subcommand", args[0], "expected backup, restore or list")
+	}
+}
+
+// backup implements tt db backup [path]
+func (c *DBCommand) backup(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return errors.NewInvalidInputError("command", "db backup", "usage: tt db backup [path]")
+	}
+	path := ""
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	backup, err := c.businessAPI.BackupDatabase(ctx, c.backupDir, path)
+	if err != nil {
+		return c.errorHandler.Handle("back up the database", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(backup)
+	}
+	fmt.Fprintf(c.printer.out, "Backed up the database to %s (%s)\n", backup.Path, formatBackupSize(backup.Size))
+	return nil
+}
+
+// restore implements tt db restore <file> [--yes]
+func (c *DBCommand) restore(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.NewInvalidInputError("command", "db restore", "usage: tt db restore <file> [--yes]")
+	}
+
+	if !c.options.Yes {
+		confirmed, err := confirm(c.printer, fmt.Sprintf("Replace the database with %s? It is backed up first.", args[0]))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			c.printer.Infof("Restore cancelled.\n")
+			return nil
+		}
+	}
+
+	restore, err := c.businessAPI.RestoreDatabase(ctx, c.backupDir, args[0])
+	if err != nil {
+		return c.errorHandler.Handle("restore the database", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(restore)
+	}
+	fmt.Fprintf(c.printer.out, "Restored the database from %s\n", restore.Restored.Path)
+	fmt.Fprintf(c.printer.out, "The database as it was is saved in %s; run tt db restore %s to take this back.\n",
+		restore.SafetyBackup.Path, filepath.Base(restore.SafetyBackup.Path))
+	return nil
+}
+
+// list implements tt db list
+func (c *DBCommand) list(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return errors.NewInvalidInputError("command", "db list", "usage: tt db list")
+	}
+
+	backups, err := c.businessAPI.ListBackups(ctx, c.backupDir)
+	if err != nil {
+		return c.errorHandler.Handle("list backups", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(backups)
+	}
+
+	if len(backups) == 0 {
+		fmt.Fprintf(c.printer.out, "No backups in %s\n", c.backupDir)
+		return nil
+	}
+	fmt.Fprintf(c.printer.out, "Backups in %s:\n", c.backupDir)
+	for _, backup := range backups {
+		kind := ""
+		if backup.Automatic {
+			kind = " (daily)"
+		}
+		fmt.Fprintf(c.printer.out, "  %s  %9s  %s%s\n", backup.CreatedAt.Local().Format("2006-01-02 15:04"),
+			formatBackupSize(backup.Size), filepath.Base(backup.Path), kind)
+	}
+	return nil
+}
+
+// formatBackupSize formats a file size in bytes, KB or MB
+func formatBackupSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(size)/(1024*1024)), ".0") + " MB"
+	case size >= 1024:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(size)/1024), ".0") + " KB"
+	default:
+		return fmt.Sprintf("%d bytes", size)
+	}
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) *App {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+		return app
+	}
+
+	run := func(t *testing.T, app *App, format OutputFormat, options DBOptions, args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := NewDBCommandWithOptions(app, options)
+		cmd.backupDir = "/backups"
+		cmd.printer = newPrinterWithWriters(format, &out, io.Discard)
+		err := cmd.Execute(ctx, args)
+		return out.String(), err
+	}
+
+	t.Run("backs up to the backup directory", func(t *testing.T) {
+		app := setup(t)
+
+		out, err := run(t, app, FormatTable, DBOptions{}, "backup")
+		require.NoError(t, err)
+		assert.Equal(t, "Backed up the database to /backups/tt-backup-1.db (4 KB)\n", out)
+	})
+
+	t.Run("backs up to a given path", func(t *testing.T) {
+		app := setup(t)
+
+		out, err := run(t, app, FormatJSON, DBOptions{}, "backup", "/tmp/copy.db")
+		require.NoError(t, err)
+		var backup api.BackupInfo
+		require.NoError(t, json.Unmarshal([]byte(out), &backup))
+		assert.Equal(t, "/tmp/copy.db", backup.Path)
+
+		mockAPI(app).failures["BackupDatabase"] = errors.NewInvalidInputError("path", "/tmp/copy.db", "file already exists")
+		_, err = run(t, app, FormatTable, DBOptions{}, "backup", "/tmp/copy.db")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "file already exists")
+	})
+
+	t.Run("lists backups newest first", func(t *testing.T) {
+		app := setup(t)
+
+		out, err := run(t, app, FormatTable, DBOptions{}, "list")
+		require.NoError(t, err)
+		assert.Equal(t, "No backups in /backups\n", out)
+
+		for i := 0; i < 2; i++ {
+			_, err := run(t, app, FormatTable, DBOptions{}, "backup")
+			require.NoError(t, err)
+		}
+		out, err = run(t, app, FormatTable, DBOptions{}, "list")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Backups in /backups:\n")
+		assert.Less(t, strings.Index(out, "tt-backup-2.db"), strings.Index(out, "tt-backup-1.db"))
+	})
+
+	t.Run("restores after confirmation and names the safety backup", func(t *testing.T) {
+		app := setup(t)
+		_, err := run(t, app, FormatTable, DBOptions{}, "backup")
+		require.NoError(t, err)
+
+		stubStdin(t, true, "n\n")
+		out, err := run(t, app, FormatTable, DBOptions{}, "restore", "tt-backup-1.db")
+		require.NoError(t, err)
+		assert.NotContains(t, out, "Restored")
+
+		stubStdin(t, true, "y\n")
+		out, err = run(t, app, FormatTable, DBOptions{}, "restore", "tt-backup-1.db")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Restored the database from "+filepath.Join("/backups", "tt-backup-1.db"))
+		assert.Contains(t, out, "run tt db restore tt-before-restore-2.db to take this back")
+	})
+
+	t.Run("needs --yes to restore without a terminal", func(t *testing.T) {
+		app := setup(t)
+		_, err := run(t, app, FormatTable, DBOptions{}, "backup")
+		require.NoError(t, err)
+
+		stubStdin(t, false, "")
+		_, err = run(t, app, FormatTable, DBOptions{}, "restore", "tt-backup-1.db")
+		require.Error(t, err)
+
+		_, err = run(t, app, FormatTable, DBOptions{Yes: true}, "restore", "tt-backup-1.db")
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects unknown subcommands and missing files", func(t *testing.T) {
+		app := setup(t)
+
+		_, err := run(t, app, FormatTable, DBOptions{}, "vacuum")
+		assert.Error(t, err)
+		_, err = run(t, app, FormatTable, DBOptions{}, "restore")
+		assert.Error(t, err)
+		_, err = run(t, app, FormatTable, DBOptions{Yes: true}, "restore", "missing.db")
+		assert.Error(t, err)
+	})
+}
@@ -3,22 +3,37 @@ package cli
 import (
 	"context"
 	"fmt"
-	"os"
-	"strconv"
 	"strings"
 
 	"time-tracker/internal/api"
-	"time-tracker/internal/errors"
 )
 
+// DeleteOptions holds the flags accepted by the delete command
+type DeleteOptions struct {
+	Select          TaskSelection // Picks the task without prompting
+	Yes             bool          // Skip the confirmation asked for tasks picked by the selection flags
+	IncludeArchived bool          // Also offer archived tasks
+}
+
 // DeleteCommand handles the delete command
 type DeleteCommand struct {
 	businessAPI api.BusinessAPI
+	printer     *Printer
+	options     DeleteOptions
 }
 
 // NewDeleteCommand creates a new delete command handler
 func NewDeleteCommand(app *App) *DeleteCommand {
-	return &DeleteCommand{businessAPI: app.businessAPI}
+	return NewDeleteCommandWithOptions(app, DeleteOptions{})
+}
+
+// NewDeleteCommandWithOptions creates a new delete command handler with the given flag values
+func NewDeleteCommandWithOptions(app *App, options DeleteOptions) *DeleteCommand {
+	return &DeleteCommand{
+		businessAPI: app.businessAPI,
+		printer:     app.newPrinter(),
+		options:     options,
+	}
 }
 
 // Execute runs the delete command
@@ -33,56 +48,69 @@ func (c *DeleteCommand) deleteTask(ctx context.Context, args []string) error {
 	var textFilter string
 
 	if len(args) > 0 {
-		if _, err := parseTimeShorthand(args[0]); err == nil {
-			// Time shorthand found
+		if _, err := c.businessAPI.ParseTimeRange(ctx, args[0]); err == nil {
+			// Time range found
 			timeRange = args[0]
 			if len(args) > 1 {
 				textFilter = strings.Join(args[1:], " ")
 			}
 		} else {
-			// Not a valid duration, treat as text filter
+			// Not a valid time range, treat as text filter
 			textFilter = strings.Join(args, " ")
 			timeRange = "1d" // Default to last 24h
 		}
-	} else {
-		timeRange = "1d" // Default to last 24h
+	} else if !c.options.Select.IsSet() {
+		timeRange = "1d" // Default to last 24h; selection flags look at every task
 	}
 
 	// Search for tasks using BusinessAPI
-	tasks, err := c.businessAPI.SearchTasks(ctx, timeRange, textFilter, api.SortByRecentFirst)
+	filter := api.TimeEntryFilter{TimeRange: timeRange, Text: textFilter, IncludeArchived: c.options.IncludeArchived}
+	tasks, err := c.businessAPI.SearchTasksWithFilter(ctx, filter, api.SortByRecentFirst)
 	if err != nil {
 		return fmt.Errorf("failed to search tasks: %w", err)
 	}
 
-	if len(tasks) == 0 {
-		fmt.Println("No tasks found to delete.")
+	if len(tasks) == 0 && !c.options.Select.IsSet() {
+		c.printer.Infof("No tasks found to delete.\n")
 		return nil
 	}
 
-	fmt.Println("Select a task to delete:")
-	for i, task := range tasks {
-		fmt.Printf("%d. %s (last worked: %s)\n", i+1, task.Task.TaskName, task.LastWorked)
+	picker := &taskPicker{
+		businessAPI: c.businessAPI,
+		printer:     c.printer,
+		command:     "delete",
+		verb:        "delete",
+		cancelled:   "Delete cancelled.",
+		describe: func(task *api.TaskActivity) string {
+			return fmt.Sprintf("%s (last worked: %s)", task.Task.TaskName, task.LastWorked)
+		},
 	}
-	fmt.Print("Enter number to delete, or 'q' to quit: ")
-
-	// Read user input
-	var input string
-	fmt.Fscanln(os.Stdin, &input)
-	if input == "q" || input == "Q" {
-		fmt.Println("Delete cancelled.")
-		return nil
+	selectedTask, err := picker.pick(ctx, c.options.Select, tasks)
+	if err != nil || selectedTask == nil {
+		return err
 	}
-	idx, err := strconv.Atoi(input)
-	if err != nil || idx < 1 || idx > len(tasks) {
-		return errors.NewInvalidInputError("selection", input, "invalid selection")
+
+	// Picking from the list is the confirmation; tasks named by flags need an explicit one
+	if c.options.Select.IsSet() && !c.options.Yes {
+		confirmed, err := confirm(c.printer, fmt.Sprintf("Delete task %q and all its time entries?", selectedTask.TaskName))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			c.printer.Infof("Delete cancelled.\n")
+			return nil
+		}
 	}
-	selectedTask := tasks[idx-1]
 
 	// Delete the task and all its time entries using BusinessAPI
-	if err := c.businessAPI.DeleteTaskWithEntries(ctx, selectedTask.Task.ID); err != nil {
+	if err := c.businessAPI.DeleteTaskWithEntries(ctx, selectedTask.ID); err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
 
-	fmt.Printf("Deleted task: %s\n", selectedTask.Task.TaskName)
+	if c.printer.IsStructured() {
+		return c.printer.Emit(selectedTask)
+	}
+
+	fmt.Printf("Deleted task: %s\n", selectedTask.TaskName)
 	return nil
 }
\ No newline at end of file
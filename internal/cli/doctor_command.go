@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/config"
+	"time-tracker/internal/errors"
+)
+
+// DoctorOptions holds the flags accepted by the doctor command
+type DoctorOptions struct {
+	Fix []string // Fix strategies to apply: trim-overlaps, stop-extras and merge-duplicates
+}
+
+// DoctorCommand handles the doctor command, which checks the time database for inconsistencies
+type DoctorCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	cfg          *config.Config
+	options      DoctorOptions
+}
+
+// NewDoctorCommand creates a new doctor command handler
+func NewDoctorCommand(app *App) *DoctorCommand {
+	return NewDoctorCommandWithOptions(app, DoctorOptions{})
+}
+
+// NewDoctorCommandWithOptions creates a new doctor command handler with the given flag values
+func NewDoctorCommandWithOptions(app *App, options DoctorOptions) *DoctorCommand {
+	cfg := app.config
+	if cfg == nil {
+		cfg = config.NewConfig()
+	}
+	return &DoctorCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		cfg:          cfg,
+		options:      options,
+	}
+}
+
+// Execute runs the doctor command. It fails when problems remain, so scripts can tell a clean database apart.
+func (c *DoctorCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return errors.NewInvalidInputError("command", "doctor", "usage: tt doctor [--fix[=strategy,...]]")
+	}
+
+	report, err := c.businessAPI.CheckConsistency(ctx, api.DoctorOptions{
+		MaxDuration: c.cfg.Validation.MaxDuration,
+		Fix:         c.options.Fix,
+	})
+	if err != nil {
+		return c.errorHandler.Handle("check the database", err)
+	}
+
+	if c.printer.IsStructured() {
+		if err := c.printer.Emit(report); err != nil {
+			return err
+		}
+	} else if err := writeDoctorReport(c.printer.out, report); err != nil {
+		return err
+	}
+
+	if len(report.Problems) > 0 {
+		return fmt.Errorf("found %s", pluralizeProblems(len(report.Problems)))
+	}
+	return nil
+}
+
+// writeDoctorReport lists the fixed problems, then the remaining ones with the fix strategy that handles them
+func writeDoctorReport(w io.Writer, report *api.DoctorReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Checked %d tasks and %d time entries.\n", report.CheckedTasks, report.CheckedEntries)
+
+	if len(report.Fixed) > 0 {
+		fmt.Fprintf(&b, "\nFixed %s:\n", pluralizeProblems(len(report.Fixed)))
+		for _, problem := range report.Fixed {
+			fmt.Fprintf(&b, "  [%s] %s\n", problem.Kind, problem.Description)
+		}
+	}
+
+	if len(report.Problems) == 0 {
+		b.WriteString("\nNo problems found.\n")
+		if len(report.Fixed) > 0 {
+			b.WriteString("Run tt undo to take the fixes back.\n")
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	fmt.Fprintf(&b, "\nFound %s:\n", pluralizeProblems(len(report.Problems)))
+	fixable := make(map[string]bool)
+	for _, problem := range report.Problems {
+		fmt.Fprintf(&b, "  [%s] %s", problem.Kind, problem.Description)
+		if problem.Fix != "" {
+			fmt.Fprintf(&b, " (fix: %s)", problem.Fix)
+			fixable[problem.Fix] = true
+		} else {
+			b.WriteString(" (fix by hand with tt edit or tt task)")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(fixable) > 0 {
+		var strategies []string
+		for _, strategy := range api.FixStrategies {
+			if fixable[strategy] {
+				strategies = append(strategies, strategy)
+			}
+		}
+		fmt.Fprintf(&b, "\nRun tt doctor --fix to apply every fix, or --fix=%s to choose.\n", strings.Join(strategies, ","))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// pluralizeProblems formats a count of problems
+func pluralizeProblems(count int) string {
+	if count == 1 {
+		return "1 problem"
+	}
+	return fmt.Sprintf("%d problems", count)
+}
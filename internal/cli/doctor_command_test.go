@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The doctor service finds and fixes the problems, see TestDoctorService_CheckConsistency; the command
+// passes the chosen fixes on and reports what comes back
+func TestDoctorCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	duplicate := &api.DoctorProblem{
+		Kind:        api.ProblemDuplicateTask,
+		Description: "Tasks 1 and 2 share a name",
+		TaskIDs:     []int64{1, 2},
+		Fix:         api.FixMergeDuplicates,
+	}
+
+	setup := func(t *testing.T, report *api.DoctorReport) *App {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+		mockAPI(app).doctorReport = report
+		return app
+	}
+
+	run := func(t *testing.T, app *App, format OutputFormat, options DoctorOptions, args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := NewDoctorCommandWithOptions(app, options)
+		cmd.printer = newPrinterWithWriters(format, &out, io.Discard)
+		err := cmd.Execute(ctx, args)
+		return out.String(), err
+	}
+
+	t.Run("reports problems with their ids and fails", func(t *testing.T) {
+		app := setup(t, &api.DoctorReport{CheckedTasks: 2, CheckedEntries: 2, Problems: []*api.DoctorProblem{duplicate}})
+
+		out, err := run(t, app, FormatTable, DoctorOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "found 1 problem")
+		assert.Contains(t, out, "Checked 2 tasks and 2 time entries.")
+		assert.Contains(t, out, "[duplicate_task] Tasks 1 and 2 share a name (fix: merge-duplicates)")
+		assert.Contains(t, out, "Run tt doctor --fix to apply every fix, or --fix=merge-duplicates to choose.")
+		require.Len(t, mockAPI(app).doctorOptions, 1)
+		assert.Empty(t, mockAPI(app).doctorOptions[0].Fix)
+	})
+
+	t.Run("fixes problems and says how to undo the fixes", func(t *testing.T) {
+		app := setup(t, &api.DoctorReport{CheckedTasks: 2, CheckedEntries: 2, Problems: []*api.DoctorProblem{}, Fixed: []*api.DoctorProblem{duplicate}})
+
+		out, err := run(t, app, FormatTable, DoctorOptions{Fix: api.FixStrategies})
+		require.NoError(t, err)
+		assert.Contains(t, out, "Fixed 1 problem:\n  [duplicate_task] Tasks 1 and 2 share a name\n")
+		assert.Contains(t, out, "No problems found.")
+		assert.Contains(t, out, "tt undo")
+		require.Len(t, mockAPI(app).doctorOptions, 1)
+		assert.Equal(t, api.FixStrategies, mockAPI(app).doctorOptions[0].Fix)
+	})
+
+	t.Run("explains problems that need fixing by hand", func(t *testing.T) {
+		app := setup(t, &api.DoctorReport{CheckedTasks: 1, CheckedEntries: 1, Problems: []*api.DoctorProblem{{
+			Kind:        api.ProblemEndBeforeStart,
+			Description: "Entry 1 ends before it starts",
+			EntryIDs:    []int64{1},
+		}}})
+
+		out, err := run(t, app, FormatTable, DoctorOptions{Fix: api.FixStrategies})
+		require.Error(t, err)
+		assert.Contains(t, out, "[end_before_start] Entry 1 ends before it starts (fix by hand with tt edit or tt task)")
+		assert.NotContains(t, out, "Fixed")
+		assert.NotContains(t, out, "--fix")
+	})
+
+	t.Run("emits the report as JSON", func(t *testing.T) {
+		app := setup(t, &api.DoctorReport{CheckedTasks: 2, CheckedEntries: 2, Problems: []*api.DoctorProblem{duplicate}})
+
+		out, err := run(t, app, FormatJSON, DoctorOptions{})
+		require.Error(t, err)
+		var report api.DoctorReport
+		require.NoError(t, json.Unmarshal([]byte(out), &report))
+		require.Len(t, report.Problems, 1)
+		assert.Equal(t, api.ProblemDuplicateTask, report.Problems[0].Kind)
+		assert.Equal(t, []int64{1, 2}, report.Problems[0].TaskIDs)
+	})
+
+	t.Run("rejects arguments and reports failed checks", func(t *testing.T) {
+		app := setup(t, nil)
+
+		_, err := run(t, app, FormatTable, DoctorOptions{}, "now")
+		assert.Error(t, err)
+		assert.Empty(t, mockAPI(app).doctorOptions)
+
+		mockAPI(app).failures["CheckConsistency"] = errors.NewInvalidInputError("fix", "everything", "expected "+strings.Join(api.FixStrategies, ", "))
+		_, err = run(t, app, FormatTable, DoctorOptions{Fix: []string{"everything"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to check the database")
+	})
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// EditOptions holds the flags accepted by the edit command
+type EditOptions struct {
+	Start string // New start time
+	End   string // New end time
+	Task  string // Name of the task to reassign the entry to
+}
+
+// EditCommand handles the edit command
+type EditCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	options      EditOptions
+}
+
+// NewEditCommand creates a new edit command handler
+func NewEditCommand(app *App) *EditCommand {
+	return NewEditCommandWithOptions(app, EditOptions{})
+}
+
+// NewEditCommandWithOptions creates a new edit command handler with the given flag values
+func NewEditCommandWithOptions(app *App, options EditOptions) *EditCommand {
+	return &EditCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		options:      options,
+	}
+}
+
+// Execute runs the edit command
+func (c *EditCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.NewInvalidInputError("command", "edit", "usage: tt edit <entry-id> [--start time] [--end time] [--task name]")
+	}
+
+	entryID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || entryID <= 0 {
+		return errors.NewInvalidInputError("entry_id", args[0], "must be a positive integer")
+	}
+
+	if c.options.Start == "" && c.options.End == "" && c.options.Task == "" {
+		return errors.NewInvalidInputError("edit", "", "specify at least one of --start, --end or --task")
+	}
+
+	return c.editTimeEntry(ctx, entryID)
+}
+
+// editTimeEntry applies the requested changes and prints a before/after comparison
+func (c *EditCommand) editTimeEntry(ctx context.Context, entryID int64) error {
+	// Get the entry so clock-only times are resolved against its own date
+	current, err := c.businessAPI.GetTimeEntry(ctx, entryID)
+	if err != nil {
+		return c.errorHandler.Handle("edit time entry", err)
+	}
+
+	update, err := c.buildUpdate(current.TimeEntry.StartTime.Local())
+	if err != nil {
+		return err
+	}
+
+	edit, err := c.businessAPI.EditTimeEntry(ctx, entryID, update)
+	if err != nil {
+		return c.errorHandler.Handle("edit time entry", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(struct {
+			Before entryRecord `json:"before"`
+			After  entryRecord `json:"after"`
+		}{
+			Before: newEntryRecord(edit.Before.TimeEntry, edit.Before.Task),
+			After:  newEntryRecord(edit.After.TimeEntry, edit.After.Task),
+		})
+	}
+
+	c.printEdit(entryID, edit)
+	return nil
+}
+
+// buildUpdate converts the command options into a TimeEntryUpdate
+func (c *EditCommand) buildUpdate(reference time.Time) (api.TimeEntryUpdate, error) {
+	var update api.TimeEntryUpdate
+	parser := newTimeParser(timeNow())
+
+	if c.options.Start != "" {
+		start, err := parser.ParseOn(c.options.Start, reference)
+		if err != nil {
+			return update, err
+		}
+		update.StartTime = &start
+	}
+
+	if c.options.End != "" {
+		end, err := parser.ParseOn(c.options.End, reference)
+		if err != nil {
+			return update, err
+		}
+		update.EndTime = &end
+	}
+
+	if c.options.Task != "" {
+		taskName := c.options.Task
+		update.TaskName = &taskName
+	}
+
+	return update, nil
+}
+
+// printEdit prints each field of the entry before and after the edit, marking changed fields
+func (c *EditCommand) printEdit(entryID int64, edit *api.TimeEntryEdit) {
+	fmt.Printf("Updated entry %d:\n", entryID)
+	fmt.Printf("  %-10s %-25s %s\n", "Field", "Before", "After")
+
+	printRow := func(field, before, after string) {
+		marker := " "
+		if before != after {
+			marker = "*"
+		}
+		fmt.Printf("%s %-10s %-25s %s\n", marker, field, before, after)
+	}
+
+	printRow("Task", edit.Before.Task.TaskName, edit.After.Task.TaskName)
+	printRow("Start", formatEntryTime(&edit.Before.TimeEntry.StartTime), formatEntryTime(&edit.After.TimeEntry.StartTime))
+	printRow("End", formatEntryTime(edit.Before.TimeEntry.EndTime), formatEntryTime(edit.After.TimeEntry.EndTime))
+	printRow("Duration", edit.Before.Duration, edit.After.Duration)
+}
+
+// formatEntryTime formats an entry time for display, showing running entries as "running"
+func formatEntryTime(t *time.Time) string {
+	if t == nil {
+		return "running"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	entryStart := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+
+	setup := func(t *testing.T) (*App, int64) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+
+		session, err := app.businessAPI.AddTimeEntry(ctx, "Original Task", entryStart, entryStart.Add(time.Hour))
+		require.NoError(t, err)
+		return app, session.TimeEntry.ID
+	}
+
+	t.Run("adjusts start time on the entry's own day", func(t *testing.T) {
+		app, entryID := setup(t)
+
+		cmd := NewEditCommandWithOptions(app, EditOptions{Start: "08:45"})
+		err := cmd.Execute(ctx, []string{"1"})
+		require.NoError(t, err)
+
+		entry, err := app.businessAPI.GetTimeEntry(ctx, entryID)
+		require.NoError(t, err)
+		assert.True(t, time.Date(2026, 10, 14, 8, 45, 0, 0, time.Local).Equal(entry.TimeEntry.StartTime))
+	})
+
+	t.Run("reassigns entry to another task", func(t *testing.T) {
+		app, entryID := setup(t)
+
+		cmd := NewEditCommandWithOptions(app, EditOptions{Task: "Other Task"})
+		err := cmd.Execute(ctx, []string{"1"})
+		require.NoError(t, err)
+
+		entry, err := app.businessAPI.GetTimeEntry(ctx, entryID)
+		require.NoError(t, err)
+		assert.Equal(t, "Other Task", entry.Task.TaskName)
+	})
+
+	t.Run("reports edits the service rejects", func(t *testing.T) {
+		app, _ := setup(t)
+		mockAPI(app).failures["EditTimeEntry"] = errors.NewValidationError("end time must be after start time", nil)
+
+		cmd := NewEditCommandWithOptions(app, EditOptions{End: "08:00"})
+		err := cmd.Execute(ctx, []string{"1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to edit time entry")
+	})
+
+	t.Run("requires at least one change", func(t *testing.T) {
+		app, _ := setup(t)
+
+		cmd := NewEditCommand(app)
+		err := cmd.Execute(ctx, []string{"1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--start, --end or --task")
+	})
+
+	t.Run("rejects invalid entry id", func(t *testing.T) {
+		app, _ := setup(t)
+
+		cmd := NewEditCommandWithOptions(app, EditOptions{Start: "09:00"})
+		err := cmd.Execute(ctx, []string{"abc"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "entry_id")
+	})
+
+	t.Run("reports missing entry", func(t *testing.T) {
+		app, _ := setup(t)
+
+		cmd := NewEditCommandWithOptions(app, EditOptions{Start: "09:00"})
+		err := cmd.Execute(ctx, []string{"99"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
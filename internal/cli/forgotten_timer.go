@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/config"
+)
+
+// checkForgottenTimer looks for a task that has been running for longer than Tasks.ForgottenAfter before
+// a command runs. On a terminal it asks whether to stop the task at the last activity tt recorded while it
+// ran, cap it at Validation.MaxDuration, keep it running or stop it at a time the user types; otherwise it
+// applies Tasks.ForgottenPolicy. Every decision is journaled, so tt undo reverts it. Questions and notices
+// are written to out.
+func checkForgottenTimer(ctx context.Context, businessAPI api.BusinessAPI, cfg *config.Config, out io.Writer) error {
+	if cfg.Tasks.ForgottenAfter <= 0 {
+		return nil
+	}
+
+	forgotten, err := businessAPI.FindForgottenTimer(ctx, cfg.Tasks.ForgottenAfter)
+	if err != nil {
+		return fmt.Errorf("failed to check for a forgotten task: %w", err)
+	}
+	if forgotten == nil {
+		return nil
+	}
+
+	check := &forgottenTimerCheck{
+		businessAPI: businessAPI,
+		out:         out,
+		forgotten:   forgotten,
+		now:         timeNow(),
+		after:       cfg.Tasks.ForgottenAfter,
+		maxDuration: cfg.Validation.MaxDuration,
+	}
+	if stdinIsTerminal() {
+		err = check.prompt(ctx)
+	} else {
+		err = check.applyPolicy(ctx, cfg.Tasks.ForgottenPolicy)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve forgotten task %q: %w", forgotten.Task.TaskName, err)
+	}
+	return nil
+}
+
+// forgottenTimerCheck resolves a single forgotten timer, interactively or by policy
+type forgottenTimerCheck struct {
+	businessAPI api.BusinessAPI
+	out         io.Writer // Where questions and notices go; stderr, so they never mix with command output
+	forgotten   *api.ForgottenTimer
+	now         time.Time
+	after       time.Duration // How long a task runs before it counts as forgotten
+	maxDuration time.Duration
+}
+
+// capAt returns when the entry reached the maximum entry duration, and whether that has already happened
+func (c *forgottenTimerCheck) capAt() (time.Time, bool) {
+	at := c.forgotten.TimeEntry.StartTime.Add(c.maxDuration)
+	return at, at.Before(c.now)
+}
+
+// applyPolicy deals with the forgotten timer without asking: warn only prints a warning, stop stops the
+// entry at its last activity, or caps it without one, cap stops it at the maximum duration once exceeded
+// and keep keeps it running
+func (c *forgottenTimerCheck) applyPolicy(ctx context.Context, policy string) error {
+	switch policy {
+	case config.ForgottenPolicyStop:
+		if c.forgotten.LastActivity != nil {
+			return c.resolve(ctx, api.ForgottenTimerStop, c.forgotten.LastActivity)
+		}
+		// Without any activity there is no telling when work stopped, so the task is capped instead
+		if at, exceeded := c.capAt(); exceeded {
+			return c.resolve(ctx, api.ForgottenTimerCap, &at)
+		}
+		return c.warn()
+	case config.ForgottenPolicyCap:
+		if at, exceeded := c.capAt(); exceeded {
+			return c.resolve(ctx, api.ForgottenTimerCap, &at)
+		}
+		return nil
+	case config.ForgottenPolicyKeep:
+		return c.resolve(ctx, api.ForgottenTimerKeep, nil)
+	default:
+		return c.warn()
+	}
+}
+
+// warn reports the forgotten timer without changing it
+func (c *forgottenTimerCheck) warn() error {
+	fmt.Fprintf(c.out, "Warning: %s; it may have been left running by mistake. Stop it with tt stop --at <time>, "+
+		"or set TT_FORGOTTEN_POLICY to stop, cap or keep it automatically.\n", c.describe())
+	return nil
+}
+
+// prompt asks what to do with the forgotten timer until it gets a usable answer. An empty answer stops the
+// entry at its last activity, or caps it without one; reaching the end of input leaves the entry alone.
+func (c *forgottenTimerCheck) prompt(ctx context.Context) error {
+	capAt, canCap := c.capAt()
+	lastActivity := c.forgotten.LastActivity
+	fmt.Fprintf(c.out, "%s. Did you forget to stop it?\n", c.describe())
+	if lastActivity != nil {
+		fmt.Fprintf(c.out, "  [s] stop it at %s, when tt last saw you at work\n", formatForgottenTime(*lastActivity))
+	}
+	if canCap {
+		fmt.Fprintf(c.out, "  [c] cap it at %s, stopping at %s\n", formatDurationHuman(c.maxDuration), formatForgottenTime(capAt))
+	}
+	fmt.Fprintf(c.out, "  [k] keep it running\n")
+	fmt.Fprintf(c.out, "  or type when you stopped, e.g. 18:30 or yesterday 6pm\n")
+
+	choice := "Choice: "
+	switch {
+	case lastActivity != nil:
+		choice = "Choice [s]: "
+	case canCap:
+		choice = "Choice [c]: "
+	}
+	for {
+		fmt.Fprint(c.out, choice)
+		answer, err := readLine(stdin)
+		if err != nil {
+			fmt.Fprintf(c.out, "\n")
+			return nil
+		}
+
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer == "" {
+			switch {
+			case lastActivity != nil:
+				answer = "s"
+			case canCap:
+				answer = "c"
+			default:
+				continue
+			}
+		}
+		switch answer {
+		case "s", "stop":
+			if lastActivity != nil {
+				return c.resolve(ctx, api.ForgottenTimerStop, lastActivity)
+			}
+			fmt.Fprintf(c.out, "tt has not seen you at work since the task started; type when you stopped.\n")
+			continue
+		case "c", "cap":
+			if canCap {
+				return c.resolve(ctx, api.ForgottenTimerCap, &capAt)
+			}
+			fmt.Fprintf(c.out, "The task has not run for longer than %s yet.\n", formatDurationHuman(c.maxDuration))
+			continue
+		case "k", "keep":
+			return c.resolve(ctx, api.ForgottenTimerKeep, nil)
+		}
+
+		at, err := newTimeParser(c.now).ParseOn(answer, c.forgotten.TimeEntry.StartTime)
+		switch {
+		case err != nil:
+			fmt.Fprintf(c.out, "%v\n", err)
+		case at.Before(c.forgotten.TimeEntry.StartTime):
+			fmt.Fprintf(c.out, "The task started at %s; enter a later time.\n", formatForgottenTime(c.forgotten.TimeEntry.StartTime))
+		case at.After(c.now):
+			fmt.Fprintf(c.out, "%s is in the future; enter an earlier time.\n", formatForgottenTime(at))
+		default:
+			return c.resolve(ctx, api.ForgottenTimerStop, &at)
+		}
+	}
+}
+
+// resolve applies a decision and reports it
+func (c *forgottenTimerCheck) resolve(ctx context.Context, action string, at *time.Time) error {
+	result, err := c.businessAPI.ResolveForgottenTimer(ctx, c.forgotten.TimeEntry.ID, api.ForgottenTimerResolution{Action: action, At: at})
+	if err != nil {
+		return err
+	}
+
+	name := result.Task.TaskName
+	if action == api.ForgottenTimerKeep {
+		fmt.Fprintf(c.out, "Keeping %q running; you will be asked again if it is still running in %s.\n",
+			name, formatDurationHuman(c.after))
+		return nil
+	}
+	stoppedAfter := at.Sub(result.TimeEntry.StartTime)
+	fmt.Fprintf(c.out, "Stopped %q at %s, after %s. Run tt undo to take this back.\n", name, formatForgottenTime(*at), formatDurationHuman(stoppedAfter))
+	return nil
+}
+
+// describe says which task has been running and for how long
+func (c *forgottenTimerCheck) describe() string {
+	return fmt.Sprintf("%q has been running for %s, since %s", c.forgotten.Task.TaskName,
+		formatDurationHuman(c.forgotten.Running), formatForgottenTime(c.forgotten.TimeEntry.StartTime))
+}
+
+// formatForgottenTime formats a time with its weekday, since a forgotten task usually spans several days
+func formatForgottenTime(t time.Time) string {
+	return t.Local().Format("Mon 2006-01-02 15:04")
+}
+
+// readLine reads a line from r one byte at a time, so nothing after the line is consumed from stdin
+// before the command itself reads it. It fails at the end of input when the line is empty.
+func readLine(r io.Reader) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return strings.TrimSuffix(string(line), "\r"), nil
+			}
+			line = append(line, buf[0])
+			continue
+		}
+		if err != nil {
+			if len(line) > 0 {
+				return string(line), nil
+			}
+			return "", err
+		}
+	}
+}
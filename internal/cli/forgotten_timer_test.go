@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckForgottenTimer(t *testing.T) {
+	ctx := context.Background()
+
+	// A task started the given time ago and still running, checked with the default configuration
+	setup := func(t *testing.T, runningFor time.Duration) (*App, *api.TaskSession, *config.Config) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+		session, err := app.businessAPI.StartNewTaskWithOptions(ctx, "Email", api.StartOptions{At: timePtr(time.Now().Add(-runningFor))})
+		require.NoError(t, err)
+		return app, session, config.NewConfig()
+	}
+
+	check := func(t *testing.T, app *App, cfg *config.Config) string {
+		var info bytes.Buffer
+		require.NoError(t, checkForgottenTimer(ctx, app.businessAPI, cfg, &info))
+		return info.String()
+	}
+
+	endTime := func(t *testing.T, app *App, session *api.TaskSession) *time.Time {
+		return app.businessAPI.(*mockBusinessAPI).timeEntries[session.TimeEntry.ID].EndTime
+	}
+
+	// seenAt has the business API report activity the given time after the task started
+	seenAt := func(app *App, session *api.TaskSession, after time.Duration) {
+		app.businessAPI.(*mockBusinessAPI).lastActivity = timePtr(session.TimeEntry.StartTime.Add(after))
+	}
+
+	t.Run("leaves recent tasks alone", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, session, cfg := setup(t, 2*time.Hour)
+		assert.Empty(t, check(t, app, cfg))
+		assert.Nil(t, endTime(t, app, session))
+	})
+
+	t.Run("does nothing when the threshold is 0", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, session, cfg := setup(t, 16*time.Hour)
+		cfg.Tasks.ForgottenAfter = 0
+		cfg.Tasks.ForgottenPolicy = config.ForgottenPolicyStop
+		assert.Empty(t, check(t, app, cfg))
+		assert.Nil(t, endTime(t, app, session))
+	})
+
+	t.Run("warns without a terminal by default", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, session, cfg := setup(t, 16*time.Hour)
+
+		info := check(t, app, cfg)
+		assert.Contains(t, info, `Warning: "Email" has been running for 16h 0m`)
+		assert.Contains(t, info, "TT_FORGOTTEN_POLICY")
+		assert.Nil(t, endTime(t, app, session))
+	})
+
+	t.Run("stops at the last activity with the stop policy", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, session, cfg := setup(t, 16*time.Hour)
+		seenAt(app, session, 3*time.Hour)
+		cfg.Tasks.ForgottenPolicy = config.ForgottenPolicyStop
+
+		info := check(t, app, cfg)
+		assert.Contains(t, info, `Stopped "Email" at`)
+		assert.Contains(t, info, "after 3h 0m")
+		end := endTime(t, app, session)
+		require.NotNil(t, end)
+		assert.WithinDuration(t, session.TimeEntry.StartTime.Add(3*time.Hour), *end, time.Second)
+
+		operations, err := app.businessAPI.ListOperations(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "forgotten", operations[0].Kind)
+	})
+
+	t.Run("caps without any activity with the stop policy", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, session, cfg := setup(t, 30*time.Hour)
+		cfg.Tasks.ForgottenPolicy = config.ForgottenPolicyStop
+
+		assert.Contains(t, check(t, app, cfg), "after 24h 0m")
+		end := endTime(t, app, session)
+		require.NotNil(t, end)
+		assert.WithinDuration(t, session.TimeEntry.StartTime.Add(24*time.Hour), *end, time.Second)
+	})
+
+	t.Run("warns without any activity within the maximum duration with the stop policy", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, session, cfg := setup(t, 16*time.Hour)
+		cfg.Tasks.ForgottenPolicy = config.ForgottenPolicyStop
+
+		assert.Contains(t, check(t, app, cfg), `Warning: "Email" has been running for 16h 0m`)
+		assert.Nil(t, endTime(t, app, session))
+	})
+
+	t.Run("caps at the maximum duration with the cap policy", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, session, cfg := setup(t, 30*time.Hour)
+		cfg.Tasks.ForgottenPolicy = config.ForgottenPolicyCap
+
+		assert.Contains(t, check(t, app, cfg), "after 24h 0m")
+		end := endTime(t, app, session)
+		require.NotNil(t, end)
+		assert.WithinDuration(t, session.TimeEntry.StartTime.Add(24*time.Hour), *end, time.Second)
+	})
+
+	t.Run("leaves tasks within the maximum duration running with the cap policy", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, session, cfg := setup(t, 16*time.Hour)
+		cfg.Tasks.ForgottenPolicy = config.ForgottenPolicyCap
+
+		assert.Empty(t, check(t, app, cfg))
+		assert.Nil(t, endTime(t, app, session))
+	})
+
+	t.Run("keeps the task running and stops asking with the keep policy", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, session, cfg := setup(t, 16*time.Hour)
+		cfg.Tasks.ForgottenPolicy = config.ForgottenPolicyKeep
+
+		assert.Contains(t, check(t, app, cfg), `Keeping "Email" running`)
+		assert.Nil(t, endTime(t, app, session))
+		assert.Empty(t, check(t, app, cfg), "A kept task is not forgotten again until the threshold passes anew")
+	})
+
+	t.Run("stops at the last activity when the prompt is answered with enter", func(t *testing.T) {
+		stubStdin(t, true, "\n")
+		app, session, cfg := setup(t, 16*time.Hour)
+		seenAt(app, session, 3*time.Hour)
+
+		info := check(t, app, cfg)
+		assert.Contains(t, info, `"Email" has been running for 16h 0m`)
+		assert.Contains(t, info, "[s] stop it at")
+		assert.NotContains(t, info, "[c] cap it", "Capping is only offered past the maximum duration")
+		end := endTime(t, app, session)
+		require.NotNil(t, end)
+		assert.WithinDuration(t, session.TimeEntry.StartTime.Add(3*time.Hour), *end, time.Second)
+	})
+
+	t.Run("caps on enter without any activity", func(t *testing.T) {
+		stubStdin(t, true, "\n")
+		app, session, cfg := setup(t, 30*time.Hour)
+
+		info := check(t, app, cfg)
+		assert.NotContains(t, info, "[s] stop it", "Stopping is only offered after some activity")
+		assert.Contains(t, info, "Choice [c]: ")
+		end := endTime(t, app, session)
+		require.NotNil(t, end)
+		assert.WithinDuration(t, session.TimeEntry.StartTime.Add(24*time.Hour), *end, time.Second)
+	})
+
+	t.Run("offers to cap past the maximum duration", func(t *testing.T) {
+		stubStdin(t, true, "c\n")
+		app, session, cfg := setup(t, 30*time.Hour)
+
+		assert.Contains(t, check(t, app, cfg), "[c] cap it at 24h 0m")
+		end := endTime(t, app, session)
+		require.NotNil(t, end)
+		assert.WithinDuration(t, session.TimeEntry.StartTime.Add(24*time.Hour), *end, time.Second)
+	})
+
+	t.Run("asks again until it gets a usable answer", func(t *testing.T) {
+		stubStdin(t, true, "\ns\nc\nsoon\n20 hours ago\n2 hours ago\n")
+		app, session, cfg := setup(t, 16*time.Hour)
+
+		info := check(t, app, cfg)
+		assert.Contains(t, info, "tt has not seen you at work since the task started")
+		assert.Contains(t, info, "has not run for longer than 24h 0m yet")
+		assert.Contains(t, info, "expected a time such as")
+		assert.Contains(t, info, "enter a later time")
+		end := endTime(t, app, session)
+		require.NotNil(t, end)
+		assert.WithinDuration(t, time.Now().Add(-2*time.Hour), *end, time.Second)
+	})
+
+	t.Run("keeps the task running when asked to", func(t *testing.T) {
+		stubStdin(t, true, "k\n")
+		app, session, cfg := setup(t, 16*time.Hour)
+
+		assert.Contains(t, check(t, app, cfg), `Keeping "Email" running`)
+		assert.Nil(t, endTime(t, app, session))
+	})
+
+	t.Run("leaves the task alone at the end of input", func(t *testing.T) {
+		stubStdin(t, true, "")
+		app, session, cfg := setup(t, 16*time.Hour)
+
+		check(t, app, cfg)
+		assert.Nil(t, endTime(t, app, session))
+	})
+}
+
+func TestReadLine(t *testing.T) {
+	input := strings.NewReader("k\r\nrest of input")
+
+	line, err := readLine(input)
+	require.NoError(t, err)
+	assert.Equal(t, "k", line)
+
+	rest, err := io.ReadAll(input)
+	require.NoError(t, err)
+	assert.Equal(t, "rest of input", string(rest), "Only the first line is consumed")
+
+	_, err = readLine(input)
+	assert.Error(t, err)
+}
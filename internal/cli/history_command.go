@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// defaultHistoryLimit is the number of operations tt history shows without an argument
+const defaultHistoryLimit = 10
+
+// HistoryCommand handles the history command, which lists the operations that tt undo can revert
+type HistoryCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+}
+
+// NewHistoryCommand creates a new history command handler
+func NewHistoryCommand(app *App) *HistoryCommand {
+	return &HistoryCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+	}
+}
+
+// Execute runs the history command
+func (c *HistoryCommand) Execute(ctx context.Context, args []string) error {
+	limit, err := parseOperationCount(args, defaultHistoryLimit, "usage: tt history [count]")
+	if err != nil {
+		return err
+	}
+
+	operations, err := c.businessAPI.ListOperations(ctx, limit)
+	if err != nil {
+		return c.errorHandler.Handle("list history", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(operations)
+	}
+
+	if len(operations) == 0 {
+		fmt.Println("No operations recorded yet.")
+		return nil
+	}
+
+	for _, operation := range operations {
+		line := fmt.Sprintf("%4d  %s  %s", operation.ID, operation.CreatedAt.Local().Format("2006-01-02 15:04:05"), operation.Description)
+		if operation.UndoneAt != nil {
+			line += " (undone)"
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// parseOperationCount reads the optional count argument of tt history and tt undo
+func parseOperationCount(args []string, defaultCount int, usage string) (int, error) {
+	switch len(args) {
+	case 0:
+		return defaultCount, nil
+	case 1:
+		count, err := strconv.Atoi(args[0])
+		if err != nil || count <= 0 {
+			return 0, errors.NewInvalidInputError("count", args[0], "must be a positive number")
+		}
+		return count, nil
+	}
+	return 0, errors.NewInvalidInputError("command", "", usage)
+}
@@ -0,0 +1,372 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// ImportOptions holds the flags accepted by the import command
+type ImportOptions struct {
+	Format string // Input format: csv, json, toggl or clockify (inferred from the file extension when empty)
+	DryRun bool   // Report what would be imported without writing anything
+}
+
+// ImportCommand handles the import command
+type ImportCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	options      ImportOptions
+}
+
+// entryReader parses time entries from an export file
+type entryReader func(r io.Reader) ([]api.ImportEntry, error)
+
+// importFormats maps the --format values accepted by tt import to their readers
+var importFormats = map[string]entryReader{
+	"csv":      readEntriesCSV,
+	"json":     readEntriesJSON,
+	"toggl":    readEntriesToggl,
+	"clockify": readEntriesClockify,
+}
+
+// NewImportCommand creates a new import command handler
+func NewImportCommand(app *App) *ImportCommand {
+	return NewImportCommandWithOptions(app, ImportOptions{})
+}
+
+// NewImportCommandWithOptions creates a new import command handler with the given flag values
+func NewImportCommandWithOptions(app *App, options ImportOptions) *ImportCommand {
+	return &ImportCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		options:      options,
+	}
+}
+
+// Execute runs the import command
+func (c *ImportCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.NewInvalidInputError("command", "import", "usage: tt import <file> [--format csv|json|toggl|clockify] [--dry-run]")
+	}
+	path := args[0]
+
+	format := strings.ToLower(strings.TrimSpace(c.options.Format))
+	if format == "" {
+		format = inferImportFormat(path)
+	}
+	read, exists := importFormats[format]
+	if !exists {
+		return errors.NewInvalidInputError("format", c.options.Format, "unsupported format, expected one of "+strings.Join(supportedImportFormats(), ", "))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	entries, err := read(file)
+	if err != nil {
+		return c.errorHandler.Handle("read "+path, err)
+	}
+
+	result, err := c.businessAPI.ImportTimeEntries(ctx, entries, c.options.DryRun)
+	if err != nil {
+		return c.errorHandler.Handle("import time entries", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(result)
+	}
+
+	if result.DryRun {
+		fmt.Printf("Dry run: would import %d time entries, skip %d duplicates and create %d tasks\n",
+			result.Imported, result.Duplicates, len(result.CreatedTasks))
+	} else {
+		fmt.Printf("Imported %d time entries, skipped %d duplicates, created %d tasks\n",
+			result.Imported, result.Duplicates, len(result.CreatedTasks))
+	}
+	for _, taskName := range result.CreatedTasks {
+		fmt.Printf("  + %s\n", taskName)
+	}
+	if len(result.CreatedProjects) > 0 {
+		fmt.Printf("Projects created: %s\n", strings.Join(result.CreatedProjects, ", "))
+	}
+	if len(result.Skipped) > 0 {
+		verb := "Skipped"
+		if result.DryRun {
+			verb = "Would skip"
+		}
+		fmt.Printf("%s %d entries:\n", verb, len(result.Skipped))
+		for _, skipped := range result.Skipped {
+			fmt.Printf("  entry %d, %s at %s: %s\n", skipped.Entry, skipped.TaskName,
+				skipped.StartTime.Local().Format("2006-01-02 15:04"), skipped.Reason)
+		}
+	}
+	return nil
+}
+
+// inferImportFormat picks a format from the file extension, defaulting to csv
+func inferImportFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".ndjson":
+		return "json"
+	default:
+		return "csv"
+	}
+}
+
+// supportedImportFormats returns the sorted names of the formats accepted by --format
+func supportedImportFormats() []string {
+	formats := make([]string, 0, len(importFormats))
+	for format := range importFormats {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// readEntriesCSV reads the CSV written by tt output format=csv
+func readEntriesCSV(r io.Reader) ([]api.ImportEntry, error) {
+	rows, err := readCSVRecords(r, "Start Time", "Task Name")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]api.ImportEntry, 0, len(rows))
+	for _, row := range rows {
+		start, err := parseImportTime(row.line, "Start Time", row.get("Start Time"))
+		if err != nil {
+			return nil, err
+		}
+		entry := api.ImportEntry{
+			TaskName:  row.get("Task Name"),
+			StartTime: start,
+			Note:      row.get("Note"),
+			Project:   row.get("Project"),
+			Tags:      strings.Fields(row.get("Tags")),
+		}
+		if end := row.get("End Time"); end != "" {
+			endTime, err := parseImportTime(row.line, "End Time", end)
+			if err != nil {
+				return nil, err
+			}
+			entry.EndTime = &endTime
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// readEntriesJSON reads the JSON array or NDJSON stream written by tt output format=json|ndjson
+func readEntriesJSON(r io.Reader) ([]api.ImportEntry, error) {
+	var records []entryRecord
+
+	reader := bufio.NewReader(r)
+	first, err := firstNonSpaceByte(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(reader)
+	if first == '[' {
+		if err := decoder.Decode(&records); err != nil {
+			return nil, errors.NewValidationError("invalid JSON export: "+err.Error(), err)
+		}
+	} else {
+		for {
+			var record entryRecord
+			if err := decoder.Decode(&record); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, errors.NewValidationError("invalid JSON export: "+err.Error(), err)
+			}
+			records = append(records, record)
+		}
+	}
+
+	entries := make([]api.ImportEntry, 0, len(records))
+	for i, record := range records {
+		if record.StartTime.IsZero() {
+			return nil, errors.NewInvalidInputError("start_time", "", fmt.Sprintf("entry %d has no start time", i+1))
+		}
+		entries = append(entries, api.ImportEntry{
+			TaskName:  record.TaskName,
+			StartTime: record.StartTime,
+			EndTime:   record.EndTime,
+			Note:      record.Note,
+			Project:   record.Project,
+			Tags:      record.Tags,
+		})
+	}
+	return entries, nil
+}
+
+// firstNonSpaceByte peeks at the first significant byte to tell a JSON array from NDJSON
+func firstNonSpaceByte(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err == io.EOF {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read JSON: %w", err)
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(b)) {
+			return b, reader.UnreadByte()
+		}
+	}
+}
+
+// readEntriesToggl reads a Toggl Track detailed report CSV export
+func readEntriesToggl(r io.Reader) ([]api.ImportEntry, error) {
+	return readSplitDateTimeCSV(r, splitDateTimeColumns{
+		startDate: "Start date",
+		startTime: "Start time",
+		endDate:   "End date",
+		endTime:   "End time",
+	})
+}
+
+// readEntriesClockify reads a Clockify detailed report CSV export
+func readEntriesClockify(r io.Reader) ([]api.ImportEntry, error) {
+	return readSplitDateTimeCSV(r, splitDateTimeColumns{
+		startDate: "Start Date",
+		startTime: "Start Time",
+		endDate:   "End Date",
+		endTime:   "End Time",
+	})
+}
+
+// splitDateTimeColumns names the columns of third-party exports that store dates and times separately
+type splitDateTimeColumns struct {
+	startDate, startTime, endDate, endTime string
+}
+
+// readSplitDateTimeCSV reads third-party exports, naming tasks after the entry description
+// and falling back to the project for entries without one
+func readSplitDateTimeCSV(r io.Reader, columns splitDateTimeColumns) ([]api.ImportEntry, error) {
+	rows, err := readCSVRecords(r, columns.startDate, columns.startTime, columns.endDate, columns.endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]api.ImportEntry, 0, len(rows))
+	for _, row := range rows {
+		taskName := row.get("Description")
+		if taskName == "" {
+			taskName = row.get("Project")
+		}
+
+		start, err := parseImportDateTime(row.line, row.get(columns.startDate), row.get(columns.startTime))
+		if err != nil {
+			return nil, err
+		}
+		entry := api.ImportEntry{TaskName: taskName, StartTime: start}
+		if row.get(columns.endDate) != "" || row.get(columns.endTime) != "" {
+			end, err := parseImportDateTime(row.line, row.get(columns.endDate), row.get(columns.endTime))
+			if err != nil {
+				return nil, err
+			}
+			entry.EndTime = &end
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// csvRecord is a CSV row whose fields can be looked up by header name
+type csvRecord struct {
+	line    int
+	columns map[string]int
+	fields  []string
+}
+
+// get returns the trimmed value of the named column, or "" when the column is missing
+func (r csvRecord) get(column string) string {
+	index, exists := r.columns[strings.ToLower(column)]
+	if !exists || index >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[index])
+}
+
+// readCSVRecords reads a CSV file with a header row, checking that the required columns are present
+func readCSVRecords(r io.Reader, required ...string) ([]csvRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.NewValidationError("invalid CSV: "+err.Error(), err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+		columns[name] = i
+	}
+	for _, name := range required {
+		if _, exists := columns[strings.ToLower(name)]; !exists {
+			return nil, errors.NewValidationError(fmt.Sprintf("CSV header has no %q column", name), nil)
+		}
+	}
+
+	var records []csvRecord
+	for line := 2; ; line++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.NewValidationError("invalid CSV: "+err.Error(), err)
+		}
+		records = append(records, csvRecord{line: line, columns: columns, fields: fields})
+	}
+	return records, nil
+}
+
+// parseImportTime parses an RFC3339 timestamp from a tt CSV export
+func parseImportTime(line int, column string, value string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.NewInvalidInputError(column, value, fmt.Sprintf("line %d: expected an RFC3339 time", line))
+	}
+	return parsed, nil
+}
+
+// importDateLayouts and importClockLayouts cover the date and time formats Toggl and Clockify export
+var (
+	importDateLayouts  = []string{"2006-01-02", "01/02/2006", "02.01.2006", "2006/01/02"}
+	importClockLayouts = []string{"15:04:05", "15:04", "03:04:05 PM", "03:04 PM", "3:04:05 PM", "3:04 PM"}
+)
+
+// parseImportDateTime combines separate date and time columns into a local time
+func parseImportDateTime(line int, date string, clock string) (time.Time, error) {
+	for _, dateLayout := range importDateLayouts {
+		for _, clockLayout := range importClockLayouts {
+			parsed, err := time.ParseInLocation(dateLayout+" "+clockLayout, date+" "+strings.ToUpper(clock), time.Local)
+			if err == nil {
+				return parsed, nil
+			}
+		}
+	}
+	return time.Time{}, errors.NewInvalidInputError("time", date+" "+clock, fmt.Sprintf("line %d: unrecognized date or time format", line))
+}
@@ -0,0 +1,300 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/repository/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportReaders_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		write entryWriter
+		read  entryReader
+	}{
+		{name: "csv", write: writeEntriesCSV, read: readEntriesCSV},
+		{name: "json", write: writeEntriesJSON, read: readEntriesJSON},
+		{name: "ndjson", write: writeEntriesNDJSON, read: readEntriesJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exported := exportTestEntries()
+			exported[0].Project = "acme/website"
+			exported[1].Project = "acme/website"
+			var buf bytes.Buffer
+			require.NoError(t, tt.write(&buf, exported))
+
+			entries, err := tt.read(&buf)
+			require.NoError(t, err)
+			require.Len(t, entries, len(exported))
+			for i, entry := range entries {
+				assert.Equal(t, exported[i].Task.TaskName, entry.TaskName)
+				assert.True(t, exported[i].TimeEntry.StartTime.Equal(entry.StartTime))
+				require.NotNil(t, entry.EndTime)
+				assert.True(t, exported[i].TimeEntry.EndTime.Equal(*entry.EndTime))
+				assert.Equal(t, exported[i].TimeEntry.Note, entry.Note)
+				assert.Equal(t, exported[i].Project, entry.Project)
+				assert.ElementsMatch(t, exported[i].TimeEntry.Tags, entry.Tags)
+			}
+		})
+	}
+}
+
+func TestReadEntriesCSV_RunningEntry(t *testing.T) {
+	input := "ID,Start Time,End Time,Duration (hours),Task Name\n" +
+		"4,2026-10-16T09:00:00+02:00,,0.00,Coding\n"
+
+	entries, err := readEntriesCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Coding", entries[0].TaskName)
+	assert.Nil(t, entries[0].EndTime)
+}
+
+func TestReadEntries_ThirdParty(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.Local)
+	}
+	endAt := func(day, hour, minute int) *time.Time {
+		end := at(day, hour, minute)
+		return &end
+	}
+
+	tests := []struct {
+		name     string
+		read     entryReader
+		input    string
+		expected []api.ImportEntry
+	}{
+		{
+			name: "toggl uses the description and falls back to the project",
+			read: readEntriesToggl,
+			input: "User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags,Amount ()\n" +
+				"Ann,ann@example.com,Acme,Website,,Landing page,No,2026-10-14,09:00:00,2026-10-14,10:30:00,01:30:00,,\n" +
+				"Ann,ann@example.com,Acme,Website,,,No,2026-10-15,23:30:00,2026-10-16,00:15:00,00:45:00,,\n",
+			expected: []api.ImportEntry{
+				{TaskName: "Landing page", StartTime: at(14, 9, 0), EndTime: endAt(14, 10, 30)},
+				{TaskName: "Website", StartTime: at(15, 23, 30), EndTime: endAt(16, 0, 15)},
+			},
+		},
+		{
+			name: "clockify accepts US dates with 12-hour times",
+			read: readEntriesClockify,
+			input: "Project,Client,Description,Task,User,Group,Email,Tags,Billable,Start Date,Start Time,End Date,End Time,Duration (h),Duration (decimal)\n" +
+				"Website,Acme,Code review,,Ann,,ann@example.com,,No,10/14/2026,01:15:00 PM,10/14/2026,02:00:00 PM,00:45:00,0.75\n",
+			expected: []api.ImportEntry{
+				{TaskName: "Code review", StartTime: at(14, 13, 15), EndTime: endAt(14, 14, 0)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := tt.read(strings.NewReader(tt.input))
+			require.NoError(t, err)
+			require.Len(t, entries, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected.TaskName, entries[i].TaskName)
+				assert.True(t, expected.StartTime.Equal(entries[i].StartTime), "start of entry %d", i+1)
+				require.NotNil(t, entries[i].EndTime)
+				assert.True(t, expected.EndTime.Equal(*entries[i].EndTime), "end of entry %d", i+1)
+			}
+		})
+	}
+}
+
+func TestReadEntries_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		read          entryReader
+		input         string
+		expectedError string
+	}{
+		{
+			name:          "csv without the task column",
+			read:          readEntriesCSV,
+			input:         "ID,Start Time,End Time\n1,2026-10-14T09:00:00Z,\n",
+			expectedError: "Task Name",
+		},
+		{
+			name:          "csv with a malformed time",
+			read:          readEntriesCSV,
+			input:         "ID,Start Time,End Time,Duration (hours),Task Name\n1,yesterday,,0.00,Coding\n",
+			expectedError: "line 2",
+		},
+		{
+			name:          "toggl with an unknown date format",
+			read:          readEntriesToggl,
+			input:         "Description,Start date,Start time,End date,End time\nCoding,14 Oct 2026,09:00,14 Oct 2026,10:00\n",
+			expectedError: "unrecognized date or time format",
+		},
+		{
+			name:          "malformed json",
+			read:          readEntriesJSON,
+			input:         `[{"task_name": "Coding",`,
+			expectedError: "invalid JSON export",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.read(strings.NewReader(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestImportCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	require.NoError(t, writeEntriesCSV(&buf, exportTestEntries()))
+	path := filepath.Join(dir, "export.csv")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+
+	t.Run("passes the read entries on", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		require.NoError(t, NewImportCommand(app).Execute(ctx, []string{path}))
+
+		imports := mockAPI(app).imports
+		require.Len(t, imports, 1)
+		assert.False(t, imports[0].dryRun)
+		var taskNames []string
+		for _, entry := range imports[0].entries {
+			taskNames = append(taskNames, entry.TaskName)
+		}
+		assert.Equal(t, []string{"Review, docs; notes", "Review, docs; notes", "Support | on-call"}, taskNames)
+	})
+
+	t.Run("asks for a dry run", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		require.NoError(t, NewImportCommandWithOptions(app, ImportOptions{DryRun: true}).Execute(ctx, []string{path}))
+
+		imports := mockAPI(app).imports
+		require.Len(t, imports, 1)
+		assert.True(t, imports[0].dryRun)
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		err := NewImportCommandWithOptions(app, ImportOptions{Format: "xml"}).Execute(ctx, []string{path})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected one of clockify, csv, json, toggl")
+	})
+
+	t.Run("requires a file argument", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		err := NewImportCommand(app).Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "usage: tt import")
+	})
+}
+
+func TestImportCommand_ExportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+
+	// newApp opens a new database in dir, so the export is read back by a real import
+	newApp := func(t *testing.T, name string) *App {
+		repo, err := sqlite.New(filepath.Join(dir, name))
+		require.NoError(t, err)
+		t.Cleanup(func() { repo.Close() })
+		return NewApp(api.NewBusinessAPI(repo))
+	}
+
+	source := newApp(t, "source.db")
+	_, err := source.businessAPI.CreateProject(ctx, "acme/website")
+	require.NoError(t, err)
+	_, err = source.businessAPI.StartNewTaskWithOptions(ctx, "Landing page", api.StartOptions{
+		Project: "acme/website", Tags: []string{"billable", "design"}, Note: "hero section", At: &start,
+	})
+	require.NoError(t, err)
+	_, err = source.businessAPI.StopAllRunningTasksAt(ctx, start.Add(90*time.Minute))
+	require.NoError(t, err)
+	_, err = source.businessAPI.AddTimeEntry(ctx, "Email", start.Add(2*time.Hour), start.Add(3*time.Hour))
+	require.NoError(t, err)
+
+	exported, err := source.businessAPI.SearchTimeEntriesWithFilter(ctx, api.TimeEntryFilter{})
+	require.NoError(t, err)
+	require.Len(t, exported, 2)
+
+	for _, format := range []string{"csv", "json"} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(dir, "export."+format)
+			require.NoError(t, NewOutputCommandWithOptions(source, OutputOptions{Out: path}).Execute(ctx, []string{"format=" + format}))
+
+			target := newApp(t, "target-"+format+".db")
+			require.NoError(t, NewImportCommand(target).Execute(ctx, []string{path}))
+
+			imported, err := target.businessAPI.SearchTimeEntriesWithFilter(ctx, api.TimeEntryFilter{})
+			require.NoError(t, err)
+			require.Len(t, imported, len(exported))
+			for i, entry := range imported {
+				assert.Equal(t, exported[i].Task.TaskName, entry.Task.TaskName)
+				assert.Equal(t, exported[i].Project, entry.Project)
+				assert.Equal(t, exported[i].TimeEntry.Tags, entry.TimeEntry.Tags)
+				assert.Equal(t, exported[i].TimeEntry.Note, entry.TimeEntry.Note)
+				assert.True(t, exported[i].TimeEntry.StartTime.Equal(entry.TimeEntry.StartTime))
+			}
+		})
+	}
+
+	t.Run("skips the running entry and time already tracked", func(t *testing.T) {
+		_, err := source.businessAPI.StartNewTask(ctx, "Code review")
+		require.NoError(t, err)
+		path := filepath.Join(dir, "running.json")
+		require.NoError(t, NewOutputCommandWithOptions(source, OutputOptions{Out: path}).Execute(ctx, []string{"format=json"}))
+
+		target := newApp(t, "target-running.db")
+		_, err = target.businessAPI.AddTimeEntry(ctx, "Meeting", start.Add(150*time.Minute), start.Add(4*time.Hour))
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		cmd := NewImportCommand(target)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{path}))
+
+		var result api.ImportResult
+		require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		assert.Equal(t, 1, result.Imported)
+		require.Len(t, result.Skipped, 2)
+		assert.Equal(t, "Email", result.Skipped[0].TaskName)
+		assert.Equal(t, "it overlaps entry 1", result.Skipped[0].Reason)
+		assert.Equal(t, "Code review", result.Skipped[1].TaskName)
+		assert.Equal(t, "it is still running", result.Skipped[1].Reason)
+
+		_, err = target.businessAPI.GetCurrentSession(ctx)
+		assert.Error(t, err, "No timer was started by the import")
+	})
+}
+
+func TestInferImportFormat(t *testing.T) {
+	assert.Equal(t, "json", inferImportFormat("export.JSON"))
+	assert.Equal(t, "json", inferImportFormat("export.ndjson"))
+	assert.Equal(t, "csv", inferImportFormat("export.csv"))
+	assert.Equal(t, "csv", inferImportFormat("export"))
+}
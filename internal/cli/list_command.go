@@ -9,17 +9,33 @@ import (
 	"time-tracker/internal/config"
 )
 
+// ListOptions holds the flags accepted by the list command
+type ListOptions struct {
+	Project     string   // Only list entries of this project and its sub-projects
+	Tags        []string // Only list entries carrying every one of these tags
+	ExcludeTags []string // Leave out entries carrying any of these tags
+}
+
 // ListCommand handles the list command
 type ListCommand struct {
 	businessAPI api.BusinessAPI
 	config      *config.Config
+	printer     *Printer
+	options     ListOptions
 }
 
 // NewListCommand creates a new list command handler
 func NewListCommand(app *App) *ListCommand {
+	return NewListCommandWithOptions(app, ListOptions{})
+}
+
+// NewListCommandWithOptions creates a new list command handler with the given flag values
+func NewListCommandWithOptions(app *App, options ListOptions) *ListCommand {
 	return &ListCommand{
 		businessAPI: app.businessAPI,
 		config:      app.config,
+		printer:     app.newPrinter(),
+		options:     options,
 	}
 }
 
@@ -38,9 +54,9 @@ func (c *ListCommand) listTasks(ctx context.Context, args []string) error {
 		timeRange = ""
 		textFilter = ""
 	} else {
-		// Check if first argument is a time shorthand
-		if _, err := parseTimeShorthand(args[0]); err == nil {
-			// Time shorthand found
+		// Check if first argument is a time range
+		if _, err := c.businessAPI.ParseTimeRange(ctx, args[0]); err == nil {
+			// Time range found
 			timeRange = args[0]
 			
 			// If there are more arguments, use them as search text
@@ -48,25 +64,46 @@ func (c *ListCommand) listTasks(ctx context.Context, args []string) error {
 				textFilter = strings.Join(args[1:], " ")
 			}
 		} else {
-			// No time shorthand, treat all arguments as search text
+			// No time range, treat all arguments as search text
 			textFilter = strings.Join(args, " ")
 		}
 	}
 
 	// Search for time entries with task information using BusinessAPI
-	entries, err := c.businessAPI.SearchTimeEntries(ctx, timeRange, textFilter)
+	filter := api.TimeEntryFilter{
+		TimeRange:   timeRange,
+		Text:        textFilter,
+		Project:     c.options.Project,
+		Tags:        c.options.Tags,
+		ExcludeTags: c.options.ExcludeTags,
+	}
+	entries, err := c.businessAPI.SearchTimeEntriesWithFilter(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("failed to search tasks: %w", err)
 	}
 
-	return c.printTimeEntries(ctx, entries)
+	if err := c.printTimeEntries(ctx, entries); err != nil {
+		return err
+	}
+
+	// Follow the entries of a project with its totals
+	if c.options.Project == "" || len(entries) == 0 || c.printer.IsStructured() {
+		return nil
+	}
+	totals, err := c.businessAPI.GetProjectTotals(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to total projects: %w", err)
+	}
+	fmt.Println()
+	printProjectTotals(totals)
+	return nil
 }
 
 // printTimeEntries prints one line per time entry in the format:
-// startTime - endTime (duration): taskName
+// [id] startTime - endTime (duration): taskName #tag - note
 // Where endTime is 'running' if the entry is running.
 func (c *ListCommand) printTimeEntries(ctx context.Context, entries []*api.TimeEntryWithTask) error {
-	if len(entries) == 0 {
+	if len(entries) == 0 && !c.printer.IsStructured() {
 		fmt.Println("No tasks found")
 		return nil
 	}
@@ -89,6 +126,10 @@ func (c *ListCommand) printTimeEntries(ctx context.Context, entries []*api.TimeE
 	})
 	
 	sortedEntries := append(finishedEntries, runningEntries...)
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newEntryRecords(sortedEntries))
+	}
 	
 	for _, entry := range sortedEntries {
 		// Use configured time format
@@ -104,7 +145,13 @@ func (c *ListCommand) printTimeEntries(ctx context.Context, entries []*api.TimeE
 		
 		// Truncate task name if configured
 		taskName := c.truncateTaskName(entry.Task.TaskName)
-		fmt.Printf("%s - %s (%s): %s\n", startStr, endStr, entry.Duration, taskName)
+		if len(entry.TimeEntry.Tags) > 0 {
+			taskName += " " + formatTags(entry.TimeEntry.Tags)
+		}
+		if entry.TimeEntry.Note != "" {
+			taskName += " - " + entry.TimeEntry.Note
+		}
+		fmt.Printf("[%d] %s - %s (%s): %s\n", entry.TimeEntry.ID, startStr, endStr, entry.Duration, taskName)
 	}
 
 	return nil
@@ -1,7 +1,10 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -169,4 +172,43 @@ func TestNewListCommand(t *testing.T) {
 	
 	assert.NotNil(t, cmd)
 	assert.NotNil(t, cmd.businessAPI)
-}
\ No newline at end of file
+}
+
+// The search service applies the tag filters, see TestSearchService_SearchByTags; list passes them on
+func TestListCommand_TagFilters(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		options ListOptions
+	}{
+		{name: "single tag", options: ListOptions{Tags: []string{"billable"}}},
+		{name: "several tags", options: ListOptions{Tags: []string{"#billable", "+meeting"}}},
+		{name: "excluded tag", options: ListOptions{ExcludeTags: []string{"meeting"}}},
+		{name: "include and exclude", options: ListOptions{Tags: []string{"meeting"}, ExcludeTags: []string{"billable"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, cleanup := setupTestAppWithMockBusinessAPI(t)
+			defer cleanup()
+			require.NoError(t, NewStartCommand(app).Execute(ctx, []string{"Client call", "+billable", "+meeting"}))
+
+			var out bytes.Buffer
+			cmd := NewListCommandWithOptions(app, tt.options)
+			cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+			require.NoError(t, cmd.Execute(ctx, []string{}))
+
+			filters := mockAPI(app).filters
+			require.Len(t, filters, 1)
+			assert.Equal(t, tt.options.Tags, filters[0].Tags)
+			assert.Equal(t, tt.options.ExcludeTags, filters[0].ExcludeTags)
+
+			var records []entryRecord
+			require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+			require.Len(t, records, 1)
+			assert.Equal(t, "Client call", records[0].TaskName)
+			assert.Equal(t, []string{"billable", "meeting"}, records[0].Tags)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"time-tracker/internal/api"
 	"time-tracker/internal/domain"
 	"time-tracker/internal/errors"
+	"time-tracker/internal/services"
 )
 
 // mockBusinessAPI implements the BusinessAPI interface for testing
@@ -20,18 +22,59 @@ type mockBusinessAPI struct {
 	nextTaskID    int64
 	nextEntryID   int64
 	currentTaskID *int64 // Track currently running task
+	projects      map[int64]*api.ProjectInfo
+	nextProjectID int64
+	operations    []*mockOperation  // Journal of undoable operations, oldest first
+	backups       []*api.BackupInfo // Backups taken, oldest first; no files are written
+	resolutions   int               // Calls to ResolveForgottenTimer
+	lastActivity  *time.Time        // Last activity reported for forgotten timers
+
+	// The mock records the arguments it is given and returns canned errors instead of applying
+	// business rules; the services tests cover the rules against the SQLite repository
+	starts        []mockStart           // Calls to StartNewTaskWithOptions
+	filters       []api.TimeEntryFilter // Filters passed to SearchTimeEntriesWithFilter and GetProjectTotals
+	imports       []mockImport          // Calls to ImportTimeEntries
+	doctorOptions []api.DoctorOptions   // Options passed to CheckConsistency
+	doctorReport  *api.DoctorReport     // Report CheckConsistency returns
+	failures      map[string]error      // Errors to return, by method name
+}
+
+// mockStart records a call to StartNewTaskWithOptions
+type mockStart struct {
+	taskName string
+	options  api.StartOptions
+}
+
+// mockImport records a call to ImportTimeEntries
+type mockImport struct {
+	entries []api.ImportEntry
+	dryRun  bool
+}
+
+// mockOperation is a journaled operation with the function that reverts it
+type mockOperation struct {
+	operation *api.Operation
+	revert    func()
 }
 
 // newMockBusinessAPI creates a new mock BusinessAPI instance
 func newMockBusinessAPI() api.BusinessAPI {
 	return &mockBusinessAPI{
-		tasks:       make(map[int64]*domain.Task),
-		timeEntries: make(map[int64]*domain.TimeEntry),
-		nextTaskID:  1,
-		nextEntryID: 1,
+		tasks:         make(map[int64]*domain.Task),
+		timeEntries:   make(map[int64]*domain.TimeEntry),
+		nextTaskID:    1,
+		nextEntryID:   1,
+		projects:      make(map[int64]*api.ProjectInfo),
+		nextProjectID: 1,
+		failures:      make(map[string]error),
 	}
 }
 
+// mockAPI returns the mock behind a test app
+func mockAPI(app *App) *mockBusinessAPI {
+	return app.businessAPI.(*mockBusinessAPI)
+}
+
 func (m *mockBusinessAPI) StartNewTask(ctx context.Context, taskName string) (*api.TaskSession, error) {
 	// Stop any running tasks first
 	_, _ = m.StopAllRunningTasks(ctx)
@@ -57,26 +100,57 @@ func (m *mockBusinessAPI) StartNewTask(ctx context.Context, taskName string) (*a
 	m.currentTaskID = &task.ID
 
 	return &api.TaskSession{
-		Task:     task,
-		Duration: "running for 0m",
+		Task:      task,
+		TimeEntry: entry,
+		Duration:  "running for 0m",
 	}, nil
 }
 
+func (m *mockBusinessAPI) StartNewTaskWithOptions(ctx context.Context, taskName string, opts api.StartOptions) (*api.TaskSession, error) {
+	m.starts = append(m.starts, mockStart{taskName: taskName, options: opts})
+	if err := m.failures["StartNewTaskWithOptions"]; err != nil {
+		return nil, err
+	}
+
+	if opts.At != nil {
+		_, _ = m.StopAllRunningTasksAt(ctx, *opts.At)
+	}
+	session, err := m.StartNewTask(ctx, taskName)
+	if err != nil {
+		return nil, err
+	}
+	if opts.At != nil {
+		session.TimeEntry.StartTime = *opts.At
+	}
+	if project := m.findProject(opts.Project); project != nil {
+		session.Task.ProjectID = &project.Project.ID
+	}
+	session.TimeEntry.Tags = opts.Tags
+	session.TimeEntry.Note = opts.Note
+	return session, nil
+}
+
 func (m *mockBusinessAPI) ResumeTask(ctx context.Context, taskID int64) (*api.TaskSession, error) {
+	return m.ResumeTaskAt(ctx, taskID, time.Now())
+}
+
+func (m *mockBusinessAPI) ResumeTaskAt(ctx context.Context, taskID int64, at time.Time) (*api.TaskSession, error) {
 	// Stop any running tasks first
-	_, _ = m.StopAllRunningTasks(ctx)
+	if _, err := m.StopAllRunningTasksAt(ctx, at); err != nil {
+		return nil, err
+	}
 
 	task, exists := m.tasks[taskID]
 	if !exists {
 		return nil, errors.NewNotFoundError("task", fmt.Sprintf("%d", taskID))
 	}
+	task.ArchivedAt = nil
 
 	// Create new time entry
-	now := time.Now()
 	entry := &domain.TimeEntry{
 		ID:        m.nextEntryID,
 		TaskID:    taskID,
-		StartTime: now,
+		StartTime: at,
 		EndTime:   nil, // Running
 	}
 	m.timeEntries[entry.ID] = entry
@@ -84,18 +158,114 @@ func (m *mockBusinessAPI) ResumeTask(ctx context.Context, taskID int64) (*api.Ta
 	m.currentTaskID = &taskID
 
 	return &api.TaskSession{
-		Task:     task,
-		Duration: "running for 0m",
+		Task:      task,
+		TimeEntry: entry,
+		Duration:  "running for 0m",
+	}, nil
+}
+
+func (m *mockBusinessAPI) AddTimeEntry(ctx context.Context, taskName string, start time.Time, end time.Time) (*api.TaskSession, error) {
+	if err := m.failures["AddTimeEntry"]; err != nil {
+		return nil, err
+	}
+
+	// Find existing task by name or create a new one
+	var task *domain.Task
+	for _, existing := range m.tasks {
+		if existing.TaskName == taskName {
+			task = existing
+			break
+		}
+	}
+	if task == nil {
+		task = &domain.Task{
+			ID:       m.nextTaskID,
+			TaskName: taskName,
+		}
+		m.tasks[task.ID] = task
+		m.nextTaskID++
+	}
+
+	entry := &domain.TimeEntry{
+		ID:        m.nextEntryID,
+		TaskID:    task.ID,
+		StartTime: start,
+		EndTime:   &end,
+	}
+	m.timeEntries[entry.ID] = entry
+	m.nextEntryID++
+
+	d := end.Sub(start)
+	return &api.TaskSession{
+		Task:      task,
+		TimeEntry: entry,
+		Duration:  fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60),
 	}, nil
 }
 
+func (m *mockBusinessAPI) EditTimeEntry(ctx context.Context, entryID int64, update api.TimeEntryUpdate) (*api.TimeEntryEdit, error) {
+	if err := m.failures["EditTimeEntry"]; err != nil {
+		return nil, err
+	}
+	before, err := m.GetTimeEntry(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := m.timeEntries[entryID]
+	updated := *entry
+	if update.StartTime != nil {
+		updated.StartTime = *update.StartTime
+	}
+	if update.EndTime != nil {
+		endTime := *update.EndTime
+		updated.EndTime = &endTime
+	}
+	if update.Note != nil {
+		updated.Note = *update.Note
+	}
+
+	if update.TaskName != nil {
+		var task *domain.Task
+		for _, existing := range m.tasks {
+			if existing.TaskName == *update.TaskName {
+				task = existing
+				break
+			}
+		}
+		if task == nil {
+			task = &domain.Task{ID: m.nextTaskID, TaskName: *update.TaskName}
+			m.tasks[task.ID] = task
+			m.nextTaskID++
+		}
+		updated.TaskID = task.ID
+	}
+
+	// Take a copy of the before state since the entry is about to change
+	beforeEntry := *before.TimeEntry
+	before.TimeEntry = &beforeEntry
+	*entry = updated
+
+	after, err := m.GetTimeEntry(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TimeEntryEdit{Before: before, After: after}, nil
+}
+
 func (m *mockBusinessAPI) StopAllRunningTasks(ctx context.Context) ([]*domain.TimeEntry, error) {
-	var stopped []*domain.TimeEntry
-	now := time.Now()
+	return m.StopAllRunningTasksAt(ctx, time.Now())
+}
+
+func (m *mockBusinessAPI) StopAllRunningTasksAt(ctx context.Context, at time.Time) ([]*domain.TimeEntry, error) {
+	if err := m.failures["StopAllRunningTasksAt"]; err != nil {
+		return nil, err
+	}
 
+	var stopped []*domain.TimeEntry
 	for _, entry := range m.timeEntries {
 		if entry.EndTime == nil {
-			entry.EndTime = &now
+			entry.EndTime = &at
 			stopped = append(stopped, entry)
 		}
 	}
@@ -104,16 +274,119 @@ func (m *mockBusinessAPI) StopAllRunningTasks(ctx context.Context) ([]*domain.Ti
 	return stopped, nil
 }
 
+func (m *mockBusinessAPI) FindForgottenTimer(ctx context.Context, after time.Duration) (*api.ForgottenTimer, error) {
+	now := time.Now()
+	for _, entry := range m.timeEntries {
+		if entry.EndTime != nil {
+			continue
+		}
+		since := entry.StartTime
+		if entry.KeptRunningAt != nil && entry.KeptRunningAt.After(since) {
+			since = *entry.KeptRunningAt
+		}
+		if now.Sub(since) <= after {
+			continue
+		}
+		running := now.Sub(entry.StartTime)
+		return &api.ForgottenTimer{
+			Task:           m.tasks[entry.TaskID],
+			TimeEntry:      entry,
+			Running:        running,
+			RunningSeconds: int64(running.Seconds()),
+			LastActivity:   m.lastActivity,
+		}, nil
+	}
+	return nil, nil
+}
+
+func (m *mockBusinessAPI) ResolveForgottenTimer(ctx context.Context, entryID int64, resolution api.ForgottenTimerResolution) (*api.TimeEntryWithTask, error) {
+	m.resolutions++
+	if err := m.failures["ResolveForgottenTimer"]; err != nil {
+		return nil, err
+	}
+	entry, exists := m.timeEntries[entryID]
+	if !exists {
+		return nil, errors.NewNotFoundError("time entry", fmt.Sprintf("%d", entryID))
+	}
+
+	before := *entry
+	now := time.Now()
+	if resolution.Action == api.ForgottenTimerKeep {
+		entry.KeptRunningAt = &now
+	} else {
+		at := *resolution.At
+		entry.EndTime = &at
+		m.currentTaskID = nil
+	}
+
+	task := m.tasks[entry.TaskID]
+	m.record("forgotten", fmt.Sprintf("Resolved forgotten %q with %s", task.TaskName, resolution.Action), func() {
+		*entry = before
+	})
+	return &api.TimeEntryWithTask{TimeEntry: entry, Task: task}, nil
+}
+
+func (m *mockBusinessAPI) PauseTask(ctx context.Context) (*api.TaskSession, error) {
+	session, err := m.GetCurrentSession(ctx)
+	if err != nil {
+		if errors.IsErrorType(err, errors.ErrorTypeNotFound) {
+			return nil, errors.NewValidationError("no task is running", nil)
+		}
+		return nil, err
+	}
+
+	_, _ = m.StopAllRunningTasks(ctx)
+	for _, entry := range m.timeEntries {
+		entry.Paused = false
+	}
+	session.TimeEntry.Paused = true
+	session.Duration = fmt.Sprintf("%dm", int(session.TimeEntry.Duration().Minutes()))
+	return session, nil
+}
+
+func (m *mockBusinessAPI) ContinueTask(ctx context.Context) (*api.TaskSession, error) {
+	var paused *domain.TimeEntry
+	for _, entry := range m.timeEntries {
+		if entry.Paused {
+			paused = entry
+		}
+	}
+	if paused == nil {
+		return nil, errors.NewValidationError("no task is paused", nil)
+	}
+
+	session, err := m.ResumeTask(ctx, paused.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	session.TimeEntry.ContinuesID = &paused.ID
+	session.TimeEntry.Tags = paused.Tags
+	paused.Paused = false
+	return session, nil
+}
+
 func (m *mockBusinessAPI) DeleteTaskWithEntries(ctx context.Context, taskID int64) error {
+	task, exists := m.tasks[taskID]
+
 	// Delete all time entries for this task
+	deleted := make(map[int64]*domain.TimeEntry)
 	for id, entry := range m.timeEntries {
 		if entry.TaskID == taskID {
+			deleted[id] = entry
 			delete(m.timeEntries, id)
 		}
 	}
 
 	// Delete the task
 	delete(m.tasks, taskID)
+	if exists {
+		m.record("delete", fmt.Sprintf("Deleted task %q and %d time entries", task.TaskName, len(deleted)), func() {
+			m.tasks[taskID] = task
+			for id, entry := range deleted {
+				m.timeEntries[id] = entry
+			}
+		})
+	}
 
 	if m.currentTaskID != nil && *m.currentTaskID == taskID {
 		m.currentTaskID = nil
@@ -128,10 +401,269 @@ func (m *mockBusinessAPI) UpdateTaskName(ctx context.Context, taskID int64, newN
 		return nil, errors.NewNotFoundError("task", fmt.Sprintf("%d", taskID))
 	}
 
+	if err := m.failures["UpdateTaskName"]; err != nil {
+		return nil, err
+	}
+
 	task.TaskName = newName
 	return task, nil
 }
 
+func (m *mockBusinessAPI) ArchiveTask(ctx context.Context, taskID int64) (*domain.Task, error) {
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return nil, errors.NewNotFoundError("task", fmt.Sprintf("%d", taskID))
+	}
+	if err := m.failures["ArchiveTask"]; err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	task.ArchivedAt = &now
+	return task, nil
+}
+
+func (m *mockBusinessAPI) UnarchiveTask(ctx context.Context, taskID int64) (*domain.Task, error) {
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return nil, errors.NewNotFoundError("task", fmt.Sprintf("%d", taskID))
+	}
+	if err := m.failures["UnarchiveTask"]; err != nil {
+		return nil, err
+	}
+	task.ArchivedAt = nil
+	return task, nil
+}
+
+func (m *mockBusinessAPI) ArchiveInactiveTasks(ctx context.Context, before time.Time) ([]*domain.Task, error) {
+	lastActive := make(map[int64]time.Time)
+	running := make(map[int64]bool)
+	for _, entry := range m.timeEntries {
+		if entry.EndTime == nil {
+			running[entry.TaskID] = true
+		} else if entry.EndTime.After(lastActive[entry.TaskID]) {
+			lastActive[entry.TaskID] = *entry.EndTime
+		}
+	}
+
+	var archived []*domain.Task
+	now := time.Now()
+	for id, task := range m.tasks {
+		last, hasEntries := lastActive[id]
+		if task.ArchivedAt == nil && hasEntries && !running[id] && last.Before(before) {
+			task.ArchivedAt = &now
+			archived = append(archived, task)
+		}
+	}
+	return archived, nil
+}
+
+func (m *mockBusinessAPI) MergeTasks(ctx context.Context, fromID int64, intoID int64) (*api.TaskMerge, error) {
+	if err := m.failures["MergeTasks"]; err != nil {
+		return nil, err
+	}
+	from, err := m.GetTask(ctx, fromID)
+	if err != nil {
+		return nil, err
+	}
+	into, err := m.GetTask(ctx, intoID)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := 0
+	for _, entry := range m.timeEntries {
+		if entry.TaskID == fromID {
+			entry.TaskID = intoID
+			moved++
+		}
+	}
+	delete(m.tasks, fromID)
+
+	if m.currentTaskID != nil && *m.currentTaskID == fromID {
+		m.currentTaskID = &intoID
+	}
+
+	return &api.TaskMerge{From: from, Into: into, MovedEntries: moved}, nil
+}
+
+// ImportTimeEntries records the entries and reports them all as imported
+func (m *mockBusinessAPI) ImportTimeEntries(ctx context.Context, entries []api.ImportEntry, dryRun bool) (*api.ImportResult, error) {
+	m.imports = append(m.imports, mockImport{entries: entries, dryRun: dryRun})
+	if err := m.failures["ImportTimeEntries"]; err != nil {
+		return nil, err
+	}
+	return &api.ImportResult{
+		DryRun:          dryRun,
+		Imported:        len(entries),
+		Skipped:         []api.ImportSkip{},
+		CreatedTasks:    []string{},
+		CreatedProjects: []string{},
+	}, nil
+}
+
+func (m *mockBusinessAPI) CreateProject(ctx context.Context, path string) (*api.ProjectInfo, error) {
+	if err := m.failures["CreateProject"]; err != nil {
+		return nil, err
+	}
+
+	var parent *api.ProjectInfo
+	segments := strings.Split(path, domain.ProjectPathSeparator)
+	for depth := range segments {
+		current := m.findProject(strings.Join(segments[:depth+1], domain.ProjectPathSeparator))
+		if current == nil {
+			project := &domain.Project{ID: m.nextProjectID, Name: segments[depth]}
+			if parent != nil {
+				project.ParentID = &parent.Project.ID
+			}
+			current = &api.ProjectInfo{Project: project, Path: strings.Join(segments[:depth+1], domain.ProjectPathSeparator)}
+			m.projects[project.ID] = current
+			m.nextProjectID++
+		}
+		parent = current
+	}
+	return parent, nil
+}
+
+func (m *mockBusinessAPI) ListProjects(ctx context.Context, includeArchived bool) ([]*api.ProjectInfo, error) {
+	var projects []*api.ProjectInfo
+	for _, project := range m.projects {
+		if project.Archived && !includeArchived {
+			continue
+		}
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Path < projects[j].Path
+	})
+	return projects, nil
+}
+
+func (m *mockBusinessAPI) ArchiveProject(ctx context.Context, path string) (*api.ProjectInfo, error) {
+	project := m.findProject(path)
+	if project == nil {
+		return nil, errors.NewNotFoundError("project", path)
+	}
+	now := time.Now()
+	for _, other := range m.projects {
+		if other.Path == path || strings.HasPrefix(other.Path, path+domain.ProjectPathSeparator) {
+			other.Archived = true
+		}
+	}
+	project.Project.ArchivedAt = &now
+	return project, nil
+}
+
+// record adds an operation to the mock journal
+func (m *mockBusinessAPI) record(kind, description string, revert func()) {
+	m.operations = append(m.operations, &mockOperation{
+		operation: &api.Operation{
+			ID:          int64(len(m.operations) + 1),
+			Kind:        kind,
+			Description: description,
+			CreatedAt:   time.Now(),
+			Changes:     1,
+		},
+		revert: revert,
+	})
+}
+
+func (m *mockBusinessAPI) ListOperations(ctx context.Context, limit int) ([]*api.Operation, error) {
+	var operations []*api.Operation
+	for i := len(m.operations) - 1; i >= 0 && len(operations) < limit; i-- {
+		operations = append(operations, m.operations[i].operation)
+	}
+	return operations, nil
+}
+
+func (m *mockBusinessAPI) UndoOperations(ctx context.Context, count int) ([]*api.Operation, error) {
+	if err := m.failures["UndoOperations"]; err != nil {
+		return nil, err
+	}
+	var undone []*api.Operation
+	now := time.Now()
+	for i := len(m.operations) - 1; i >= 0 && len(undone) < count; i-- {
+		if m.operations[i].operation.UndoneAt != nil {
+			continue
+		}
+		m.operations[i].revert()
+		m.operations[i].operation.UndoneAt = &now
+		undone = append(undone, m.operations[i].operation)
+	}
+	return undone, nil
+}
+
+// SubscribeEvents sends the current event and an event for each journaled operation, then ends the stream
+func (m *mockBusinessAPI) SubscribeEvents(ctx context.Context) (<-chan *api.Event, error) {
+	current, _ := m.GetCurrentSession(ctx)
+	events := make(chan *api.Event, len(m.operations)+1)
+	events <- &api.Event{Type: api.EventCurrent, Time: time.Now(), Current: current}
+	for _, journaled := range m.operations {
+		operation := journaled.operation
+		events <- &api.Event{ID: operation.ID, Type: operation.Kind, Description: operation.Description, Time: operation.CreatedAt, Current: current}
+	}
+	close(events)
+	return events, nil
+}
+
+func (m *mockBusinessAPI) BackupDatabase(ctx context.Context, dir string, path string) (*api.BackupInfo, error) {
+	if path == "" {
+		path = filepath.Join(dir, fmt.Sprintf("tt-backup-%d.db", len(m.backups)+1))
+	}
+	if err := m.failures["BackupDatabase"]; err != nil {
+		return nil, err
+	}
+	backup := &api.BackupInfo{Path: path, Size: 4096, CreatedAt: time.Now()}
+	m.backups = append(m.backups, backup)
+	return backup, nil
+}
+
+func (m *mockBusinessAPI) AutoBackupDatabase(ctx context.Context, dir string, retention api.BackupRetention) (*api.AutoBackup, error) {
+	return &api.AutoBackup{Pruned: []*api.BackupInfo{}}, nil
+}
+
+func (m *mockBusinessAPI) ListBackups(ctx context.Context, dir string) ([]*api.BackupInfo, error) {
+	backups := []*api.BackupInfo{}
+	for i := len(m.backups) - 1; i >= 0; i-- {
+		if filepath.Dir(m.backups[i].Path) == dir {
+			backups = append(backups, m.backups[i])
+		}
+	}
+	return backups, nil
+}
+
+func (m *mockBusinessAPI) RestoreDatabase(ctx context.Context, dir string, path string) (*api.BackupRestore, error) {
+	for _, backup := range m.backups {
+		if backup.Path == path || backup.Path == filepath.Join(dir, path) {
+			safety := &api.BackupInfo{Path: filepath.Join(dir, fmt.Sprintf("tt-before-restore-%d.db", len(m.backups)+1)), Size: 4096, CreatedAt: time.Now()}
+			m.backups = append(m.backups, safety)
+			return &api.BackupRestore{Restored: backup, SafetyBackup: safety}, nil
+		}
+	}
+	return nil, errors.NewNotFoundError("backup", path)
+}
+
+// CheckConsistency records the options and returns the canned doctor report, or an empty one
+func (m *mockBusinessAPI) CheckConsistency(ctx context.Context, opts api.DoctorOptions) (*api.DoctorReport, error) {
+	m.doctorOptions = append(m.doctorOptions, opts)
+	if err := m.failures["CheckConsistency"]; err != nil {
+		return nil, err
+	}
+	if m.doctorReport != nil {
+		return m.doctorReport, nil
+	}
+	return &api.DoctorReport{CheckedTasks: len(m.tasks), CheckedEntries: len(m.timeEntries), Problems: []*api.DoctorProblem{}}, nil
+}
+
+// findProject returns the project at path, or nil
+func (m *mockBusinessAPI) findProject(path string) *api.ProjectInfo {
+	for _, project := range m.projects {
+		if project.Path == path {
+			return project
+		}
+	}
+	return nil
+}
+
 func (m *mockBusinessAPI) GetCurrentSession(ctx context.Context) (*api.TaskSession, error) {
 	if m.currentTaskID == nil {
 		return nil, errors.NewNotFoundError("running task", "")
@@ -160,8 +692,9 @@ func (m *mockBusinessAPI) GetCurrentSession(ctx context.Context) (*api.TaskSessi
 	minutes := int(duration.Minutes())
 	
 	return &api.TaskSession{
-		Task:     task,
-		Duration: fmt.Sprintf("running for %dm", minutes),
+		Task:      task,
+		TimeEntry: runningEntry,
+		Duration:  fmt.Sprintf("running for %dm", minutes),
 	}, nil
 }
 
@@ -173,6 +706,35 @@ func (m *mockBusinessAPI) GetTask(ctx context.Context, id int64) (*domain.Task,
 	return task, nil
 }
 
+func (m *mockBusinessAPI) GetTaskByName(ctx context.Context, name string) (*domain.Task, error) {
+	for _, task := range m.tasks {
+		if task.TaskName == name {
+			return task, nil
+		}
+	}
+	return nil, errors.NewNotFoundError("task", name)
+}
+
+func (m *mockBusinessAPI) GetTimeEntry(ctx context.Context, id int64) (*api.TimeEntryWithTask, error) {
+	entry, exists := m.timeEntries[id]
+	if !exists {
+		return nil, errors.NewNotFoundError("time entry", fmt.Sprintf("%d", id))
+	}
+
+	var d time.Duration
+	if entry.EndTime != nil {
+		d = entry.EndTime.Sub(entry.StartTime)
+	} else {
+		d = time.Since(entry.StartTime)
+	}
+
+	return &api.TimeEntryWithTask{
+		TimeEntry: entry,
+		Task:      m.tasks[entry.TaskID],
+		Duration:  fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60),
+	}, nil
+}
+
 func (m *mockBusinessAPI) GetTaskSummary(ctx context.Context, taskID int64) (*api.TaskSummary, error) {
 	task, exists := m.tasks[taskID]
 	if !exists {
@@ -212,77 +774,69 @@ func (m *mockBusinessAPI) GetTaskSummary(ctx context.Context, taskID int64) (*ap
 		return entries[i].StartTime.Before(entries[j].StartTime)
 	})
 
+	// Count segments continuing an earlier entry as pauses of its session
+	var pauseCount int
+	var pausedDuration time.Duration
+	isPaused := false
+	for _, entry := range entries {
+		isPaused = isPaused || entry.Paused
+		if entry.ContinuesID == nil {
+			continue
+		}
+		if previous, exists := m.timeEntries[*entry.ContinuesID]; exists && previous.EndTime != nil {
+			pauseCount++
+			pausedDuration += entry.StartTime.Sub(*previous.EndTime)
+		}
+	}
+
 	hours := int(totalDuration.Hours())
 	minutes := int(totalDuration.Minutes()) % 60
 
 	return &api.TaskSummary{
-		Task:         task,
-		TimeEntries:  entries,
-		TotalTime:    fmt.Sprintf("%dh %dm", hours, minutes),
-		SessionCount: len(entries),
-		RunningCount: runningCount,
-		FirstEntry:   firstEntry,
-		LastEntry:    lastEntry,
-		IsRunning:    runningCount > 0,
+		Task:           task,
+		TimeEntries:    entries,
+		TotalTime:      fmt.Sprintf("%dh %dm", hours, minutes),
+		SessionCount:   len(entries) - pauseCount,
+		RunningCount:   runningCount,
+		PauseCount:     pauseCount,
+		PausedDuration: pausedDuration,
+		PausedTime:     fmt.Sprintf("%dm", int(pausedDuration.Minutes())),
+		FirstEntry:     firstEntry,
+		LastEntry:      lastEntry,
+		IsRunning:      runningCount > 0,
+		IsPaused:       isPaused,
 	}, nil
 }
 
 func (m *mockBusinessAPI) ParseTimeRange(ctx context.Context, timeStr string) (*api.TimeRange, error) {
-	// Simple implementation for testing
 	if timeStr == "" {
 		return nil, nil
 	}
-	
-	now := time.Now()
-	var start time.Time
-	
-	switch timeStr {
-	case "1h":
-		start = now.Add(-1 * time.Hour)
-	case "1d":
-		start = now.Add(-24 * time.Hour)
-	case "1w":
-		start = now.Add(-7 * 24 * time.Hour)
-	default:
-		return nil, fmt.Errorf("unsupported time range: %s", timeStr)
-	}
-	
-	return &api.TimeRange{
-		Start: start,
-		End:   now,
-	}, nil
+	return services.ParseTimeRangeAt(timeStr, time.Now())
 }
 
 func (m *mockBusinessAPI) SearchTasks(ctx context.Context, timeRange string, textFilter string, sortOrder api.SortOrder) ([]*api.TaskActivity, error) {
+	return m.SearchTasksWithFilter(ctx, api.TimeEntryFilter{TimeRange: timeRange, Text: textFilter}, sortOrder)
+}
+
+func (m *mockBusinessAPI) SearchTasksWithFilter(ctx context.Context, filter api.TimeEntryFilter, sortOrder api.SortOrder) ([]*api.TaskActivity, error) {
 	var result []*api.TaskActivity
 	
-	// Get time range if specified
-	var timeRangeObj *api.TimeRange
-	if timeRange != "" {
-		tr, err := m.ParseTimeRange(ctx, timeRange)
-		if err != nil {
-			return nil, err
-		}
-		timeRangeObj = tr
+	matching, err := m.SearchTimeEntriesWithFilter(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
 	
 	for _, task := range m.tasks {
-		// Apply text filter
-		if textFilter != "" && !strings.Contains(strings.ToLower(task.TaskName), strings.ToLower(textFilter)) {
+		if task.ArchivedAt != nil && !filter.IncludeArchived {
 			continue
 		}
 		
 		// Find entries for this task
 		var taskEntries []*domain.TimeEntry
-		for _, entry := range m.timeEntries {
-			if entry.TaskID == task.ID {
-				// Apply time filter
-				if timeRangeObj != nil {
-					if entry.StartTime.Before(timeRangeObj.Start) || entry.StartTime.After(timeRangeObj.End) {
-						continue
-					}
-				}
-				taskEntries = append(taskEntries, entry)
+		for _, entry := range matching {
+			if entry.Task.ID == task.ID {
+				taskEntries = append(taskEntries, entry.TimeEntry)
 			}
 		}
 		
@@ -309,18 +863,32 @@ func (m *mockBusinessAPI) SearchTasks(ctx context.Context, timeRange string, tex
 		sort.Slice(result, func(i, j int) bool {
 			return result[i].Task.TaskName < result[j].Task.TaskName
 		})
+	} else {
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].LastWorked.After(result[j].LastWorked)
+		})
 	}
 	
 	return result, nil
 }
 
 func (m *mockBusinessAPI) SearchTimeEntries(ctx context.Context, timeRange string, textFilter string) ([]*api.TimeEntryWithTask, error) {
+	return m.SearchTimeEntriesWithFilter(ctx, api.TimeEntryFilter{TimeRange: timeRange, Text: textFilter})
+}
+
+func (m *mockBusinessAPI) SearchTimeEntriesWithFilter(ctx context.Context, filter api.TimeEntryFilter) ([]*api.TimeEntryWithTask, error) {
 	var result []*api.TimeEntryWithTask
+	textFilter := filter.Text
+	
+	m.filters = append(m.filters, filter)
+	if err := m.failures["SearchTimeEntriesWithFilter"]; err != nil {
+		return nil, err
+	}
 	
 	// Get time range if specified
 	var timeRangeObj *api.TimeRange
-	if timeRange != "" {
-		tr, err := m.ParseTimeRange(ctx, timeRange)
+	if filter.TimeRange != "" {
+		tr, err := m.ParseTimeRange(ctx, filter.TimeRange)
 		if err != nil {
 			return nil, err
 		}
@@ -330,8 +898,9 @@ func (m *mockBusinessAPI) SearchTimeEntries(ctx context.Context, timeRange strin
 	for _, entry := range m.timeEntries {
 		task := m.tasks[entry.TaskID]
 		
-		// Apply text filter
-		if textFilter != "" && !strings.Contains(strings.ToLower(task.TaskName), strings.ToLower(textFilter)) {
+		// Apply text filter to the task name and the entry's note
+		if textFilter != "" && !strings.Contains(strings.ToLower(task.TaskName), strings.ToLower(textFilter)) &&
+			!strings.Contains(strings.ToLower(entry.Note), strings.ToLower(textFilter)) {
 			continue
 		}
 		
@@ -342,6 +911,11 @@ func (m *mockBusinessAPI) SearchTimeEntries(ctx context.Context, timeRange strin
 			}
 		}
 		
+		var project string
+		if task.ProjectID != nil {
+			project = m.projects[*task.ProjectID].Path
+		}
+		
 		// Calculate duration
 		var duration string
 		if entry.EndTime != nil {
@@ -360,6 +934,7 @@ func (m *mockBusinessAPI) SearchTimeEntries(ctx context.Context, timeRange strin
 			TimeEntry: entry,
 			Task:      task,
 			Duration:  duration,
+			Project:   project,
 		})
 	}
 	
@@ -376,6 +951,34 @@ func (m *mockBusinessAPI) GetTodayStatistics(ctx context.Context) (*api.DayStati
 	return &api.DayStatistics{}, nil
 }
 
+func (m *mockBusinessAPI) GetProjectTotals(ctx context.Context, filter api.TimeEntryFilter) ([]*api.ProjectTotal, error) {
+	entries, err := m.SearchTimeEntriesWithFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	reporting := services.NewReportingService(nil, services.NewTimeService(nil), nil, nil)
+	return reporting.RollupByProject(entries), nil
+}
+
+func (m *mockBusinessAPI) GetReport(ctx context.Context, opts api.ReportOptions) (*api.Report, error) {
+	entries, err := m.SearchTimeEntriesWithFilter(ctx, api.TimeEntryFilter{})
+	if err != nil {
+		return nil, err
+	}
+	// Building the report needs no repository, only duration formatting
+	reporting := services.NewReportingService(nil, services.NewTimeService(nil), nil, nil)
+	return reporting.BuildReport(entries, opts, time.Now())
+}
+
+func (m *mockBusinessAPI) GetTimeline(ctx context.Context, date time.Time) (*api.Timeline, error) {
+	entries, err := m.SearchTimeEntriesWithFilter(ctx, api.TimeEntryFilter{})
+	if err != nil {
+		return nil, err
+	}
+	reporting := services.NewReportingService(nil, services.NewTimeService(nil), nil, nil)
+	return reporting.BuildTimeline(entries, date, time.Now()), nil
+}
+
 // setupTestAppWithMockBusinessAPI creates a test app with mock BusinessAPI
 func setupTestAppWithMockBusinessAPI(t *testing.T) (*App, func()) {
 	mockAPI := newMockBusinessAPI()
@@ -386,4 +989,9 @@ func setupTestAppWithMockBusinessAPI(t *testing.T) (*App, func()) {
 	}
 	
 	return app, cleanup
+}
+
+// timePtr returns a pointer to t, for optional times in test fixtures
+func timePtr(t time.Time) *time.Time {
+	return &t
 }
\ No newline at end of file
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// NoteCommand handles the note command, which annotates a time entry
+type NoteCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+}
+
+// NewNoteCommand creates a new note command handler
+func NewNoteCommand(app *App) *NoteCommand {
+	return &NoteCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+	}
+}
+
+// Execute runs the note command. A leading entry ID followed by text annotates that entry;
+// otherwise all arguments form the note for the running entry.
+func (c *NoteCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.NewInvalidInputError("command", "note", "usage: tt note \"text\" or tt note <entry-id> \"text\"")
+	}
+
+	if len(args) > 1 {
+		if entryID, err := strconv.ParseInt(args[0], 10, 64); err == nil && entryID > 0 {
+			return c.setNote(ctx, entryID, strings.Join(args[1:], " "))
+		}
+	}
+
+	session, err := c.businessAPI.GetCurrentSession(ctx)
+	if err != nil {
+		if errors.IsErrorType(err, errors.ErrorTypeNotFound) {
+			return errors.NewValidationError("no task is running; use tt note <entry-id> \"text\" to annotate an earlier entry", err)
+		}
+		return c.errorHandler.Handle("get current session", err)
+	}
+	return c.setNote(ctx, session.TimeEntry.ID, strings.Join(args, " "))
+}
+
+// setNote replaces the note of the given entry; an empty text removes it
+func (c *NoteCommand) setNote(ctx context.Context, entryID int64, text string) error {
+	edit, err := c.businessAPI.EditTimeEntry(ctx, entryID, api.TimeEntryUpdate{Note: &text})
+	if err != nil {
+		return c.errorHandler.Handle("set note", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newEntryRecord(edit.After.TimeEntry, edit.After.Task))
+	}
+
+	if edit.After.TimeEntry.Note == "" {
+		fmt.Printf("Removed note from entry %d (%s)\n", entryID, edit.After.Task.TaskName)
+		return nil
+	}
+	fmt.Printf("Added note to entry %d (%s): %s\n", entryID, edit.After.Task.TaskName, edit.After.TimeEntry.Note)
+	return nil
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	entryStart := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+
+	setup := func(t *testing.T) (*App, int64, int64) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+
+		earlier, err := app.businessAPI.AddTimeEntry(ctx, "Code review", entryStart, entryStart.Add(time.Hour))
+		require.NoError(t, err)
+		running, err := app.businessAPI.StartNewTask(ctx, "Support")
+		require.NoError(t, err)
+		return app, earlier.TimeEntry.ID, running.TimeEntry.ID
+	}
+
+	noteOf := func(t *testing.T, app *App, entryID int64) string {
+		entry, err := app.businessAPI.GetTimeEntry(ctx, entryID)
+		require.NoError(t, err)
+		return entry.TimeEntry.Note
+	}
+
+	t.Run("annotates the running entry", func(t *testing.T) {
+		app, earlierID, runningID := setup(t)
+
+		require.NoError(t, NewNoteCommand(app).Execute(ctx, []string{"customer", "called about invoices"}))
+		assert.Equal(t, "customer called about invoices", noteOf(t, app, runningID))
+		assert.Empty(t, noteOf(t, app, earlierID))
+	})
+
+	t.Run("annotates an entry by ID", func(t *testing.T) {
+		app, earlierID, runningID := setup(t)
+
+		require.NoError(t, NewNoteCommand(app).Execute(ctx, []string{"1", "reviewing PR 412"}))
+		assert.Equal(t, "reviewing PR 412", noteOf(t, app, earlierID))
+		assert.Empty(t, noteOf(t, app, runningID))
+	})
+
+	t.Run("a lone number is a note for the running entry", func(t *testing.T) {
+		app, _, runningID := setup(t)
+
+		require.NoError(t, NewNoteCommand(app).Execute(ctx, []string{"412"}))
+		assert.Equal(t, "412", noteOf(t, app, runningID))
+	})
+
+	t.Run("an empty note removes the existing one", func(t *testing.T) {
+		app, earlierID, _ := setup(t)
+
+		require.NoError(t, NewNoteCommand(app).Execute(ctx, []string{"1", "reviewing PR 412"}))
+		require.NoError(t, NewNoteCommand(app).Execute(ctx, []string{"1", ""}))
+		assert.Empty(t, noteOf(t, app, earlierID))
+	})
+
+	t.Run("requires a running entry without an ID", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		err := NewNoteCommand(app).Execute(ctx, []string{"reviewing PR 412"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no task is running")
+	})
+
+	t.Run("requires text", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		err := NewNoteCommand(app).Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "usage: tt note")
+	})
+}
+
+func TestStartCommand_Note(t *testing.T) {
+	ctx := context.Background()
+	app, cleanup := setupTestAppWithMockBusinessAPI(t)
+	defer cleanup()
+
+	cmd := NewStartCommandWithOptions(app, StartOptions{Note: "reviewing PR 412"})
+	require.NoError(t, cmd.Execute(ctx, []string{"Code review", "+billable"}))
+
+	entries, err := app.businessAPI.SearchTimeEntries(ctx, "", "pr 412")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Code review", entries[0].Task.TaskName)
+	assert.Equal(t, "reviewing PR 412", entries[0].TimeEntry.Note)
+}
@@ -2,24 +2,45 @@ package cli
 
 import (
 	"context"
-	"encoding/csv"
 	"fmt"
 	"os"
-	"strconv"
+	"sort"
 	"strings"
-	"time"
 	"time-tracker/internal/api"
 	"time-tracker/internal/errors"
 )
 
+// OutputOptions holds the flags accepted by the output command
+type OutputOptions struct {
+	Range       string   // Time range expression limiting the exported entries (e.g. "last-month")
+	Filter      string   // Text filter on task names and notes
+	Project     string   // Only export entries of this project and its sub-projects
+	Tags        []string // Only export entries carrying every one of these tags
+	ExcludeTags []string // Leave out entries carrying any of these tags
+	Out         string   // File to write to instead of stdout
+}
+
 // OutputCommand handles the output command
 type OutputCommand struct {
-	businessAPI api.BusinessAPI
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	options      OutputOptions
 }
 
 // NewOutputCommand creates a new output command handler
 func NewOutputCommand(app *App) *OutputCommand {
-	return &OutputCommand{businessAPI: app.businessAPI}
+	return NewOutputCommandWithOptions(app, OutputOptions{})
+}
+
+// NewOutputCommandWithOptions creates a new output command handler with the given flag values
+func NewOutputCommandWithOptions(app *App, options OutputOptions) *OutputCommand {
+	return &OutputCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		options:      options,
+	}
 }
 
 // Execute runs the output command
@@ -30,7 +51,7 @@ func (c *OutputCommand) Execute(ctx context.Context, args []string) error {
 // outputTasks outputs tasks in the specified format
 func (c *OutputCommand) outputTasks(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.NewInvalidInputError("command", "output", "usage: tt output format=csv")
+		return errors.NewInvalidInputError("command", "output", "usage: tt output format=csv|json|ndjson|ics|md|timesheet [--range range] [--filter text] [--project path] [--tag tag] [--exclude-tag tag] [--out file]")
 	}
 
 	// Parse format option
@@ -40,59 +61,54 @@ func (c *OutputCommand) outputTasks(ctx context.Context, args []string) error {
 	}
 
 	format = strings.TrimPrefix(format, "format=")
-	switch format {
-	case "csv":
-		return c.outputCSV(ctx)
-	default:
-		return errors.NewInvalidInputError("format", format, "unsupported format")
+	write, exists := exportFormats[format]
+	if !exists {
+		return errors.NewInvalidInputError("format", format, "unsupported format, expected one of "+strings.Join(supportedExportFormats(), ", "))
 	}
+
+	return c.export(ctx, write)
 }
 
-// outputCSV outputs all tasks in CSV format
-func (c *OutputCommand) outputCSV(ctx context.Context) error {
-	// Get all time entries with task information using BusinessAPI
-	entries, err := c.businessAPI.SearchTimeEntries(ctx, "", "")
+// export fetches the selected time entries and writes them to stdout or the --out file
+func (c *OutputCommand) export(ctx context.Context, write entryWriter) error {
+	// Get time entries with task information using BusinessAPI
+	entries, err := c.businessAPI.SearchTimeEntriesWithFilter(ctx, api.TimeEntryFilter{
+		TimeRange:   c.options.Range,
+		Text:        c.options.Filter,
+		Project:     c.options.Project,
+		Tags:        c.options.Tags,
+		ExcludeTags: c.options.ExcludeTags,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get time entries: %w", err)
+		return c.errorHandler.Handle("get time entries", err)
 	}
 
-	// Create CSV writer
-	writer := csv.NewWriter(os.Stdout)
-	defer writer.Flush()
-
-	// Write header
-	header := []string{"ID", "Start Time", "End Time", "Duration (hours)", "Task Name"}
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("failed to write CSV header: %w", err)
+	if c.options.Out == "" {
+		return write(os.Stdout, entries)
 	}
 
-	// Write entries
-	for _, entryWithTask := range entries {
-		entry := entryWithTask.TimeEntry
-		
-		// Format start time
-		startTime := entry.StartTime.Format(time.RFC3339)
-
-		// Format end time
-		var endTime string
-		var duration float64
-		if entry.EndTime != nil {
-			endTime = entry.EndTime.Format(time.RFC3339)
-			duration = entry.EndTime.Sub(entry.StartTime).Hours()
-		}
-
-		// Write row
-		row := []string{
-			strconv.FormatInt(entry.ID, 10),
-			startTime,
-			endTime,
-			fmt.Sprintf("%.2f", duration),
-			entryWithTask.Task.TaskName,
-		}
-		if err := writer.Write(row); err != nil {
-			return fmt.Errorf("failed to write CSV row: %w", err)
-		}
+	file, err := os.Create(c.options.Out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", c.options.Out, err)
+	}
+	if err := write(file, entries); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.options.Out, err)
 	}
 
+	c.printer.Infof("Exported %d time entries to %s\n", len(entries), c.options.Out)
 	return nil
-}
\ No newline at end of file
+}
+
+// supportedExportFormats returns the sorted names of the formats accepted by format=
+func supportedExportFormats() []string {
+	formats := make([]string, 0, len(exportFormats))
+	for format := range exportFormats {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
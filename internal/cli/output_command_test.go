@@ -2,7 +2,12 @@ package cli
 
 import (
 	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -81,67 +86,72 @@ func TestOutputCommand_OutputTasks(t *testing.T) {
 	})
 
 	t.Run("rejects unsupported format", func(t *testing.T) {
-		err := cmd.outputTasks(ctx, []string{"format=json"})
+		err := cmd.outputTasks(ctx, []string{"format=xml"})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unsupported format")
 	})
 }
 
 func TestOutputCommand_OutputCSV(t *testing.T) {
-	app, cleanup := setupTestAppWithMockBusinessAPI(t)
-	defer cleanup()
-
-	cmd := NewOutputCommand(app)
 	ctx := context.Background()
 
-	t.Run("outputs CSV with running task", func(t *testing.T) {
-		// Create a running task
-		_, err := app.businessAPI.StartNewTask(ctx, "Running CSV Task")
-		require.NoError(t, err)
-
-		err = cmd.outputCSV(ctx)
-		assert.NoError(t, err)
-	})
+	// export runs tt output format=csv --out and returns the rows of the file, header first
+	export := func(t *testing.T, app *App) [][]string {
+		outPath := filepath.Join(t.TempDir(), "export.csv")
+		require.NoError(t, NewOutputCommandWithOptions(app, OutputOptions{Out: outPath}).Execute(ctx, []string{"format=csv"}))
 
-	t.Run("outputs CSV with stopped task", func(t *testing.T) {
-		// Create and stop a task
-		_, err := app.businessAPI.StartNewTask(ctx, "Stopped CSV Task")
+		file, err := os.Open(outPath)
 		require.NoError(t, err)
-		_, err = app.businessAPI.StopAllRunningTasks(ctx)
+		defer file.Close()
+		rows, err := csv.NewReader(file).ReadAll()
 		require.NoError(t, err)
-
-		err = cmd.outputCSV(ctx)
-		assert.NoError(t, err)
-	})
-
-	t.Run("outputs CSV with mixed tasks", func(t *testing.T) {
-		// Create multiple tasks with different states
-		_, err := app.businessAPI.StartNewTask(ctx, "Task 1")
+		require.NotEmpty(t, rows)
+		assert.Equal(t, []string{"ID", "Start Time", "End Time", "Duration (hours)", "Task Name", "Project", "Tags", "Note"}, rows[0])
+		return rows
+	}
+
+	t.Run("outputs CSV with running and stopped tasks", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+		_, err := app.businessAPI.StartNewTask(ctx, "Stopped CSV Task")
 		require.NoError(t, err)
 		_, err = app.businessAPI.StopAllRunningTasks(ctx)
 		require.NoError(t, err)
-
-		_, err = app.businessAPI.StartNewTask(ctx, "Task 2")
+		_, err = app.businessAPI.StartNewTask(ctx, "Running CSV Task")
 		require.NoError(t, err)
 
-		err = cmd.outputCSV(ctx)
-		assert.NoError(t, err)
+		rows := export(t, app)
+		require.Len(t, rows, 3)
+		for _, row := range rows[1:] {
+			switch row[4] {
+			case "Running CSV Task":
+				assert.Empty(t, row[2], "A running entry has no end time")
+			case "Stopped CSV Task":
+				assert.NotEmpty(t, row[2])
+			default:
+				t.Errorf("unexpected task %q", row[4])
+			}
+		}
 	})
 
 	t.Run("outputs CSV with special characters in task names", func(t *testing.T) {
-		// Create task with special characters
-		_, err := app.businessAPI.StartNewTask(ctx, "Task with, comma and \"quotes\"")
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+		_, err := app.businessAPI.StartNewTask(ctx, `Task with, comma and "quotes"`)
 		require.NoError(t, err)
 
-		err = cmd.outputCSV(ctx)
-		assert.NoError(t, err)
+		outPath := filepath.Join(t.TempDir(), "export.csv")
+		require.NoError(t, NewOutputCommandWithOptions(app, OutputOptions{Out: outPath}).Execute(ctx, []string{"format=csv"}))
+		content, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"Task with, comma and ""quotes"""`)
 	})
 
-	t.Run("handles empty data", func(t *testing.T) {
-		// Stop all tasks and clear data would be ideal, but our mock doesn't support clearing
-		// Test with no additional tasks
-		err := cmd.outputCSV(ctx)
-		assert.NoError(t, err)
+	t.Run("outputs only the header without data", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		assert.Len(t, export(t, app), 1)
 	})
 }
 
@@ -153,4 +163,64 @@ func TestNewOutputCommand(t *testing.T) {
 	
 	assert.NotNil(t, cmd)
 	assert.NotNil(t, cmd.businessAPI)
-}
\ No newline at end of file
+}
+
+func TestOutputCommand_Options(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(-3 * time.Hour)
+
+	setup := func(t *testing.T) *App {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+
+		_, err := app.businessAPI.AddTimeEntry(ctx, "Acme website", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		_, err = app.businessAPI.AddTimeEntry(ctx, "Internal", start.Add(time.Hour), start.Add(2*time.Hour))
+		require.NoError(t, err)
+		return app
+	}
+
+	t.Run("writes to the --out file with range and filter applied", func(t *testing.T) {
+		app := setup(t)
+		outPath := filepath.Join(t.TempDir(), "export.csv")
+
+		cmd := NewOutputCommandWithOptions(app, OutputOptions{Range: "1d", Filter: "acme", Out: outPath})
+		require.NoError(t, cmd.Execute(ctx, []string{"format=csv"}))
+
+		content, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Acme website")
+		assert.NotContains(t, string(content), "Internal")
+	})
+
+	t.Run("accepts every supported format", func(t *testing.T) {
+		app := setup(t)
+		for _, format := range supportedExportFormats() {
+			outPath := filepath.Join(t.TempDir(), "export."+format)
+			cmd := NewOutputCommandWithOptions(app, OutputOptions{Out: outPath})
+			require.NoError(t, cmd.Execute(ctx, []string{"format=" + format}), format)
+
+			content, err := os.ReadFile(outPath)
+			require.NoError(t, err)
+			assert.True(t, strings.Contains(string(content), "Acme website"), format)
+		}
+	})
+
+	t.Run("rejects invalid range", func(t *testing.T) {
+		app := setup(t)
+
+		cmd := NewOutputCommandWithOptions(app, OutputOptions{Range: "fortnight"})
+		err := cmd.Execute(ctx, []string{"format=csv"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get time entries")
+	})
+
+	t.Run("reports unwritable output path", func(t *testing.T) {
+		app := setup(t)
+
+		cmd := NewOutputCommandWithOptions(app, OutputOptions{Out: filepath.Join(t.TempDir(), "missing", "export.csv")})
+		err := cmd.Execute(ctx, []string{"format=csv"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create")
+	})
+}
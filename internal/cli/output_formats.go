@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
+)
+
+// entryWriter writes time entries to w in a particular export format
+type entryWriter func(w io.Writer, entries []*api.TimeEntryWithTask) error
+
+// exportFormats maps the format= values accepted by tt output to their writers
+var exportFormats = map[string]entryWriter{
+	"csv":       writeEntriesCSV,
+	"json":      writeEntriesJSON,
+	"ndjson":    writeEntriesNDJSON,
+	"ics":       writeEntriesICS,
+	"md":        writeEntriesMarkdown,
+	"timesheet": writeEntriesTimesheet,
+}
+
+// writeEntriesCSV writes one row per time entry
+func writeEntriesCSV(w io.Writer, entries []*api.TimeEntryWithTask) error {
+	writer := csv.NewWriter(w)
+
+	// Write header
+	header := []string{"ID", "Start Time", "End Time", "Duration (hours)", "Task Name", "Project", "Tags", "Note"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	// Write entries
+	for _, entryWithTask := range entries {
+		entry := entryWithTask.TimeEntry
+
+		// Format start time
+		startTime := entry.StartTime.Format(time.RFC3339)
+
+		// Format end time
+		var endTime string
+		var duration float64
+		if entry.EndTime != nil {
+			endTime = entry.EndTime.Format(time.RFC3339)
+			duration = entry.EndTime.Sub(entry.StartTime).Hours()
+		}
+
+		// Write row
+		row := []string{
+			strconv.FormatInt(entry.ID, 10),
+			startTime,
+			endTime,
+			fmt.Sprintf("%.2f", duration),
+			entryWithTask.Task.TaskName,
+			entryWithTask.Project,
+			strings.Join(entry.Tags, " "),
+			entry.Note,
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeEntriesJSON writes all time entries as a single JSON array
+func writeEntriesJSON(w io.Writer, entries []*api.TimeEntryWithTask) error {
+	return newPrinterWithWriters(FormatJSON, w, io.Discard).Emit(newEntryRecords(entries))
+}
+
+// writeEntriesNDJSON writes one JSON object per time entry
+func writeEntriesNDJSON(w io.Writer, entries []*api.TimeEntryWithTask) error {
+	return newPrinterWithWriters(FormatNDJSON, w, io.Discard).Emit(newEntryRecords(entries))
+}
+
+// writeEntriesICS writes an iCalendar document with one VEVENT per time entry.
+// Running entries end at the time of export.
+func writeEntriesICS(w io.Writer, entries []*api.TimeEntryWithTask) error {
+	const icsTimeFormat = "20060102T150405Z"
+	now := timeNow().UTC()
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//time-tracker//tt//EN",
+		"CALSCALE:GREGORIAN",
+	}
+	for _, entryWithTask := range entries {
+		entry := entryWithTask.TimeEntry
+		end := now
+		if entry.EndTime != nil {
+			end = entry.EndTime.UTC()
+		}
+
+		description := "Duration: " + formatDurationHuman(end.Sub(entry.StartTime))
+		if entry.EndTime == nil {
+			description += " (running)"
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:tt-entry-%d@time-tracker", entry.ID),
+			"DTSTAMP:"+now.Format(icsTimeFormat),
+			"DTSTART:"+entry.StartTime.UTC().Format(icsTimeFormat),
+			"DTEND:"+end.Format(icsTimeFormat),
+			"SUMMARY:"+escapeICSText(entryWithTask.Task.TaskName),
+			"DESCRIPTION:"+escapeICSText(description),
+		)
+		if len(entry.Tags) > 0 {
+			categories := make([]string, len(entry.Tags))
+			for i, tag := range entry.Tags {
+				categories[i] = escapeICSText(tag)
+			}
+			lines = append(lines, "CATEGORIES:"+strings.Join(categories, ","))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldICSLine(line)+"\r\n"); err != nil {
+			return fmt.Errorf("failed to write iCalendar: %w", err)
+		}
+	}
+	return nil
+}
+
+// escapeICSText escapes characters with special meaning in iCalendar text values
+func escapeICSText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// foldICSLine splits lines longer than 75 octets as required by RFC 5545,
+// without breaking multi-byte characters
+func foldICSLine(line string) string {
+	const maxOctets = 75
+	if len(line) <= maxOctets {
+		return line
+	}
+
+	var folded strings.Builder
+	lineLength := 0
+	for _, r := range line {
+		runeLength := len(string(r))
+		if lineLength+runeLength > maxOctets {
+			folded.WriteString("\r\n ")
+			lineLength = 1
+		}
+		folded.WriteRune(r)
+		lineLength += runeLength
+	}
+	return folded.String()
+}
+
+// writeEntriesMarkdown writes a Markdown table of time entries with a total row
+func writeEntriesMarkdown(w io.Writer, entries []*api.TimeEntryWithTask) error {
+	var b strings.Builder
+	b.WriteString("| ID | Start | End | Duration | Task | Tags |\n")
+	b.WriteString("|---:|-------|-----|---------:|------|------|\n")
+
+	var total time.Duration
+	for _, entryWithTask := range entries {
+		entry := entryWithTask.TimeEntry
+		duration := entryDuration(entry)
+		total += duration
+
+		end := "running"
+		if entry.EndTime != nil {
+			end = entry.EndTime.Format("2006-01-02 15:04")
+		}
+
+		fmt.Fprintf(&b, "| %d | %s | %s | %s | %s | %s |\n",
+			entry.ID,
+			entry.StartTime.Format("2006-01-02 15:04"),
+			end,
+			formatDurationHuman(duration),
+			escapeMarkdownCell(entryWithTask.Task.TaskName),
+			formatTags(entry.Tags))
+	}
+	fmt.Fprintf(&b, "| | | **Total** | **%s** | | |\n", formatDurationHuman(total))
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write Markdown: %w", err)
+	}
+	return nil
+}
+
+// escapeMarkdownCell keeps task names from breaking the table layout
+func escapeMarkdownCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+// writeEntriesTimesheet writes a CSV with one row per task and one column per day, in hours,
+// listing the tags used on each task's entries after its name.
+// Entries spanning midnight are split across the days they cover; running entries count up to now.
+func writeEntriesTimesheet(w io.Writer, entries []*api.TimeEntryWithTask) error {
+	hoursByTask := make(map[string]map[string]time.Duration)
+	tagsByTask := make(map[string][]string)
+	var firstDay, lastDay time.Time
+
+	for _, entryWithTask := range entries {
+		entry := entryWithTask.TimeEntry
+		start := entry.StartTime.Local()
+		end := start.Add(entryDuration(entry))
+
+		taskName := entryWithTask.Task.TaskName
+		if hoursByTask[taskName] == nil {
+			hoursByTask[taskName] = make(map[string]time.Duration)
+		}
+		tagsByTask[taskName] = append(tagsByTask[taskName], entry.Tags...)
+
+		for dayStart := startOfLocalDay(start); dayStart.Before(end); dayStart = dayStart.AddDate(0, 0, 1) {
+			dayEnd := dayStart.AddDate(0, 0, 1)
+			segmentStart, segmentEnd := maxTime(start, dayStart), minTime(end, dayEnd)
+			hoursByTask[taskName][dayStart.Format("2006-01-02")] += segmentEnd.Sub(segmentStart)
+
+			if firstDay.IsZero() || dayStart.Before(firstDay) {
+				firstDay = dayStart
+			}
+			if dayStart.After(lastDay) {
+				lastDay = dayStart
+			}
+		}
+	}
+
+	// Every day in the period gets a column, including days without entries
+	var days []string
+	if !firstDay.IsZero() {
+		for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+			days = append(days, day.Format("2006-01-02"))
+		}
+	}
+
+	taskNames := make([]string, 0, len(hoursByTask))
+	for taskName := range hoursByTask {
+		taskNames = append(taskNames, taskName)
+	}
+	sort.Strings(taskNames)
+
+	formatHours := func(d time.Duration) string {
+		return fmt.Sprintf("%.2f", d.Hours())
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append(append([]string{"Task", "Tags"}, days...), "Total")); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	dayTotals := make(map[string]time.Duration)
+	var grandTotal time.Duration
+	for _, taskName := range taskNames {
+		row := []string{taskName, strings.Join(domain.UniqueTags(tagsByTask[taskName]), " ")}
+		var taskTotal time.Duration
+		for _, day := range days {
+			duration := hoursByTask[taskName][day]
+			row = append(row, formatHours(duration))
+			taskTotal += duration
+			dayTotals[day] += duration
+		}
+		grandTotal += taskTotal
+		if err := writer.Write(append(row, formatHours(taskTotal))); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	totalRow := []string{"Total", ""}
+	for _, day := range days {
+		totalRow = append(totalRow, formatHours(dayTotals[day]))
+	}
+	if err := writer.Write(append(totalRow, formatHours(grandTotal))); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// startOfLocalDay returns midnight at the start of t's day
+func startOfLocalDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// minTime returns the earlier of two times
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// maxTime returns the later of two times
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportTestEntries returns two completed, tagged entries on one task, the second with a note,
+// and a third spanning midnight on another
+func exportTestEntries() []*api.TimeEntryWithTask {
+	review := &domain.Task{ID: 1, TaskName: "Review, docs; notes"}
+	support := &domain.Task{ID: 2, TaskName: "Support | on-call"}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.Local)
+	}
+	entry := func(id int64, task *domain.Task, start, end time.Time, tags ...string) *api.TimeEntryWithTask {
+		return &api.TimeEntryWithTask{
+			TimeEntry: &domain.TimeEntry{ID: id, TaskID: task.ID, StartTime: start, EndTime: &end, Tags: tags},
+			Task:      task,
+		}
+	}
+
+	entries := []*api.TimeEntryWithTask{
+		entry(1, review, at(14, 9, 0), at(14, 10, 30), "billable", "meeting"),
+		entry(2, review, at(15, 9, 0), at(15, 9, 45), "billable"),
+		entry(3, support, at(15, 23, 0), at(16, 1, 0)),
+	}
+	entries[1].TimeEntry.Note = `reviewing PR 412, "part 2"`
+	return entries
+}
+
+func TestWriteEntriesJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeEntriesJSON(&out, exportTestEntries()))
+
+	var records []entryRecord
+	require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+	require.Len(t, records, 3)
+	assert.Equal(t, int64(5400), records[0].DurationSeconds)
+	assert.Equal(t, "1h 30m", records[0].Duration)
+	assert.Equal(t, []string{"billable", "meeting"}, records[0].Tags)
+	assert.Empty(t, records[2].Tags)
+	assert.Equal(t, `reviewing PR 412, "part 2"`, records[1].Note)
+	assert.Empty(t, records[0].Note)
+}
+
+func TestWriteEntriesICS(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeEntriesICS(&out, exportTestEntries()))
+	ics := out.String()
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 3, strings.Count(ics, "BEGIN:VEVENT"))
+	assert.Contains(t, ics, "UID:tt-entry-1@time-tracker")
+	assert.Contains(t, ics, "DTSTART:"+time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local).UTC().Format("20060102T150405Z"))
+	assert.Contains(t, ics, `SUMMARY:Review\, docs\; notes`)
+	assert.Contains(t, ics, "DESCRIPTION:Duration: 1h 30m")
+	assert.Contains(t, ics, "CATEGORIES:billable,meeting\r\n")
+	assert.Equal(t, 2, strings.Count(ics, "CATEGORIES:"))
+}
+
+func TestFoldICSLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	folded := foldICSLine(line)
+
+	for _, part := range strings.Split(folded, "\r\n") {
+		assert.LessOrEqual(t, len(part), 75)
+	}
+	assert.Equal(t, line, strings.ReplaceAll(folded, "\r\n ", ""))
+	assert.Equal(t, "SHORT:line", foldICSLine("SHORT:line"))
+}
+
+func TestWriteEntriesMarkdown(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeEntriesMarkdown(&out, exportTestEntries()))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+
+	require.Len(t, lines, 6)
+	assert.Equal(t, "| ID | Start | End | Duration | Task | Tags |", lines[0])
+	assert.Equal(t, "| 1 | 2026-10-14 09:00 | 2026-10-14 10:30 | 1h 30m | Review, docs; notes | #billable #meeting |", lines[2])
+	assert.Contains(t, lines[4], `Support \| on-call`)
+	assert.Equal(t, "| | | **Total** | **4h 15m** | | |", lines[5])
+}
+
+func TestWriteEntriesTimesheet(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeEntriesTimesheet(&out, exportTestEntries()))
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+
+	expected := [][]string{
+		{"Task", "Tags", "2026-10-14", "2026-10-15", "2026-10-16", "Total"},
+		{"Review, docs; notes", "billable meeting", "1.50", "0.75", "0.00", "2.25"},
+		{"Support | on-call", "", "0.00", "1.00", "1.00", "2.00"},
+		{"Total", "", "1.50", "1.75", "1.00", "4.25"},
+	}
+	assert.Equal(t, expected, rows)
+}
+
+func TestWriteEntriesTimesheet_Empty(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeEntriesTimesheet(&out, nil))
+	assert.Equal(t, "Task,Tags,Total\nTotal,,0.00\n", out.String())
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// PauseCommand handles the pause command, which ends the running entry so it can be continued later
+type PauseCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+}
+
+// NewPauseCommand creates a new pause command handler
+func NewPauseCommand(app *App) *PauseCommand {
+	return &PauseCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+	}
+}
+
+// Execute runs the pause command
+func (c *PauseCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return errors.NewInvalidInputError("command", "pause", "usage: tt pause")
+	}
+
+	session, err := c.businessAPI.PauseTask(ctx)
+	if err != nil {
+		return c.errorHandler.Handle("pause task", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newEntryRecord(session.TimeEntry, session.Task))
+	}
+
+	fmt.Printf("Paused: %s (after %s)\n", session.Task.TaskName, formatDurationHuman(entryDuration(session.TimeEntry)))
+	fmt.Println("Run tt continue to pick it up again")
+	return nil
+}
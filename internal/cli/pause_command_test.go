@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pauses the running task", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		started, err := app.businessAPI.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		cmd := NewPauseCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		var record entryRecord
+		require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+		assert.Equal(t, started.TimeEntry.ID, record.ID)
+		assert.Equal(t, "Write report", record.TaskName)
+		assert.True(t, record.Paused)
+		assert.False(t, record.Running)
+
+		_, err = app.businessAPI.GetCurrentSession(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("requires a running task", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		err := NewPauseCommand(app).Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no task is running")
+	})
+
+	t.Run("rejects arguments", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		err := NewPauseCommand(app).Execute(ctx, []string{"Write report"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "usage: tt pause")
+	})
+}
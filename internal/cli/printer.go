@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/errors"
+)
+
+// OutputFormat identifies how command results are rendered
+type OutputFormat string
+
+const (
+	FormatTable  OutputFormat = "table"  // Human-readable text (default)
+	FormatJSON   OutputFormat = "json"   // A single indented JSON document
+	FormatNDJSON OutputFormat = "ndjson" // One compact JSON object per line
+)
+
+// ParseOutputFormat validates a format name given on the command line or in configuration
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch OutputFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FormatTable:
+		return FormatTable, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatNDJSON:
+		return FormatNDJSON, nil
+	default:
+		return "", errors.NewInvalidInputError("format", value, "expected table, json or ndjson")
+	}
+}
+
+// Printer writes command results either as text or as JSON.
+// In structured formats, informational messages and prompts go to stderr so stdout stays machine-readable.
+type Printer struct {
+	format OutputFormat
+	out    io.Writer
+	info   io.Writer
+}
+
+// NewPrinter creates a printer writing to stdout in the given format
+func NewPrinter(format OutputFormat) *Printer {
+	return newPrinterWithWriters(format, os.Stdout, os.Stderr)
+}
+
+// newPrinterWithWriters creates a printer with explicit writers, used by tests
+func newPrinterWithWriters(format OutputFormat, out io.Writer, errOut io.Writer) *Printer {
+	info := out
+	if format != FormatTable {
+		info = errOut
+	}
+	return &Printer{format: format, out: out, info: info}
+}
+
+// IsStructured reports whether results should be emitted as JSON rather than text
+func (p *Printer) IsStructured() bool {
+	return p.format != FormatTable
+}
+
+// Infof writes an informational message that is not part of the command result
+func (p *Printer) Infof(format string, args ...interface{}) {
+	fmt.Fprintf(p.info, format, args...)
+}
+
+// Emit writes v as JSON. In ndjson format slices are written one element per line.
+func (p *Printer) Emit(v interface{}) error {
+	if p.format == FormatNDJSON {
+		encoder := json.NewEncoder(p.out)
+		value := reflect.ValueOf(v)
+		if value.Kind() == reflect.Slice {
+			for i := 0; i < value.Len(); i++ {
+				if err := encoder.Encode(value.Index(i).Interface()); err != nil {
+					return fmt.Errorf("failed to write JSON: %w", err)
+				}
+			}
+			return nil
+		}
+		if err := encoder.Encode(v); err != nil {
+			return fmt.Errorf("failed to write JSON: %w", err)
+		}
+		return nil
+	}
+
+	encoder := json.NewEncoder(p.out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
+
+// entryRecord is the JSON representation of a time entry, flattened with its task name, project and duration
+type entryRecord struct {
+	domain.TimeEntry
+	TaskName        string `json:"task_name,omitempty"`
+	Running         bool   `json:"running"`
+	DurationSeconds int64  `json:"duration_seconds"`
+	Duration        string `json:"duration"`
+	Project         string `json:"project,omitempty"`
+}
+
+// projectRecord is the JSON representation of a project, flattened with its path
+type projectRecord struct {
+	domain.Project
+	Path     string `json:"path"`
+	Archived bool   `json:"archived"`
+}
+
+// summaryRecord is the JSON representation of a task summary
+type summaryRecord struct {
+	Task          *domain.Task  `json:"task"`
+	Entries       []entryRecord `json:"entries"`
+	SessionCount  int           `json:"session_count"`
+	RunningCount  int           `json:"running_count"`
+	PauseCount    int           `json:"pause_count"`
+	PausedSeconds int64         `json:"paused_seconds"`
+	PausedTime    string        `json:"paused_time"`
+	FirstEntry    time.Time     `json:"first_entry"`
+	LastEntry     time.Time     `json:"last_entry"`
+	TotalSeconds  int64         `json:"total_seconds"`
+	Total         string        `json:"total"`
+	Running       bool          `json:"running"`
+	Paused        bool          `json:"paused"`
+	Tags          []string      `json:"tags,omitempty"`
+}
+
+// newEntryRecord converts a time entry and its optional task to its JSON representation
+func newEntryRecord(entry *domain.TimeEntry, task *domain.Task) entryRecord {
+	duration := entryDuration(entry)
+	record := entryRecord{
+		TimeEntry:       *entry,
+		Running:         entry.EndTime == nil,
+		DurationSeconds: int64(duration.Seconds()),
+		Duration:        formatDurationHuman(duration),
+	}
+	if task != nil {
+		record.TaskName = task.TaskName
+	}
+	return record
+}
+
+// newEntryRecords converts time entries with tasks to their JSON representation
+func newEntryRecords(entries []*api.TimeEntryWithTask) []entryRecord {
+	records := make([]entryRecord, 0, len(entries))
+	for _, entry := range entries {
+		record := newEntryRecord(entry.TimeEntry, entry.Task)
+		record.Project = entry.Project
+		records = append(records, record)
+	}
+	return records
+}
+
+// newProjectRecord converts a project to its JSON representation
+func newProjectRecord(project *api.ProjectInfo) projectRecord {
+	return projectRecord{Project: *project.Project, Path: project.Path, Archived: project.Archived}
+}
+
+// newSummaryRecord converts a task summary to its JSON representation
+func newSummaryRecord(summary *api.TaskSummary) summaryRecord {
+	var total time.Duration
+	entries := make([]entryRecord, 0, len(summary.TimeEntries))
+	for _, entry := range summary.TimeEntries {
+		total += entryDuration(entry)
+		entries = append(entries, newEntryRecord(entry, summary.Task))
+	}
+
+	return summaryRecord{
+		Task:          summary.Task,
+		Entries:       entries,
+		SessionCount:  summary.SessionCount,
+		RunningCount:  summary.RunningCount,
+		PauseCount:    summary.PauseCount,
+		PausedSeconds: int64(summary.PausedDuration.Seconds()),
+		PausedTime:    formatDurationHuman(summary.PausedDuration),
+		FirstEntry:    summary.FirstEntry,
+		LastEntry:     summary.LastEntry,
+		TotalSeconds:  int64(total.Seconds()),
+		Total:         formatDurationHuman(total),
+		Running:       summary.IsRunning,
+		Paused:        summary.IsPaused,
+		Tags:          summary.Tags,
+	}
+}
+
+// entryDuration returns the length of an entry, measuring running entries up to now
+func entryDuration(entry *domain.TimeEntry) time.Duration {
+	end := timeNow()
+	if entry.EndTime != nil {
+		end = *entry.EndTime
+	}
+	return end.Sub(entry.StartTime)
+}
+
+// formatTags formats tags for display, as in "#billable #meeting"
+func formatTags(tags []string) string {
+	prefixed := make([]string, len(tags))
+	for i, tag := range tags {
+		prefixed[i] = "#" + tag
+	}
+	return strings.Join(prefixed, " ")
+}
+
+// formatDurationHuman formats a duration as "1h 30m" or "45m"
+func formatDurationHuman(duration time.Duration) string {
+	if duration < 0 {
+		duration = 0
+	}
+	hours := int(duration.Hours())
+	minutes := int(duration.Minutes()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
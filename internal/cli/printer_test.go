@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/internal/config"
+	"time-tracker/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    OutputFormat
+		expectError bool
+	}{
+		{input: "", expected: FormatTable},
+		{input: "table", expected: FormatTable},
+		{input: "json", expected: FormatJSON},
+		{input: "NDJSON", expected: FormatNDJSON},
+		{input: "csv", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			format, err := ParseOutputFormat(tt.input)
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "expected table, json or ndjson")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func TestPrinter_Emit(t *testing.T) {
+	records := []domain.Task{{ID: 1, TaskName: "First"}, {ID: 2, TaskName: "Second"}}
+
+	t.Run("json writes one indented document", func(t *testing.T) {
+		var out bytes.Buffer
+		printer := newPrinterWithWriters(FormatJSON, &out, io.Discard)
+
+		require.NoError(t, printer.Emit(records))
+
+		var decoded []domain.Task
+		require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		assert.Equal(t, records, decoded)
+		assert.Contains(t, out.String(), "\n  ")
+	})
+
+	t.Run("ndjson writes one object per line", func(t *testing.T) {
+		var out bytes.Buffer
+		printer := newPrinterWithWriters(FormatNDJSON, &out, io.Discard)
+
+		require.NoError(t, printer.Emit(records))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, `{"id":1,"name":"First"}`, lines[0])
+		assert.Equal(t, `{"id":2,"name":"Second"}`, lines[1])
+	})
+
+	t.Run("structured formats send messages to stderr", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		printer := newPrinterWithWriters(FormatJSON, &out, &errOut)
+
+		printer.Infof("Select a task:\n")
+
+		assert.Empty(t, out.String())
+		assert.Equal(t, "Select a task:\n", errOut.String())
+	})
+}
+
+func TestApp_NewPrinterUsesConfiguredFormat(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Commands.ListDefaultFormat = "ndjson"
+	app := NewAppWithConfig(newMockBusinessAPI(), cfg)
+
+	assert.Equal(t, FormatNDJSON, app.newPrinter().format)
+	assert.Equal(t, FormatTable, NewApp(newMockBusinessAPI()).newPrinter().format)
+}
+
+func TestCommands_JSONOutput(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+
+	t.Run("list emits entries with seconds and human durations", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+		_, err := app.businessAPI.AddTimeEntry(ctx, "Meeting", start, start.Add(90*time.Minute))
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		cmd := NewListCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		var entries []map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Meeting", entries[0]["task_name"])
+		assert.Equal(t, float64(5400), entries[0]["duration_seconds"])
+		assert.Equal(t, "1h 30m", entries[0]["duration"])
+		assert.Equal(t, false, entries[0]["running"])
+	})
+
+	t.Run("list emits an empty array when nothing matches", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		var out bytes.Buffer
+		cmd := NewListCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		assert.Equal(t, "[]\n", out.String())
+	})
+
+	t.Run("current emits the running entry", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+		_, err := app.businessAPI.StartNewTask(ctx, "Coding")
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		cmd := NewCurrentCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatNDJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		var entry entryRecord
+		require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+		assert.Equal(t, "Coding", entry.TaskName)
+		assert.True(t, entry.Running)
+		assert.Nil(t, entry.EndTime)
+	})
+
+	t.Run("current emits null when nothing is running", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		var out bytes.Buffer
+		cmd := NewCurrentCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		assert.Equal(t, "null\n", out.String())
+	})
+
+	t.Run("summary emits totals", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+		_, err := app.businessAPI.AddTimeEntry(ctx, "Review", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		_, err = app.businessAPI.AddTimeEntry(ctx, "Review", start.Add(2*time.Hour), start.Add(150*time.Minute))
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		cmd := NewSummaryCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{"Review"}))
+
+		var summary summaryRecord
+		require.NoError(t, json.Unmarshal(out.Bytes(), &summary))
+		assert.Equal(t, "Review", summary.Task.TaskName)
+		assert.Len(t, summary.Entries, 2)
+		assert.Equal(t, int64(5400), summary.TotalSeconds)
+		assert.Equal(t, "1h 30m", summary.Total)
+	})
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/errors"
+)
+
+// ProjectOptions holds the flags accepted by the project command
+type ProjectOptions struct {
+	All bool // Include archived projects in tt project list
+}
+
+// ProjectCommand handles the project management subcommands (add, list, archive)
+type ProjectCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	options      ProjectOptions
+}
+
+// NewProjectCommand creates a new project command handler
+func NewProjectCommand(app *App) *ProjectCommand {
+	return NewProjectCommandWithOptions(app, ProjectOptions{})
+}
+
+// NewProjectCommandWithOptions creates a new project command handler with the given flag values
+func NewProjectCommandWithOptions(app *App, options ProjectOptions) *ProjectCommand {
+	return &ProjectCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		options:      options,
+	}
+}
+
+// Execute runs the project command, dispatching on the subcommand in args[0]
+func (c *ProjectCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.NewInvalidInputError("command", "project", "usage: tt project add <client/project>, tt project list [--all] or tt project archive <client/project>")
+	}
+
+	switch args[0] {
+	case "add":
+		return c.addProject(ctx, args[1:])
+	case "list":
+		return c.listProjects(ctx, args[1:])
+	case "archive":
+		return c.archiveProject(ctx, args[1:])
+	default:
+		return errors.NewInvalidInputError("subcommand", args[0], "expected add, list or archive")
+	}
+}
+
+// addProject implements tt project add <client/project>
+func (c *ProjectCommand) addProject(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.NewInvalidInputError("command", "project add", "usage: tt project add <client/project>")
+	}
+
+	project, err := c.businessAPI.CreateProject(ctx, args[0])
+	if err != nil {
+		return c.errorHandler.Handle("add project", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newProjectRecord(project))
+	}
+
+	fmt.Printf("Added project: %s\n", project.Path)
+	return nil
+}
+
+// listProjects implements tt project list [--all]
+func (c *ProjectCommand) listProjects(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return errors.NewInvalidInputError("command", "project list", "usage: tt project list [--all]")
+	}
+
+	projects, err := c.businessAPI.ListProjects(ctx, c.options.All)
+	if err != nil {
+		return c.errorHandler.Handle("list projects", err)
+	}
+
+	if c.printer.IsStructured() {
+		records := make([]projectRecord, 0, len(projects))
+		for _, project := range projects {
+			records = append(records, newProjectRecord(project))
+		}
+		return c.printer.Emit(records)
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("No projects found")
+		return nil
+	}
+	for _, project := range projects {
+		line := project.Path
+		if project.Archived {
+			line += " (archived)"
+		}
+		fmt.Printf("[%d] %s\n", project.Project.ID, line)
+	}
+	return nil
+}
+
+// archiveProject implements tt project archive <client/project>
+func (c *ProjectCommand) archiveProject(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.NewInvalidInputError("command", "project archive", "usage: tt project archive <client/project>")
+	}
+
+	project, err := c.businessAPI.ArchiveProject(ctx, args[0])
+	if err != nil {
+		return c.errorHandler.Handle("archive project", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newProjectRecord(project))
+	}
+
+	fmt.Printf("Archived project: %s\n", project.Path)
+	return nil
+}
+
+// printProjectTotals prints a project rollup, indenting sub-projects below their parent
+func printProjectTotals(totals []*api.ProjectTotal) {
+	fmt.Printf("%-40s %10s %12s\n", "Project", "Sessions", "Total")
+	fmt.Println(strings.Repeat("-", 64))
+	for _, total := range totals {
+		name := "(no project)"
+		if total.Project != "" {
+			segments := strings.Split(total.Project, domain.ProjectPathSeparator)
+			name = strings.Repeat("  ", total.Depth) + segments[len(segments)-1]
+		}
+		fmt.Printf("%-40s %10d %12s\n", name, total.SessionCount, total.Total)
+	}
+}
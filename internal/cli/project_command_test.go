@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("adds, lists and archives projects", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		require.NoError(t, NewProjectCommand(app).Execute(ctx, []string{"add", "acme/website"}))
+		require.NoError(t, NewProjectCommand(app).Execute(ctx, []string{"add", "acme/mobile"}))
+		require.NoError(t, NewProjectCommand(app).Execute(ctx, []string{"archive", "acme/mobile"}))
+
+		list := func(options ProjectOptions) []projectRecord {
+			var out bytes.Buffer
+			cmd := NewProjectCommandWithOptions(app, options)
+			cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+			require.NoError(t, cmd.Execute(ctx, []string{"list"}))
+
+			var records []projectRecord
+			require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+			return records
+		}
+
+		active := list(ProjectOptions{})
+		require.Len(t, active, 2)
+		assert.Equal(t, "acme", active[0].Path)
+		assert.Equal(t, "acme/website", active[1].Path)
+		assert.Equal(t, "website", active[1].Name)
+		require.NotNil(t, active[1].ParentID)
+		assert.Equal(t, active[0].ID, *active[1].ParentID)
+
+		all := list(ProjectOptions{All: true})
+		require.Len(t, all, 3)
+		assert.Equal(t, "acme/mobile", all[1].Path)
+		assert.True(t, all[1].Archived)
+	})
+
+	t.Run("rejects invalid usage", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		tests := []struct {
+			args        []string
+			expectError string
+		}{
+			{args: []string{}, expectError: "usage: tt project"},
+			{args: []string{"add"}, expectError: "usage: tt project add"},
+			{args: []string{"archive", "globex"}, expectError: "not found"},
+			{args: []string{"rename", "acme"}, expectError: "expected add, list or archive"},
+		}
+		for _, tt := range tests {
+			err := NewProjectCommand(app).Execute(ctx, tt.args)
+			require.Error(t, err, tt.args)
+			assert.Contains(t, err.Error(), tt.expectError)
+		}
+	})
+}
+
+// The services resolve projects and filter by them, see TestSearchService_SearchTimeEntriesByProject;
+// the commands pass the project on and show what comes back
+func TestProjectFilters(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) *App {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+
+		for _, path := range []string{"acme/website", "acme/mobile", "internal"} {
+			_, err := app.businessAPI.CreateProject(ctx, path)
+			require.NoError(t, err)
+		}
+		starts := []struct{ task, project string }{
+			{"Landing page", "acme/website"},
+			{"App store", "acme/mobile"},
+			{"Planning", "internal"},
+		}
+		for _, start := range starts {
+			require.NoError(t, NewStartCommandWithOptions(app, StartOptions{Project: start.project}).Execute(ctx, []string{start.task}))
+		}
+		_, err := app.businessAPI.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+		return app
+	}
+
+	t.Run("start passes the project on", func(t *testing.T) {
+		app := setup(t)
+		mockAPI(app).failures["StartNewTaskWithOptions"] = errors.NewNotFoundError("project", "globex")
+
+		err := NewStartCommandWithOptions(app, StartOptions{Project: "globex"}).Execute(ctx, []string{"Sales call"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+		starts := mockAPI(app).starts
+		assert.Equal(t, "globex", starts[len(starts)-1].options.Project)
+	})
+
+	t.Run("list shows the project of each entry", func(t *testing.T) {
+		app := setup(t)
+
+		var out bytes.Buffer
+		cmd := NewListCommandWithOptions(app, ListOptions{Project: "acme"})
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		filters := mockAPI(app).filters
+		require.Len(t, filters, 1)
+		assert.Equal(t, "acme", filters[0].Project)
+
+		var entries []entryRecord
+		require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+		var projects []string
+		for _, entry := range entries {
+			projects = append(projects, entry.Project)
+		}
+		assert.ElementsMatch(t, []string{"acme/website", "acme/mobile", "internal"}, projects)
+	})
+
+	t.Run("summary shows the totals per project", func(t *testing.T) {
+		app := setup(t)
+
+		var out bytes.Buffer
+		cmd := NewSummaryCommandWithOptions(app, SummaryOptions{Project: "acme"})
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		filters := mockAPI(app).filters
+		require.Len(t, filters, 1)
+		assert.Equal(t, "acme", filters[0].Project)
+
+		var totals []map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &totals))
+		require.Len(t, totals, 4)
+		assert.Equal(t, "acme", totals[0]["project"])
+		assert.Equal(t, float64(2), totals[0]["session_count"])
+		assert.Equal(t, "acme/mobile", totals[1]["project"])
+		assert.Equal(t, float64(1), totals[1]["depth"])
+	})
+
+	t.Run("output adds the project column", func(t *testing.T) {
+		app := setup(t)
+		outPath := filepath.Join(t.TempDir(), "export.csv")
+
+		cmd := NewOutputCommandWithOptions(app, OutputOptions{Project: "internal", Out: outPath})
+		require.NoError(t, cmd.Execute(ctx, []string{"format=csv"}))
+
+		filters := mockAPI(app).filters
+		require.Len(t, filters, 1)
+		assert.Equal(t, "internal", filters[0].Project)
+
+		content, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Task Name,Project,Tags,Note\n")
+		assert.Contains(t, string(content), "Planning,internal,,\n")
+		assert.Contains(t, string(content), "Landing page,acme/website,,\n")
+	})
+}
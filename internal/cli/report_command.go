@@ -0,0 +1,330 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// reportUsage describes the arguments and flags of tt report
+const reportUsage = "usage: tt report day|week|month [date] [--group-by task|project|tag|day] [--format table|csv|md|json|ndjson]"
+
+// reportWriter writes a report to w in a particular format
+type reportWriter func(w io.Writer, report *api.Report) error
+
+// reportFormats maps the --format values accepted by tt report to their writers
+var reportFormats = map[string]reportWriter{
+	"table":  writeReportTable,
+	"csv":    writeReportCSV,
+	"md":     writeReportMarkdown,
+	"json":   writeReportJSON,
+	"ndjson": writeReportNDJSON,
+}
+
+// ReportOptions holds the flags accepted by the report command
+type ReportOptions struct {
+	GroupBy string // task, project, tag or day
+	Format  string // table, csv, md, json or ndjson; the global output format when empty
+}
+
+// ReportCommand handles the report command, which totals the time per task, project, tag or day over a period
+type ReportCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	options      ReportOptions
+}
+
+// NewReportCommand creates a new report command handler
+func NewReportCommand(app *App) *ReportCommand {
+	return NewReportCommandWithOptions(app, ReportOptions{})
+}
+
+// NewReportCommandWithOptions creates a new report command handler with the given flag values
+func NewReportCommandWithOptions(app *App, options ReportOptions) *ReportCommand {
+	return &ReportCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		options:      options,
+	}
+}
+
+// Execute runs the report command
+func (c *ReportCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.NewInvalidInputError("command", "report", reportUsage)
+	}
+
+	opts := api.ReportOptions{Period: args[0], GroupBy: c.options.GroupBy}
+	if len(args) == 2 {
+		date, err := newTimeParser(timeNow()).ParseDate(args[1])
+		if err != nil {
+			return err
+		}
+		opts.Date = date
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.options.Format))
+	if format == "" {
+		format = string(c.printer.format)
+	}
+	write, exists := reportFormats[format]
+	if !exists {
+		return errors.NewInvalidInputError("format", c.options.Format, "unsupported format, expected one of "+strings.Join(supportedReportFormats(), ", "))
+	}
+
+	report, err := c.businessAPI.GetReport(ctx, opts)
+	if err != nil {
+		return c.errorHandler.Handle("build report", err)
+	}
+	return write(c.printer.out, report)
+}
+
+// writeReportJSON writes the report as a single indented JSON document
+func writeReportJSON(w io.Writer, report *api.Report) error {
+	return newPrinterWithWriters(FormatJSON, w, io.Discard).Emit(report)
+}
+
+// writeReportNDJSON writes the report as one line of JSON
+func writeReportNDJSON(w io.Writer, report *api.Report) error {
+	return newPrinterWithWriters(FormatNDJSON, w, io.Discard).Emit(report)
+}
+
+// writeReportTable writes the report as aligned columns, in hours and minutes, with a day column
+// for every day of a week or month. The running entry's row is marked with an asterisk.
+func writeReportTable(w io.Writer, report *api.Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s, by %s\n\n", reportTitle(report), report.GroupBy)
+
+	if len(report.Rows) == 0 {
+		b.WriteString("No time tracked in this period.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	days := reportDayHeaders(report)
+	labels := make([]string, len(report.Rows))
+	keyWidth := len("Total")
+	for i, row := range report.Rows {
+		labels[i] = reportRowLabel(report, row)
+		if row.Running {
+			labels[i] += " *"
+		}
+		keyWidth = max(keyWidth, len([]rune(labels[i])))
+	}
+	keyWidth = min(keyWidth, 40)
+	columnWidths := make([]int, len(days))
+	for i, day := range days {
+		columnWidths[i] = max(len(day), 5)
+	}
+
+	writeLine := func(label string, cells []string, total, share string) {
+		fmt.Fprintf(&b, "%-*s", keyWidth, truncateRunes(label, keyWidth))
+		for i, cell := range cells {
+			fmt.Fprintf(&b, "  %*s", columnWidths[i], cell)
+		}
+		fmt.Fprintf(&b, "  %7s  %6s\n", total, share)
+	}
+
+	writeLine("", days, "Total", "Share")
+	for i, row := range report.Rows {
+		cells := make([]string, len(days))
+		for day := range days {
+			cells[day] = formatReportClock(row.Days[day])
+		}
+		writeLine(labels[i], cells, formatReportClock(row.Duration), fmt.Sprintf("%.1f%%", row.Share))
+	}
+
+	width := keyWidth + 17
+	for _, columnWidth := range columnWidths {
+		width += columnWidth + 2
+	}
+	b.WriteString(strings.Repeat("-", width) + "\n")
+
+	totals := make([]string, len(days))
+	for day := range days {
+		totals[day] = formatReportClock(report.DayTotals[day])
+	}
+	writeLine("Total", totals, formatReportClock(report.Duration), "100.0%")
+
+	if report.RunningTask != "" {
+		fmt.Fprintf(&b, "\n* Running: %s, %s so far\n", report.RunningTask, formatDurationHuman(report.RunningDuration))
+	}
+	if report.GroupBy == api.ReportGroupByTag {
+		b.WriteString("\nEntries with several tags count towards each of them.\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeReportCSV writes one row per group with a column per day, in hours, and a total row
+func writeReportCSV(w io.Writer, report *api.Report) error {
+	formatHours := func(d time.Duration) string {
+		return fmt.Sprintf("%.2f", d.Hours())
+	}
+
+	writer := csv.NewWriter(w)
+	header := []string{strings.ToUpper(report.GroupBy[:1]) + report.GroupBy[1:]}
+	for _, day := range report.Days {
+		header = append(header, day.Format("2006-01-02"))
+	}
+	if err := writer.Write(append(header, "Total", "Share (%)", "Running")); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, row := range report.Rows {
+		record := []string{row.Key}
+		for _, duration := range row.Days {
+			record = append(record, formatHours(duration))
+		}
+		running := ""
+		if row.Running {
+			running = "yes"
+		}
+		record = append(record, formatHours(row.Duration), fmt.Sprintf("%.1f", row.Share), running)
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	totalRow := []string{"Total"}
+	for _, duration := range report.DayTotals {
+		totalRow = append(totalRow, formatHours(duration))
+	}
+	if err := writer.Write(append(totalRow, formatHours(report.Duration), "100.0", "")); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeReportMarkdown writes the report as a Markdown table with a bold total row
+func writeReportMarkdown(w io.Writer, report *api.Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s, by %s**\n\n", reportTitle(report), report.GroupBy)
+
+	days := reportDayHeaders(report)
+	header := append(append([]string{strings.ToUpper(report.GroupBy[:1]) + report.GroupBy[1:]}, days...), "Total", "Share")
+	b.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	b.WriteString("|---" + strings.Repeat("|---:", len(header)-1) + "|\n")
+
+	formatCell := func(d time.Duration) string {
+		if d == 0 {
+			return ""
+		}
+		return formatDurationHuman(d)
+	}
+
+	for _, row := range report.Rows {
+		label := escapeMarkdownCell(reportRowLabel(report, row))
+		if row.Running {
+			label += ` \*`
+		}
+		cells := []string{label}
+		for day := range days {
+			cells = append(cells, formatCell(row.Days[day]))
+		}
+		cells = append(cells, formatDurationHuman(row.Duration), fmt.Sprintf("%.1f%%", row.Share))
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	cells := []string{"**Total**"}
+	for day := range days {
+		cells = append(cells, formatCell(report.DayTotals[day]))
+	}
+	cells = append(cells, "**"+formatDurationHuman(report.Duration)+"**", "100.0%")
+	b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+
+	if report.RunningTask != "" {
+		fmt.Fprintf(&b, "\n\\* Running: %s, %s so far\n", escapeMarkdownCell(report.RunningTask), formatDurationHuman(report.RunningDuration))
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write Markdown: %w", err)
+	}
+	return nil
+}
+
+// reportTitle names the period of a report, e.g. "Week of Mon 2026-10-12"
+func reportTitle(report *api.Report) string {
+	start := report.Start.Local()
+	switch report.Period {
+	case api.ReportPeriodDay:
+		return start.Format("Mon 2006-01-02")
+	case api.ReportPeriodWeek:
+		return "Week of " + start.Format("Mon 2006-01-02")
+	default:
+		return start.Format("January 2006")
+	}
+}
+
+// reportDayHeaders returns the headers of the day columns: weekday and day for a week, the day of the month
+// for a month. Reports of a single day, or grouped by day, have no day columns since they would repeat the total.
+func reportDayHeaders(report *api.Report) []string {
+	if len(report.Days) <= 1 || report.GroupBy == api.ReportGroupByDay {
+		return nil
+	}
+
+	headers := make([]string, len(report.Days))
+	for i, day := range report.Days {
+		if report.Period == api.ReportPeriodWeek {
+			headers[i] = day.Format("Mon 02")
+		} else {
+			headers[i] = day.Format("02")
+		}
+	}
+	return headers
+}
+
+// reportRowLabel names a row, spelling out time without a project or tag and adding the weekday to dates
+func reportRowLabel(report *api.Report, row *api.ReportRow) string {
+	switch {
+	case row.Key == "" && report.GroupBy == api.ReportGroupByProject:
+		return "(no project)"
+	case row.Key == "" && report.GroupBy == api.ReportGroupByTag:
+		return "(untagged)"
+	case report.GroupBy == api.ReportGroupByDay:
+		if day, err := time.ParseInLocation("2006-01-02", row.Key, time.Local); err == nil {
+			return day.Format("Mon 2006-01-02")
+		}
+	}
+	return row.Key
+}
+
+// formatReportClock formats a duration as hours and minutes, "1:30", keeping report columns narrow
+func formatReportClock(duration time.Duration) string {
+	if duration <= 0 {
+		return "-"
+	}
+	minutes := int(duration.Minutes())
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// truncateRunes cuts text to width characters, ending with an ellipsis when shortened
+func truncateRunes(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// supportedReportFormats returns the sorted names of the formats accepted by tt report --format
+func supportedReportFormats() []string {
+	formats := make([]string, 0, len(reportFormats))
+	for format := range reportFormats {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"time-tracker/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	app, cleanup := setupTestAppWithMockBusinessAPI(t)
+	defer cleanup()
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 9, day, hour, 0, 0, 0, time.Local)
+	}
+	for _, entry := range []struct {
+		task       string
+		start, end time.Time
+	}{
+		{"Write docs", at(14, 9), at(14, 11)},
+		{"Code review", at(15, 14), at(15, 15)},
+		{"Write docs", at(16, 9), at(16, 10)},
+	} {
+		_, err := app.businessAPI.AddTimeEntry(ctx, entry.task, entry.start, entry.end)
+		require.NoError(t, err)
+	}
+
+	run := func(t *testing.T, format OutputFormat, options ReportOptions, args ...string) string {
+		var out bytes.Buffer
+		cmd := NewReportCommandWithOptions(app, options)
+		cmd.printer = newPrinterWithWriters(format, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, args))
+		return out.String()
+	}
+
+	t.Run("prints a grid of tasks per day", func(t *testing.T) {
+		out := run(t, FormatTable, ReportOptions{}, "week", "2026-09-16")
+
+		assert.Contains(t, out, "Week of Mon 2026-09-14, by task")
+		assert.Regexp(t, `\s+Mon 14\s+Tue 15\s+Wed 16\s+Thu 17\s+Fri 18\s+Sat 19\s+Sun 20\s+Total\s+Share`, out)
+		assert.Regexp(t, `Write docs\s+2:00\s+-\s+1:00\s+-\s+-\s+-\s+-\s+3:00\s+75\.0%`, out)
+		assert.Regexp(t, `Code review\s+-\s+1:00\s+-(\s+-){4}\s+1:00\s+25\.0%`, out)
+		assert.Regexp(t, `Total\s+2:00\s+1:00\s+1:00(\s+-){4}\s+4:00\s+100\.0%`, out)
+	})
+
+	t.Run("groups by day", func(t *testing.T) {
+		out := run(t, FormatTable, ReportOptions{GroupBy: "day"}, "month", "2026-09-01")
+
+		assert.Contains(t, out, "September 2026, by day")
+		assert.Regexp(t, `Mon 2026-09-14\s+2:00\s+50\.0%`, out)
+		assert.Regexp(t, `Total\s+4:00\s+100\.0%`, out)
+	})
+
+	t.Run("marks the running entry", func(t *testing.T) {
+		_, err := app.businessAPI.StartNewTask(ctx, "Plan sprint")
+		require.NoError(t, err)
+		defer app.businessAPI.StopAllRunningTasks(ctx)
+
+		out := run(t, FormatTable, ReportOptions{}, "day")
+		assert.Contains(t, out, "Plan sprint *")
+		assert.Contains(t, out, "* Running: Plan sprint")
+	})
+
+	t.Run("says when nothing was tracked", func(t *testing.T) {
+		out := run(t, FormatTable, ReportOptions{}, "day", "2026-08-01")
+		assert.Contains(t, out, "No time tracked in this period.")
+	})
+
+	t.Run("writes csv and markdown", func(t *testing.T) {
+		out := run(t, FormatTable, ReportOptions{Format: "csv"}, "week", "2026-09-16")
+		records, err := csv.NewReader(bytes.NewBufferString(out)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		assert.Equal(t, []string{"Task", "2026-09-14", "2026-09-15", "2026-09-16", "2026-09-17", "2026-09-18", "2026-09-19", "2026-09-20", "Total", "Share (%)", "Running"}, records[0])
+		assert.Equal(t, []string{"Write docs", "2.00", "0.00", "1.00", "0.00", "0.00", "0.00", "0.00", "3.00", "75.0", ""}, records[1])
+		assert.Equal(t, "4.00", records[3][8])
+
+		out = run(t, FormatTable, ReportOptions{Format: "md"}, "week", "2026-09-16")
+		assert.Contains(t, out, "| Task | Mon 14 | Tue 15 | Wed 16 | Thu 17 | Fri 18 | Sat 19 | Sun 20 | Total | Share |")
+		assert.Contains(t, out, "| Write docs | 2h 0m |  | 1h 0m |  |  |  |  | 3h 0m | 75.0% |")
+		assert.Contains(t, out, "| **Total** |")
+	})
+
+	t.Run("follows the global format and lets --format override it", func(t *testing.T) {
+		for _, options := range []ReportOptions{{}, {Format: "json"}} {
+			format := FormatJSON
+			if options.Format != "" {
+				format = FormatTable
+			}
+			out := run(t, format, options, "week", "2026-09-16")
+
+			var report api.Report
+			require.NoError(t, json.Unmarshal([]byte(out), &report))
+			assert.Equal(t, "week", report.Period)
+			assert.Equal(t, int64(4*3600), report.TotalSeconds)
+			require.Len(t, report.Rows, 2)
+			assert.Equal(t, "Write docs", report.Rows[0].Key)
+			assert.Equal(t, []int64{7200, 0, 3600, 0, 0, 0, 0}, report.Rows[0].DaySeconds)
+		}
+	})
+
+	t.Run("rejects invalid arguments", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			options  ReportOptions
+			args     []string
+			expected string
+		}{
+			{"missing period", ReportOptions{}, nil, "usage: tt report"},
+			{"unknown period", ReportOptions{}, []string{"year"}, "expected day, week or month"},
+			{"invalid date", ReportOptions{}, []string{"week", "14/10/2026"}, "expected a date such as"},
+			{"unknown grouping", ReportOptions{GroupBy: "client"}, []string{"week"}, "expected task, project, tag or day"},
+			{"unknown format", ReportOptions{Format: "ics"}, []string{"week"}, "expected one of csv, json, md, ndjson, table"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cmd := NewReportCommandWithOptions(app, tt.options)
+				cmd.printer = newPrinterWithWriters(FormatTable, io.Discard, io.Discard)
+				err := cmd.Execute(ctx, tt.args)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expected)
+			})
+		}
+	})
+}
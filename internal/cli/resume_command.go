@@ -3,20 +3,37 @@ package cli
 import (
 	"context"
 	"fmt"
-	"os"
-	"strconv"
 	"time-tracker/internal/api"
 	"time-tracker/internal/errors"
 )
 
+// ResumeOptions holds the flags accepted by the resume command
+type ResumeOptions struct {
+	Select          TaskSelection // Picks the task without prompting
+	IncludeArchived bool          // Also offer archived tasks
+	At              string        // When work resumed, e.g. "09:15" or "yesterday 17:00"; now when empty
+	Ago             string        // How long ago work resumed, e.g. "10m"; excludes At
+}
+
 // ResumeCommand handles the resume command
 type ResumeCommand struct {
 	businessAPI api.BusinessAPI
+	printer     *Printer
+	options     ResumeOptions
 }
 
 // NewResumeCommand creates a new resume command handler
 func NewResumeCommand(app *App) *ResumeCommand {
-	return &ResumeCommand{businessAPI: app.businessAPI}
+	return NewResumeCommandWithOptions(app, ResumeOptions{})
+}
+
+// NewResumeCommandWithOptions creates a new resume command handler with the given flag values
+func NewResumeCommandWithOptions(app *App, options ResumeOptions) *ResumeCommand {
+	return &ResumeCommand{
+		businessAPI: app.businessAPI,
+		printer:     app.newPrinter(),
+		options:     options,
+	}
 }
 
 // Execute runs the resume command
@@ -26,53 +43,65 @@ func (c *ResumeCommand) Execute(ctx context.Context, args []string) error {
 
 // resumeTask implements the resume scenario
 func (c *ResumeCommand) resumeTask(ctx context.Context, args []string) error {
+	// Check --at and --ago before offering any tasks
+	at, err := resolveAt(c.options.At, c.options.Ago, timeNow())
+	if err != nil {
+		return err
+	}
+
 	// Determine time range (default: today)
 	var timeRange string
 	if len(args) > 0 {
-		// Validate the time shorthand
-		if _, err := parseTimeShorthand(args[0]); err != nil {
+		// Validate the time range
+		if _, err := c.businessAPI.ParseTimeRange(ctx, args[0]); err != nil {
 			return errors.NewInvalidInputError("time_shorthand", args[0], "invalid time shorthand")
 		}
 		timeRange = args[0]
-	} else {
-		timeRange = "1d" // Default to today
+	} else if !c.options.Select.IsSet() {
+		timeRange = "1d" // Default to today; selection flags look at every task
 	}
 
 	// Search for tasks in the period using BusinessAPI
-	tasks, err := c.businessAPI.SearchTasks(ctx, timeRange, "", api.SortByRecentFirst)
+	filter := api.TimeEntryFilter{TimeRange: timeRange, IncludeArchived: c.options.IncludeArchived}
+	tasks, err := c.businessAPI.SearchTasksWithFilter(ctx, filter, api.SortByRecentFirst)
 	if err != nil {
 		return fmt.Errorf("failed to search tasks: %w", err)
 	}
-	if len(tasks) == 0 {
-		fmt.Println("No tasks found in the selected period.")
+	if len(tasks) == 0 && !c.options.Select.IsSet() {
+		c.printer.Infof("No tasks found in the selected period.\n")
 		return nil
 	}
 
-	fmt.Println("Select a task to resume:")
-	for i, task := range tasks {
-		fmt.Printf("%d. %s (last worked: %s)\n", i+1, task.Task.TaskName, task.LastWorked)
+	picker := &taskPicker{
+		businessAPI: c.businessAPI,
+		printer:     c.printer,
+		command:     "resume",
+		verb:        "resume",
+		cancelled:   "Resume cancelled.",
+		describe: func(task *api.TaskActivity) string {
+			return fmt.Sprintf("%s (last worked: %s)", task.Task.TaskName, task.LastWorked)
+		},
 	}
-	fmt.Print("Enter number to resume, or 'q' to quit: ")
-
-	// Read user input
-	var input string
-	fmt.Fscanln(os.Stdin, &input)
-	if input == "q" || input == "Q" {
-		fmt.Println("Resume cancelled.")
-		return nil
+	selectedTask, err := picker.pick(ctx, c.options.Select, tasks)
+	if err != nil || selectedTask == nil {
+		return err
 	}
-	idx, err := strconv.Atoi(input)
-	if err != nil || idx < 1 || idx > len(tasks) {
-		return errors.NewInvalidInputError("selection", input, "invalid selection")
-	}
-	selectedTask := tasks[idx-1]
 
 	// Resume the selected task using BusinessAPI
-	session, err := c.businessAPI.ResumeTask(ctx, selectedTask.Task.ID)
+	var session *api.TaskSession
+	if at != nil {
+		session, err = c.businessAPI.ResumeTaskAt(ctx, selectedTask.ID, *at)
+	} else {
+		session, err = c.businessAPI.ResumeTask(ctx, selectedTask.ID)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to resume task: %w", err)
 	}
-	
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newEntryRecord(session.TimeEntry, session.Task))
+	}
+
 	fmt.Printf("Resumed task: %s\n", session.Task.TaskName)
 	return nil
 }
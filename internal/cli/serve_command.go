@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/server"
+)
+
+// defaultServeAddr is where tt serve listens without --addr; only local clients can connect
+const defaultServeAddr = "127.0.0.1:7070"
+
+// ServeOptions holds the flags accepted by the serve command
+type ServeOptions struct {
+	Addr        string // Host and port to listen on
+	AllowRemote bool   // Listen on an address other machines can reach; needs a token
+	Token       string // Bearer token every request must send
+}
+
+// ServeCommand handles the serve command, which exposes the business API over HTTP until interrupted
+type ServeCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	options      ServeOptions
+}
+
+// NewServeCommand creates a new serve command handler
+func NewServeCommand(app *App) *ServeCommand {
+	return NewServeCommandWithOptions(app, ServeOptions{})
+}
+
+// NewServeCommandWithOptions creates a new serve command handler with the given flag values
+func NewServeCommandWithOptions(app *App, options ServeOptions) *ServeCommand {
+	if options.Addr == "" {
+		options.Addr = defaultServeAddr
+	}
+	return &ServeCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		options:      options,
+	}
+}
+
+// Execute runs the server until ctx is cancelled or the process is interrupted
+func (c *ServeCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return errors.NewInvalidInputError("command", "serve", "usage: tt serve [--addr host:port] [--allow-remote --token token]")
+	}
+	// The API can start, stop and delete anything and the host checks only hold browsers back,
+	// so other machines get to use it only when asked for and with a token
+	if !isLoopbackAddr(c.options.Addr) {
+		if !c.options.AllowRemote {
+			return c.errorHandler.Handle("start server", errors.NewInvalidInputError("addr", c.options.Addr,
+				"other machines could reach it; use a loopback address such as 127.0.0.1, or --allow-remote with --token"))
+		}
+		if c.options.Token == "" {
+			return c.errorHandler.Handle("start server", errors.NewInvalidInputError("token", "",
+				"--allow-remote needs a --token that clients send as a bearer token"))
+		}
+	}
+
+	listener, err := net.Listen("tcp", c.options.Addr)
+	if err != nil {
+		return c.errorHandler.Handle("start server", errors.NewInvalidInputError("addr", c.options.Addr, err.Error()))
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c.printer.Infof("Serving the tt API on http://%s (press Ctrl-C to stop)\n", listener.Addr())
+	if err := server.NewWithOptions(c.businessAPI, server.Options{Token: c.options.Token}).Serve(ctx, listener); err != nil {
+		return fmt.Errorf("server failed: %w", err)
+	}
+	return nil
+}
+
+// isLoopbackAddr reports whether addr only accepts connections from this machine: localhost or a loopback IP.
+// Hosts given by other names, and a missing host, which listens on every interface, do not count.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
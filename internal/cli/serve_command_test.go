@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeCommand_Execute(t *testing.T) {
+	app, cleanup := setupTestAppWithMockBusinessAPI(t)
+	defer cleanup()
+
+	t.Run("listens until the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var info bytes.Buffer
+		cmd := NewServeCommandWithOptions(app, ServeOptions{Addr: "127.0.0.1:0"})
+		cmd.printer = newPrinterWithWriters(FormatTable, &info, &info)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+		assert.Contains(t, info.String(), "Serving the tt API on http://127.0.0.1:")
+	})
+
+	t.Run("reports an address in use", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		err = NewServeCommandWithOptions(app, ServeOptions{Addr: listener.Addr().String()}).Execute(context.Background(), []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to start server")
+	})
+
+	t.Run("refuses addresses other machines can reach", func(t *testing.T) {
+		for _, addr := range []string{"0.0.0.0:8080", ":8080", "192.168.1.20:8080", "[::]:8080", "tt.example.com:8080"} {
+			err := NewServeCommandWithOptions(app, ServeOptions{Addr: addr}).Execute(context.Background(), []string{})
+			require.Error(t, err, addr)
+			assert.Contains(t, err.Error(), "--allow-remote", addr)
+		}
+	})
+
+	t.Run("refuses to serve other machines without a token", func(t *testing.T) {
+		err := NewServeCommandWithOptions(app, ServeOptions{Addr: "0.0.0.0:8080", AllowRemote: true}).Execute(context.Background(), []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--token")
+	})
+
+	t.Run("serves other machines when allowed and given a token", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var info bytes.Buffer
+		cmd := NewServeCommandWithOptions(app, ServeOptions{Addr: "0.0.0.0:0", AllowRemote: true, Token: "secret"})
+		cmd.printer = newPrinterWithWriters(FormatTable, &info, &info)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+		assert.Contains(t, info.String(), "Serving the tt API")
+	})
+
+	t.Run("defaults to the local address", func(t *testing.T) {
+		assert.Equal(t, defaultServeAddr, NewServeCommand(app).options.Addr)
+	})
+
+	t.Run("rejects arguments", func(t *testing.T) {
+		assert.Error(t, NewServeCommand(app).Execute(context.Background(), []string{"7070"}))
+	})
+}
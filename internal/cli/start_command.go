@@ -4,21 +4,40 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
 	"time-tracker/internal/errors"
 )
 
+// StartOptions holds the flags accepted by the start command
+type StartOptions struct {
+	Project string // Existing project to file the task under (e.g. "acme/website")
+	Note    string // Note for the new time entry
+	At      string // When work started, e.g. "09:15" or "yesterday 17:00"; now when empty
+	Ago     string // How long ago work started, e.g. "10m"; excludes At
+}
+
 // StartCommand handles the start command
 type StartCommand struct {
 	businessAPI  api.BusinessAPI
 	errorHandler *ErrorHandler
+	printer      *Printer
+	options      StartOptions
 }
 
 // NewStartCommand creates a new start command handler
 func NewStartCommand(app *App) *StartCommand {
+	return NewStartCommandWithOptions(app, StartOptions{})
+}
+
+// NewStartCommandWithOptions creates a new start command handler with the given flag values
+func NewStartCommandWithOptions(app *App, options StartOptions) *StartCommand {
 	return &StartCommand{
 		businessAPI:  app.businessAPI,
 		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		options:      options,
 	}
 }
 
@@ -27,26 +46,54 @@ func (c *StartCommand) Execute(ctx context.Context, args []string) error {
 	if len(args) < 1 {
 		return errors.NewInvalidInputError("command", "start", "usage: tt start \"your text here\"")
 	}
-	text := strings.Join(args, " ")
-	return c.createNewTask(ctx, text)
+	text, tags := domain.ExtractTags(strings.Join(args, " "))
+	if len(tags) > 0 && strings.TrimSpace(text) == "" {
+		return errors.NewInvalidInputError("task", strings.Join(args, " "), "a task name is needed besides its tags")
+	}
+	at, err := resolveAt(c.options.At, c.options.Ago, timeNow())
+	if err != nil {
+		return err
+	}
+	return c.createNewTask(ctx, text, tags, at)
 }
 
-// createNewTask creates a new task
-func (c *StartCommand) createNewTask(ctx context.Context, taskName string) error {
+// createNewTask creates a new task, tagging its first time entry with tags. The entry starts at at, or now when nil.
+func (c *StartCommand) createNewTask(ctx context.Context, taskName string, tags []string, at *time.Time) error {
 	// Check if there's a current running task to maintain backward compatibility
 	currentSession, err := c.businessAPI.GetCurrentSession(ctx)
 	hasRunningTask := err == nil && currentSession != nil
 
 	// Use BusinessAPI's StartNewTask which handles stopping running tasks automatically
-	session, err := c.businessAPI.StartNewTask(ctx, taskName)
+	session, err := c.businessAPI.StartNewTaskWithOptions(ctx, taskName, api.StartOptions{
+		Project: c.options.Project,
+		Tags:    tags,
+		Note:    c.options.Note,
+		At:      at,
+	})
 	if err != nil {
 		return c.errorHandler.Handle("start task", err)
 	}
 
+	if c.printer.IsStructured() {
+		record := newEntryRecord(session.TimeEntry, session.Task)
+		record.Project = c.options.Project
+		return c.printer.Emit(record)
+	}
+
 	// Show stopping message if there was a running task (for e2e test compatibility)
 	if hasRunningTask {
 		fmt.Println("All running tasks have been stopped")
 	}
-	fmt.Printf("Started new task: %s\n", session.Task.TaskName)
+	started := session.Task.TaskName
+	if c.options.Project != "" {
+		started += " (" + c.options.Project + ")"
+	}
+	if session.TimeEntry != nil && len(session.TimeEntry.Tags) > 0 {
+		started += " " + formatTags(session.TimeEntry.Tags)
+	}
+	fmt.Printf("Started new task: %s\n", started)
+	if session.TimeEntry != nil && session.TimeEntry.Note != "" {
+		fmt.Printf("Note: %s\n", session.TimeEntry.Note)
+	}
 	return nil
 }
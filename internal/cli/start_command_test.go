@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -79,7 +80,7 @@ func TestStartCommand_CreateNewTask(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("creates task when none running", func(t *testing.T) {
-		err := cmd.createNewTask(ctx, "New Task")
+		err := cmd.createNewTask(ctx, "New Task", nil, nil)
 		assert.NoError(t, err)
 
 		session, err := app.businessAPI.GetCurrentSession(ctx)
@@ -93,7 +94,7 @@ func TestStartCommand_CreateNewTask(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create new task - should stop previous
-		err = cmd.createNewTask(ctx, "New Task")
+		err = cmd.createNewTask(ctx, "New Task", nil, nil)
 		assert.NoError(t, err)
 
 		session, err := app.businessAPI.GetCurrentSession(ctx)
@@ -111,4 +112,80 @@ func TestNewStartCommand(t *testing.T) {
 	assert.NotNil(t, cmd)
 	assert.NotNil(t, cmd.businessAPI)
 	assert.NotNil(t, cmd.errorHandler)
-}
\ No newline at end of file
+}
+
+func TestStartCommand_At(t *testing.T) {
+	ctx := context.Background()
+	app, cleanup := setupTestAppWithMockBusinessAPI(t)
+	defer cleanup()
+
+	t.Run("starts the entry at the given time", func(t *testing.T) {
+		at := time.Now().Add(-15 * time.Minute).Truncate(time.Second)
+		cmd := NewStartCommandWithOptions(app, StartOptions{At: at.Format("2006-01-02 15:04:05")})
+		require.NoError(t, cmd.Execute(ctx, []string{"Code review"}))
+
+		session, err := app.businessAPI.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		assert.True(t, at.Equal(session.TimeEntry.StartTime))
+	})
+
+	t.Run("rejects --at together with --ago", func(t *testing.T) {
+		cmd := NewStartCommandWithOptions(app, StartOptions{At: "09:00", Ago: "10m"})
+		err := cmd.Execute(ctx, []string{"Code review"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be used together")
+	})
+}
+
+func TestStartCommand_Tags(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		args         []string
+		expectedTask string
+		expectedTags []string
+	}{
+		{
+			name:         "plus and hash prefixes",
+			args:         []string{"Review", "PR", "+Billable", "#meeting"},
+			expectedTask: "Review PR",
+			expectedTags: []string{"billable", "meeting"},
+		},
+		{
+			name:         "quoted tags",
+			args:         []string{"Customer call #support +Support"},
+			expectedTask: "Customer call",
+			expectedTags: []string{"support"},
+		},
+		{
+			name:         "no tags",
+			args:         []string{"Plain task"},
+			expectedTask: "Plain task",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, cleanup := setupTestAppWithMockBusinessAPI(t)
+			defer cleanup()
+
+			require.NoError(t, NewStartCommand(app).Execute(ctx, tt.args))
+
+			starts := mockAPI(app).starts
+			require.Len(t, starts, 1)
+			assert.Equal(t, tt.expectedTask, starts[0].taskName)
+			assert.Equal(t, tt.expectedTags, starts[0].options.Tags)
+		})
+	}
+
+	t.Run("requires a task name besides tags", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		err := NewStartCommand(app).Execute(ctx, []string{"+billable"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "task name")
+		assert.Empty(t, mockAPI(app).starts)
+	})
+}
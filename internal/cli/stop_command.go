@@ -3,36 +3,74 @@ package cli
 import (
 	"context"
 	"fmt"
+	"time"
 	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
 	"time-tracker/internal/errors"
 )
 
+// StopOptions holds the flags accepted by the stop command
+type StopOptions struct {
+	At  string // When work stopped, e.g. "17:30" or "yesterday 17:00"; now when empty
+	Ago string // How long ago work stopped, e.g. "10m"; excludes At
+}
+
 // StopCommand handles the stop command
 type StopCommand struct {
 	businessAPI api.BusinessAPI
+	printer     *Printer
+	options     StopOptions
 }
 
 // NewStopCommand creates a new stop command handler
 func NewStopCommand(app *App) *StopCommand {
-	return &StopCommand{businessAPI: app.businessAPI}
+	return NewStopCommandWithOptions(app, StopOptions{})
+}
+
+// NewStopCommandWithOptions creates a new stop command handler with the given flag values
+func NewStopCommandWithOptions(app *App, options StopOptions) *StopCommand {
+	return &StopCommand{
+		businessAPI: app.businessAPI,
+		printer:     app.newPrinter(),
+		options:     options,
+	}
 }
 
 // Execute runs the stop command
 func (c *StopCommand) Execute(ctx context.Context, args []string) error {
 	if len(args) != 0 {
-		return errors.NewInvalidInputError("command", "stop", "usage: tt stop")
+		return errors.NewInvalidInputError("command", "stop", "usage: tt stop [--at TIME | --ago DURATION]")
 	}
-	return c.stopRunningTasks(ctx)
+	at, err := resolveAt(c.options.At, c.options.Ago, timeNow())
+	if err != nil {
+		return err
+	}
+	return c.stopRunningTasks(ctx, at)
 }
 
-// stopRunningTasks marks all running tasks as complete
-func (c *StopCommand) stopRunningTasks(ctx context.Context) error {
-	// Use BusinessAPI's StopAllRunningTasks
-	_, err := c.businessAPI.StopAllRunningTasks(ctx)
+// stopRunningTasks marks all running tasks as complete at at, or now when nil
+func (c *StopCommand) stopRunningTasks(ctx context.Context, at *time.Time) error {
+	var stopped []*domain.TimeEntry
+	var err error
+	if at != nil {
+		stopped, err = c.businessAPI.StopAllRunningTasksAt(ctx, *at)
+	} else {
+		stopped, err = c.businessAPI.StopAllRunningTasks(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to stop running tasks: %w", err)
 	}
 
+	if c.printer.IsStructured() {
+		records := make([]entryRecord, 0, len(stopped))
+		for _, entry := range stopped {
+			// The task name is informational; an entry is still reported if its task cannot be loaded
+			task, _ := c.businessAPI.GetTask(ctx, entry.TaskID)
+			records = append(records, newEntryRecord(entry, task))
+		}
+		return c.printer.Emit(records)
+	}
+
 	// Always show the same message for backward compatibility with e2e tests
 	fmt.Println("All running tasks have been stopped")
 	return nil
@@ -3,6 +3,10 @@ package cli
 import (
 	"context"
 	"testing"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -56,6 +60,35 @@ func TestStopCommand_Execute(t *testing.T) {
 		assert.NoError(t, err) // Should not error
 	})
 
+	t.Run("stops at an earlier time", func(t *testing.T) {
+		session, err := app.businessAPI.StartNewTaskWithOptions(ctx, "Backdated Task", api.StartOptions{At: timePtr(time.Now().Add(-time.Hour))})
+		require.NoError(t, err)
+
+		err = NewStopCommandWithOptions(app, StopOptions{Ago: "10m"}).Execute(ctx, nil)
+		require.NoError(t, err)
+
+		_, err = app.businessAPI.GetCurrentSession(ctx)
+		assert.Error(t, err, "Nothing should be running after the stop")
+		stopped, err := app.businessAPI.GetTimeEntry(ctx, session.TimeEntry.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stopped.TimeEntry.EndTime)
+		assert.WithinDuration(t, time.Now().Add(-10*time.Minute), *stopped.TimeEntry.EndTime, 5*time.Second)
+	})
+
+	t.Run("reports stops the service rejects", func(t *testing.T) {
+		_, err := app.businessAPI.StartNewTask(ctx, "Just Started")
+		require.NoError(t, err)
+		mockAPI(app).failures["StopAllRunningTasksAt"] = errors.NewValidationError("cannot stop entry 1 before it started", nil)
+		defer func() {
+			delete(mockAPI(app).failures, "StopAllRunningTasksAt")
+			_, _ = app.businessAPI.StopAllRunningTasks(ctx)
+		}()
+
+		err = NewStopCommandWithOptions(app, StopOptions{Ago: "1h"}).Execute(ctx, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "before it started")
+	})
+
 	t.Run("rejects arguments", func(t *testing.T) {
 		err := cmd.Execute(ctx, []string{"unexpected", "args"})
 		assert.Error(t, err)
@@ -76,7 +109,7 @@ func TestStopCommand_StopRunningTasks(t *testing.T) {
 		require.NoError(t, err)
 
 		// Stop it
-		err = cmd.stopRunningTasks(ctx)
+		err = cmd.stopRunningTasks(ctx, nil)
 		assert.NoError(t, err)
 
 		// Verify it's stopped
@@ -89,7 +122,7 @@ func TestStopCommand_StopRunningTasks(t *testing.T) {
 		_, _ = app.businessAPI.StopAllRunningTasks(ctx)
 
 		// Stop when nothing is running
-		err := cmd.stopRunningTasks(ctx)
+		err := cmd.stopRunningTasks(ctx, nil)
 		assert.NoError(t, err)
 	})
 }
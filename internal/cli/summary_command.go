@@ -3,22 +3,40 @@ package cli
 import (
 	"context"
 	"fmt"
-	"os"
-	"strconv"
 	"strings"
+	"time"
 
 	"time-tracker/internal/api"
-	"time-tracker/internal/errors"
 )
 
+// SummaryOptions holds the flags accepted by the summary command
+type SummaryOptions struct {
+	Project         string        // Summarize this project and its sub-projects instead of a single task
+	Tags            []string      // Only consider tasks with entries carrying every one of these tags
+	ExcludeTags     []string      // Leave out entries carrying any of these tags
+	Select          TaskSelection // Picks the task without prompting
+	IncludeArchived bool          // Also offer archived tasks
+}
+
 // SummaryCommand handles the summary command
 type SummaryCommand struct {
 	businessAPI api.BusinessAPI
+	printer     *Printer
+	options     SummaryOptions
 }
 
 // NewSummaryCommand creates a new summary command handler
 func NewSummaryCommand(app *App) *SummaryCommand {
-	return &SummaryCommand{businessAPI: app.businessAPI}
+	return NewSummaryCommandWithOptions(app, SummaryOptions{})
+}
+
+// NewSummaryCommandWithOptions creates a new summary command handler with the given flag values
+func NewSummaryCommandWithOptions(app *App, options SummaryOptions) *SummaryCommand {
+	return &SummaryCommand{
+		businessAPI: app.businessAPI,
+		printer:     app.newPrinter(),
+		options:     options,
+	}
 }
 
 // Execute runs the summary command
@@ -33,9 +51,9 @@ func (c *SummaryCommand) summaryTask(ctx context.Context, args []string) error {
 	var textFilter string
 
 	if len(args) > 0 {
-		// Check if first argument is a time shorthand
-		if _, err := parseTimeShorthand(args[0]); err == nil {
-			// Time shorthand found
+		// Check if first argument is a time range
+		if _, err := c.businessAPI.ParseTimeRange(ctx, args[0]); err == nil {
+			// Time range found
 			timeRange = args[0]
 
 			// If there are more arguments, use them as search text
@@ -43,48 +61,78 @@ func (c *SummaryCommand) summaryTask(ctx context.Context, args []string) error {
 				textFilter = strings.Join(args[1:], " ")
 			}
 		} else {
-			// No time shorthand, treat all arguments as search text
+			// No time range, treat all arguments as search text
 			textFilter = strings.Join(args, " ")
 		}
 	}
 
+	filter := api.TimeEntryFilter{
+		TimeRange:       timeRange,
+		Text:            textFilter,
+		Project:         c.options.Project,
+		Tags:            c.options.Tags,
+		ExcludeTags:     c.options.ExcludeTags,
+		IncludeArchived: c.options.IncludeArchived,
+	}
+	if c.options.Project != "" {
+		return c.showProjectSummary(ctx, filter)
+	}
+
 	// Search for tasks using BusinessAPI
-	tasks, err := c.businessAPI.SearchTasks(ctx, timeRange, textFilter, api.SortByName)
+	tasks, err := c.businessAPI.SearchTasksWithFilter(ctx, filter, api.SortByName)
 	if err != nil {
 		return fmt.Errorf("failed to search tasks: %w", err)
 	}
 
-	if len(tasks) == 0 {
-		fmt.Println("No tasks found matching the criteria.")
+	if len(tasks) == 0 && !c.options.Select.IsSet() {
+		c.printer.Infof("No tasks found matching the criteria.\n")
 		return nil
 	}
 
 	// If only one task, show its summary directly
-	if len(tasks) == 1 {
+	if len(tasks) == 1 && !c.options.Select.IsSet() {
 		return c.showTaskSummary(ctx, tasks[0].Task.ID)
 	}
 
 	// Multiple tasks found, let user choose
-	fmt.Println("Select a task to summarize:")
-	for i, task := range tasks {
-		fmt.Printf("%d. %s\n", i+1, task.Task.TaskName)
+	picker := &taskPicker{
+		businessAPI: c.businessAPI,
+		printer:     c.printer,
+		command:     "summary",
+		verb:        "summarize",
+		cancelled:   "Summary cancelled.",
+		describe: func(task *api.TaskActivity) string {
+			return task.Task.TaskName
+		},
+	}
+	selectedTask, err := picker.pick(ctx, c.options.Select, tasks)
+	if err != nil || selectedTask == nil {
+		return err
 	}
-	fmt.Print("Enter number to summarize, or 'q' to quit: ")
 
-	// Read user input
-	var input string
-	fmt.Fscanln(os.Stdin, &input)
-	if input == "q" || input == "Q" {
-		fmt.Println("Summary cancelled.")
-		return nil
+	return c.showTaskSummary(ctx, selectedTask.ID)
+}
+
+// showProjectSummary displays the time spent per project, rolled up to the selected project
+func (c *SummaryCommand) showProjectSummary(ctx context.Context, filter api.TimeEntryFilter) error {
+	totals, err := c.businessAPI.GetProjectTotals(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to get project summary: %w", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(totals)
 	}
-	idx, err := strconv.Atoi(input)
-	if err != nil || idx < 1 || idx > len(tasks) {
-		return errors.NewInvalidInputError("selection", input, "invalid selection")
+
+	if len(totals) == 0 {
+		fmt.Println("No time entries found for this project.")
+		return nil
 	}
-	selectedTask := tasks[idx-1]
 
-	return c.showTaskSummary(ctx, selectedTask.Task.ID)
+	fmt.Printf("\nSummary for project: %s\n", filter.Project)
+	fmt.Println(strings.Repeat("=", len(filter.Project)+21))
+	printProjectTotals(totals)
+	return nil
 }
 
 // showTaskSummary displays a detailed summary for a specific task
@@ -95,6 +143,10 @@ func (c *SummaryCommand) showTaskSummary(ctx context.Context, taskID int64) erro
 		return fmt.Errorf("failed to get task summary: %w", err)
 	}
 
+	if c.printer.IsStructured() {
+		return c.printer.Emit(newSummaryRecord(summary))
+	}
+
 	// Print summary header
 	fmt.Printf("\nSummary for: %s\n", summary.Task.TaskName)
 	fmt.Println(strings.Repeat("=", len(summary.Task.TaskName)+12))
@@ -103,6 +155,18 @@ func (c *SummaryCommand) showTaskSummary(ctx context.Context, taskID int64) erro
 	fmt.Printf("%-20s %-20s %-15s %s\n", "Start Time", "End Time", "Duration", "Status")
 	fmt.Println(strings.Repeat("-", 75))
 
+	// Entries that a later segment continues ended with a pause
+	continued := make(map[int64]*time.Time)
+	for _, entry := range summary.TimeEntries {
+		if entry.ContinuesID != nil {
+			continued[*entry.ContinuesID] = &entry.StartTime
+		}
+	}
+	endByID := make(map[int64]*time.Time, len(summary.TimeEntries))
+	for _, entry := range summary.TimeEntries {
+		endByID[entry.ID] = entry.EndTime
+	}
+
 	// Print each session
 	for _, entry := range summary.TimeEntries {
 		startStr := entry.StartTime.Format("2006-01-02 15:04:05")
@@ -115,6 +179,9 @@ func (c *SummaryCommand) showTaskSummary(ctx context.Context, taskID int64) erro
 			minutes := int(duration.Minutes()) % 60
 			durationStr = fmt.Sprintf("%dh %dm", hours, minutes)
 			status = "Completed"
+			if _, isContinued := continued[entry.ID]; isContinued || entry.Paused {
+				status = "Paused"
+			}
 		} else {
 			endStr = "running"
 			duration := timeNow().Sub(entry.StartTime)
@@ -124,6 +191,14 @@ func (c *SummaryCommand) showTaskSummary(ctx context.Context, taskID int64) erro
 			status = "Running"
 		}
 
+		if entry.ContinuesID != nil {
+			if previousEnd := endByID[*entry.ContinuesID]; previousEnd != nil {
+				status += fmt.Sprintf(" (continued after %s)", formatDurationHuman(entry.StartTime.Sub(*previousEnd)))
+			}
+		}
+		if len(entry.Tags) > 0 {
+			status += " " + formatTags(entry.Tags)
+		}
 		fmt.Printf("%-20s %-20s %-15s %s\n", startStr, endStr, durationStr, status)
 	}
 
@@ -135,12 +210,22 @@ func (c *SummaryCommand) showTaskSummary(ctx context.Context, taskID int64) erro
 	latestStr := summary.LastEntry.Format("2006-01-02 15:04:05")
 
 	fmt.Printf("Total Sessions: %d", summary.SessionCount)
+	if summary.PauseCount == 1 {
+		fmt.Printf(", 1 pause, %s paused", formatDurationHuman(summary.PausedDuration))
+	} else if summary.PauseCount > 1 {
+		fmt.Printf(", %d pauses, %s paused", summary.PauseCount, formatDurationHuman(summary.PausedDuration))
+	}
 	if summary.RunningCount > 0 {
 		fmt.Printf(" (%d running)", summary.RunningCount)
+	} else if summary.IsPaused {
+		fmt.Printf(" (paused)")
 	}
 	fmt.Printf("\n")
 	fmt.Printf("Time Range: %s to %s\n", earliestStr, latestStr)
 	fmt.Printf("Total Time: %s\n", summary.TotalTime)
+	if len(summary.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", formatTags(summary.Tags))
+	}
 
 	return nil
 }
\ No newline at end of file
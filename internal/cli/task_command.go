@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/errors"
+)
+
+// TaskCommand handles the task management subcommands (rename, merge)
+type TaskCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+}
+
+// NewTaskCommand creates a new task command handler
+func NewTaskCommand(app *App) *TaskCommand {
+	return &TaskCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+	}
+}
+
+// Execute runs the task command, dispatching on the subcommand in args[0]
+func (c *TaskCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.NewInvalidInputError("command", "task", "usage: tt task rename <id|name> <new-name> or tt task merge <from> <into>")
+	}
+
+	switch args[0] {
+	case "rename":
+		return c.renameTask(ctx, args[1:])
+	case "merge":
+		return c.mergeTasks(ctx, args[1:])
+	default:
+		return errors.NewInvalidInputError("subcommand", args[0], "expected rename or merge")
+	}
+}
+
+// renameTask implements tt task rename <id|name> <new-name>
+func (c *TaskCommand) renameTask(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return errors.NewInvalidInputError("command", "task rename", "usage: tt task rename <id|name> <new-name>")
+	}
+
+	task, err := resolveTask(ctx, c.businessAPI, args[0])
+	if err != nil {
+		return c.errorHandler.Handle("rename task", err)
+	}
+
+	oldName := task.TaskName
+	renamed, err := c.businessAPI.UpdateTaskName(ctx, task.ID, args[1])
+	if err != nil {
+		return c.errorHandler.Handle("rename task", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(renamed)
+	}
+
+	fmt.Printf("Renamed task %d: %s -> %s\n", renamed.ID, oldName, renamed.TaskName)
+	return nil
+}
+
+// mergeTasks implements tt task merge <from> <into>
+func (c *TaskCommand) mergeTasks(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return errors.NewInvalidInputError("command", "task merge", "usage: tt task merge <from> <into>")
+	}
+
+	from, err := resolveTask(ctx, c.businessAPI, args[0])
+	if err != nil {
+		return c.errorHandler.Handle("merge tasks", err)
+	}
+	into, err := resolveTask(ctx, c.businessAPI, args[1])
+	if err != nil {
+		return c.errorHandler.Handle("merge tasks", err)
+	}
+
+	merge, err := c.businessAPI.MergeTasks(ctx, from.ID, into.ID)
+	if err != nil {
+		return c.errorHandler.Handle("merge tasks", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(merge)
+	}
+
+	fmt.Printf("Merged task %d (%s) into task %d (%s), moved %d time entries\n",
+		merge.From.ID, merge.From.TaskName, merge.Into.ID, merge.Into.TaskName, merge.MovedEntries)
+	return nil
+}
+
+// resolveTask looks a task up by numeric ID, falling back to its exact name
+func resolveTask(ctx context.Context, businessAPI api.BusinessAPI, ref string) (*domain.Task, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil && id > 0 {
+		return businessAPI.GetTask(ctx, id)
+	}
+	return businessAPI.GetTaskByName(ctx, ref)
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskCommand_Rename(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+
+	setup := func(t *testing.T) *App {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+
+		_, err := app.businessAPI.AddTimeEntry(ctx, "code reveiw", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		_, err = app.businessAPI.AddTimeEntry(ctx, "Planning", start.Add(time.Hour), start.Add(2*time.Hour))
+		require.NoError(t, err)
+		return app
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		failure     error
+		expectError string
+	}{
+		{name: "by id", args: []string{"rename", "1", "Code review"}},
+		{name: "by name", args: []string{"rename", "code reveiw", "Code review"}},
+		{name: "missing task", args: []string{"rename", "Unknown", "Code review"}, expectError: "not found"},
+		{
+			name:        "name rejected by the service",
+			args:        []string{"rename", "1", "Planning"},
+			failure:     errors.NewValidationError(`task name "Planning" is already used by task 2, merge the tasks instead`, nil),
+			expectError: "merge the tasks instead",
+		},
+		{name: "missing new name", args: []string{"rename", "1"}, expectError: "usage: tt task rename"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setup(t)
+			if tt.failure != nil {
+				mockAPI(app).failures["UpdateTaskName"] = tt.failure
+			}
+
+			err := NewTaskCommand(app).Execute(ctx, tt.args)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+
+			task, err := app.businessAPI.GetTask(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, "Code review", task.TaskName)
+		})
+	}
+}
+
+func TestTaskCommand_Merge(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+
+	setup := func(t *testing.T) *App {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+
+		_, err := app.businessAPI.AddTimeEntry(ctx, "code reveiw", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		_, err = app.businessAPI.AddTimeEntry(ctx, "Code review", start.Add(time.Hour), start.Add(2*time.Hour))
+		require.NoError(t, err)
+		return app
+	}
+
+	t.Run("moves entries and deletes the source task", func(t *testing.T) {
+		app := setup(t)
+
+		err := NewTaskCommand(app).Execute(ctx, []string{"merge", "code reveiw", "2"})
+		require.NoError(t, err)
+
+		_, err = app.businessAPI.GetTask(ctx, 1)
+		assert.Error(t, err)
+
+		entry, err := app.businessAPI.GetTimeEntry(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), entry.Task.ID)
+	})
+
+	t.Run("reports merges the service rejects", func(t *testing.T) {
+		app := setup(t)
+		mockAPI(app).failures["MergeTasks"] = errors.NewValidationError("cannot merge a task into itself", nil)
+
+		err := NewTaskCommand(app).Execute(ctx, []string{"merge", "1", "code reveiw"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "into itself")
+	})
+
+	t.Run("requires two tasks", func(t *testing.T) {
+		app := setup(t)
+
+		err := NewTaskCommand(app).Execute(ctx, []string{"merge", "1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "usage: tt task merge")
+	})
+}
+
+func TestTaskCommand_UnknownSubcommand(t *testing.T) {
+	app, cleanup := setupTestAppWithMockBusinessAPI(t)
+	defer cleanup()
+
+	err := NewTaskCommand(app).Execute(context.Background(), []string{"split"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected rename or merge")
+}
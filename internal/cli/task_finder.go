@@ -0,0 +1,305 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"time-tracker/internal/api"
+)
+
+// taskFinderRows is the number of tasks shown at once; the list scrolls to follow the cursor
+const taskFinderRows = 10
+
+// Keys understood by the task finder, decoded from the raw terminal input
+const (
+	keyNone = iota
+	keyRune
+	keyBackspace
+	keyClear
+	keyEnter
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyCancel
+)
+
+// taskFinder is a fuzzy-finder style picker: typing filters the tasks, the arrow keys move
+// the cursor and Enter selects. It expects a terminal in raw mode and draws below the cursor.
+type taskFinder struct {
+	in       *bufio.Reader
+	out      io.Writer
+	width    int                            // Terminal width; longer lines are cut so they never wrap
+	title    string                         // Shown before the query, e.g. "Select a task to resume"
+	describe func(*api.TaskActivity) string // Formats a task in the list
+
+	tasks   []*api.TaskActivity
+	query   []rune
+	matches []*api.TaskActivity // Tasks matching the query, best match first
+	cursor  int                 // Index of the highlighted match
+	offset  int                 // Index of the first visible match
+}
+
+// newTaskFinder creates a finder over the tasks, which are listed in order until a query is typed
+func newTaskFinder(in io.Reader, out io.Writer, width int, title string, describe func(*api.TaskActivity) string, tasks []*api.TaskActivity) *taskFinder {
+	finder := &taskFinder{
+		in:       bufio.NewReader(in),
+		out:      out,
+		width:    width,
+		title:    title,
+		describe: describe,
+		tasks:    tasks,
+	}
+	finder.filter()
+	return finder
+}
+
+// run shows the finder until a task is selected or the user quits with Esc or Ctrl-C.
+// A nil task without an error means the user quit.
+func (f *taskFinder) run() (*api.TaskActivity, error) {
+	defer io.WriteString(f.out, "\r\x1b[J")
+
+	for {
+		if err := f.render(); err != nil {
+			return nil, err
+		}
+
+		key, r, err := f.readKey()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case keyRune:
+			f.query = append(f.query, r)
+			f.filter()
+		case keyBackspace:
+			if len(f.query) > 0 {
+				f.query = f.query[:len(f.query)-1]
+				f.filter()
+			}
+		case keyClear:
+			f.query = nil
+			f.filter()
+		case keyUp:
+			f.move(-1)
+		case keyDown:
+			f.move(1)
+		case keyPageUp:
+			f.move(-taskFinderRows)
+		case keyPageDown:
+			f.move(taskFinderRows)
+		case keyEnter:
+			if len(f.matches) > 0 {
+				return f.matches[f.cursor], nil
+			}
+		case keyCancel:
+			return nil, nil
+		}
+	}
+}
+
+// filter recomputes the matches for the current query and moves the cursor to the best one
+func (f *taskFinder) filter() {
+	type scored struct {
+		task  *api.TaskActivity
+		score int
+	}
+
+	var candidates []scored
+	for _, task := range f.tasks {
+		if score, ok := fuzzyScore(string(f.query), task.Task.TaskName); ok {
+			candidates = append(candidates, scored{task: task, score: score})
+		}
+	}
+	// Equal scores keep the order the tasks were given in, most recent first
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	f.matches = make([]*api.TaskActivity, len(candidates))
+	for i, candidate := range candidates {
+		f.matches[i] = candidate.task
+	}
+	f.cursor, f.offset = 0, 0
+}
+
+// move shifts the cursor by delta matches, scrolling the visible window along
+func (f *taskFinder) move(delta int) {
+	if len(f.matches) == 0 {
+		return
+	}
+	f.cursor = min(max(f.cursor+delta, 0), len(f.matches)-1)
+	if f.cursor < f.offset {
+		f.offset = f.cursor
+	}
+	if f.cursor >= f.offset+taskFinderRows {
+		f.offset = f.cursor - taskFinderRows + 1
+	}
+}
+
+// render redraws the finder below the cursor and leaves the cursor after the query
+func (f *taskFinder) render() error {
+	promptLine := f.fit(fmt.Sprintf("%s: %s", f.title, string(f.query)))
+	lines := []string{promptLine}
+
+	end := min(f.offset+taskFinderRows, len(f.matches))
+	for i := f.offset; i < end; i++ {
+		if i == f.cursor {
+			lines = append(lines, "\x1b[7m"+f.fit("> "+f.describe(f.matches[i]))+"\x1b[0m")
+		} else {
+			lines = append(lines, f.fit("  "+f.describe(f.matches[i])))
+		}
+	}
+
+	if len(f.matches) == 0 {
+		lines = append(lines, f.fit("  no matching tasks"))
+	} else {
+		lines = append(lines, f.fit(fmt.Sprintf("  [%d/%d] %s", f.cursor+1, len(f.matches), taskPreview(f.matches[f.cursor]))))
+	}
+	lines = append(lines, f.fit("  type to filter, ↑/↓ to move, Enter to select, Esc to quit"))
+
+	var b strings.Builder
+	b.WriteString("\r\x1b[J")
+	b.WriteString(strings.Join(lines, "\r\n"))
+	fmt.Fprintf(&b, "\x1b[%dA\r", len(lines)-1)
+	if column := len([]rune(promptLine)); column > 0 {
+		fmt.Fprintf(&b, "\x1b[%dC", column)
+	}
+
+	_, err := io.WriteString(f.out, b.String())
+	return err
+}
+
+// fit cuts a line to the terminal width so it never wraps, which would break redrawing
+func (f *taskFinder) fit(line string) string {
+	runes := []rune(line)
+	if f.width <= 1 || len(runes) < f.width {
+		return line
+	}
+	return string(runes[:f.width-2]) + "…"
+}
+
+// readKey reads one key press, decoding the escape sequences sent for arrow and paging keys
+func (f *taskFinder) readKey() (int, rune, error) {
+	r, _, err := f.in.ReadRune()
+	if err != nil {
+		return keyNone, 0, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return keyEnter, 0, nil
+	case 0x7f, 0x08: // Backspace, Ctrl-H
+		return keyBackspace, 0, nil
+	case 0x15: // Ctrl-U
+		return keyClear, 0, nil
+	case 0x10: // Ctrl-P
+		return keyUp, 0, nil
+	case 0x0e: // Ctrl-N
+		return keyDown, 0, nil
+	case 0x03, 0x04, 0x07: // Ctrl-C, Ctrl-D, Ctrl-G
+		return keyCancel, 0, nil
+	case 0x1b:
+		return f.readEscape()
+	}
+
+	if unicode.IsPrint(r) {
+		return keyRune, r, nil
+	}
+	return keyNone, 0, nil
+}
+
+// readEscape decodes the rest of an escape sequence. A lone Esc, with nothing sent along with it, quits.
+func (f *taskFinder) readEscape() (int, rune, error) {
+	if f.in.Buffered() == 0 {
+		return keyCancel, 0, nil
+	}
+
+	introducer, err := f.in.ReadByte()
+	if err != nil {
+		return keyNone, 0, err
+	}
+	if introducer != '[' && introducer != 'O' {
+		return keyNone, 0, nil
+	}
+
+	// Parameters run until a final byte in the range @ to ~
+	var sequence []byte
+	for {
+		b, err := f.in.ReadByte()
+		if err != nil {
+			return keyNone, 0, err
+		}
+		sequence = append(sequence, b)
+		if b >= 0x40 && b <= 0x7e {
+			break
+		}
+	}
+
+	switch string(sequence) {
+	case "A":
+		return keyUp, 0, nil
+	case "B":
+		return keyDown, 0, nil
+	case "5~":
+		return keyPageUp, 0, nil
+	case "6~":
+		return keyPageDown, 0, nil
+	}
+	return keyNone, 0, nil
+}
+
+// taskPreview describes the highlighted task: when it was last worked on and its total time
+func taskPreview(task *api.TaskActivity) string {
+	preview := fmt.Sprintf("last worked %s, %s in %d", task.LastWorked.Local().Format("2006-01-02 15:04"), task.TotalTime, task.SessionCount)
+	if task.SessionCount == 1 {
+		preview += " session"
+	} else {
+		preview += " sessions"
+	}
+	if task.IsRunning {
+		preview += ", running"
+	}
+	return preview
+}
+
+// fuzzyScore reports whether the letters of the query appear in the text in order, ignoring case
+// and spaces in the query. Runs of consecutive letters and letters starting a word score higher.
+func fuzzyScore(query, text string) (int, bool) {
+	var needle []rune
+	for _, r := range strings.ToLower(query) {
+		if !unicode.IsSpace(r) {
+			needle = append(needle, r)
+		}
+	}
+	if len(needle) == 0 {
+		return 0, true
+	}
+
+	haystack := []rune(strings.ToLower(text))
+	score, matched, previous := 0, 0, -2
+	for i, r := range haystack {
+		if matched == len(needle) {
+			break
+		}
+		if r != needle[matched] {
+			continue
+		}
+
+		score++
+		if i == previous+1 {
+			score += 2
+		}
+		if i == 0 || !unicode.IsLetter(haystack[i-1]) && !unicode.IsDigit(haystack[i-1]) {
+			score += 3
+		}
+		previous = i
+		matched++
+	}
+	return score, matched == len(needle)
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		text    string
+		matches bool
+	}{
+		{"empty query matches everything", "", "Code review", true},
+		{"letters in order", "crv", "Code review", true},
+		{"ignores case", "CODE", "code review", true},
+		{"ignores spaces in the query", "code rev", "Code review", true},
+		{"letters out of order", "vrc", "Code review", false},
+		{"missing letter", "codez", "Code review", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := fuzzyScore(tt.query, tt.text)
+			assert.Equal(t, tt.matches, ok)
+		})
+	}
+
+	t.Run("prefers word starts and consecutive letters", func(t *testing.T) {
+		wordStart, _ := fuzzyScore("lb", "Fix login bug")
+		scattered, _ := fuzzyScore("lb", "Global cables")
+		assert.Greater(t, wordStart, scattered)
+
+		consecutive, _ := fuzzyScore("rev", "Code review")
+		apart, _ := fuzzyScore("rev", "Read overview")
+		assert.Greater(t, consecutive, apart)
+	})
+}
+
+func TestTaskFinder(t *testing.T) {
+	lastWorked := time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)
+	names := []string{"Write docs", "Fix login bug", "Code review", "Release notes"}
+	var tasks []*api.TaskActivity
+	for i, name := range names {
+		tasks = append(tasks, &api.TaskActivity{
+			Task:         &domain.Task{ID: int64(i + 1), TaskName: name},
+			LastWorked:   lastWorked,
+			TotalTime:    "1h 30m",
+			SessionCount: 2,
+		})
+	}
+	describe := func(task *api.TaskActivity) string { return task.Task.TaskName }
+
+	run := func(t *testing.T, input string) (*api.TaskActivity, string) {
+		var out bytes.Buffer
+		finder := newTaskFinder(strings.NewReader(input), &out, 80, "Select a task to resume", describe, tasks)
+		selected, err := finder.run()
+		require.NoError(t, err)
+		return selected, out.String()
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"enter selects the first task", "\r", "Write docs"},
+		{"down arrow moves the cursor", "\x1b[B\x1b[B\r", "Code review"},
+		{"up arrow stops at the top", "\x1b[B\x1b[A\x1b[A\r", "Write docs"},
+		{"down arrow stops at the bottom", strings.Repeat("\x1b[B", 10) + "\r", "Release notes"},
+		{"ctrl-n and ctrl-p move too", "\x0e\x0e\x10\r", "Fix login bug"},
+		{"typing filters the tasks", "login\r", "Fix login bug"},
+		{"fuzzy query", "rlnt\r", "Release notes"},
+		{"best match comes first", "ie\r", "Code review"},
+		{"backspace widens the filter again", "loginx\x7f\r", "Fix login bug"},
+		{"ctrl-u clears the query", "zzz\x15\r", "Write docs"},
+		{"enter without matches waits for a match", "zzz\r\x15\r", "Write docs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, _ := run(t, tt.input)
+			require.NotNil(t, selected)
+			assert.Equal(t, tt.expected, selected.Task.TaskName)
+		})
+	}
+
+	t.Run("escape and ctrl-c quit", func(t *testing.T) {
+		for _, input := range []string{"\x1b", "log\x03"} {
+			selected, _ := run(t, input)
+			assert.Nil(t, selected)
+		}
+	})
+
+	t.Run("shows the query, matches and a preview", func(t *testing.T) {
+		_, out := run(t, "doc\r")
+		assert.Contains(t, out, "Select a task to resume: doc")
+		assert.Contains(t, out, "> Write docs")
+		assert.Contains(t, out, "[1/1] last worked 2026-10-16 09:30, 1h 30m in 2 sessions")
+	})
+
+	t.Run("says when nothing matches", func(t *testing.T) {
+		_, out := run(t, "zzz\x1b")
+		assert.Contains(t, out, "no matching tasks")
+	})
+
+	t.Run("scrolls long lists", func(t *testing.T) {
+		var many []*api.TaskActivity
+		for i := 1; i <= 40; i++ {
+			many = append(many, &api.TaskActivity{Task: &domain.Task{ID: int64(i), TaskName: fmt.Sprintf("Task %02d", i)}})
+		}
+		var out bytes.Buffer
+		finder := newTaskFinder(strings.NewReader(strings.Repeat("\x1b[6~", 2)+"\x1b[B\r"), &out, 80, "Select a task to resume", describe, many)
+
+		selected, err := finder.run()
+		require.NoError(t, err)
+		assert.Equal(t, "Task 22", selected.Task.TaskName)
+		assert.Equal(t, 12, finder.offset)
+	})
+
+	t.Run("cuts lines to the terminal width", func(t *testing.T) {
+		var out bytes.Buffer
+		finder := newTaskFinder(strings.NewReader("\r"), &out, 12, "Select a task to resume", describe, tasks)
+		_, err := finder.run()
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Select a t…")
+		assert.NotContains(t, out.String(), "Select a task")
+	})
+}
+
+func TestTaskPicker_Finder(t *testing.T) {
+	ctx := context.Background()
+	app, cleanup := setupTestAppWithMockBusinessAPI(t)
+	defer cleanup()
+
+	start := time.Now().Add(-3 * time.Hour)
+	for i, name := range []string{"Write docs", "Fix login bug"} {
+		from := start.Add(time.Duration(i) * time.Hour)
+		_, err := app.businessAPI.AddTimeEntry(ctx, name, from, from.Add(30*time.Minute))
+		require.NoError(t, err)
+	}
+
+	useFinder := func(t *testing.T, input string) *bytes.Buffer {
+		stubStdin(t, true, input)
+		restored := false
+		rawTerminal = func() (func(), error) {
+			return func() { restored = true }, nil
+		}
+		var out bytes.Buffer
+		previousOut := terminalOut
+		terminalOut = &out
+		t.Cleanup(func() {
+			terminalOut = previousOut
+			assert.True(t, restored, "terminal mode should be restored")
+		})
+		return &out
+	}
+
+	t.Run("resume uses the finder on a terminal", func(t *testing.T) {
+		out := useFinder(t, "docs\r")
+
+		require.NoError(t, NewResumeCommand(app).Execute(ctx, []string{"1d"}))
+		session, err := app.businessAPI.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Write docs", session.Task.TaskName)
+		assert.Contains(t, out.String(), "Select a task to resume: docs")
+	})
+
+	t.Run("quitting the finder cancels", func(t *testing.T) {
+		useFinder(t, "\x1b")
+
+		var info bytes.Buffer
+		cmd := NewDeleteCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatTable, &info, &info)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+		assert.Contains(t, info.String(), "Delete cancelled.")
+
+		tasks, err := app.businessAPI.SearchTasks(ctx, "", "", api.SortByRecentFirst)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+	})
+}
@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/errors"
+)
+
+// stdin is where interactive prompts read their answers from; it can be replaced in tests
+var stdin io.Reader = os.Stdin
+
+// stdinIsTerminal reports whether prompts can be answered interactively; it can be replaced in tests
+var stdinIsTerminal = func() bool {
+	return isTerminal(os.Stdin)
+}
+
+// terminalOut is where the task finder draws; it can be replaced in tests
+var terminalOut io.Writer = os.Stderr
+
+// rawTerminal switches stdin to raw mode for the task finder and returns a function restoring it;
+// it can be replaced in tests. It fails when stderr, where the finder draws, is not a terminal.
+var rawTerminal = func() (func(), error) {
+	if !isTerminal(os.Stderr) {
+		return nil, fmt.Errorf("stderr is not a terminal")
+	}
+	return makeRaw(os.Stdin)
+}
+
+// TaskSelection holds the flags that select a task without prompting, so commands can run unattended
+type TaskSelection struct {
+	ID   int64  // Select the task with this ID
+	Name string // Select the task with this exact name, or the only task whose name contains it
+	Last bool   // Select the most recently worked task
+}
+
+// IsSet reports whether any selection flag was given
+func (s TaskSelection) IsSet() bool {
+	return s.ID != 0 || s.Name != "" || s.Last
+}
+
+// validate rejects combinations of selection flags
+func (s TaskSelection) validate() error {
+	count := 0
+	if s.ID != 0 {
+		count++
+	}
+	if s.Name != "" {
+		count++
+	}
+	if s.Last {
+		count++
+	}
+	if count > 1 {
+		return errors.NewInvalidInputError("selection", "", "use only one of --id, --name and --last")
+	}
+	if s.ID < 0 {
+		return errors.NewInvalidInputError("id", strconv.FormatInt(s.ID, 10), "task IDs are positive numbers")
+	}
+	return nil
+}
+
+// taskPicker chooses the task a command works on, from selection flags or interactively
+// with the task finder, or a numbered list where the terminal cannot run the finder
+type taskPicker struct {
+	businessAPI api.BusinessAPI
+	printer     *Printer
+	command     string                         // Command name, for hints such as "tt resume --last"
+	verb        string                         // Completes "Select a task to ..." in the prompt
+	cancelled   string                         // Printed when the prompt is quit
+	describe    func(*api.TaskActivity) string // Formats a task in the list
+}
+
+// pick returns the task chosen by the selection flags, or prompts among the candidates, which are listed in order.
+// A nil task without an error means the prompt was quit.
+func (p *taskPicker) pick(ctx context.Context, selection TaskSelection, candidates []*api.TaskActivity) (*domain.Task, error) {
+	if err := selection.validate(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case selection.ID != 0:
+		return p.businessAPI.GetTask(ctx, selection.ID)
+	case selection.Name != "":
+		return p.pickByName(ctx, selection.Name, candidates)
+	case selection.Last:
+		return mostRecentTask(candidates)
+	}
+
+	selected, err := p.prompt(candidates)
+	if err != nil || selected == nil {
+		return nil, err
+	}
+	return selected.Task, nil
+}
+
+// pickByName prefers an exact name match, then the only candidate whose name contains the text
+func (p *taskPicker) pickByName(ctx context.Context, name string, candidates []*api.TaskActivity) (*domain.Task, error) {
+	task, err := p.businessAPI.GetTaskByName(ctx, name)
+	if err == nil {
+		return task, nil
+	}
+	if !errors.IsErrorType(err, errors.ErrorTypeNotFound) {
+		return nil, err
+	}
+
+	needle := strings.ToLower(strings.TrimSpace(name))
+	var matches []*api.TaskActivity
+	for _, candidate := range candidates {
+		taskName := strings.ToLower(candidate.Task.TaskName)
+		if taskName == needle {
+			return candidate.Task, nil
+		}
+		if strings.Contains(taskName, needle) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return nil, errors.NewNotFoundError("task", name)
+	case len(matches) == 1:
+		return matches[0].Task, nil
+	case stdinIsTerminal():
+		selected, err := p.prompt(matches)
+		if err != nil || selected == nil {
+			return nil, err
+		}
+		return selected.Task, nil
+	}
+
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		names[i] = fmt.Sprintf("%q (id %d)", match.Task.TaskName, match.Task.ID)
+	}
+	return nil, errors.NewInvalidInputError("name", name,
+		fmt.Sprintf("matches %d tasks: %s; use --id or a more specific name", len(matches), strings.Join(names, ", ")))
+}
+
+// prompt lets the user choose among the candidates with the task finder, or the numbered list when
+// the terminal cannot be switched to raw mode. It fails with an invalid input error instead of
+// waiting for an answer when stdin is not a terminal.
+func (p *taskPicker) prompt(candidates []*api.TaskActivity) (*api.TaskActivity, error) {
+	if !stdinIsTerminal() {
+		return nil, errors.NewInvalidInputError("selection", "",
+			fmt.Sprintf("stdin is not a terminal; select the task with --id, --name or --last, e.g. tt %s --last", p.command))
+	}
+
+	restore, err := rawTerminal()
+	if err != nil {
+		return p.promptNumbered(candidates)
+	}
+	finder := newTaskFinder(stdin, terminalOut, terminalWidth(os.Stderr), "Select a task to "+p.verb, p.describeTask, candidates)
+	selected, err := finder.run()
+	restore()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selection: %w", err)
+	}
+	if selected == nil {
+		p.printer.Infof("%s\n", p.cancelled)
+	}
+	return selected, nil
+}
+
+// promptNumbered shows the numbered list of candidates and reads the number of the user's choice
+func (p *taskPicker) promptNumbered(candidates []*api.TaskActivity) (*api.TaskActivity, error) {
+	p.printer.Infof("Select a task to %s:\n", p.verb)
+	for i, candidate := range candidates {
+		p.printer.Infof("%d. %s\n", i+1, p.describeTask(candidate))
+	}
+	p.printer.Infof("Enter number to %s, or 'q' to quit: ", p.verb)
+
+	// Read user input
+	var input string
+	fmt.Fscanln(stdin, &input)
+	if input == "q" || input == "Q" {
+		p.printer.Infof("%s\n", p.cancelled)
+		return nil, nil
+	}
+	idx, err := strconv.Atoi(input)
+	if err != nil || idx < 1 || idx > len(candidates) {
+		return nil, errors.NewInvalidInputError("selection", input, "invalid selection")
+	}
+	return candidates[idx-1], nil
+}
+
+// describeTask formats a task for the prompt, marking archived tasks, which are only offered with --include-archived
+func (p *taskPicker) describeTask(task *api.TaskActivity) string {
+	if task.Task.IsArchived() {
+		return p.describe(task) + " [archived]"
+	}
+	return p.describe(task)
+}
+
+// confirm asks a yes/no question, answering no unless the user types y or yes.
+// It fails with an invalid input error when stdin is not a terminal, pointing at the --yes flag.
+func confirm(printer *Printer, question string) (bool, error) {
+	if !stdinIsTerminal() {
+		return false, errors.NewInvalidInputError("yes", "", "stdin is not a terminal; pass --yes to confirm")
+	}
+
+	printer.Infof("%s [y/N]: ", question)
+	var input string
+	fmt.Fscanln(stdin, &input)
+	answer := strings.ToLower(strings.TrimSpace(input))
+	return answer == "y" || answer == "yes", nil
+}
+
+// mostRecentTask returns the task worked on last among the candidates
+func mostRecentTask(candidates []*api.TaskActivity) (*domain.Task, error) {
+	if len(candidates) == 0 {
+		return nil, errors.NewNotFoundError("task", "last")
+	}
+	latest := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.LastWorked.After(latest.LastWorked) {
+			latest = candidate
+		}
+	}
+	return latest.Task, nil
+}
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubStdin replaces the prompt input for the duration of the test.
+// Prompts use the numbered list, as if the terminal could not run the task finder.
+func stubStdin(t *testing.T, terminal bool, input string) {
+	previousStdin, previousIsTerminal, previousRawTerminal := stdin, stdinIsTerminal, rawTerminal
+	stdin = strings.NewReader(input)
+	stdinIsTerminal = func() bool { return terminal }
+	rawTerminal = func() (func(), error) { return nil, fmt.Errorf("not a terminal") }
+	t.Cleanup(func() {
+		stdin, stdinIsTerminal, rawTerminal = previousStdin, previousIsTerminal, previousRawTerminal
+	})
+}
+
+func TestTaskSelection(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(-5 * time.Hour)
+
+	// Three stopped tasks; "Code review" was worked on last
+	setup := func(t *testing.T) (*App, map[string]int64) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		t.Cleanup(cleanup)
+
+		ids := make(map[string]int64)
+		for i, name := range []string{"Write docs", "Fix login bug", "Code review"} {
+			from := start.Add(time.Duration(i) * time.Hour)
+			entry, err := app.businessAPI.AddTimeEntry(ctx, name, from, from.Add(30*time.Minute))
+			require.NoError(t, err)
+			ids[name] = entry.Task.ID
+		}
+		return app, ids
+	}
+
+	runningTask := func(t *testing.T, app *App) string {
+		session, err := app.businessAPI.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		return session.Task.TaskName
+	}
+
+	t.Run("prompting without a terminal is an invalid input error", func(t *testing.T) {
+		stubStdin(t, false, "1\n")
+		tests := []struct {
+			name string
+			run  func(app *App) error
+		}{
+			{"resume", func(app *App) error { return NewResumeCommand(app).Execute(ctx, []string{"1d"}) }},
+			{"summary", func(app *App) error { return NewSummaryCommand(app).Execute(ctx, []string{}) }},
+			{"delete", func(app *App) error { return NewDeleteCommand(app).Execute(ctx, []string{}) }},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				app, _ := setup(t)
+				err := tt.run(app)
+				require.Error(t, err)
+				assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+				assert.Contains(t, err.Error(), "tt "+tt.name+" --last")
+			})
+		}
+	})
+
+	t.Run("prompts on a terminal", func(t *testing.T) {
+		stubStdin(t, true, "2\n")
+		app, _ := setup(t)
+
+		require.NoError(t, NewResumeCommand(app).Execute(ctx, []string{"1d"}))
+		assert.Equal(t, "Fix login bug", runningTask(t, app))
+	})
+
+	t.Run("resume --last picks the most recent task", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, _ := setup(t)
+
+		cmd := NewResumeCommandWithOptions(app, ResumeOptions{Select: TaskSelection{Last: true}})
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+		assert.Equal(t, "Code review", runningTask(t, app))
+	})
+
+	t.Run("resume --id picks that task", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, ids := setup(t)
+
+		cmd := NewResumeCommandWithOptions(app, ResumeOptions{Select: TaskSelection{ID: ids["Write docs"]}})
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+		assert.Equal(t, "Write docs", runningTask(t, app))
+	})
+
+	t.Run("--name matches exactly or by a unique part", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			selector string
+			expected string
+		}{
+			{"exact name", "Fix login bug", "Fix login bug"},
+			{"different case", "code REVIEW", "Code review"},
+			{"unique part", "docs", "Write docs"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				stubStdin(t, false, "")
+				app, _ := setup(t)
+
+				cmd := NewResumeCommandWithOptions(app, ResumeOptions{Select: TaskSelection{Name: tt.selector}})
+				require.NoError(t, cmd.Execute(ctx, []string{}))
+				assert.Equal(t, tt.expected, runningTask(t, app))
+			})
+		}
+	})
+
+	t.Run("an ambiguous --name lists the matches", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, _ := setup(t)
+
+		cmd := NewResumeCommandWithOptions(app, ResumeOptions{Select: TaskSelection{Name: "i"}})
+		err := cmd.Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+		assert.Contains(t, err.Error(), `"Fix login bug"`)
+	})
+
+	t.Run("an unknown --name is not found", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, _ := setup(t)
+
+		cmd := NewResumeCommandWithOptions(app, ResumeOptions{Select: TaskSelection{Name: "holiday"}})
+		err := cmd.Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeNotFound))
+	})
+
+	t.Run("only one selection flag is allowed", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, _ := setup(t)
+
+		cmd := NewSummaryCommandWithOptions(app, SummaryOptions{Select: TaskSelection{Name: "docs", Last: true}})
+		err := cmd.Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+	})
+
+	t.Run("summary --last shows the most recent task", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, _ := setup(t)
+
+		cmd := NewSummaryCommandWithOptions(app, SummaryOptions{Select: TaskSelection{Last: true}})
+		assert.NoError(t, cmd.Execute(ctx, []string{}))
+	})
+
+	t.Run("delete with a selector and --yes deletes without asking", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, ids := setup(t)
+
+		cmd := NewDeleteCommandWithOptions(app, DeleteOptions{Select: TaskSelection{Last: true}, Yes: true})
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+		_, err := app.businessAPI.GetTask(ctx, ids["Code review"])
+		assert.Error(t, err)
+	})
+
+	t.Run("delete with a selector needs --yes without a terminal", func(t *testing.T) {
+		stubStdin(t, false, "")
+		app, ids := setup(t)
+
+		cmd := NewDeleteCommandWithOptions(app, DeleteOptions{Select: TaskSelection{Last: true}})
+		err := cmd.Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+		assert.Contains(t, err.Error(), "--yes")
+		_, err = app.businessAPI.GetTask(ctx, ids["Code review"])
+		assert.NoError(t, err)
+	})
+
+	t.Run("delete with a selector asks for confirmation on a terminal", func(t *testing.T) {
+		tests := []struct {
+			answer  string
+			deleted bool
+		}{
+			{"y\n", true},
+			{"n\n", false},
+			{"\n", false},
+		}
+		for _, tt := range tests {
+			t.Run(strings.TrimSpace(tt.answer), func(t *testing.T) {
+				stubStdin(t, true, tt.answer)
+				app, ids := setup(t)
+
+				cmd := NewDeleteCommandWithOptions(app, DeleteOptions{Select: TaskSelection{Name: "Write docs"}})
+				require.NoError(t, cmd.Execute(ctx, []string{}))
+				_, err := app.businessAPI.GetTask(ctx, ids["Write docs"])
+				assert.Equal(t, tt.deleted, err != nil)
+			})
+		}
+	})
+}
@@ -0,0 +1,29 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// isTerminal reports whether the file is a character device other than the null device
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	devNull, err := os.Stat(os.DevNull)
+	return err != nil || !os.SameFile(info, devNull)
+}
+
+// makeRaw is not supported here, so interactive prompts use the numbered list
+func makeRaw(file *os.File) (func(), error) {
+	return nil, fmt.Errorf("raw terminal mode is not supported on %s", runtime.GOOS)
+}
+
+// terminalWidth returns 0 as the width is unknown
+func terminalWidth(file *os.File) int {
+	return 0
+}
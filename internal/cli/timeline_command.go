@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// Working hours shown by tt timeline unless sessions fall outside them or --full-day is given
+const (
+	timelineWorkdayStart = 8
+	timelineWorkdayEnd   = 18
+)
+
+// Characters drawing the timeline bars, in increasing order of precedence when segments share a cell
+const (
+	timelineIdle        = '·'
+	timelineTracked     = '█'
+	timelineRunning     = '▒'
+	timelineOverlapping = '▚'
+)
+
+// timelineMaxLabelWidth caps the width of the task name column
+const timelineMaxLabelWidth = 20
+
+// TimelineOptions holds the flags accepted by the timeline command
+type TimelineOptions struct {
+	FullDay bool // Show all 24 hours instead of the working hours
+}
+
+// TimelineCommand handles the timeline command, which draws a day's sessions as a bar per task
+type TimelineCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+	width        int // Total width of the drawing, from Display.SummaryWidth
+	options      TimelineOptions
+}
+
+// NewTimelineCommand creates a new timeline command handler
+func NewTimelineCommand(app *App) *TimelineCommand {
+	return NewTimelineCommandWithOptions(app, TimelineOptions{})
+}
+
+// NewTimelineCommandWithOptions creates a new timeline command handler with the given flag values
+func NewTimelineCommandWithOptions(app *App, options TimelineOptions) *TimelineCommand {
+	return &TimelineCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+		width:        app.displayConfig().SummaryWidth,
+		options:      options,
+	}
+}
+
+// Execute runs the timeline command
+func (c *TimelineCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return errors.NewInvalidInputError("command", "timeline", "usage: tt timeline [date] [--full-day]")
+	}
+
+	date := timeNow()
+	if len(args) == 1 {
+		parsed, err := newTimeParser(date).ParseDate(args[0])
+		if err != nil {
+			return err
+		}
+		date = parsed
+	}
+
+	timeline, err := c.businessAPI.GetTimeline(ctx, date)
+	if err != nil {
+		return c.errorHandler.Handle("get timeline", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(timeline)
+	}
+	return writeTimeline(c.printer.out, timeline, c.width, c.options.FullDay)
+}
+
+// writeTimeline draws an hour axis and one bar per task, followed by the day's totals and a legend.
+// The bars span the working hours, widened to the hours holding sessions, or the whole day when fullDay is set.
+func writeTimeline(w io.Writer, timeline *api.Timeline, width int, fullDay bool) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Timeline for %s\n\n", timeline.Start.Local().Format("Mon 2006-01-02"))
+
+	if len(timeline.Rows) == 0 {
+		b.WriteString("No sessions on this day.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	firstHour, lastHour := 0, 24
+	if !fullDay {
+		firstHour, lastHour = timelineHours(timeline)
+	}
+	windowStart := timeline.Start.Add(time.Duration(firstHour) * time.Hour)
+	window := time.Duration(lastHour-firstHour) * time.Hour
+
+	labelWidth := len("Total")
+	for _, row := range timeline.Rows {
+		labelWidth = max(labelWidth, len([]rune(row.Task.TaskName)))
+	}
+	labelWidth = min(labelWidth, timelineMaxLabelWidth)
+	// The label and total columns take labelWidth + 9 characters around the bar
+	barWidth := max(width-labelWidth-9, 12)
+
+	fmt.Fprintf(&b, "%-*s %s\n", labelWidth, "", timelineAxis(firstHour, lastHour, barWidth))
+	for _, row := range timeline.Rows {
+		bar := timelineBar(row.Segments, windowStart, window, barWidth)
+		fmt.Fprintf(&b, "%-*s %s %7s\n", labelWidth, truncateRunes(row.Task.TaskName, labelWidth), bar, formatDurationHuman(row.Duration))
+	}
+
+	sessions := "sessions"
+	if timeline.SessionCount == 1 {
+		sessions = "session"
+	}
+	switches := "switches"
+	if timeline.Switches == 1 {
+		switches = "switch"
+	}
+	fmt.Fprintf(&b, "\nTotal %s in %d %s, %d task %s\n", formatDurationHuman(timeline.Duration), timeline.SessionCount, sessions, timeline.Switches, switches)
+	fmt.Fprintf(&b, "%c tracked  %c running  %c overlapping  %c idle\n", timelineTracked, timelineRunning, timelineOverlapping, timelineIdle)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// timelineHours returns the first and last hour to draw: the working hours, widened to every session of the day
+func timelineHours(timeline *api.Timeline) (int, int) {
+	firstHour, lastHour := timelineWorkdayStart, timelineWorkdayEnd
+	for _, row := range timeline.Rows {
+		for _, segment := range row.Segments {
+			firstHour = min(firstHour, int(segment.Start.Sub(timeline.Start).Hours()))
+			lastHour = max(lastHour, int(math.Ceil(segment.End.Sub(timeline.Start).Hours())))
+		}
+	}
+	return firstHour, min(lastHour, 24)
+}
+
+// timelineAxis labels the hours along a bar of barWidth cells, skipping hours so labels stay apart
+func timelineAxis(firstHour, lastHour, barWidth int) string {
+	hours := lastHour - firstHour
+	cellsPerHour := float64(barWidth) / float64(hours)
+
+	step := 1
+	for _, candidate := range []int{1, 2, 3, 4, 6, 12} {
+		step = candidate
+		if cellsPerHour*float64(candidate) >= 3 {
+			break
+		}
+	}
+
+	axis := []rune(strings.Repeat(" ", barWidth))
+	for hour := firstHour; hour <= lastHour; hour++ {
+		if hour%step != 0 {
+			continue
+		}
+		position := int(math.Round(float64(hour-firstHour) * cellsPerHour))
+		if position+2 > barWidth {
+			break
+		}
+		copy(axis[position:], []rune(fmt.Sprintf("%02d", hour%24)))
+	}
+	return string(axis)
+}
+
+// timelineBar draws segments as cells of window/barWidth each, starting at windowStart. A cell is filled
+// when a segment covers at least half of it, or lies entirely within it so short sessions still show.
+// Overlapping segments take precedence over the running one, which takes precedence over the others.
+func timelineBar(segments []*api.TimelineSegment, windowStart time.Time, window time.Duration, barWidth int) string {
+	cell := window / time.Duration(barWidth)
+	cells := []rune(strings.Repeat(string(timelineIdle), barWidth))
+	precedence := map[rune]int{timelineIdle: 0, timelineTracked: 1, timelineRunning: 2, timelineOverlapping: 3}
+
+	for _, segment := range segments {
+		symbol := timelineTracked
+		switch {
+		case segment.Overlaps:
+			symbol = timelineOverlapping
+		case segment.Running:
+			symbol = timelineRunning
+		}
+
+		first := max(int(segment.Start.Sub(windowStart)/cell), 0)
+		last := min(int(segment.End.Sub(windowStart)/cell), barWidth-1)
+		for i := first; i <= last; i++ {
+			cellStart := windowStart.Add(time.Duration(i) * cell)
+			cellEnd := cellStart.Add(cell)
+			coverage := minTime(segment.End, cellEnd).Sub(maxTime(segment.Start, cellStart))
+			within := !segment.Start.Before(cellStart) && !segment.End.After(cellEnd)
+			if (coverage*2 >= cell || within) && precedence[symbol] > precedence[cells[i]] {
+				cells[i] = symbol
+			}
+		}
+	}
+	return string(cells)
+}
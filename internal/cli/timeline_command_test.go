@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	app, cleanup := setupTestAppWithMockBusinessAPI(t)
+	defer cleanup()
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 9, 14, hour, minute, 0, 0, time.Local)
+	}
+	for _, entry := range []struct {
+		task       string
+		start, end time.Time
+	}{
+		{"Write docs", at(9, 0), at(11, 0)},
+		{"Code review", at(11, 30), at(12, 0)},
+		{"Write docs", at(13, 0), at(14, 0)},
+	} {
+		_, err := app.businessAPI.AddTimeEntry(ctx, entry.task, entry.start, entry.end)
+		require.NoError(t, err)
+	}
+
+	run := func(t *testing.T, format OutputFormat, options TimelineOptions, args ...string) string {
+		var out bytes.Buffer
+		cmd := NewTimelineCommandWithOptions(app, options)
+		cmd.printer = newPrinterWithWriters(format, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, args))
+		return out.String()
+	}
+
+	t.Run("draws a bar per task over the working hours", func(t *testing.T) {
+		out := run(t, FormatTable, TimelineOptions{}, "2026-09-14")
+		lines := strings.Split(out, "\n")
+
+		assert.Equal(t, "Timeline for Mon 2026-09-14", lines[0])
+		require.GreaterOrEqual(t, len(lines), 5)
+		assert.Regexp(t, `^\s+08\s+09\s+10\s+11\s+12\s+13\s+14\s+15\s+16\s+17\s+$`, lines[2])
+		assert.Regexp(t, `^Write docs  ·+█+·+█+·+ +3h 0m$`, lines[3])
+		assert.Regexp(t, `^Code review ·+█+·+ +30m$`, lines[4])
+		for _, line := range lines[3:5] {
+			assert.Equal(t, 75, len([]rune(line)), "lines fill Display.SummaryWidth")
+		}
+		assert.Contains(t, out, "Total 3h 30m in 3 sessions, 2 task switches")
+		assert.Contains(t, out, "█ tracked  ▒ running  ▚ overlapping  · idle")
+	})
+
+	t.Run("covers the whole day with --full-day", func(t *testing.T) {
+		out := run(t, FormatTable, TimelineOptions{FullDay: true}, "2026-09-14")
+		assert.Regexp(t, `\s00\s.*\s12\s`, out)
+	})
+
+	t.Run("marks the running entry", func(t *testing.T) {
+		_, err := app.businessAPI.StartNewTask(ctx, "Plan sprint")
+		require.NoError(t, err)
+		defer app.businessAPI.StopAllRunningTasks(ctx)
+
+		// The running entry is only a moment old, so it fits within a single cell
+		out := run(t, FormatTable, TimelineOptions{}, "today")
+		assert.Regexp(t, `Plan sprint ·*▒·*`, out)
+	})
+
+	t.Run("marks overlapping entries", func(t *testing.T) {
+		timeline := &api.Timeline{Start: at(0, 0), End: at(24, 0), SessionCount: 2, Rows: []*api.TimelineRow{
+			{Task: &domain.Task{ID: 1, TaskName: "Write docs"}, Duration: 2 * time.Hour, Segments: []*api.TimelineSegment{{Start: at(9, 0), End: at(11, 0), Overlaps: true}}},
+			{Task: &domain.Task{ID: 2, TaskName: "Code review"}, Duration: time.Hour, Segments: []*api.TimelineSegment{{Start: at(10, 0), End: at(11, 0), Overlaps: true}}},
+		}}
+		var out bytes.Buffer
+		require.NoError(t, writeTimeline(&out, timeline, 75, false))
+		assert.Regexp(t, `Write docs\s+·+▚+·+`, out.String())
+	})
+
+	t.Run("says when the day is empty", func(t *testing.T) {
+		out := run(t, FormatTable, TimelineOptions{}, "2026-09-13")
+		assert.Contains(t, out, "No sessions on this day.")
+	})
+
+	t.Run("emits JSON", func(t *testing.T) {
+		out := run(t, FormatJSON, TimelineOptions{}, "2026-09-14")
+
+		var timeline api.Timeline
+		require.NoError(t, json.Unmarshal([]byte(out), &timeline))
+		assert.Equal(t, 2, timeline.Switches)
+		require.Len(t, timeline.Rows, 2)
+		assert.Equal(t, "Write docs", timeline.Rows[0].Task.TaskName)
+		assert.Len(t, timeline.Rows[0].Segments, 2)
+	})
+
+	t.Run("rejects invalid arguments", func(t *testing.T) {
+		cmd := NewTimelineCommand(app)
+		err := cmd.Execute(ctx, []string{"14/09/2026"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected a date such as")
+
+		err = cmd.Execute(ctx, []string{"today", "extra"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "usage: tt timeline")
+	})
+}
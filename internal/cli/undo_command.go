@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"time-tracker/internal/api"
+)
+
+// UndoCommand handles the undo command, which reverts the most recent operations from the journal
+type UndoCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+}
+
+// NewUndoCommand creates a new undo command handler
+func NewUndoCommand(app *App) *UndoCommand {
+	return &UndoCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+	}
+}
+
+// Execute runs the undo command
+func (c *UndoCommand) Execute(ctx context.Context, args []string) error {
+	count, err := parseOperationCount(args, 1, "usage: tt undo [count]")
+	if err != nil {
+		return err
+	}
+
+	undone, err := c.businessAPI.UndoOperations(ctx, count)
+	if err != nil {
+		return c.errorHandler.Handle("undo", err)
+	}
+
+	if c.printer.IsStructured() {
+		return c.printer.Emit(undone)
+	}
+
+	for _, operation := range undone {
+		fmt.Printf("Undone: %s\n", operation.Description)
+	}
+	if len(undone) < count {
+		fmt.Printf("Only %d operations could be undone\n", len(undone))
+	}
+	return nil
+}
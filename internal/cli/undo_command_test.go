@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndoCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("brings back a deleted task", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		session, err := app.businessAPI.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		require.NoError(t, app.businessAPI.DeleteTaskWithEntries(ctx, session.Task.ID))
+
+		var out bytes.Buffer
+		cmd := NewUndoCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		var undone []*api.Operation
+		require.NoError(t, json.Unmarshal(out.Bytes(), &undone))
+		require.Len(t, undone, 1)
+		assert.Equal(t, "delete", undone[0].Kind)
+		assert.NotNil(t, undone[0].UndoneAt)
+
+		task, err := app.businessAPI.GetTask(ctx, session.Task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Write report", task.TaskName)
+	})
+
+	t.Run("reports when there is nothing to undo", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+		mockAPI(app).failures["UndoOperations"] = errors.NewValidationError("nothing to undo", nil)
+
+		err := NewUndoCommand(app).Execute(ctx, []string{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nothing to undo")
+	})
+
+	t.Run("rejects invalid counts", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		for _, args := range [][]string{{"0"}, {"two"}, {"1", "2"}} {
+			assert.Error(t, NewUndoCommand(app).Execute(ctx, args), "args %v", args)
+		}
+	})
+}
+
+func TestHistoryCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("lists operations newest first, marking undone ones", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		for _, name := range []string{"Write report", "Code review"} {
+			session, err := app.businessAPI.StartNewTask(ctx, name)
+			require.NoError(t, err)
+			require.NoError(t, app.businessAPI.DeleteTaskWithEntries(ctx, session.Task.ID))
+		}
+		_, err := app.businessAPI.UndoOperations(ctx, 1)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		cmd := NewHistoryCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{"5"}))
+
+		var operations []*api.Operation
+		require.NoError(t, json.Unmarshal(out.Bytes(), &operations))
+		require.Len(t, operations, 2)
+		assert.Contains(t, operations[0].Description, "Code review")
+		assert.NotNil(t, operations[0].UndoneAt)
+		assert.Contains(t, operations[1].Description, "Write report")
+		assert.Nil(t, operations[1].UndoneAt)
+	})
+
+	t.Run("limits the number of operations", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		for _, name := range []string{"Write report", "Code review"} {
+			session, err := app.businessAPI.StartNewTask(ctx, name)
+			require.NoError(t, err)
+			require.NoError(t, app.businessAPI.DeleteTaskWithEntries(ctx, session.Task.ID))
+		}
+
+		var out bytes.Buffer
+		cmd := NewHistoryCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{"1"}))
+
+		var operations []*api.Operation
+		require.NoError(t, json.Unmarshal(out.Bytes(), &operations))
+		assert.Len(t, operations, 1)
+	})
+}
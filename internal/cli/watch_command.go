@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+)
+
+// WatchCommand handles the watch command, which prints changes to the tracking state as they happen
+type WatchCommand struct {
+	businessAPI  api.BusinessAPI
+	errorHandler *ErrorHandler
+	printer      *Printer
+}
+
+// NewWatchCommand creates a new watch command handler
+func NewWatchCommand(app *App) *WatchCommand {
+	return &WatchCommand{
+		businessAPI:  app.businessAPI,
+		errorHandler: NewErrorHandler(),
+		printer:      app.newPrinter(),
+	}
+}
+
+// Execute prints events until ctx is cancelled or the process is interrupted
+func (c *WatchCommand) Execute(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return errors.NewInvalidInputError("command", "watch", "usage: tt watch [--json]")
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	events, err := c.businessAPI.SubscribeEvents(ctx)
+	if err != nil {
+		return c.errorHandler.Handle("watch", err)
+	}
+
+	// Events are streamed one per line in both structured formats, so consumers can read them as they come
+	if c.printer.IsStructured() {
+		c.printer.format = FormatNDJSON
+	}
+
+	c.printer.Infof("Watching for changes (press Ctrl-C to stop)\n")
+	for event := range events {
+		if err := c.printEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printEvent writes an event as a line of JSON, or as its time and description
+func (c *WatchCommand) printEvent(event *api.Event) error {
+	if c.printer.IsStructured() {
+		return c.printer.Emit(event)
+	}
+
+	at := event.Time.Local().Format("15:04:05")
+	if event.Type != api.EventCurrent {
+		fmt.Printf("%s  %s\n", at, event.Description)
+		return nil
+	}
+	if event.Current == nil {
+		fmt.Printf("%s  No task running\n", at)
+	} else {
+		fmt.Printf("%s  Running: %s (%s)\n", at, event.Current.Task.TaskName, event.Current.Duration)
+	}
+	return nil
+}
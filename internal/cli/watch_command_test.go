@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"time-tracker/internal/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("streams events one per line in JSON", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		session, err := app.businessAPI.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		require.NoError(t, app.businessAPI.DeleteTaskWithEntries(ctx, session.Task.ID))
+
+		var out bytes.Buffer
+		cmd := NewWatchCommand(app)
+		cmd.printer = newPrinterWithWriters(FormatJSON, &out, io.Discard)
+		require.NoError(t, cmd.Execute(ctx, []string{}))
+
+		var events []*api.Event
+		lines := bufio.NewScanner(&out)
+		for lines.Scan() {
+			var event api.Event
+			require.NoError(t, json.Unmarshal(lines.Bytes(), &event), lines.Text())
+			events = append(events, &event)
+		}
+		require.Len(t, events, 2)
+		assert.Equal(t, api.EventCurrent, events[0].Type)
+		assert.Equal(t, `Deleted task "Write report" and 1 time entries`, events[1].Description)
+	})
+
+	t.Run("rejects arguments", func(t *testing.T) {
+		app, cleanup := setupTestAppWithMockBusinessAPI(t)
+		defer cleanup()
+
+		assert.Error(t, NewWatchCommand(app).Execute(ctx, []string{"now"}))
+	})
+}
@@ -15,15 +15,20 @@ type Config struct {
 	Display     DisplayConfig
 	Application ApplicationConfig
 	Commands    CommandsConfig
+	Tasks       TasksConfig
 }
 
 // DatabaseConfig holds database-related configuration
 type DatabaseConfig struct {
-	Dir            string        `env:"TT_DB_DIR"`
-	Filename       string        `env:"TT_DB_FILENAME"`
-	QueryTimeout   time.Duration `env:"TT_DB_QUERY_TIMEOUT"`
-	WriteTimeout   time.Duration `env:"TT_DB_WRITE_TIMEOUT"`
-	DirPermissions uint32        `env:"TT_DB_DIR_PERMISSIONS"`
+	Dir              string        `env:"TT_DB_DIR"`
+	Filename         string        `env:"TT_DB_FILENAME"`
+	QueryTimeout     time.Duration `env:"TT_DB_QUERY_TIMEOUT"`
+	WriteTimeout     time.Duration `env:"TT_DB_WRITE_TIMEOUT"`
+	DirPermissions   uint32        `env:"TT_DB_DIR_PERMISSIONS"`
+	BackupDir        string        `env:"TT_DB_BACKUP_DIR"`         // Where backups go; the backups directory next to the database when empty
+	AutoBackup       bool          `env:"TT_DB_AUTO_BACKUP"`        // Back the database up on the first command of each day
+	BackupKeepDaily  int           `env:"TT_DB_BACKUP_KEEP_DAILY"`  // Keep the automatic backups of this many recent days
+	BackupKeepWeekly int           `env:"TT_DB_BACKUP_KEEP_WEEKLY"` // Also keep the newest automatic backup of this many recent weeks
 }
 
 // TimeConfig holds time formatting configuration
@@ -57,6 +62,21 @@ type CommandsConfig struct {
 	OutputDefaultFormat string `env:"TT_OUTPUT_DEFAULT_FORMAT"`
 }
 
+// TasksConfig holds task housekeeping configuration
+type TasksConfig struct {
+	AutoArchiveDays int           `env:"TT_AUTO_ARCHIVE_DAYS"` // Archive tasks not worked on for this many days; 0 disables it
+	ForgottenAfter  time.Duration `env:"TT_FORGOTTEN_AFTER"`   // Treat a task running this long as forgotten; 0 disables the check
+	ForgottenPolicy string        `env:"TT_FORGOTTEN_POLICY"`  // What to do with a forgotten task without a terminal: warn, stop, cap or keep
+}
+
+// Policies for a forgotten running task when tt cannot ask, see TasksConfig.ForgottenPolicy
+const (
+	ForgottenPolicyWarn = "warn" // Print a warning and leave the task running
+	ForgottenPolicyStop = "stop" // Stop the task at the last activity tt saw while it ran, or cap it without one
+	ForgottenPolicyCap  = "cap"  // Stop the task once it reaches Validation.MaxDuration
+	ForgottenPolicyKeep = "keep" // Keep the task running without warning again until it looks forgotten anew
+)
+
 // NewConfig creates a new configuration with sensible defaults
 func NewConfig() *Config {
 	homeDir, _ := os.UserHomeDir()
@@ -64,11 +84,14 @@ func NewConfig() *Config {
 	
 	return &Config{
 		Database: DatabaseConfig{
-			Dir:            defaultDBDir,
-			Filename:       "tt.db",
-			QueryTimeout:   10 * time.Second,
-			WriteTimeout:   5 * time.Second,
-			DirPermissions: 0755,
+			Dir:              defaultDBDir,
+			Filename:         "tt.db",
+			QueryTimeout:     10 * time.Second,
+			WriteTimeout:     5 * time.Second,
+			DirPermissions:   0755,
+			AutoBackup:       true,
+			BackupKeepDaily:  7,
+			BackupKeepWeekly: 4,
 		},
 		Time: TimeConfig{
 			DisplayFormat: "2006-01-02 15:04:05",
@@ -91,6 +114,11 @@ func NewConfig() *Config {
 			ListDefaultFormat:   "table",
 			OutputDefaultFormat: "csv",
 		},
+		Tasks: TasksConfig{
+			AutoArchiveDays: 0,
+			ForgottenAfter:  12 * time.Hour,
+			ForgottenPolicy: ForgottenPolicyWarn,
+		},
 	}
 }
 
@@ -99,6 +127,14 @@ func (c *Config) GetDatabasePath() string {
 	return filepath.Join(c.Database.Dir, c.Database.Filename)
 }
 
+// GetBackupDir returns the directory backups are written to
+func (c *Config) GetBackupDir() string {
+	if c.Database.BackupDir != "" {
+		return c.Database.BackupDir
+	}
+	return filepath.Join(c.Database.Dir, "backups")
+}
+
 // GetQueryTimeout returns the database query timeout
 func (c *Config) GetQueryTimeout() time.Duration {
 	return c.Database.QueryTimeout
@@ -133,6 +169,24 @@ func (c *Config) LoadFromEnvironment() error {
 			c.Database.DirPermissions = uint32(p)
 		}
 	}
+	if dir := os.Getenv("TT_DB_BACKUP_DIR"); dir != "" {
+		c.Database.BackupDir = dir
+	}
+	if autoBackup := os.Getenv("TT_DB_AUTO_BACKUP"); autoBackup != "" {
+		if b, err := strconv.ParseBool(autoBackup); err == nil {
+			c.Database.AutoBackup = b
+		}
+	}
+	if keep := os.Getenv("TT_DB_BACKUP_KEEP_DAILY"); keep != "" {
+		if n, err := strconv.Atoi(keep); err == nil {
+			c.Database.BackupKeepDaily = n
+		}
+	}
+	if keep := os.Getenv("TT_DB_BACKUP_KEEP_WEEKLY"); keep != "" {
+		if n, err := strconv.Atoi(keep); err == nil {
+			c.Database.BackupKeepWeekly = n
+		}
+	}
 
 	// Time configuration
 	if format := os.Getenv("TT_TIME_DISPLAY_FORMAT"); format != "" {
@@ -191,6 +245,21 @@ func (c *Config) LoadFromEnvironment() error {
 		c.Commands.OutputDefaultFormat = format
 	}
 
+	// Tasks configuration
+	if days := os.Getenv("TT_AUTO_ARCHIVE_DAYS"); days != "" {
+		if n, err := strconv.Atoi(days); err == nil {
+			c.Tasks.AutoArchiveDays = n
+		}
+	}
+	if after := os.Getenv("TT_FORGOTTEN_AFTER"); after != "" {
+		if d, err := time.ParseDuration(after); err == nil {
+			c.Tasks.ForgottenAfter = d
+		}
+	}
+	if policy := os.Getenv("TT_FORGOTTEN_POLICY"); policy != "" {
+		c.Tasks.ForgottenPolicy = policy
+	}
+
 	return nil
 }
 
@@ -209,6 +278,12 @@ func (c *Config) Validate() error {
 	if c.Database.WriteTimeout <= 0 {
 		return &ConfigError{Field: "database.write_timeout", Message: "write timeout must be positive"}
 	}
+	if c.Database.BackupKeepDaily < 1 {
+		return &ConfigError{Field: "database.backup_keep_daily", Message: "at least one daily backup must be kept"}
+	}
+	if c.Database.BackupKeepWeekly < 0 {
+		return &ConfigError{Field: "database.backup_keep_weekly", Message: "weekly backups kept cannot be negative"}
+	}
 
 	// Validate time configuration
 	if c.Time.DisplayFormat == "" {
@@ -239,6 +314,19 @@ func (c *Config) Validate() error {
 		return &ConfigError{Field: "application.timeout", Message: "application timeout must be positive"}
 	}
 
+	// Validate tasks configuration
+	if c.Tasks.AutoArchiveDays < 0 {
+		return &ConfigError{Field: "tasks.auto_archive_days", Message: "auto-archive days cannot be negative"}
+	}
+	if c.Tasks.ForgottenAfter < 0 {
+		return &ConfigError{Field: "tasks.forgotten_after", Message: "forgotten task threshold cannot be negative"}
+	}
+	switch c.Tasks.ForgottenPolicy {
+	case ForgottenPolicyWarn, ForgottenPolicyStop, ForgottenPolicyCap, ForgottenPolicyKeep:
+	default:
+		return &ConfigError{Field: "tasks.forgotten_policy", Message: "forgotten task policy must be one of warn, stop, cap or keep"}
+	}
+
 	return nil
 }
 
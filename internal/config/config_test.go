@@ -0,0 +1,199 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewConfig_BackupAndTasksDefaults(t *testing.T) {
+	cfg := NewConfig()
+
+	if !cfg.Database.AutoBackup {
+		t.Error("AutoBackup should be on by default")
+	}
+	if cfg.Database.BackupKeepDaily != 7 {
+		t.Errorf("BackupKeepDaily = %d, want 7", cfg.Database.BackupKeepDaily)
+	}
+	if cfg.Database.BackupKeepWeekly != 4 {
+		t.Errorf("BackupKeepWeekly = %d, want 4", cfg.Database.BackupKeepWeekly)
+	}
+	if cfg.Database.BackupDir != "" {
+		t.Errorf("BackupDir = %q, want it empty", cfg.Database.BackupDir)
+	}
+	if want := filepath.Join(cfg.Database.Dir, "backups"); cfg.GetBackupDir() != want {
+		t.Errorf("GetBackupDir() = %q, want %q", cfg.GetBackupDir(), want)
+	}
+	if cfg.Tasks.AutoArchiveDays != 0 {
+		t.Errorf("AutoArchiveDays = %d, want 0", cfg.Tasks.AutoArchiveDays)
+	}
+	if cfg.Tasks.ForgottenAfter != 12*time.Hour {
+		t.Errorf("ForgottenAfter = %v, want 12h", cfg.Tasks.ForgottenAfter)
+	}
+	if cfg.Tasks.ForgottenPolicy != ForgottenPolicyWarn {
+		t.Errorf("ForgottenPolicy = %q, want %q", cfg.Tasks.ForgottenPolicy, ForgottenPolicyWarn)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestLoadFromEnvironment_BackupAndTasks(t *testing.T) {
+	t.Run("overrides the defaults", func(t *testing.T) {
+		backupDir := t.TempDir()
+		t.Setenv("TT_DB_BACKUP_DIR", backupDir)
+		t.Setenv("TT_DB_AUTO_BACKUP", "false")
+		t.Setenv("TT_DB_BACKUP_KEEP_DAILY", "3")
+		t.Setenv("TT_DB_BACKUP_KEEP_WEEKLY", "0")
+		t.Setenv("TT_AUTO_ARCHIVE_DAYS", "30")
+		t.Setenv("TT_FORGOTTEN_AFTER", "8h")
+		t.Setenv("TT_FORGOTTEN_POLICY", "stop")
+
+		cfg := NewConfig()
+		if err := cfg.LoadFromEnvironment(); err != nil {
+			t.Fatalf("LoadFromEnvironment() error = %v", err)
+		}
+
+		if cfg.GetBackupDir() != backupDir {
+			t.Errorf("GetBackupDir() = %q, want %q", cfg.GetBackupDir(), backupDir)
+		}
+		if cfg.Database.AutoBackup {
+			t.Error("AutoBackup should be off")
+		}
+		if cfg.Database.BackupKeepDaily != 3 {
+			t.Errorf("BackupKeepDaily = %d, want 3", cfg.Database.BackupKeepDaily)
+		}
+		if cfg.Database.BackupKeepWeekly != 0 {
+			t.Errorf("BackupKeepWeekly = %d, want 0", cfg.Database.BackupKeepWeekly)
+		}
+		if cfg.Tasks.AutoArchiveDays != 30 {
+			t.Errorf("AutoArchiveDays = %d, want 30", cfg.Tasks.AutoArchiveDays)
+		}
+		if cfg.Tasks.ForgottenAfter != 8*time.Hour {
+			t.Errorf("ForgottenAfter = %v, want 8h", cfg.Tasks.ForgottenAfter)
+		}
+		if cfg.Tasks.ForgottenPolicy != ForgottenPolicyStop {
+			t.Errorf("ForgottenPolicy = %q, want %q", cfg.Tasks.ForgottenPolicy, ForgottenPolicyStop)
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+
+	t.Run("keeps the defaults for values it cannot parse", func(t *testing.T) {
+		t.Setenv("TT_DB_AUTO_BACKUP", "sometimes")
+		t.Setenv("TT_DB_BACKUP_KEEP_DAILY", "a week")
+		t.Setenv("TT_AUTO_ARCHIVE_DAYS", "monthly")
+		t.Setenv("TT_FORGOTTEN_AFTER", "half a day")
+
+		cfg := NewConfig()
+		if err := cfg.LoadFromEnvironment(); err != nil {
+			t.Fatalf("LoadFromEnvironment() error = %v", err)
+		}
+
+		defaults := NewConfig()
+		if cfg.Database != defaults.Database {
+			t.Errorf("Database = %+v, want the defaults %+v", cfg.Database, defaults.Database)
+		}
+		if cfg.Tasks != defaults.Tasks {
+			t.Errorf("Tasks = %+v, want the defaults %+v", cfg.Tasks, defaults.Tasks)
+		}
+	})
+
+	t.Run("fails to load an unknown policy", func(t *testing.T) {
+		t.Setenv("TT_DB_DIR", t.TempDir())
+		t.Setenv("TT_FORGOTTEN_POLICY", "ignore")
+
+		if _, err := NewLoader().Load(); err == nil {
+			t.Error("Load() should reject an unknown forgotten task policy")
+		}
+	})
+}
+
+func TestValidate_BackupAndTasks(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(cfg *Config)
+		expectedField string
+	}{
+		{
+			name:          "negative daily backups",
+			modify:        func(cfg *Config) { cfg.Database.BackupKeepDaily = -1 },
+			expectedField: "database.backup_keep_daily",
+		},
+		{
+			name:          "no daily backups",
+			modify:        func(cfg *Config) { cfg.Database.BackupKeepDaily = 0 },
+			expectedField: "database.backup_keep_daily",
+		},
+		{
+			name:          "negative weekly backups",
+			modify:        func(cfg *Config) { cfg.Database.BackupKeepWeekly = -1 },
+			expectedField: "database.backup_keep_weekly",
+		},
+		{
+			name:   "no weekly backups",
+			modify: func(cfg *Config) { cfg.Database.BackupKeepWeekly = 0 },
+		},
+		{
+			name:          "negative auto-archive days",
+			modify:        func(cfg *Config) { cfg.Tasks.AutoArchiveDays = -1 },
+			expectedField: "tasks.auto_archive_days",
+		},
+		{
+			name:          "negative forgotten threshold",
+			modify:        func(cfg *Config) { cfg.Tasks.ForgottenAfter = -time.Hour },
+			expectedField: "tasks.forgotten_after",
+		},
+		{
+			name:   "forgotten check disabled",
+			modify: func(cfg *Config) { cfg.Tasks.ForgottenAfter = 0 },
+		},
+		{
+			name:          "unknown forgotten policy",
+			modify:        func(cfg *Config) { cfg.Tasks.ForgottenPolicy = "ignore" },
+			expectedField: "tasks.forgotten_policy",
+		},
+		{
+			name:          "empty forgotten policy",
+			modify:        func(cfg *Config) { cfg.Tasks.ForgottenPolicy = "" },
+			expectedField: "tasks.forgotten_policy",
+		},
+		{
+			name:   "stop policy",
+			modify: func(cfg *Config) { cfg.Tasks.ForgottenPolicy = ForgottenPolicyStop },
+		},
+		{
+			name:   "cap policy",
+			modify: func(cfg *Config) { cfg.Tasks.ForgottenPolicy = ForgottenPolicyCap },
+		},
+		{
+			name:   "keep policy",
+			modify: func(cfg *Config) { cfg.Tasks.ForgottenPolicy = ForgottenPolicyKeep },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.expectedField == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want none", err)
+				}
+				return
+			}
+
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("Validate() error = %v, want a ConfigError", err)
+			}
+			if configErr.Field != tt.expectedField {
+				t.Errorf("Validate() rejected %q, want %q", configErr.Field, tt.expectedField)
+			}
+		})
+	}
+}
@@ -66,6 +66,10 @@ type ConfigOverrides struct {
 	DBQueryTimeout   *time.Duration
 	DBWriteTimeout   *time.Duration
 	DBDirPermissions *uint32
+	BackupDir        *string
+	AutoBackup       *bool
+	BackupKeepDaily  *int
+	BackupKeepWeekly *int
 
 	// Time overrides
 	TimeFormat *string
@@ -87,6 +91,11 @@ type ConfigOverrides struct {
 	// Commands overrides
 	ListDefaultFormat   *string
 	OutputDefaultFormat *string
+
+	// Tasks overrides
+	AutoArchiveDays *int
+	ForgottenAfter  *time.Duration
+	ForgottenPolicy *string
 }
 
 // applyOverrides applies command line overrides to the configuration
@@ -107,6 +116,18 @@ func (l *Loader) applyOverrides(config *Config, overrides *ConfigOverrides) {
 	if overrides.DBDirPermissions != nil {
 		config.Database.DirPermissions = *overrides.DBDirPermissions
 	}
+	if overrides.BackupDir != nil {
+		config.Database.BackupDir = *overrides.BackupDir
+	}
+	if overrides.AutoBackup != nil {
+		config.Database.AutoBackup = *overrides.AutoBackup
+	}
+	if overrides.BackupKeepDaily != nil {
+		config.Database.BackupKeepDaily = *overrides.BackupKeepDaily
+	}
+	if overrides.BackupKeepWeekly != nil {
+		config.Database.BackupKeepWeekly = *overrides.BackupKeepWeekly
+	}
 
 	// Time overrides
 	if overrides.TimeFormat != nil {
@@ -150,6 +171,17 @@ func (l *Loader) applyOverrides(config *Config, overrides *ConfigOverrides) {
 	if overrides.OutputDefaultFormat != nil {
 		config.Commands.OutputDefaultFormat = *overrides.OutputDefaultFormat
 	}
+
+	// Tasks overrides
+	if overrides.AutoArchiveDays != nil {
+		config.Tasks.AutoArchiveDays = *overrides.AutoArchiveDays
+	}
+	if overrides.ForgottenAfter != nil {
+		config.Tasks.ForgottenAfter = *overrides.ForgottenAfter
+	}
+	if overrides.ForgottenPolicy != nil {
+		config.Tasks.ForgottenPolicy = *overrides.ForgottenPolicy
+	}
 }
 
 
@@ -15,16 +15,20 @@ func NewTaskMapper() *TaskMapper {
 // ToDatabase converts a domain Task to a database Task.
 func (m *TaskMapper) ToDatabase(domainTask Task) sqlite.Task {
 	return sqlite.Task{
-		ID:       domainTask.ID,
-		TaskName: domainTask.TaskName,
+		ID:         domainTask.ID,
+		TaskName:   domainTask.TaskName,
+		ProjectID:  domainTask.ProjectID,
+		ArchivedAt: domainTask.ArchivedAt,
 	}
 }
 
 // FromDatabase converts a database Task to a domain Task.
 func (m *TaskMapper) FromDatabase(dbTask sqlite.Task) Task {
 	return Task{
-		ID:       dbTask.ID,
-		TaskName: dbTask.TaskName,
+		ID:         dbTask.ID,
+		TaskName:   dbTask.TaskName,
+		ProjectID:  dbTask.ProjectID,
+		ArchivedAt: dbTask.ArchivedAt,
 	}
 }
 
@@ -57,20 +61,28 @@ func NewTimeEntryMapper() *TimeEntryMapper {
 // ToDatabase converts a domain TimeEntry to a database TimeEntry.
 func (m *TimeEntryMapper) ToDatabase(domainEntry TimeEntry) sqlite.TimeEntry {
 	return sqlite.TimeEntry{
-		ID:        domainEntry.ID,
-		TaskID:    domainEntry.TaskID,
-		StartTime: domainEntry.StartTime,
-		EndTime:   domainEntry.EndTime,
+		ID:            domainEntry.ID,
+		TaskID:        domainEntry.TaskID,
+		StartTime:     domainEntry.StartTime,
+		EndTime:       domainEntry.EndTime,
+		Note:          domainEntry.Note,
+		ContinuesID:   domainEntry.ContinuesID,
+		Paused:        domainEntry.Paused,
+		KeptRunningAt: domainEntry.KeptRunningAt,
 	}
 }
 
 // FromDatabase converts a database TimeEntry to a domain TimeEntry.
 func (m *TimeEntryMapper) FromDatabase(dbEntry sqlite.TimeEntry) TimeEntry {
 	return TimeEntry{
-		ID:        dbEntry.ID,
-		TaskID:    dbEntry.TaskID,
-		StartTime: dbEntry.StartTime,
-		EndTime:   dbEntry.EndTime,
+		ID:            dbEntry.ID,
+		TaskID:        dbEntry.TaskID,
+		StartTime:     dbEntry.StartTime,
+		EndTime:       dbEntry.EndTime,
+		Note:          dbEntry.Note,
+		ContinuesID:   dbEntry.ContinuesID,
+		Paused:        dbEntry.Paused,
+		KeptRunningAt: dbEntry.KeptRunningAt,
 	}
 }
 
@@ -92,6 +104,34 @@ func (m *TimeEntryMapper) FromDatabaseSlice(dbEntries []sqlite.TimeEntry) []Time
 	return domainEntries
 }
 
+// ProjectMapper handles conversion between domain and database Project models.
+type ProjectMapper struct{}
+
+// NewProjectMapper creates a new ProjectMapper instance.
+func NewProjectMapper() *ProjectMapper {
+	return &ProjectMapper{}
+}
+
+// ToDatabase converts a domain Project to a database Project.
+func (m *ProjectMapper) ToDatabase(domainProject Project) sqlite.Project {
+	return sqlite.Project{
+		ID:         domainProject.ID,
+		Name:       domainProject.Name,
+		ParentID:   domainProject.ParentID,
+		ArchivedAt: domainProject.ArchivedAt,
+	}
+}
+
+// FromDatabase converts a database Project to a domain Project.
+func (m *ProjectMapper) FromDatabase(dbProject sqlite.Project) Project {
+	return Project{
+		ID:         dbProject.ID,
+		Name:       dbProject.Name,
+		ParentID:   dbProject.ParentID,
+		ArchivedAt: dbProject.ArchivedAt,
+	}
+}
+
 // SearchOptionsMapper handles conversion between domain and database SearchOptions.
 type SearchOptionsMapper struct{}
 
@@ -103,20 +143,26 @@ func NewSearchOptionsMapper() *SearchOptionsMapper {
 // ToDatabase converts domain SearchOptions to database SearchOptions.
 func (m *SearchOptionsMapper) ToDatabase(domainOpts SearchOptions) sqlite.SearchOptions {
 	return sqlite.SearchOptions{
-		StartTime: domainOpts.StartTime,
-		EndTime:   domainOpts.EndTime,
-		TaskID:    domainOpts.TaskID,
-		TaskName:  domainOpts.TaskName,
+		StartTime:   domainOpts.StartTime,
+		EndTime:     domainOpts.EndTime,
+		TaskID:      domainOpts.TaskID,
+		TaskName:    domainOpts.TaskName,
+		ProjectIDs:  domainOpts.ProjectIDs,
+		Tags:        domainOpts.Tags,
+		ExcludeTags: domainOpts.ExcludeTags,
 	}
 }
 
 // FromDatabase converts database SearchOptions to domain SearchOptions.
 func (m *SearchOptionsMapper) FromDatabase(dbOpts sqlite.SearchOptions) SearchOptions {
 	return SearchOptions{
-		StartTime: dbOpts.StartTime,
-		EndTime:   dbOpts.EndTime,
-		TaskID:    dbOpts.TaskID,
-		TaskName:  dbOpts.TaskName,
+		StartTime:   dbOpts.StartTime,
+		EndTime:     dbOpts.EndTime,
+		TaskID:      dbOpts.TaskID,
+		TaskName:    dbOpts.TaskName,
+		ProjectIDs:  dbOpts.ProjectIDs,
+		Tags:        dbOpts.Tags,
+		ExcludeTags: dbOpts.ExcludeTags,
 	}
 }
 
@@ -124,6 +170,7 @@ func (m *SearchOptionsMapper) FromDatabase(dbOpts sqlite.SearchOptions) SearchOp
 type Mapper struct {
 	Task          *TaskMapper
 	TimeEntry     *TimeEntryMapper
+	Project       *ProjectMapper
 	SearchOptions *SearchOptionsMapper
 }
 
@@ -132,6 +179,7 @@ func NewMapper() *Mapper {
 	return &Mapper{
 		Task:          NewTaskMapper(),
 		TimeEntry:     NewTimeEntryMapper(),
+		Project:       NewProjectMapper(),
 		SearchOptions: NewSearchOptionsMapper(),
 	}
 }
\ No newline at end of file
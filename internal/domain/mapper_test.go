@@ -26,18 +26,22 @@ func TestTaskMapper_ToDatabase(t *testing.T) {
 
 func TestTaskMapper_FromDatabase(t *testing.T) {
 	mapper := NewTaskMapper()
+	archivedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
 	dbTask := sqlite.Task{
-		ID:       1,
-		TaskName: "Test Task",
+		ID:         1,
+		TaskName:   "Test Task",
+		ArchivedAt: &archivedAt,
 	}
 
 	result := mapper.FromDatabase(dbTask)
 
 	expected := Task{
-		ID:       1,
-		TaskName: "Test Task",
+		ID:         1,
+		TaskName:   "Test Task",
+		ArchivedAt: &archivedAt,
 	}
 	assert.Equal(t, expected, result)
+	assert.True(t, result.IsArchived())
 }
 
 func TestTaskMapper_ToDatabaseSlice(t *testing.T) {
@@ -85,20 +89,27 @@ func TestTaskMapper_EmptySlice(t *testing.T) {
 func TestTimeEntryMapper_ToDatabase(t *testing.T) {
 	mapper := NewTimeEntryMapper()
 	endTime := time.Now()
+	continuesID := int64(7)
 	domainEntry := TimeEntry{
-		ID:        1,
-		TaskID:    2,
-		StartTime: time.Now().Add(-time.Hour),
-		EndTime:   &endTime,
+		ID:          1,
+		TaskID:      2,
+		StartTime:   time.Now().Add(-time.Hour),
+		EndTime:     &endTime,
+		Note:        "reviewing PR 412",
+		ContinuesID: &continuesID,
+		Paused:      true,
 	}
 
 	result := mapper.ToDatabase(domainEntry)
 
 	expected := sqlite.TimeEntry{
-		ID:        1,
-		TaskID:    2,
-		StartTime: domainEntry.StartTime,
-		EndTime:   &endTime,
+		ID:          1,
+		TaskID:      2,
+		StartTime:   domainEntry.StartTime,
+		EndTime:     &endTime,
+		Note:        "reviewing PR 412",
+		ContinuesID: &continuesID,
+		Paused:      true,
 	}
 	assert.Equal(t, expected, result)
 }
@@ -106,20 +117,27 @@ func TestTimeEntryMapper_ToDatabase(t *testing.T) {
 func TestTimeEntryMapper_FromDatabase(t *testing.T) {
 	mapper := NewTimeEntryMapper()
 	endTime := time.Now()
+	continuesID := int64(7)
 	dbEntry := sqlite.TimeEntry{
-		ID:        1,
-		TaskID:    2,
-		StartTime: time.Now().Add(-time.Hour),
-		EndTime:   &endTime,
+		ID:          1,
+		TaskID:      2,
+		StartTime:   time.Now().Add(-time.Hour),
+		EndTime:     &endTime,
+		Note:        "reviewing PR 412",
+		ContinuesID: &continuesID,
+		Paused:      true,
 	}
 
 	result := mapper.FromDatabase(dbEntry)
 
 	expected := TimeEntry{
-		ID:        1,
-		TaskID:    2,
-		StartTime: dbEntry.StartTime,
-		EndTime:   &endTime,
+		ID:          1,
+		TaskID:      2,
+		StartTime:   dbEntry.StartTime,
+		EndTime:     &endTime,
+		Note:        "reviewing PR 412",
+		ContinuesID: &continuesID,
+		Paused:      true,
 	}
 	assert.Equal(t, expected, result)
 }
@@ -215,4 +233,23 @@ func TestMapper_Integration(t *testing.T) {
 	dbEntry := mapper.TimeEntry.ToDatabase(originalEntry)
 	convertedEntry := mapper.TimeEntry.FromDatabase(dbEntry)
 	assert.Equal(t, originalEntry, convertedEntry)
-}
\ No newline at end of file
+}
+func TestProjectMapper_RoundTrip(t *testing.T) {
+	mapper := NewProjectMapper()
+	parentID := int64(1)
+	archivedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	originalProject := Project{ID: 2, Name: "website", ParentID: &parentID, ArchivedAt: &archivedAt}
+
+	dbProject := mapper.ToDatabase(originalProject)
+	assert.Equal(t, sqlite.Project{ID: 2, Name: "website", ParentID: &parentID, ArchivedAt: &archivedAt}, dbProject)
+	assert.Equal(t, originalProject, mapper.FromDatabase(dbProject))
+}
+
+func TestTaskMapper_ProjectID(t *testing.T) {
+	mapper := NewTaskMapper()
+	projectID := int64(3)
+
+	dbTask := mapper.ToDatabase(Task{ID: 1, TaskName: "Landing page", ProjectID: &projectID})
+	assert.Equal(t, &projectID, dbTask.ProjectID)
+	assert.Equal(t, &projectID, mapper.FromDatabase(dbTask).ProjectID)
+}
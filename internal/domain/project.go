@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// ProjectPathSeparator separates a client from its projects in project paths such as "acme/website".
+const ProjectPathSeparator = "/"
+
+// Project represents a project in the domain model.
+// A project without a parent is a top-level project or client.
+type Project struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	ParentID   *int64     `json:"parent_id"`
+	ArchivedAt *time.Time `json:"archived_at"`
+}
+
+// NewProject creates a new Project with the given name under an optional parent.
+func NewProject(name string, parentID *int64) Project {
+	return Project{
+		Name:     name,
+		ParentID: parentID,
+	}
+}
+
+// IsArchived returns true if the project has been archived.
+func (p Project) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// IsValid checks if the project has valid data.
+func (p Project) IsValid() bool {
+	return p.Name != ""
+}
+
+// String returns the project name for display purposes.
+func (p Project) String() string {
+	return p.Name
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProject(t *testing.T) {
+	parentID := int64(1)
+
+	assert.Equal(t, Project{Name: "acme"}, NewProject("acme", nil))
+	assert.Equal(t, Project{Name: "website", ParentID: &parentID}, NewProject("website", &parentID))
+}
+
+func TestProject_IsArchived(t *testing.T) {
+	archivedAt := time.Now()
+
+	assert.False(t, Project{ID: 1, Name: "acme"}.IsArchived())
+	assert.True(t, Project{ID: 1, Name: "acme", ArchivedAt: &archivedAt}.IsArchived())
+}
+
+func TestProject_IsValid(t *testing.T) {
+	assert.True(t, Project{Name: "acme"}.IsValid())
+	assert.False(t, Project{}.IsValid())
+}
@@ -6,8 +6,11 @@ import "time"
 // This is a domain model that mirrors the database search options
 // but belongs to the domain layer for proper separation of concerns.
 type SearchOptions struct {
-	StartTime *time.Time
-	EndTime   *time.Time
-	TaskID    *int64
-	TaskName  *string
+	StartTime   *time.Time
+	EndTime     *time.Time
+	TaskID      *int64
+	TaskName    *string
+	ProjectIDs  []int64
+	Tags        []string
+	ExcludeTags []string
 }
\ No newline at end of file
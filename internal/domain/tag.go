@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// TagPrefixes lists the characters that mark a word as a tag, as in "+billable" or "#meeting".
+const TagPrefixes = "+#"
+
+// NormalizeTag strips a leading tag prefix from name and lowercases it.
+// It returns false if the result is not a valid tag name.
+func NormalizeTag(name string) (string, bool) {
+	tag := strings.ToLower(strings.TrimLeft(strings.TrimSpace(name), TagPrefixes))
+	return tag, IsValidTag(tag)
+}
+
+// IsValidTag checks that a tag starts with a letter and contains only letters, digits, '-' and '_'.
+func IsValidTag(tag string) bool {
+	for i, r := range tag {
+		if i == 0 && !unicode.IsLetter(r) {
+			return false
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return tag != ""
+}
+
+// ExtractTags splits the tag words out of text, so "Review +billable #meeting" yields
+// "Review" and the tags billable and meeting. Words that only look like tags, such as
+// "#123", are kept in the text. Text without tags is returned unchanged.
+func ExtractTags(text string) (string, []string) {
+	var words, tags []string
+	for _, word := range strings.Fields(text) {
+		if strings.ContainsRune(TagPrefixes, rune(word[0])) {
+			if tag, ok := NormalizeTag(word); ok {
+				tags = append(tags, tag)
+				continue
+			}
+		}
+		words = append(words, word)
+	}
+
+	if len(tags) == 0 {
+		return text, nil
+	}
+	return strings.Join(words, " "), UniqueTags(tags)
+}
+
+// UniqueTags returns the tags sorted with duplicates removed.
+func UniqueTags(tags []string) []string {
+	unique := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if !seen[tag] {
+			seen[tag] = true
+			unique = append(unique, tag)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
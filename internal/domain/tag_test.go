@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{input: "billable", expected: "billable", valid: true},
+		{input: "+Billable", expected: "billable", valid: true},
+		{input: "#client-call", expected: "client-call", valid: true},
+		{input: " #q4_2026 ", expected: "q4_2026", valid: true},
+		{input: "#123", expected: "123", valid: false},
+		{input: "+", expected: "", valid: false},
+		{input: "#bad.tag", expected: "bad.tag", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			tag, valid := NormalizeTag(tt.input)
+			assert.Equal(t, tt.expected, tag)
+			assert.Equal(t, tt.valid, valid)
+		})
+	}
+}
+
+func TestExtractTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		expectedText string
+		expectedTags []string
+	}{
+		{
+			name:         "text without tags is unchanged",
+			text:         "Fix  login bug",
+			expectedText: "Fix  login bug",
+		},
+		{
+			name:         "tags are removed from the text",
+			text:         "+billable Review PR #Meeting",
+			expectedText: "Review PR",
+			expectedTags: []string{"billable", "meeting"},
+		},
+		{
+			name:         "duplicate tags are merged",
+			text:         "Support #support +SUPPORT",
+			expectedText: "Support",
+			expectedTags: []string{"support"},
+		},
+		{
+			name:         "issue numbers are not tags",
+			text:         "Fix issue #123 +billable",
+			expectedText: "Fix issue #123",
+			expectedTags: []string{"billable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, tags := ExtractTags(tt.text)
+			assert.Equal(t, tt.expectedText, text)
+			assert.Equal(t, tt.expectedTags, tags)
+		})
+	}
+}
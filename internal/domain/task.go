@@ -1,10 +1,14 @@
 package domain
 
+import "time"
+
 // Task represents a task in the domain model.
 // This is a pure domain model without database-specific concerns.
 type Task struct {
-	ID       int64
-	TaskName string
+	ID         int64      `json:"id"`
+	TaskName   string     `json:"name"`
+	ProjectID  *int64     `json:"project_id,omitempty"`  // nil when the task belongs to no project
+	ArchivedAt *time.Time `json:"archived_at,omitempty"` // nil unless the task is archived
 }
 
 // NewTask creates a new Task with the given name.
@@ -19,6 +23,11 @@ func (t Task) IsValid() bool {
 	return t.TaskName != ""
 }
 
+// IsArchived reports whether the task has been archived.
+func (t Task) IsArchived() bool {
+	return t.ArchivedAt != nil
+}
+
 // String returns the task name for display purposes.
 func (t Task) String() string {
 	return t.TaskName
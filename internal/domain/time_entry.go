@@ -1,16 +1,25 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
+// MaxNoteLength is the maximum number of characters in a time entry note.
+const MaxNoteLength = 500
+
 // TimeEntry represents a time tracking entry in the domain model.
 // This is a pure domain model without database-specific concerns.
 type TimeEntry struct {
-	ID        int64
-	TaskID    int64
-	StartTime time.Time
-	EndTime   *time.Time
+	ID            int64      `json:"id"`
+	TaskID        int64      `json:"task_id"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       *time.Time `json:"end_time"`                  // nil while the entry is running
+	Tags          []string   `json:"tags,omitempty"`            // Tag names without their prefix, sorted
+	Note          string     `json:"note,omitempty"`            // Free-text note on what was done, empty if none
+	ContinuesID   *int64     `json:"continues_id,omitempty"`    // Segment this entry continues after a pause, nil for a new session
+	Paused        bool       `json:"paused,omitempty"`          // Ended by a pause and waiting to be continued
+	KeptRunningAt *time.Time `json:"kept_running_at,omitempty"` // When the user chose to keep the entry running after it looked forgotten
 }
 
 // NewTimeEntry creates a new TimeEntry for the given task.
@@ -53,4 +62,10 @@ func (te TimeEntry) IsValid() bool {
 		return false
 	}
 	return true
-}
\ No newline at end of file
+}
+
+// NormalizeNote trims a note and collapses runs of whitespace, including newlines,
+// into single spaces so it fits on one line.
+func NormalizeNote(note string) string {
+	return strings.Join(strings.Fields(note), " ")
+}
@@ -200,4 +200,23 @@ func TestTimeEntry_IsValid(t *testing.T) {
 			assert.Equal(t, tt.expected, result)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestNormalizeNote(t *testing.T) {
+	tests := []struct {
+		name     string
+		note     string
+		expected string
+	}{
+		{name: "plain note", note: "reviewing PR 412", expected: "reviewing PR 412"},
+		{name: "surrounding whitespace", note: "  reviewing PR 412\t", expected: "reviewing PR 412"},
+		{name: "newlines and runs of spaces", note: "first line\nsecond   line", expected: "first line second line"},
+		{name: "only whitespace", note: " \n ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeNote(tt.note))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite/migrations"
+
+	moderncsqlite "modernc.org/sqlite"
+)
+
+// restorer is implemented by the driver connections of modernc.org/sqlite, giving access to SQLite's online backup API
+type restorer interface {
+	NewRestore(srcURI string) (*moderncsqlite.Backup, error)
+}
+
+// Backup writes a consistent copy of the database to path with VACUUM INTO, which is safe while other
+// connections keep using the database. path must not exist yet.
+func (r *SQLiteRepository) Backup(ctx context.Context, path string) error {
+	if r.tx != nil {
+		return errors.NewValidationError("cannot back up the database inside a transaction", nil)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return errors.NewInvalidInputError("path", path, "file already exists")
+	}
+
+	if _, err := r.db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return errors.NewDatabaseError("back up database", err)
+	}
+	return nil
+}
+
+// Restore replaces the contents of the database with the backup at path through SQLite's online backup API,
+// so other connections see either the old or the restored database. The backup must be an intact tt database
+// whose schema is not newer than this version of tt; older schemas are migrated once restored.
+func (r *SQLiteRepository) Restore(ctx context.Context, path string) error {
+	if r.tx != nil {
+		return errors.NewValidationError("cannot restore the database inside a transaction", nil)
+	}
+	if err := checkBackup(ctx, path); err != nil {
+		return err
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return errors.NewDatabaseError("restore database", err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(driverConn interface{}) error {
+		driver, ok := driverConn.(restorer)
+		if !ok {
+			return fmt.Errorf("the database driver does not support online restores")
+		}
+		restore, err := driver.NewRestore(path)
+		if err != nil {
+			return err
+		}
+		if _, err := restore.Step(-1); err != nil {
+			restore.Finish()
+			return err
+		}
+		return restore.Finish()
+	})
+	if err != nil {
+		return errors.NewDatabaseError("restore database", err)
+	}
+
+	if err := migrations.RunMigrations(r.db); err != nil {
+		return errors.NewDatabaseError("migrate restored database", err)
+	}
+	return nil
+}
+
+// checkBackup makes sure the file at path is an intact tt database that this version of tt can read
+func checkBackup(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return errors.NewNotFoundError("backup", path)
+		}
+		return errors.NewDatabaseError("open backup", err)
+	}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return errors.NewDatabaseError("open backup", err)
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&result); err != nil {
+		return errors.NewInvalidInputError("backup", path, fmt.Sprintf("not a readable SQLite database: %v", err))
+	}
+	if result != "ok" {
+		return errors.NewInvalidInputError("backup", path, "the database is damaged: "+result)
+	}
+
+	version, err := migrations.SchemaVersion(db)
+	if err != nil {
+		return errors.NewInvalidInputError("backup", path, err.Error())
+	}
+	latest, err := migrations.LatestVersion()
+	if err != nil {
+		return errors.NewDatabaseError("check backup schema", err)
+	}
+	if version > latest {
+		return errors.NewInvalidInputError("backup", path,
+			fmt.Sprintf("its schema version %d is newer than version %d of this tt; upgrade tt to restore it", version, latest))
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*SQLiteRepository, string) {
+		dir := t.TempDir()
+		repo, err := New(filepath.Join(dir, "tt.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { repo.Close() })
+		require.NoError(t, repo.CreateTask(ctx, &Task{TaskName: "Before the backup"}))
+		return repo, dir
+	}
+
+	taskNames := func(t *testing.T, repo *SQLiteRepository) []string {
+		tasks, err := repo.ListTasks(ctx)
+		require.NoError(t, err)
+		var names []string
+		for _, task := range tasks {
+			names = append(names, task.TaskName)
+		}
+		return names
+	}
+
+	// alterBackup runs statements directly against a backup file
+	alterBackup := func(t *testing.T, path string, statements ...string) {
+		db, err := sql.Open("sqlite", path)
+		require.NoError(t, err)
+		defer db.Close()
+		for _, statement := range statements {
+			_, err := db.Exec(statement)
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("restores the database as it was backed up", func(t *testing.T) {
+		repo, dir := setup(t)
+		backup := filepath.Join(dir, "backup.db")
+		require.NoError(t, repo.Backup(ctx, backup))
+
+		require.NoError(t, repo.CreateTask(ctx, &Task{TaskName: "After the backup"}))
+		assert.ElementsMatch(t, []string{"Before the backup", "After the backup"}, taskNames(t, repo))
+
+		require.NoError(t, repo.Restore(ctx, backup))
+		assert.Equal(t, []string{"Before the backup"}, taskNames(t, repo))
+	})
+
+	t.Run("refuses to overwrite an existing file", func(t *testing.T) {
+		repo, dir := setup(t)
+		existing := filepath.Join(dir, "existing.db")
+		require.NoError(t, os.WriteFile(existing, []byte("keep me"), 0644))
+
+		err := repo.Backup(ctx, existing)
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+		content, err := os.ReadFile(existing)
+		require.NoError(t, err)
+		assert.Equal(t, "keep me", string(content))
+	})
+
+	t.Run("migrates backups made by older versions", func(t *testing.T) {
+		repo, dir := setup(t)
+		backup := filepath.Join(dir, "backup.db")
+		require.NoError(t, repo.Backup(ctx, backup))
+		alterBackup(t, backup,
+			"DELETE FROM migrations WHERE version = 10",
+			"ALTER TABLE time_entries DROP COLUMN kept_running_at")
+
+		require.NoError(t, repo.Restore(ctx, backup))
+		require.NoError(t, repo.CreateTimeEntry(ctx, &TimeEntry{TaskID: 1, StartTime: time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)}))
+		entries, err := repo.ListTimeEntries(ctx)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("rejects backups it cannot restore", func(t *testing.T) {
+		repo, dir := setup(t)
+
+		err := repo.Restore(ctx, filepath.Join(dir, "missing.db"))
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeNotFound))
+
+		notADatabase := filepath.Join(dir, "notes.txt")
+		require.NoError(t, os.WriteFile(notADatabase, []byte("not a database at all, just some text"), 0644))
+		err = repo.Restore(ctx, notADatabase)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a readable SQLite database")
+
+		foreign := filepath.Join(dir, "foreign.db")
+		alterBackup(t, foreign, "CREATE TABLE notes (id INTEGER PRIMARY KEY)")
+		err = repo.Restore(ctx, foreign)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a tt database")
+
+		newer := filepath.Join(dir, "newer.db")
+		require.NoError(t, repo.Backup(ctx, newer))
+		alterBackup(t, newer, "INSERT INTO migrations (version, dirty) VALUES (999, FALSE)")
+		err = repo.Restore(ctx, newer)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "schema version 999 is newer")
+
+		assert.Equal(t, []string{"Before the backup"}, taskNames(t, repo), "A rejected backup leaves the database alone")
+	})
+}
@@ -7,6 +7,13 @@ import (
 	"time-tracker/internal/errors"
 )
 
+// DBTX is the subset of *sql.DB and *sql.Tx used to run queries, so helpers work inside and outside transactions
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
 // HandleDatabaseError converts database errors to structured app errors
 func HandleDatabaseError(operation string, err error) error {
 	return errors.NewDatabaseError(operation, err)
@@ -33,7 +40,7 @@ func ValidateRowsAffected(result sql.Result, entityType string, id string) error
 }
 
 // ExecuteWithLastInsertID executes a query and returns the last insert ID
-func ExecuteWithLastInsertID(ctx context.Context, db *sql.DB, query string, args ...interface{}) (int64, error) {
+func ExecuteWithLastInsertID(ctx context.Context, db DBTX, query string, args ...interface{}) (int64, error) {
 	result, err := db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, HandleDatabaseError("execute query", err)
@@ -48,7 +55,7 @@ func ExecuteWithLastInsertID(ctx context.Context, db *sql.DB, query string, args
 }
 
 // ExecuteWithRowsAffected executes a query and validates that rows were affected
-func ExecuteWithRowsAffected(ctx context.Context, db *sql.DB, query string, entityType string, id string, args ...interface{}) error {
+func ExecuteWithRowsAffected(ctx context.Context, db DBTX, query string, entityType string, id string, args ...interface{}) error {
 	result, err := db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return HandleDatabaseError("execute query", err)
@@ -58,7 +65,7 @@ func ExecuteWithRowsAffected(ctx context.Context, db *sql.DB, query string, enti
 }
 
 // QuerySingle executes a query that returns a single row and scans it
-func QuerySingle[T any](ctx context.Context, db *sql.DB, query string, scanFunc func(Scanner) (*T, error), entityType string, id string, args ...interface{}) (*T, error) {
+func QuerySingle[T any](ctx context.Context, db DBTX, query string, scanFunc func(Scanner) (*T, error), entityType string, id string, args ...interface{}) (*T, error) {
 	row := db.QueryRowContext(ctx, query, args...)
 	result, err := scanFunc(row)
 	if err != nil {
@@ -71,7 +78,7 @@ func QuerySingle[T any](ctx context.Context, db *sql.DB, query string, scanFunc
 }
 
 // QueryMultiple executes a query that returns multiple rows and scans them
-func QueryMultiple[T any](ctx context.Context, db *sql.DB, query string, scanFunc func(Rows) ([]*T, error), entityType string, args ...interface{}) ([]*T, error) {
+func QueryMultiple[T any](ctx context.Context, db DBTX, query string, scanFunc func(Rows) ([]*T, error), entityType string, args ...interface{}) ([]*T, error) {
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, HandleDatabaseError("query "+entityType, err)
@@ -84,4 +91,22 @@ func QueryMultiple[T any](ctx context.Context, db *sql.DB, query string, scanFun
 	}
 
 	return results, nil
-}
\ No newline at end of file
+}
+
+// ExecuteInTransaction runs fn inside a transaction, committing on success and rolling back on error
+func ExecuteInTransaction(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleDatabaseError("begin transaction", err)
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return HandleDatabaseError("commit transaction", err)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+-- 1. Recreate tasks without project_id (SQLite doesn't support DROP COLUMN with foreign keys)
+CREATE TABLE tasks_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_name TEXT NOT NULL
+);
+
+INSERT INTO tasks_old (id, task_name)
+SELECT id, task_name FROM tasks;
+
+DROP TABLE tasks;
+ALTER TABLE tasks_old RENAME TO tasks;
+
+-- 2. Drop projects table
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,11 @@
+-- 1. Create projects table; a project without a parent is a top-level client or project
+CREATE TABLE IF NOT EXISTS projects (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    parent_id INTEGER,
+    archived_at DATETIME,
+    FOREIGN KEY (parent_id) REFERENCES projects(id)
+);
+
+-- 2. Add optional project_id column to tasks
+ALTER TABLE tasks ADD COLUMN project_id INTEGER REFERENCES projects(id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_time_entry_tags_tag_id;
+DROP TABLE IF EXISTS time_entry_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,16 @@
+-- 1. Create tags table; names are stored lowercase without their + or # prefix
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE
+);
+
+-- 2. Link tags to time entries
+CREATE TABLE IF NOT EXISTS time_entry_tags (
+    time_entry_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    PRIMARY KEY (time_entry_id, tag_id),
+    FOREIGN KEY (time_entry_id) REFERENCES time_entries(id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entry_tags_tag_id ON time_entry_tags(tag_id);
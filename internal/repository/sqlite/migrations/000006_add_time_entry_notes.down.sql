@@ -0,0 +1,14 @@
+-- 1. Recreate time_entries without note (SQLite doesn't support DROP COLUMN with foreign keys)
+CREATE TABLE time_entries_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    start_time DATETIME NOT NULL,
+    end_time DATETIME,
+    task_id INTEGER NOT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id)
+);
+
+INSERT INTO time_entries_old (id, start_time, end_time, task_id)
+SELECT id, start_time, end_time, task_id FROM time_entries;
+
+DROP TABLE time_entries;
+ALTER TABLE time_entries_old RENAME TO time_entries;
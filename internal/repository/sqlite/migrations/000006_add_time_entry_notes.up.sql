@@ -0,0 +1,2 @@
+-- 1. Add a free-text note to time entries; entries without a note store an empty string
+ALTER TABLE time_entries ADD COLUMN note TEXT NOT NULL DEFAULT '';
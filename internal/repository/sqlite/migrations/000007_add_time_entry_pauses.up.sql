@@ -0,0 +1,5 @@
+-- 1. Link a time entry to the segment it continues after a pause
+ALTER TABLE time_entries ADD COLUMN continues_id INTEGER REFERENCES time_entries(id);
+
+-- 2. Mark entries ended by a pause that have not been continued yet
+ALTER TABLE time_entries ADD COLUMN paused BOOLEAN NOT NULL DEFAULT 0;
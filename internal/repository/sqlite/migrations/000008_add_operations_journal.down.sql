@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_operation_changes_operation_id;
+DROP TABLE IF EXISTS operation_changes;
+DROP TABLE IF EXISTS operations;
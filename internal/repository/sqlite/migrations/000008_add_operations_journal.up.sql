@@ -0,0 +1,20 @@
+-- 1. Journal of operations that changed data, so they can be undone
+CREATE TABLE IF NOT EXISTS operations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    description TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    undone_at DATETIME
+);
+
+-- 2. Rows changed by each operation; before_json holds the row as it was, NULL when the operation created it
+CREATE TABLE IF NOT EXISTS operation_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operation_id INTEGER NOT NULL,
+    table_name TEXT NOT NULL,
+    row_id INTEGER NOT NULL,
+    before_json TEXT,
+    FOREIGN KEY (operation_id) REFERENCES operations(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_operation_changes_operation_id ON operation_changes(operation_id);
@@ -0,0 +1,12 @@
+-- 1. Recreate tasks without archived_at (SQLite doesn't support DROP COLUMN with foreign keys)
+CREATE TABLE tasks_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_name TEXT NOT NULL,
+    project_id INTEGER REFERENCES projects(id)
+);
+
+INSERT INTO tasks_old (id, task_name, project_id)
+SELECT id, task_name, project_id FROM tasks;
+
+DROP TABLE tasks;
+ALTER TABLE tasks_old RENAME TO tasks;
@@ -0,0 +1,2 @@
+-- 1. Archived tasks are left out of task searches and menus; their time still counts in reports
+ALTER TABLE tasks ADD COLUMN archived_at DATETIME;
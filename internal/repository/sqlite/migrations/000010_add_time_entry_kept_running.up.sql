@@ -0,0 +1,2 @@
+-- 1. Mark running entries the user chose to keep running after being warned they look forgotten
+ALTER TABLE time_entries ADD COLUMN kept_running_at DATETIME;
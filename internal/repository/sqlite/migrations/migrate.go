@@ -125,6 +125,39 @@ func RunMigrations(db *sql.DB) error {
 	return nil
 }
 
+// LatestVersion returns the version of the newest migration, the schema version RunMigrations brings databases to
+func LatestVersion() (int, error) {
+	migrations, err := loadAllMigrations()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// SchemaVersion returns the version of the newest migration applied to db. It fails when db has no
+// migrations table, so is not a tt database, or when a migration failed and left it dirty.
+func SchemaVersion(db *sql.DB) (int, error) {
+	var tables int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'migrations'").Scan(&tables); err != nil {
+		return 0, fmt.Errorf("failed to look for the migrations table: %w", err)
+	}
+	if tables == 0 {
+		return 0, fmt.Errorf("no migrations table found; this is not a tt database")
+	}
+	if err := checkDirtyDatabase(db); err != nil {
+		return 0, err
+	}
+
+	var version int
+	if err := db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM migrations WHERE dirty = FALSE").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read the schema version: %w", err)
+	}
+	return version, nil
+}
+
 func getDatabasePath(db *sql.DB) (string, error) {
 	// For SQLite, we need to get the database path from the connection
 	// This is a simplified approach - in practice, the database path should be passed in
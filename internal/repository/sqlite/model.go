@@ -6,16 +6,63 @@ import "time"
 // Add this struct for the new tasks table
 //
 type Task struct {
-	ID       int64
-	TaskName string
+	ID         int64
+	TaskName   string
+	ProjectID  *int64     // NULL when the task belongs to no project
+	ArchivedAt *time.Time // NULL unless the task is archived
+}
+
+// Project represents a project, or a client when it has no parent
+type Project struct {
+	ID         int64
+	Name       string
+	ParentID   *int64     // NULL for top-level projects and clients
+	ArchivedAt *time.Time // NULL while the project is active
+}
+
+// TimeEntryTag links a tag, by name, to a time entry
+type TimeEntryTag struct {
+	TimeEntryID int64
+	Name        string
+}
+
+// Tables whose rows are recorded in the operations journal
+const (
+	TableTasks         = "tasks"
+	TableTimeEntries   = "time_entries"
+	TableTimeEntryTags = "time_entry_tags"
+	TableProjects      = "projects"
+)
+
+// Operation is an entry of the operations journal, describing a change that can be undone
+type Operation struct {
+	ID          int64
+	Kind        string // What was done, such as "start" or "delete"
+	Description string
+	CreatedAt   time.Time
+	UndoneAt    *time.Time // NULL until the operation is undone
+	ChangeCount int        // Number of rows changed, filled in when listing operations
+}
+
+// OperationChange records a row changed by an operation together with its contents before the change
+type OperationChange struct {
+	ID          int64
+	OperationID int64
+	TableName   string  // One of the Table constants
+	RowID       int64   // ID of the changed row; the time entry ID for tags
+	BeforeJSON  *string // The row before the change as JSON, NULL when the operation created it
 }
 
 // TimeEntry represents a single time tracking entry
 // Update to use TaskID instead of Description
 //
 type TimeEntry struct {
-	ID        int64
-	TaskID    int64
-	StartTime time.Time
-	EndTime   *time.Time // Using pointer to allow NULL values
+	ID            int64
+	TaskID        int64
+	StartTime     time.Time
+	EndTime       *time.Time // Using pointer to allow NULL values
+	Note          string     // Free-text note, empty when the entry has none
+	ContinuesID   *int64     // Entry this one continues after a pause, NULL for a new session
+	Paused        bool       // Ended by a pause and not continued yet
+	KeptRunningAt *time.Time // When the user chose to keep the entry running after it looked forgotten
 } 
\ No newline at end of file
@@ -25,10 +25,14 @@ const (
 
 // SearchOptions contains all possible search parameters
 type SearchOptions struct {
-	StartTime *time.Time
-	EndTime   *time.Time
-	TaskID    *int64
-	TaskName  *string
+	StartTime   *time.Time
+	EndTime     *time.Time
+	TaskID      *int64
+	TaskName    *string
+	ProjectIDs  []int64  // Only entries of tasks in one of these projects
+	Tags        []string // Only entries carrying every one of these tags
+	ExcludeTags []string // Only entries carrying none of these tags
+	PausedOnly  bool     // Only entries ended by a pause that have not been continued
 }
 
 // Repository defines the interface for database operations
@@ -36,6 +40,7 @@ type Repository interface {
 	// Create operations
 	CreateTimeEntry(ctx context.Context, entry *TimeEntry) error
 	CreateTask(ctx context.Context, task *Task) error
+	CreateProject(ctx context.Context, project *Project) error
 
 	// Read operations
 	GetTimeEntry(ctx context.Context, id int64) (*TimeEntry, error)
@@ -43,14 +48,41 @@ type Repository interface {
 	SearchTimeEntries(ctx context.Context, opts SearchOptions) ([]*TimeEntry, error)
 	GetTask(ctx context.Context, id int64) (*Task, error)
 	ListTasks(ctx context.Context) ([]*Task, error)
+	GetProject(ctx context.Context, id int64) (*Project, error)
+	ListProjects(ctx context.Context) ([]*Project, error)
+	ListTimeEntryTags(ctx context.Context, entryIDs []int64) ([]*TimeEntryTag, error)
 
 	// Update operations
 	UpdateTimeEntry(ctx context.Context, entry *TimeEntry) error
 	UpdateTask(ctx context.Context, task *Task) error
+	UpdateProject(ctx context.Context, project *Project) error
+	SetTimeEntryTags(ctx context.Context, entryID int64, tags []string) error
+	MergeTasks(ctx context.Context, fromID int64, intoID int64) (int64, error)
+
+	// Restore operations put back rows with their original IDs, inserting or overwriting them
+	RestoreTimeEntry(ctx context.Context, entry *TimeEntry) error
+	RestoreTask(ctx context.Context, task *Task) error
+	RestoreProject(ctx context.Context, project *Project) error
+
+	// Operations journal
+	CreateOperation(ctx context.Context, operation *Operation, changes []*OperationChange) error
+	ListOperations(ctx context.Context, limit int, includeUndone bool) ([]*Operation, error)
+	ListOperationsAfter(ctx context.Context, afterID int64) ([]*Operation, error)
+	ListOperationChanges(ctx context.Context, operationID int64) ([]*OperationChange, error)
+	MarkOperationUndone(ctx context.Context, id int64, undoneAt time.Time) error
+	PruneOperations(ctx context.Context, keep int) error
+
+	// Transactions
+	WithTransaction(ctx context.Context, fn func(repo Repository) error) error
 
 	// Delete operations
 	DeleteTimeEntry(ctx context.Context, id int64) error
 	DeleteTask(ctx context.Context, id int64) error
+	DeleteProject(ctx context.Context, id int64) error
+
+	// Backups
+	Backup(ctx context.Context, path string) error
+	Restore(ctx context.Context, path string) error
 
 	// Utility
 	Close() error
@@ -65,9 +97,34 @@ type DatabaseConfig interface {
 // SQLiteRepository implements the Repository interface
 type SQLiteRepository struct {
 	db     *sql.DB
+	tx     *sql.Tx // Set on repositories bound to a transaction by WithTransaction
 	config DatabaseConfig
 }
 
+// conn returns the transaction the repository is bound to, or the database otherwise
+func (r *SQLiteRepository) conn() DBTX {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// inTransaction runs fn in the repository's transaction, starting a new one if it is not bound to one
+func (r *SQLiteRepository) inTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+	return ExecuteInTransaction(ctx, r.db, fn)
+}
+
+// WithTransaction runs fn with a repository bound to a single transaction, committing if fn succeeds
+// and rolling back if it returns an error. Nested calls join the outer transaction.
+func (r *SQLiteRepository) WithTransaction(ctx context.Context, fn func(repo Repository) error) error {
+	return r.inTransaction(ctx, func(tx *sql.Tx) error {
+		return fn(&SQLiteRepository{db: r.db, tx: tx, config: r.config})
+	})
+}
+
 // withTimeout creates a context with timeout for database operations
 func (r *SQLiteRepository) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, timeout)
@@ -112,8 +169,11 @@ func NewWithConfig(dbPath string, config DatabaseConfig) (*SQLiteRepository, err
 	return &SQLiteRepository{db: db, config: config}, nil
 }
 
-// Close closes the database connection
+// Close closes the database connection. It does nothing on a repository bound to a transaction.
 func (r *SQLiteRepository) Close() error {
+	if r.tx != nil {
+		return nil
+	}
 	return r.db.Close()
 }
 
@@ -124,10 +184,10 @@ func (r *SQLiteRepository) CreateTimeEntry(ctx context.Context, entry *TimeEntry
 	defer cancel()
 	
 	query := `
-	INSERT INTO time_entries (start_time, end_time, task_id)
-	VALUES (?, ?, ?)`
+	INSERT INTO time_entries (start_time, end_time, task_id, note, continues_id, paused, kept_running_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)`
 
-	id, err := ExecuteWithLastInsertID(timeoutCtx, r.db, query, FormatTimeForDB(entry.StartTime), FormatTimePtrForDB(entry.EndTime), entry.TaskID)
+	id, err := ExecuteWithLastInsertID(timeoutCtx, r.conn(), query, FormatTimeForDB(entry.StartTime), FormatTimePtrForDB(entry.EndTime), entry.TaskID, entry.Note, entry.ContinuesID, entry.Paused, FormatTimePtrForDB(entry.KeptRunningAt))
 	if err != nil {
 		return err
 	}
@@ -143,43 +203,113 @@ func (r *SQLiteRepository) GetTimeEntry(ctx context.Context, id int64) (*TimeEnt
 	defer cancel()
 	
 	query := `
-	SELECT id, start_time, end_time, task_id
+	SELECT id, start_time, end_time, task_id, note, continues_id, paused, kept_running_at
 	FROM time_entries
 	WHERE id = ?`
 
-	return QuerySingle(timeoutCtx, r.db, query, ScanTimeEntry, "time entry", fmt.Sprintf("%d", id), id)
+	return QuerySingle(timeoutCtx, r.conn(), query, ScanTimeEntry, "time entry", fmt.Sprintf("%d", id), id)
 }
 
 // ListTimeEntries retrieves all time entries
 func (r *SQLiteRepository) ListTimeEntries(ctx context.Context) ([]*TimeEntry, error) {
 	query := `
-	SELECT id, start_time, end_time, task_id
+	SELECT id, start_time, end_time, task_id, note, continues_id, paused, kept_running_at
 	FROM time_entries
 	ORDER BY start_time ASC`
 
-	return QueryMultiple(ctx, r.db, query, ScanTimeEntries, "time entries")
+	return QueryMultiple(ctx, r.conn(), query, ScanTimeEntries, "time entries")
 }
 
 // UpdateTimeEntry updates an existing time entry
 func (r *SQLiteRepository) UpdateTimeEntry(ctx context.Context, entry *TimeEntry) error {
 	query := `
 	UPDATE time_entries
-	SET start_time = ?, end_time = ?, task_id = ?
+	SET start_time = ?, end_time = ?, task_id = ?, note = ?, continues_id = ?, paused = ?, kept_running_at = ?
 	WHERE id = ?`
 
-	return ExecuteWithRowsAffected(ctx, r.db, query, "time entry", fmt.Sprintf("%d", entry.ID), FormatTimeForDB(entry.StartTime), FormatTimePtrForDB(entry.EndTime), entry.TaskID, entry.ID)
+	return ExecuteWithRowsAffected(ctx, r.conn(), query, "time entry", fmt.Sprintf("%d", entry.ID), FormatTimeForDB(entry.StartTime), FormatTimePtrForDB(entry.EndTime), entry.TaskID, entry.Note, entry.ContinuesID, entry.Paused, FormatTimePtrForDB(entry.KeptRunningAt), entry.ID)
 }
 
-// DeleteTimeEntry deletes a time entry by ID
+// DeleteTimeEntry deletes a time entry by ID together with its tag links, unlinking segments that continue it
 func (r *SQLiteRepository) DeleteTimeEntry(ctx context.Context, id int64) error {
-	query := `DELETE FROM time_entries WHERE id = ?`
-	return ExecuteWithRowsAffected(ctx, r.db, query, "time entry", fmt.Sprintf("%d", id), id)
+	return r.inTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM time_entry_tags WHERE time_entry_id = ?`, id); err != nil {
+			return HandleDatabaseError("delete time entry tags", err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE time_entries SET continues_id = NULL WHERE continues_id = ?`, id); err != nil {
+			return HandleDatabaseError("unlink continued time entries", err)
+		}
+		query := `DELETE FROM time_entries WHERE id = ?`
+		return ExecuteWithRowsAffected(ctx, tx, query, "time entry", fmt.Sprintf("%d", id), id)
+	})
+}
+
+// SetTimeEntryTags replaces the tags of a time entry, creating tags that don't exist yet
+func (r *SQLiteRepository) SetTimeEntryTags(ctx context.Context, entryID int64, tags []string) error {
+	timeoutCtx, cancel := r.withWriteTimeout(ctx)
+	defer cancel()
+
+	return r.inTransaction(timeoutCtx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(timeoutCtx, `DELETE FROM time_entry_tags WHERE time_entry_id = ?`, entryID); err != nil {
+			return HandleDatabaseError("clear time entry tags", err)
+		}
+		for _, tag := range tags {
+			if _, err := tx.ExecContext(timeoutCtx, `INSERT OR IGNORE INTO tags (name) VALUES (?)`, tag); err != nil {
+				return HandleDatabaseError("create tag", err)
+			}
+			query := `
+			INSERT INTO time_entry_tags (time_entry_id, tag_id)
+			SELECT ?, id FROM tags WHERE name = ?`
+			if _, err := tx.ExecContext(timeoutCtx, query, entryID, tag); err != nil {
+				return HandleDatabaseError("tag time entry", err)
+			}
+		}
+		return nil
+	})
+}
+
+// maxTagLookupIDs limits the number of time entry IDs bound to a single tag query
+const maxTagLookupIDs = 500
+
+// ListTimeEntryTags retrieves the tags of the given time entries, ordered by entry and tag name
+func (r *SQLiteRepository) ListTimeEntryTags(ctx context.Context, entryIDs []int64) ([]*TimeEntryTag, error) {
+	timeoutCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	var tags []*TimeEntryTag
+	for start := 0; start < len(entryIDs); start += maxTagLookupIDs {
+		end := start + maxTagLookupIDs
+		if end > len(entryIDs) {
+			end = len(entryIDs)
+		}
+
+		placeholders := make([]string, 0, end-start)
+		args := make([]interface{}, 0, end-start)
+		for _, id := range entryIDs[start:end] {
+			placeholders = append(placeholders, "?")
+			args = append(args, id)
+		}
+
+		query := `
+		SELECT time_entry_tags.time_entry_id, tags.name
+		FROM time_entry_tags
+		JOIN tags ON tags.id = time_entry_tags.tag_id
+		WHERE time_entry_tags.time_entry_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY time_entry_tags.time_entry_id ASC, tags.name ASC`
+
+		batch, err := QueryMultiple(timeoutCtx, r.conn(), query, ScanTimeEntryTags, "time entry tags", args...)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, batch...)
+	}
+	return tags, nil
 }
 
 // CreateTask creates a new task
 func (r *SQLiteRepository) CreateTask(ctx context.Context, task *Task) error {
-	query := `INSERT INTO tasks (task_name) VALUES (?)`
-	id, err := ExecuteWithLastInsertID(ctx, r.db, query, task.TaskName)
+	query := `INSERT INTO tasks (task_name, project_id, archived_at) VALUES (?, ?, ?)`
+	id, err := ExecuteWithLastInsertID(ctx, r.conn(), query, task.TaskName, task.ProjectID, FormatTimePtrForDB(task.ArchivedAt))
 	if err != nil {
 		return err
 	}
@@ -189,28 +319,240 @@ func (r *SQLiteRepository) CreateTask(ctx context.Context, task *Task) error {
 
 // GetTask retrieves a task by ID
 func (r *SQLiteRepository) GetTask(ctx context.Context, id int64) (*Task, error) {
-	query := `SELECT id, task_name FROM tasks WHERE id = ?`
-	return QuerySingle(ctx, r.db, query, ScanTask, "task", fmt.Sprintf("%d", id), id)
+	query := `SELECT id, task_name, project_id, archived_at FROM tasks WHERE id = ?`
+	return QuerySingle(ctx, r.conn(), query, ScanTask, "task", fmt.Sprintf("%d", id), id)
 }
 
 // ListTasks retrieves all tasks
 func (r *SQLiteRepository) ListTasks(ctx context.Context) ([]*Task, error) {
-	query := `SELECT id, task_name FROM tasks ORDER BY task_name ASC`
-	return QueryMultiple(ctx, r.db, query, ScanTasks, "tasks")
+	query := `SELECT id, task_name, project_id, archived_at FROM tasks ORDER BY task_name ASC`
+	return QueryMultiple(ctx, r.conn(), query, ScanTasks, "tasks")
 }
 
 // UpdateTask updates an existing task
 func (r *SQLiteRepository) UpdateTask(ctx context.Context, task *Task) error {
-	query := `UPDATE tasks SET task_name = ? WHERE id = ?`
-	return ExecuteWithRowsAffected(ctx, r.db, query, "task", fmt.Sprintf("%d", task.ID), task.TaskName, task.ID)
+	query := `UPDATE tasks SET task_name = ?, project_id = ?, archived_at = ? WHERE id = ?`
+	return ExecuteWithRowsAffected(ctx, r.conn(), query, "task", fmt.Sprintf("%d", task.ID), task.TaskName, task.ProjectID, FormatTimePtrForDB(task.ArchivedAt), task.ID)
+}
+
+// CreateProject creates a new project
+func (r *SQLiteRepository) CreateProject(ctx context.Context, project *Project) error {
+	query := `INSERT INTO projects (name, parent_id, archived_at) VALUES (?, ?, ?)`
+	id, err := ExecuteWithLastInsertID(ctx, r.conn(), query, project.Name, project.ParentID, FormatTimePtrForDB(project.ArchivedAt))
+	if err != nil {
+		return err
+	}
+	project.ID = id
+	return nil
+}
+
+// GetProject retrieves a project by ID
+func (r *SQLiteRepository) GetProject(ctx context.Context, id int64) (*Project, error) {
+	query := `SELECT id, name, parent_id, archived_at FROM projects WHERE id = ?`
+	return QuerySingle(ctx, r.conn(), query, ScanProject, "project", fmt.Sprintf("%d", id), id)
+}
+
+// ListProjects retrieves all projects, including archived ones
+func (r *SQLiteRepository) ListProjects(ctx context.Context) ([]*Project, error) {
+	query := `SELECT id, name, parent_id, archived_at FROM projects ORDER BY name ASC`
+	return QueryMultiple(ctx, r.conn(), query, ScanProjects, "projects")
+}
+
+// UpdateProject updates an existing project
+func (r *SQLiteRepository) UpdateProject(ctx context.Context, project *Project) error {
+	query := `UPDATE projects SET name = ?, parent_id = ?, archived_at = ? WHERE id = ?`
+	return ExecuteWithRowsAffected(ctx, r.conn(), query, "project", fmt.Sprintf("%d", project.ID), project.Name, project.ParentID, FormatTimePtrForDB(project.ArchivedAt), project.ID)
+}
+
+// MergeTasks moves every time entry from one task to another and deletes the emptied task
+// in a single transaction. It returns the number of time entries moved.
+func (r *SQLiteRepository) MergeTasks(ctx context.Context, fromID int64, intoID int64) (int64, error) {
+	timeoutCtx, cancel := r.withWriteTimeout(ctx)
+	defer cancel()
+
+	var moved int64
+	err := r.inTransaction(timeoutCtx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(timeoutCtx, `UPDATE time_entries SET task_id = ? WHERE task_id = ?`, intoID, fromID)
+		if err != nil {
+			return HandleDatabaseError("move time entries", err)
+		}
+		moved, err = result.RowsAffected()
+		if err != nil {
+			return HandleDatabaseError("get rows affected", err)
+		}
+
+		result, err = tx.ExecContext(timeoutCtx, `DELETE FROM tasks WHERE id = ?`, fromID)
+		if err != nil {
+			return HandleDatabaseError("delete merged task", err)
+		}
+		return ValidateRowsAffected(result, "task", fmt.Sprintf("%d", fromID))
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
 }
 
 // DeleteTask deletes a task by ID
 func (r *SQLiteRepository) DeleteTask(ctx context.Context, id int64) error {
 	query := `DELETE FROM tasks WHERE id = ?`
-	return ExecuteWithRowsAffected(ctx, r.db, query, "task", fmt.Sprintf("%d", id), id)
+	return ExecuteWithRowsAffected(ctx, r.conn(), query, "task", fmt.Sprintf("%d", id), id)
+}
+
+// DeleteProject deletes a project by ID
+func (r *SQLiteRepository) DeleteProject(ctx context.Context, id int64) error {
+	query := `DELETE FROM projects WHERE id = ?`
+	return ExecuteWithRowsAffected(ctx, r.conn(), query, "project", fmt.Sprintf("%d", id), id)
+}
+
+// RestoreTimeEntry inserts a time entry with its original ID, or overwrites the entry with that ID
+func (r *SQLiteRepository) RestoreTimeEntry(ctx context.Context, entry *TimeEntry) error {
+	query := `
+	INSERT INTO time_entries (id, start_time, end_time, task_id, note, continues_id, paused, kept_running_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET
+		start_time = excluded.start_time, end_time = excluded.end_time, task_id = excluded.task_id,
+		note = excluded.note, continues_id = excluded.continues_id, paused = excluded.paused,
+		kept_running_at = excluded.kept_running_at`
+
+	_, err := r.conn().ExecContext(ctx, query, entry.ID, FormatTimeForDB(entry.StartTime), FormatTimePtrForDB(entry.EndTime), entry.TaskID, entry.Note, entry.ContinuesID, entry.Paused, FormatTimePtrForDB(entry.KeptRunningAt))
+	if err != nil {
+		return HandleDatabaseError("restore time entry", err)
+	}
+	return nil
 }
 
+// RestoreTask inserts a task with its original ID, or overwrites the task with that ID
+func (r *SQLiteRepository) RestoreTask(ctx context.Context, task *Task) error {
+	query := `
+	INSERT INTO tasks (id, task_name, project_id, archived_at) VALUES (?, ?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET task_name = excluded.task_name, project_id = excluded.project_id, archived_at = excluded.archived_at`
+
+	if _, err := r.conn().ExecContext(ctx, query, task.ID, task.TaskName, task.ProjectID, FormatTimePtrForDB(task.ArchivedAt)); err != nil {
+		return HandleDatabaseError("restore task", err)
+	}
+	return nil
+}
+
+// RestoreProject inserts a project with its original ID, or overwrites the project with that ID
+func (r *SQLiteRepository) RestoreProject(ctx context.Context, project *Project) error {
+	query := `
+	INSERT INTO projects (id, name, parent_id, archived_at) VALUES (?, ?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET name = excluded.name, parent_id = excluded.parent_id, archived_at = excluded.archived_at`
+
+	if _, err := r.conn().ExecContext(ctx, query, project.ID, project.Name, project.ParentID, FormatTimePtrForDB(project.ArchivedAt)); err != nil {
+		return HandleDatabaseError("restore project", err)
+	}
+	return nil
+}
+
+// CreateOperation adds an operation and its changes to the journal in a single transaction
+func (r *SQLiteRepository) CreateOperation(ctx context.Context, operation *Operation, changes []*OperationChange) error {
+	timeoutCtx, cancel := r.withWriteTimeout(ctx)
+	defer cancel()
+
+	return r.inTransaction(timeoutCtx, func(tx *sql.Tx) error {
+		query := `INSERT INTO operations (kind, description, created_at) VALUES (?, ?, ?)`
+		id, err := ExecuteWithLastInsertID(timeoutCtx, tx, query, operation.Kind, operation.Description, FormatTimeForDB(operation.CreatedAt))
+		if err != nil {
+			return err
+		}
+		operation.ID = id
+
+		for _, change := range changes {
+			change.OperationID = id
+			query := `INSERT INTO operation_changes (operation_id, table_name, row_id, before_json) VALUES (?, ?, ?, ?)`
+			change.ID, err = ExecuteWithLastInsertID(timeoutCtx, tx, query, id, change.TableName, change.RowID, change.BeforeJSON)
+			if err != nil {
+				return err
+			}
+		}
+		operation.ChangeCount = len(changes)
+		return nil
+	})
+}
+
+// ListOperations retrieves up to limit journaled operations, newest first, leaving out undone ones
+// unless includeUndone is set. A limit of 0 or less returns every operation.
+func (r *SQLiteRepository) ListOperations(ctx context.Context, limit int, includeUndone bool) ([]*Operation, error) {
+	timeoutCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+	SELECT operations.id, operations.kind, operations.description, operations.created_at, operations.undone_at,
+		(SELECT COUNT(*) FROM operation_changes WHERE operation_changes.operation_id = operations.id)
+	FROM operations`
+	if !includeUndone {
+		query += ` WHERE operations.undone_at IS NULL`
+	}
+	query += ` ORDER BY operations.id DESC`
+
+	var args []interface{}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	return QueryMultiple(timeoutCtx, r.conn(), query, ScanOperations, "operations", args...)
+}
+
+// ListOperationsAfter retrieves the operations journaled after the one with the given ID, oldest first,
+// including undone ones
+func (r *SQLiteRepository) ListOperationsAfter(ctx context.Context, afterID int64) ([]*Operation, error) {
+	timeoutCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+	SELECT operations.id, operations.kind, operations.description, operations.created_at, operations.undone_at,
+		(SELECT COUNT(*) FROM operation_changes WHERE operation_changes.operation_id = operations.id)
+	FROM operations
+	WHERE operations.id > ?
+	ORDER BY operations.id ASC`
+
+	return QueryMultiple(timeoutCtx, r.conn(), query, ScanOperations, "operations", afterID)
+}
+
+// ListOperationChanges retrieves the changes of an operation in the order they were made
+func (r *SQLiteRepository) ListOperationChanges(ctx context.Context, operationID int64) ([]*OperationChange, error) {
+	timeoutCtx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+	SELECT id, operation_id, table_name, row_id, before_json
+	FROM operation_changes
+	WHERE operation_id = ?
+	ORDER BY id ASC`
+
+	return QueryMultiple(timeoutCtx, r.conn(), query, ScanOperationChanges, "operation changes", operationID)
+}
+
+// MarkOperationUndone records when an operation was undone
+func (r *SQLiteRepository) MarkOperationUndone(ctx context.Context, id int64, undoneAt time.Time) error {
+	query := `UPDATE operations SET undone_at = ? WHERE id = ?`
+	return ExecuteWithRowsAffected(ctx, r.conn(), query, "operation", fmt.Sprintf("%d", id), FormatTimeForDB(undoneAt), id)
+}
+
+// PruneOperations removes all but the newest keep operations from the journal, together with their changes
+func (r *SQLiteRepository) PruneOperations(ctx context.Context, keep int) error {
+	timeoutCtx, cancel := r.withWriteTimeout(ctx)
+	defer cancel()
+
+	return r.inTransaction(timeoutCtx, func(tx *sql.Tx) error {
+		oldest := `SELECT id FROM operations ORDER BY id DESC LIMIT -1 OFFSET ?`
+		if _, err := tx.ExecContext(timeoutCtx, `DELETE FROM operation_changes WHERE operation_id IN (`+oldest+`)`, keep); err != nil {
+			return HandleDatabaseError("prune operation changes", err)
+		}
+		if _, err := tx.ExecContext(timeoutCtx, `DELETE FROM operations WHERE id IN (`+oldest+`)`, keep); err != nil {
+			return HandleDatabaseError("prune operations", err)
+		}
+		return nil
+	})
+}
+
+// taggedEntriesQuery selects the IDs of time entries by tag name, to be completed with a WHERE clause
+const taggedEntriesQuery = "SELECT time_entry_tags.time_entry_id FROM time_entry_tags JOIN tags ON tags.id = time_entry_tags.tag_id"
+
 // SearchTimeEntries searches for time entries based on the provided options
 func (r *SQLiteRepository) SearchTimeEntries(ctx context.Context, opts SearchOptions) ([]*TimeEntry, error) {
 	// Add timeout for potentially long-running search operations
@@ -235,7 +577,7 @@ func (r *SQLiteRepository) SearchTimeEntries(ctx context.Context, opts SearchOpt
 		}
 		timeCondition += ")"
 		conditions = append(conditions, timeCondition)
-	} else if opts.TaskID == nil && opts.TaskName == nil {
+	} else if opts.TaskID == nil && opts.TaskName == nil && len(opts.ProjectIDs) == 0 && len(opts.Tags) == 0 && len(opts.ExcludeTags) == 0 && !opts.PausedOnly {
 		// Only filter for running tasks if no search criteria are provided
 		conditions = append(conditions, "end_time IS NULL")
 	}
@@ -254,9 +596,39 @@ func (r *SQLiteRepository) SearchTimeEntries(ctx context.Context, opts SearchOpt
 		args = append(args, "%"+*opts.TaskName+"%")
 	}
 
+	// Build project condition (join with tasks)
+	if len(opts.ProjectIDs) > 0 {
+		joinTasks = true
+		placeholders := make([]string, len(opts.ProjectIDs))
+		for i, projectID := range opts.ProjectIDs {
+			placeholders[i] = "?"
+			args = append(args, projectID)
+		}
+		conditions = append(conditions, "tasks.project_id IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	// Build tag conditions: every included tag must be present and no excluded tag may be
+	for _, tag := range opts.Tags {
+		conditions = append(conditions, "time_entries.id IN ("+taggedEntriesQuery+" WHERE tags.name = ?)")
+		args = append(args, tag)
+	}
+	if len(opts.ExcludeTags) > 0 {
+		placeholders := make([]string, len(opts.ExcludeTags))
+		for i, tag := range opts.ExcludeTags {
+			placeholders[i] = "?"
+			args = append(args, tag)
+		}
+		conditions = append(conditions, "time_entries.id NOT IN ("+taggedEntriesQuery+" WHERE tags.name IN ("+strings.Join(placeholders, ", ")+"))")
+	}
+
+	// Build pause condition
+	if opts.PausedOnly {
+		conditions = append(conditions, "paused = 1")
+	}
+
 	// Build the final query
 	query := `
-	SELECT time_entries.id, start_time, end_time, task_id
+	SELECT time_entries.id, start_time, end_time, task_id, note, continues_id, paused, kept_running_at
 	FROM time_entries`
 	if joinTasks {
 		query += " JOIN tasks ON time_entries.task_id = tasks.id"
@@ -267,5 +639,5 @@ func (r *SQLiteRepository) SearchTimeEntries(ctx context.Context, opts SearchOpt
 	query += " ORDER BY start_time ASC"
 
 	// Execute the query
-	return QueryMultiple(timeoutCtx, r.db, query, ScanTimeEntries, "time entries", args...)
+	return QueryMultiple(timeoutCtx, r.conn(), query, ScanTimeEntries, "time entries", args...)
 }
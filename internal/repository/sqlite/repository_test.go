@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -145,6 +146,7 @@ func TestUpdateTimeEntry(t *testing.T) {
 	entry.StartTime = newTime
 	entry.EndTime = &endTime
 	entry.TaskID = task.ID
+	entry.Note = "reviewing PR 412"
 
 	err = repo.UpdateTimeEntry(context.Background(), entry)
 	require.NoError(t, err)
@@ -155,6 +157,7 @@ func TestUpdateTimeEntry(t *testing.T) {
 	assert.Equal(t, newTime.Unix(), retrieved.StartTime.Unix())
 	assert.Equal(t, endTime.Unix(), retrieved.EndTime.Unix())
 	assert.Equal(t, task.ID, retrieved.TaskID)
+	assert.Equal(t, "reviewing PR 412", retrieved.Note)
 
 	// Test updating non-existent entry
 	nonExistent := &TimeEntry{ID: 999, TaskID: task.ID}
@@ -302,6 +305,351 @@ func TestSearchTimeEntries(t *testing.T) {
 	}
 }
 
+func TestPausedTimeEntries(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	task := &Task{TaskName: "Write report"}
+	require.NoError(t, repo.CreateTask(ctx, task))
+
+	start := time.Now().Add(-2 * time.Hour)
+	pausedAt := start.Add(30 * time.Minute)
+	paused := &TimeEntry{TaskID: task.ID, StartTime: start, EndTime: &pausedAt, Paused: true}
+	require.NoError(t, repo.CreateTimeEntry(ctx, paused))
+	stopped := &TimeEntry{TaskID: task.ID, StartTime: start.Add(-time.Hour), EndTime: &start}
+	require.NoError(t, repo.CreateTimeEntry(ctx, stopped))
+
+	// Only the paused entry is found
+	found, err := repo.SearchTimeEntries(ctx, SearchOptions{PausedOnly: true})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, paused.ID, found[0].ID)
+	assert.True(t, found[0].Paused)
+
+	// Continuing links the new segment to the paused one
+	continued := &TimeEntry{TaskID: task.ID, StartTime: pausedAt.Add(10 * time.Minute), ContinuesID: &paused.ID}
+	require.NoError(t, repo.CreateTimeEntry(ctx, continued))
+	retrieved, err := repo.GetTimeEntry(ctx, continued.ID)
+	require.NoError(t, err)
+	require.NotNil(t, retrieved.ContinuesID)
+	assert.Equal(t, paused.ID, *retrieved.ContinuesID)
+
+	// Deleting the paused segment unlinks its continuation
+	require.NoError(t, repo.DeleteTimeEntry(ctx, paused.ID))
+	retrieved, err = repo.GetTimeEntry(ctx, continued.ID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved.ContinuesID)
+}
+
+func TestMergeTasks(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	from := &Task{TaskName: "code reveiw"}
+	require.NoError(t, repo.CreateTask(ctx, from))
+	into := &Task{TaskName: "Code review"}
+	require.NoError(t, repo.CreateTask(ctx, into))
+
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		end := now.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, repo.CreateTimeEntry(ctx, &TimeEntry{TaskID: from.ID, StartTime: end.Add(-30 * time.Minute), EndTime: &end}))
+	}
+
+	// Test merging moves entries and deletes the source task
+	moved, err := repo.MergeTasks(ctx, from.ID, into.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+
+	_, err = repo.GetTask(ctx, from.ID)
+	assert.Error(t, err)
+
+	entries, err := repo.SearchTimeEntries(ctx, SearchOptions{TaskID: &into.ID})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// Test merging a missing task rolls back and reports not found
+	_, err = repo.MergeTasks(ctx, from.ID, into.ID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestArchivedTasks(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	task := &Task{TaskName: "Old experiment"}
+	require.NoError(t, repo.CreateTask(ctx, task))
+
+	// Test archiving persists the time the task was archived
+	archivedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	task.ArchivedAt = &archivedAt
+	require.NoError(t, repo.UpdateTask(ctx, task))
+
+	stored, err := repo.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.ArchivedAt)
+	assert.True(t, archivedAt.Equal(*stored.ArchivedAt))
+
+	tasks, err := repo.ListTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.NotNil(t, tasks[0].ArchivedAt)
+
+	// Test unarchiving clears it again
+	task.ArchivedAt = nil
+	require.NoError(t, repo.UpdateTask(ctx, task))
+
+	stored, err = repo.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.ArchivedAt)
+}
+
+func TestWithTransaction(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Test a failing function rolls back every write made through the transaction
+	failure := errors.New("abort")
+	err := repo.WithTransaction(ctx, func(tx Repository) error {
+		if err := tx.CreateTask(ctx, &Task{TaskName: "Rolled back"}); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	tasks, err := repo.ListTasks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	// Test a successful function commits its writes
+	err = repo.WithTransaction(ctx, func(tx Repository) error {
+		task := &Task{TaskName: "Committed"}
+		if err := tx.CreateTask(ctx, task); err != nil {
+			return err
+		}
+		end := time.Now()
+		return tx.CreateTimeEntry(ctx, &TimeEntry{TaskID: task.ID, StartTime: end.Add(-time.Hour), EndTime: &end})
+	})
+	require.NoError(t, err)
+
+	tasks, err = repo.ListTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Committed", tasks[0].TaskName)
+}
+
+func TestProjects(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Test creating a client with a project below it
+	client := &Project{Name: "acme"}
+	require.NoError(t, repo.CreateProject(ctx, client))
+	assert.Greater(t, client.ID, int64(0))
+
+	project := &Project{Name: "website", ParentID: &client.ID}
+	require.NoError(t, repo.CreateProject(ctx, project))
+
+	retrieved, err := repo.GetProject(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "website", retrieved.Name)
+	require.NotNil(t, retrieved.ParentID)
+	assert.Equal(t, client.ID, *retrieved.ParentID)
+	assert.Nil(t, retrieved.ArchivedAt)
+
+	// Test archiving a project
+	archivedAt := time.Now().Truncate(time.Second)
+	retrieved.ArchivedAt = &archivedAt
+	require.NoError(t, repo.UpdateProject(ctx, retrieved))
+
+	projects, err := repo.ListProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+	assert.Equal(t, "acme", projects[0].Name)
+	require.NotNil(t, projects[1].ArchivedAt)
+	assert.True(t, archivedAt.Equal(*projects[1].ArchivedAt))
+
+	// Test tasks keep their project and entries can be searched by project
+	inProject := &Task{TaskName: "Landing page", ProjectID: &project.ID}
+	require.NoError(t, repo.CreateTask(ctx, inProject))
+	unassigned := &Task{TaskName: "Email"}
+	require.NoError(t, repo.CreateTask(ctx, unassigned))
+
+	task, err := repo.GetTask(ctx, inProject.ID)
+	require.NoError(t, err)
+	require.NotNil(t, task.ProjectID)
+	assert.Equal(t, project.ID, *task.ProjectID)
+
+	end := time.Now()
+	require.NoError(t, repo.CreateTimeEntry(ctx, &TimeEntry{TaskID: inProject.ID, StartTime: end.Add(-time.Hour), EndTime: &end}))
+	require.NoError(t, repo.CreateTimeEntry(ctx, &TimeEntry{TaskID: unassigned.ID, StartTime: end.Add(-2 * time.Hour), EndTime: &end}))
+
+	entries, err := repo.SearchTimeEntries(ctx, SearchOptions{ProjectIDs: []int64{client.ID, project.ID}})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, inProject.ID, entries[0].TaskID)
+
+	// Test updating a task can clear its project
+	task.ProjectID = nil
+	require.NoError(t, repo.UpdateTask(ctx, task))
+	task, err = repo.GetTask(ctx, inProject.ID)
+	require.NoError(t, err)
+	assert.Nil(t, task.ProjectID)
+
+	// Test missing projects are reported as not found
+	_, err = repo.GetProject(ctx, 999)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestTimeEntryTags(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	task := &Task{TaskName: "Support"}
+	require.NoError(t, repo.CreateTask(ctx, task))
+
+	end := time.Now()
+	entries := make([]*TimeEntry, 3)
+	for i := range entries {
+		entries[i] = &TimeEntry{TaskID: task.ID, StartTime: end.Add(time.Duration(-3+i) * time.Hour), EndTime: &end}
+		require.NoError(t, repo.CreateTimeEntry(ctx, entries[i]))
+	}
+
+	// Test tagging entries, sharing tags between them
+	require.NoError(t, repo.SetTimeEntryTags(ctx, entries[0].ID, []string{"billable", "meeting"}))
+	require.NoError(t, repo.SetTimeEntryTags(ctx, entries[1].ID, []string{"billable"}))
+	require.NoError(t, repo.SetTimeEntryTags(ctx, entries[2].ID, []string{"internal"}))
+
+	tags, err := repo.ListTimeEntryTags(ctx, []int64{entries[0].ID, entries[1].ID})
+	require.NoError(t, err)
+	require.Len(t, tags, 3)
+	assert.Equal(t, TimeEntryTag{TimeEntryID: entries[0].ID, Name: "billable"}, *tags[0])
+	assert.Equal(t, TimeEntryTag{TimeEntryID: entries[0].ID, Name: "meeting"}, *tags[1])
+	assert.Equal(t, TimeEntryTag{TimeEntryID: entries[1].ID, Name: "billable"}, *tags[2])
+
+	// Test searching by included and excluded tags
+	found, err := repo.SearchTimeEntries(ctx, SearchOptions{Tags: []string{"billable"}})
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	found, err = repo.SearchTimeEntries(ctx, SearchOptions{Tags: []string{"billable", "meeting"}})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, entries[0].ID, found[0].ID)
+
+	found, err = repo.SearchTimeEntries(ctx, SearchOptions{Tags: []string{"billable"}, ExcludeTags: []string{"meeting"}})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, entries[1].ID, found[0].ID)
+
+	// Test replacing tags and deleting a tagged entry
+	require.NoError(t, repo.SetTimeEntryTags(ctx, entries[0].ID, []string{"internal"}))
+	require.NoError(t, repo.DeleteTimeEntry(ctx, entries[2].ID))
+
+	found, err = repo.SearchTimeEntries(ctx, SearchOptions{Tags: []string{"internal"}})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, entries[0].ID, found[0].ID)
+
+	tags, err = repo.ListTimeEntryTags(ctx, []int64{entries[2].ID})
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestOperationsJournal(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Test restoring deleted rows with their original IDs
+	task := &Task{TaskName: "Support"}
+	require.NoError(t, repo.CreateTask(ctx, task))
+	end := time.Now().Truncate(time.Second)
+	entry := &TimeEntry{TaskID: task.ID, StartTime: end.Add(-time.Hour), EndTime: &end, Note: "tickets"}
+	require.NoError(t, repo.CreateTimeEntry(ctx, entry))
+
+	require.NoError(t, repo.DeleteTimeEntry(ctx, entry.ID))
+	require.NoError(t, repo.DeleteTask(ctx, task.ID))
+	require.NoError(t, repo.RestoreTask(ctx, task))
+	require.NoError(t, repo.RestoreTimeEntry(ctx, entry))
+
+	restored, err := repo.GetTimeEntry(ctx, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, restored.TaskID)
+	assert.Equal(t, "tickets", restored.Note)
+
+	// Test restoring over an existing row overwrites it
+	entry.Note = "calls"
+	require.NoError(t, repo.RestoreTimeEntry(ctx, entry))
+	restored, err = repo.GetTimeEntry(ctx, entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "calls", restored.Note)
+
+	// Test recording operations with their changes
+	before := `{"id":1}`
+	for i := 0; i < 3; i++ {
+		operation := &Operation{Kind: "edit", Description: "Edit", CreatedAt: end}
+		changes := []*OperationChange{
+			{TableName: TableTimeEntries, RowID: entry.ID, BeforeJSON: &before},
+			{TableName: TableTasks, RowID: task.ID},
+		}
+		require.NoError(t, repo.CreateOperation(ctx, operation, changes))
+		assert.Greater(t, operation.ID, int64(0))
+	}
+
+	operations, err := repo.ListOperations(ctx, 0, true)
+	require.NoError(t, err)
+	require.Len(t, operations, 3)
+	assert.Greater(t, operations[0].ID, operations[1].ID)
+	assert.Equal(t, 2, operations[0].ChangeCount)
+
+	changes, err := repo.ListOperationChanges(ctx, operations[0].ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, TableTimeEntries, changes[0].TableName)
+	require.NotNil(t, changes[0].BeforeJSON)
+	assert.Equal(t, before, *changes[0].BeforeJSON)
+	assert.Nil(t, changes[1].BeforeJSON)
+
+	// Test listing the operations after a known one, oldest first
+	after, err := repo.ListOperationsAfter(ctx, operations[2].ID)
+	require.NoError(t, err)
+	require.Len(t, after, 2)
+	assert.Equal(t, operations[1].ID, after[0].ID)
+	assert.Equal(t, operations[0].ID, after[1].ID)
+
+	after, err = repo.ListOperationsAfter(ctx, operations[0].ID)
+	require.NoError(t, err)
+	assert.Empty(t, after)
+
+	// Test undone operations are left out unless asked for
+	require.NoError(t, repo.MarkOperationUndone(ctx, operations[0].ID, end))
+	pending, err := repo.ListOperations(ctx, 1, false)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, operations[1].ID, pending[0].ID)
+
+	// Test pruning keeps the newest operations
+	require.NoError(t, repo.PruneOperations(ctx, 1))
+	operations, err = repo.ListOperations(ctx, 0, true)
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	require.NotNil(t, operations[0].UndoneAt)
+
+	changes, err = repo.ListOperationChanges(ctx, pending[0].ID)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
 // Helper function to create string pointer
 func stringPtr(s string) *string {
 	return &s
@@ -13,12 +13,18 @@ type Scanner interface {
 func ScanTimeEntry(scanner Scanner) (*TimeEntry, error) {
 	entry := &TimeEntry{}
 	var endTime sql.NullTime
+	var continuesID sql.NullInt64
+	var keptRunningAt sql.NullTime
 
 	err := scanner.Scan(
 		&entry.ID,
 		&entry.StartTime,
 		&endTime,
 		&entry.TaskID,
+		&entry.Note,
+		&continuesID,
+		&entry.Paused,
+		&keptRunningAt,
 	)
 	if err != nil {
 		return nil, err
@@ -27,6 +33,12 @@ func ScanTimeEntry(scanner Scanner) (*TimeEntry, error) {
 	if endTime.Valid {
 		entry.EndTime = &endTime.Time
 	}
+	if continuesID.Valid {
+		entry.ContinuesID = &continuesID.Int64
+	}
+	if keptRunningAt.Valid {
+		entry.KeptRunningAt = &keptRunningAt.Time
+	}
 
 	return entry, nil
 }
@@ -59,10 +71,20 @@ func ScanTimeEntries(rows Rows) ([]*TimeEntry, error) {
 // ScanTask scans a single task from a database row
 func ScanTask(scanner Scanner) (*Task, error) {
 	task := &Task{}
-	err := scanner.Scan(&task.ID, &task.TaskName)
+	var projectID sql.NullInt64
+	var archivedAt sql.NullTime
+
+	err := scanner.Scan(&task.ID, &task.TaskName, &projectID, &archivedAt)
 	if err != nil {
 		return nil, err
 	}
+
+	if projectID.Valid {
+		task.ProjectID = &projectID.Int64
+	}
+	if archivedAt.Valid {
+		task.ArchivedAt = &archivedAt.Time
+	}
 	return task, nil
 }
 
@@ -82,4 +104,137 @@ func ScanTasks(rows Rows) ([]*Task, error) {
 	}
 
 	return tasks, nil
-}
\ No newline at end of file
+}
+
+// ScanProject scans a single project from a database row
+func ScanProject(scanner Scanner) (*Project, error) {
+	project := &Project{}
+	var parentID sql.NullInt64
+	var archivedAt sql.NullTime
+
+	err := scanner.Scan(&project.ID, &project.Name, &parentID, &archivedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		project.ParentID = &parentID.Int64
+	}
+	if archivedAt.Valid {
+		project.ArchivedAt = &archivedAt.Time
+	}
+	return project, nil
+}
+
+// ScanProjects scans multiple projects from database rows
+func ScanProjects(rows Rows) ([]*Project, error) {
+	var projects []*Project
+	for rows.Next() {
+		project, err := ScanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// ScanOperation scans a single journaled operation, with its number of changes, from a database row
+func ScanOperation(scanner Scanner) (*Operation, error) {
+	operation := &Operation{}
+	var undoneAt sql.NullTime
+
+	err := scanner.Scan(&operation.ID, &operation.Kind, &operation.Description, &operation.CreatedAt, &undoneAt, &operation.ChangeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if undoneAt.Valid {
+		operation.UndoneAt = &undoneAt.Time
+	}
+	return operation, nil
+}
+
+// ScanOperations scans multiple journaled operations from database rows
+func ScanOperations(rows Rows) ([]*Operation, error) {
+	var operations []*Operation
+	for rows.Next() {
+		operation, err := ScanOperation(rows)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return operations, nil
+}
+
+// ScanOperationChange scans a single operation change from a database row
+func ScanOperationChange(scanner Scanner) (*OperationChange, error) {
+	change := &OperationChange{}
+	var beforeJSON sql.NullString
+
+	err := scanner.Scan(&change.ID, &change.OperationID, &change.TableName, &change.RowID, &beforeJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if beforeJSON.Valid {
+		change.BeforeJSON = &beforeJSON.String
+	}
+	return change, nil
+}
+
+// ScanOperationChanges scans multiple operation changes from database rows
+func ScanOperationChanges(rows Rows) ([]*OperationChange, error) {
+	var changes []*OperationChange
+	for rows.Next() {
+		change, err := ScanOperationChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// ScanTimeEntryTag scans a single time entry tag link from a database row
+func ScanTimeEntryTag(scanner Scanner) (*TimeEntryTag, error) {
+	tag := &TimeEntryTag{}
+	if err := scanner.Scan(&tag.TimeEntryID, &tag.Name); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// ScanTimeEntryTags scans multiple time entry tag links from database rows
+func ScanTimeEntryTags(rows Rows) ([]*TimeEntryTag, error) {
+	var tags []*TimeEntryTag
+	for rows.Next() {
+		tag, err := ScanTimeEntryTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -32,8 +32,12 @@ func (ts *TestScanner) Scan(dest ...interface{}) error {
 			*v = ts.data[i].(time.Time)
 		case *sql.NullTime:
 			*v = ts.data[i].(sql.NullTime)
+		case *sql.NullInt64:
+			*v = ts.data[i].(sql.NullInt64)
 		case *string:
 			*v = ts.data[i].(string)
+		case *bool:
+			*v = ts.data[i].(bool)
 		}
 	}
 	
@@ -55,6 +59,10 @@ func TestScanTimeEntry(t *testing.T) {
 					time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
 					sql.NullTime{Time: time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC), Valid: true},
 					int64(100),
+					"reviewing PR 412",
+					sql.NullInt64{},
+					true,
+					sql.NullTime{},
 				},
 			},
 			expected: &TimeEntry{
@@ -62,6 +70,8 @@ func TestScanTimeEntry(t *testing.T) {
 				TaskID:    100,
 				StartTime: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
 				EndTime:   func() *time.Time { t := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC); return &t }(),
+				Note:      "reviewing PR 412",
+				Paused:    true,
 			},
 			expectError: false,
 		},
@@ -73,13 +83,19 @@ func TestScanTimeEntry(t *testing.T) {
 					time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
 					sql.NullTime{Valid: false},
 					int64(200),
+					"",
+					sql.NullInt64{Int64: 1, Valid: true},
+					false,
+					sql.NullTime{Time: time.Date(2024, 1, 15, 21, 0, 0, 0, time.UTC), Valid: true},
 				},
 			},
 			expected: &TimeEntry{
-				ID:        2,
-				TaskID:    200,
-				StartTime: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
-				EndTime:   nil,
+				ID:            2,
+				TaskID:        200,
+				StartTime:     time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+				EndTime:       nil,
+				ContinuesID:   func() *int64 { id := int64(1); return &id }(),
+				KeptRunningAt: func() *time.Time { t := time.Date(2024, 1, 15, 21, 0, 0, 0, time.UTC); return &t }(),
 			},
 			expectError: false,
 		},
@@ -105,6 +121,9 @@ func TestScanTimeEntry(t *testing.T) {
 				assert.NotNil(t, result)
 				assert.Equal(t, tt.expected.ID, result.ID)
 				assert.Equal(t, tt.expected.TaskID, result.TaskID)
+				assert.Equal(t, tt.expected.Note, result.Note)
+				assert.Equal(t, tt.expected.ContinuesID, result.ContinuesID)
+				assert.Equal(t, tt.expected.Paused, result.Paused)
 				assert.True(t, tt.expected.StartTime.Equal(result.StartTime))
 				if tt.expected.EndTime == nil {
 					assert.Nil(t, result.EndTime)
@@ -112,6 +131,12 @@ func TestScanTimeEntry(t *testing.T) {
 					assert.NotNil(t, result.EndTime)
 					assert.True(t, tt.expected.EndTime.Equal(*result.EndTime))
 				}
+				if tt.expected.KeptRunningAt == nil {
+					assert.Nil(t, result.KeptRunningAt)
+				} else {
+					assert.NotNil(t, result.KeptRunningAt)
+					assert.True(t, tt.expected.KeptRunningAt.Equal(*result.KeptRunningAt))
+				}
 			}
 		})
 	}
@@ -130,6 +155,8 @@ func TestScanTask(t *testing.T) {
 				data: []interface{}{
 					int64(1),
 					"Test Task",
+					sql.NullInt64{Valid: false},
+					sql.NullTime{},
 				},
 			},
 			expected: &Task{
@@ -138,12 +165,31 @@ func TestScanTask(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Task in a project",
+			scanner: &TestScanner{
+				data: []interface{}{
+					int64(3),
+					"Landing page",
+					sql.NullInt64{Int64: 7, Valid: true},
+					sql.NullTime{},
+				},
+			},
+			expected: &Task{
+				ID:        3,
+				TaskName:  "Landing page",
+				ProjectID: func() *int64 { id := int64(7); return &id }(),
+			},
+			expectError: false,
+		},
 		{
 			name: "Empty task name",
 			scanner: &TestScanner{
 				data: []interface{}{
 					int64(2),
 					"",
+					sql.NullInt64{},
+					sql.NullTime{},
 				},
 			},
 			expected: &Task{
@@ -152,6 +198,23 @@ func TestScanTask(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Archived task",
+			scanner: &TestScanner{
+				data: []interface{}{
+					int64(4),
+					"Old experiment",
+					sql.NullInt64{},
+					sql.NullTime{Time: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), Valid: true},
+				},
+			},
+			expected: &Task{
+				ID:         4,
+				TaskName:   "Old experiment",
+				ArchivedAt: func() *time.Time { t := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC); return &t }(),
+			},
+			expectError: false,
+		},
 		{
 			name: "Scanner error",
 			scanner: &TestScanner{
@@ -174,6 +237,8 @@ func TestScanTask(t *testing.T) {
 				assert.NotNil(t, result)
 				assert.Equal(t, tt.expected.ID, result.ID)
 				assert.Equal(t, tt.expected.TaskName, result.TaskName)
+				assert.Equal(t, tt.expected.ProjectID, result.ProjectID)
+				assert.Equal(t, tt.expected.ArchivedAt, result.ArchivedAt)
 			}
 		})
 	}
@@ -220,8 +285,12 @@ func (tr *TestRows) Scan(dest ...interface{}) error {
 			*v = rowData[i].(time.Time)
 		case *sql.NullTime:
 			*v = rowData[i].(sql.NullTime)
+		case *sql.NullInt64:
+			*v = rowData[i].(sql.NullInt64)
 		case *string:
 			*v = rowData[i].(string)
+		case *bool:
+			*v = rowData[i].(bool)
 		}
 	}
 	
@@ -248,12 +317,20 @@ func TestScanTimeEntries(t *testing.T) {
 						time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
 						sql.NullTime{Time: time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC), Valid: true},
 						int64(100),
+						"",
+						sql.NullInt64{},
+						false,
+						sql.NullTime{},
 					},
 					{
 						int64(2),
 						time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
 						sql.NullTime{Valid: false},
 						int64(200),
+						"",
+						sql.NullInt64{},
+						false,
+						sql.NullTime{},
 					},
 				},
 			},
@@ -285,7 +362,7 @@ func TestScanTimeEntries(t *testing.T) {
 			name: "Scan error",
 			rows: &TestRows{
 				rows: [][]interface{}{
-					{int64(1), time.Now(), sql.NullTime{}, int64(100)},
+					{int64(1), time.Now(), sql.NullTime{}, int64(100), "", sql.NullInt64{}, false, sql.NullTime{}},
 				},
 				err: sql.ErrConnDone,
 			},
@@ -331,8 +408,8 @@ func TestScanTasks(t *testing.T) {
 			name: "Multiple tasks",
 			rows: &TestRows{
 				rows: [][]interface{}{
-					{int64(1), "Task 1"},
-					{int64(2), "Task 2"},
+					{int64(1), "Task 1", sql.NullInt64{}, sql.NullTime{}},
+					{int64(2), "Task 2", sql.NullInt64{Int64: 5, Valid: true}, sql.NullTime{}},
 				},
 			},
 			expected: []*Task{
@@ -353,7 +430,7 @@ func TestScanTasks(t *testing.T) {
 			name: "Scan error",
 			rows: &TestRows{
 				rows: [][]interface{}{
-					{int64(1), "Task 1"},
+					{int64(1), "Task 1", sql.NullInt64{}, sql.NullTime{}},
 				},
 				err: sql.ErrConnDone,
 			},
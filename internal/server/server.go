@@ -0,0 +1,483 @@
+// Package server exposes the business API over HTTP with JSON bodies, so editor plugins
+// and menu-bar widgets can share a single running tt process.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/validation"
+)
+
+// web holds the dashboard, a single page using the API below, served at /
+//
+//go:embed web
+var web embed.FS
+
+// maxRequestBodyBytes limits the size of request bodies; requests are small JSON objects
+const maxRequestBodyBytes = 1 << 20
+
+// shutdownTimeout is how long requests in flight may take to finish once the server stops
+const shutdownTimeout = 5 * time.Second
+
+// keepAliveInterval is how often an idle event stream sends a comment, so proxies keep the connection open
+const keepAliveInterval = 30 * time.Second
+
+// Server routes the REST endpoints to the business API
+type Server struct {
+	businessAPI api.BusinessAPI
+	mux         *http.ServeMux
+	mu          sync.Mutex      // Serializes API calls, as SQLite allows a single writer
+	hosts       map[string]bool // Host headers naming the address the server listens on; no others are served
+	token       string          // Bearer token every request must send, empty when none is needed
+}
+
+// Options holds the settings of a server
+type Options struct {
+	Token string // Bearer token every request must send; requests with it may name any host
+}
+
+// New creates a server for the business API
+func New(businessAPI api.BusinessAPI) *Server {
+	return NewWithOptions(businessAPI, Options{})
+}
+
+// NewWithOptions creates a server for the business API with the given settings
+func NewWithOptions(businessAPI api.BusinessAPI, options Options) *Server {
+	s := &Server{
+		businessAPI: businessAPI,
+		mux:         http.NewServeMux(),
+		token:       options.Token,
+	}
+	s.routes()
+	return s
+}
+
+// routes registers the endpoints
+func (s *Server) routes() {
+	s.handle("GET /api/current", s.handleCurrent)
+	s.handle("POST /api/start", s.handleStart)
+	s.handle("POST /api/stop", s.handleStop)
+	s.handle("GET /api/tasks", s.handleSearchTasks)
+	s.handle("GET /api/tasks/{id}/summary", s.handleSummary)
+	s.handle("POST /api/tasks/{id}/resume", s.handleResume)
+	s.handle("GET /api/entries", s.handleSearchEntries)
+	s.handle("GET /api/dashboard", s.handleDashboard)
+	s.mux.HandleFunc("GET /api/events", s.handleEvents)
+
+	// The dashboard files are routed one by one, so a wrong method on an API path is still reported as such
+	dashboard, err := fs.Sub(web, "web")
+	if err != nil {
+		panic(err) // The directory is embedded at build time
+	}
+	files := http.FileServerFS(dashboard)
+	s.mux.Handle("GET /{$}", files)
+	entries, _ := fs.ReadDir(dashboard, ".")
+	for _, entry := range entries {
+		s.mux.Handle("GET /"+entry.Name(), files)
+	}
+}
+
+// ServeHTTP implements http.Handler. Requests must be addressed to the server itself and come from its own
+// pages or from outside a browser, and POSTs must send JSON, so that web pages the user visits can neither
+// drive the API through the browser nor read it by rebinding their DNS name to the loopback address.
+// With a token, every request must send it instead of naming the server, as clients on other machines
+// reach it by names of their own.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" {
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			_, body := describeError(errors.NewPermissionError("requests", "the API without a valid bearer token"))
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: body})
+			return
+		}
+	} else if !s.hosts[strings.ToLower(r.Host)] {
+		writeError(w, errors.NewPermissionError("requests", fmt.Sprintf("host %q", r.Host)))
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if parsed, err := url.Parse(origin); err != nil || parsed.Scheme != "http" || !s.hosts[strings.ToLower(parsed.Host)] {
+			writeError(w, errors.NewPermissionError("requests", fmt.Sprintf("origin %q", origin)))
+			return
+		}
+	}
+	if r.Method == http.MethodPost {
+		contentType := r.Header.Get("Content-Type")
+		if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/json" {
+			_, body := describeError(errors.NewInvalidInputError("Content-Type", contentType, "POST requests must send application/json"))
+			writeJSON(w, http.StatusUnsupportedMediaType, errorResponse{Error: body})
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized reports whether the request sends the server's token as a bearer token
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// listenOn serves requests addressed to addr, the address the server listens on. When that is a loopback
+// or unspecified address, the loopback names of the same port are accepted too, such as localhost:7070.
+func (s *Server) listenOn(addr string) {
+	s.hosts = map[string]bool{strings.ToLower(addr): true}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return
+	}
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsUnspecified()) {
+		for _, name := range []string{"localhost", "127.0.0.1", "::1"} {
+			s.hosts[net.JoinHostPort(name, port)] = true
+		}
+	}
+}
+
+// handlerFunc handles an API request, returning the status and value to send as JSON
+type handlerFunc func(r *http.Request) (int, interface{}, error)
+
+// handle registers an API handler, writing its result or error as JSON
+func (s *Server) handle(pattern string, handler handlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		status, body, err := handler(r)
+		s.mu.Unlock()
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, status, body)
+	})
+}
+
+// startRequest is the body of POST /api/start
+type startRequest struct {
+	Task string `json:"task"`
+	api.StartOptions
+}
+
+// atRequest is the optional body of POST /api/stop and POST /api/tasks/{id}/resume
+type atRequest struct {
+	At *time.Time `json:"at,omitempty"` // When to stop or resume, if not now
+}
+
+func (s *Server) handleCurrent(r *http.Request) (int, interface{}, error) {
+	session, err := s.businessAPI.GetCurrentSession(r.Context())
+	return http.StatusOK, session, err
+}
+
+func (s *Server) handleStart(r *http.Request) (int, interface{}, error) {
+	var request startRequest
+	if err := decodeBody(r, &request); err != nil {
+		return 0, nil, err
+	}
+	if strings.TrimSpace(request.Task) == "" {
+		return 0, nil, errors.NewInvalidInputError("task", request.Task, "task name is required")
+	}
+
+	session, err := s.businessAPI.StartNewTaskWithOptions(r.Context(), request.Task, request.StartOptions)
+	return http.StatusCreated, session, err
+}
+
+func (s *Server) handleStop(r *http.Request) (int, interface{}, error) {
+	var request atRequest
+	if err := decodeBody(r, &request); err != nil {
+		return 0, nil, err
+	}
+
+	if request.At != nil {
+		stopped, err := s.businessAPI.StopAllRunningTasksAt(r.Context(), *request.At)
+		return http.StatusOK, stopped, err
+	}
+	stopped, err := s.businessAPI.StopAllRunningTasks(r.Context())
+	return http.StatusOK, stopped, err
+}
+
+func (s *Server) handleResume(r *http.Request) (int, interface{}, error) {
+	taskID, err := pathID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var request atRequest
+	if err := decodeBody(r, &request); err != nil {
+		return 0, nil, err
+	}
+
+	if request.At != nil {
+		session, err := s.businessAPI.ResumeTaskAt(r.Context(), taskID, *request.At)
+		return http.StatusOK, session, err
+	}
+	session, err := s.businessAPI.ResumeTask(r.Context(), taskID)
+	return http.StatusOK, session, err
+}
+
+func (s *Server) handleSummary(r *http.Request) (int, interface{}, error) {
+	taskID, err := pathID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	summary, err := s.businessAPI.GetTaskSummary(r.Context(), taskID)
+	return http.StatusOK, summary, err
+}
+
+func (s *Server) handleSearchTasks(r *http.Request) (int, interface{}, error) {
+	filter, err := queryFilter(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	sortOrder := api.SortOrder(r.URL.Query().Get("sort"))
+	switch sortOrder {
+	case "":
+		sortOrder = api.SortByRecentFirst
+	case api.SortByRecentFirst, api.SortByOldestFirst, api.SortByName, api.SortByDuration:
+	default:
+		return 0, nil, errors.NewInvalidInputError("sort", string(sortOrder), "use recent_first, oldest_first, name or duration")
+	}
+
+	tasks, err := s.businessAPI.SearchTasksWithFilter(r.Context(), filter, sortOrder)
+	return http.StatusOK, nonNil(tasks), err
+}
+
+func (s *Server) handleSearchEntries(r *http.Request) (int, interface{}, error) {
+	filter, err := queryFilter(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	entries, err := s.businessAPI.SearchTimeEntriesWithFilter(r.Context(), filter)
+	return http.StatusOK, nonNil(entries), err
+}
+
+func (s *Server) handleDashboard(r *http.Request) (int, interface{}, error) {
+	timeRange := r.URL.Query().Get("range")
+	if timeRange == "" {
+		timeRange = "today"
+	}
+
+	dashboard, err := s.businessAPI.GetDashboardData(r.Context(), timeRange)
+	return http.StatusOK, dashboard, err
+}
+
+// handleEvents streams events as Server-Sent Events until the client disconnects.
+// The stream is not serialized with the other requests, which would block while it lasts.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, fmt.Errorf("streaming is not supported by the connection"))
+		return
+	}
+
+	s.mu.Lock()
+	events, err := s.businessAPI.SubscribeEvents(r.Context())
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes an event in the Server-Sent Events format, named after its type
+func writeEvent(w io.Writer, event *api.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.ID != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+// queryFilter reads a time entry filter from the query string: range, text, project,
+// tag and exclude_tag (both repeatable or comma-separated) and include_archived
+func queryFilter(r *http.Request) (api.TimeEntryFilter, error) {
+	query := r.URL.Query()
+	filter := api.TimeEntryFilter{
+		TimeRange:   query.Get("range"),
+		Text:        query.Get("text"),
+		Project:     query.Get("project"),
+		Tags:        splitValues(query["tag"]),
+		ExcludeTags: splitValues(query["exclude_tag"]),
+	}
+
+	if value := query.Get("include_archived"); value != "" {
+		includeArchived, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, errors.NewInvalidInputError("include_archived", value, "must be true or false")
+		}
+		filter.IncludeArchived = includeArchived
+	}
+	return filter, nil
+}
+
+// splitValues flattens repeated and comma-separated query values
+func splitValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// pathID reads the {id} path segment
+func pathID(r *http.Request) (int64, error) {
+	value := r.PathValue("id")
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.NewInvalidInputError("id", value, "must be a number")
+	}
+	return id, nil
+}
+
+// decodeBody reads a JSON request body into v; an empty body leaves v unchanged
+func decodeBody(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil && err != io.EOF {
+		return errors.NewInvalidInputError("body", "", fmt.Sprintf("invalid JSON: %v", err))
+	}
+	return nil
+}
+
+// nonNil turns empty results into empty JSON arrays rather than null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// errorResponse is the JSON body of failed requests
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+// errorBody describes an error with the type and code of the application error
+type errorBody struct {
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeError sends an error with the status matching its application error type
+func writeError(w http.ResponseWriter, err error) {
+	status, body := describeError(err)
+	writeJSON(w, status, errorResponse{Error: body})
+}
+
+// describeError returns the status matching an error and the body describing it
+func describeError(err error) (int, errorBody) {
+	status := httpStatus(err)
+	body := errorBody{Type: "unknown", Code: errors.GetErrorCode(err), Message: errors.GetUserMessage(err)}
+	if appErr, ok := errors.AsAppError(err); ok {
+		body.Type = appErr.Type.String()
+	} else if validationErr, ok := err.(*validation.ValidationError); ok {
+		status = http.StatusBadRequest
+		body = errorBody{Type: errors.ErrorTypeValidation.String(), Code: "VALIDATION_FAILED", Message: validationErr.GetUserFriendlyMessage()}
+	}
+	return status, body
+}
+
+// httpStatus returns the status reporting an error to API clients, after the type of the application error
+func httpStatus(err error) int {
+	if appErr, ok := errors.AsAppError(err); ok {
+		switch appErr.Type {
+		case errors.ErrorTypeValidation:
+			return http.StatusUnprocessableEntity // The request was understood but conflicts with the stored data
+		case errors.ErrorTypeNotFound:
+			return http.StatusNotFound
+		case errors.ErrorTypeInvalidInput:
+			return http.StatusBadRequest
+		case errors.ErrorTypeTimeout:
+			return http.StatusGatewayTimeout
+		case errors.ErrorTypePermission:
+			return http.StatusForbidden
+		default:
+			return http.StatusInternalServerError
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON sends v as an indented JSON body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+// Serve accepts connections on the listener until ctx is cancelled, then waits for requests in flight
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	s.listenOn(listener.Addr().String())
+	httpServer := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+		// Requests end with ctx, so event streams do not hold up the shutdown
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
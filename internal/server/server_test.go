@@ -0,0 +1,398 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/internal/api"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestServer(t *testing.T) (*Server, api.BusinessAPI) {
+	// A database file rather than :memory:, as event streams poll on a connection of their own
+	repo, err := sqlite.New(filepath.Join(t.TempDir(), "tt.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+
+	businessAPI := api.NewBusinessAPI(repo)
+	server := New(businessAPI)
+	server.listenOn(testAddr)
+	return server, businessAPI
+}
+
+// testAddr is the address test servers act as if they listened on
+const testAddr = "127.0.0.1:7070"
+
+// newRequest creates a request to the test server, as a client outside the browser would send it
+func newRequest(method, target, body string) *http.Request {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	request.Host = testAddr
+	if method == "POST" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	return request
+}
+
+// do sends a request to the server and decodes the JSON response into v, returning the status
+func do(t *testing.T, server *Server, method, target, body string, v interface{}) int {
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, newRequest(method, target, body))
+
+	if v != nil {
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), v), recorder.Body.String())
+	}
+	return recorder.Code
+}
+
+func TestServer_Workflow(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	var errResponse errorResponse
+	assert.Equal(t, http.StatusNotFound, do(t, server, "GET", "/api/current", "", &errResponse))
+	assert.Equal(t, "not_found", errResponse.Error.Type)
+	assert.Equal(t, "NOT_FOUND", errResponse.Error.Code)
+
+	var started api.TaskSession
+	status := do(t, server, "POST", "/api/start", `{"task": "Write report", "tags": ["docs"], "note": "first draft"}`, &started)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "Write report", started.Task.TaskName)
+	assert.Equal(t, []string{"docs"}, started.TimeEntry.Tags)
+
+	var current api.TaskSession
+	assert.Equal(t, http.StatusOK, do(t, server, "GET", "/api/current", "", &current))
+	assert.Equal(t, started.Task.ID, current.Task.ID)
+
+	// Tasks and entries have the same keys as in the JSON output of the CLI
+	var raw struct {
+		Task      map[string]interface{} `json:"task"`
+		TimeEntry map[string]interface{} `json:"time_entry"`
+	}
+	assert.Equal(t, http.StatusOK, do(t, server, "GET", "/api/current", "", &raw))
+	assert.Equal(t, "Write report", raw.Task["name"])
+	assert.Equal(t, float64(started.Task.ID), raw.TimeEntry["task_id"])
+	assert.NotEmpty(t, raw.TimeEntry["start_time"])
+
+	var stopped []map[string]interface{}
+	assert.Equal(t, http.StatusOK, do(t, server, "POST", "/api/stop", "", &stopped))
+	require.Len(t, stopped, 1)
+	assert.NotEmpty(t, stopped[0]["end_time"])
+	assert.Equal(t, []interface{}{"docs"}, stopped[0]["tags"])
+	assert.Equal(t, "first draft", stopped[0]["note"])
+
+	var tasks []*api.TaskActivity
+	assert.Equal(t, http.StatusOK, do(t, server, "GET", "/api/tasks?range=1d&tag=docs", "", &tasks))
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Write report", tasks[0].Task.TaskName)
+
+	var resumed api.TaskSession
+	assert.Equal(t, http.StatusOK, do(t, server, "POST", "/api/tasks/1/resume", "", &resumed))
+	assert.Equal(t, started.Task.ID, resumed.Task.ID)
+	assert.Nil(t, resumed.TimeEntry.EndTime)
+
+	var summary api.TaskSummary
+	assert.Equal(t, http.StatusOK, do(t, server, "GET", "/api/tasks/1/summary", "", &summary))
+	assert.Equal(t, 2, summary.SessionCount)
+	assert.True(t, summary.IsRunning)
+
+	var entries []*api.TimeEntryWithTask
+	assert.Equal(t, http.StatusOK, do(t, server, "GET", "/api/entries?text=draft", "", &entries))
+	assert.Len(t, entries, 1)
+
+	var dashboard api.DashboardData
+	assert.Equal(t, http.StatusOK, do(t, server, "GET", "/api/dashboard", "", &dashboard))
+	require.NotNil(t, dashboard.RunningTask)
+	assert.Equal(t, "Write report", dashboard.RunningTask.Task.TaskName)
+	assert.Equal(t, 1, dashboard.TodayStats.TaskCount)
+}
+
+func TestServer_Backdating(t *testing.T) {
+	server, _ := setupTestServer(t)
+	now := time.Now().Truncate(time.Second)
+	body := func(fields string, at time.Time) string {
+		return `{` + fields + `"at": "` + at.Format(time.RFC3339) + `"}`
+	}
+
+	var started api.TaskSession
+	require.Equal(t, http.StatusCreated, do(t, server, "POST", "/api/start", body(`"task": "Write report", `, now.Add(-time.Hour)), &started))
+	assert.True(t, now.Add(-time.Hour).Equal(started.TimeEntry.StartTime))
+
+	var errResponse errorResponse
+	assert.Equal(t, http.StatusUnprocessableEntity, do(t, server, "POST", "/api/stop", body("", now.Add(-2*time.Hour)), &errResponse))
+	assert.Contains(t, errResponse.Error.Message, "before it started")
+
+	var stopped []map[string]interface{}
+	require.Equal(t, http.StatusOK, do(t, server, "POST", "/api/stop", body("", now.Add(-30*time.Minute)), &stopped))
+	require.Len(t, stopped, 1)
+
+	var resumed api.TaskSession
+	require.Equal(t, http.StatusOK, do(t, server, "POST", "/api/tasks/1/resume", body("", now.Add(-10*time.Minute)), &resumed))
+	assert.True(t, now.Add(-10*time.Minute).Equal(resumed.TimeEntry.StartTime))
+}
+
+func TestServer_Errors(t *testing.T) {
+	server, businessAPI := setupTestServer(t)
+	_, err := businessAPI.StartNewTask(context.Background(), "Write report")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		code   string
+	}{
+		{"unknown task", "POST", "/api/tasks/99/resume", "", http.StatusNotFound, "NOT_FOUND"},
+		{"task ID that is not a number", "GET", "/api/tasks/abc/summary", "", http.StatusBadRequest, "INVALID_INPUT"},
+		{"missing task name", "POST", "/api/start", `{"note": "no name"}`, http.StatusBadRequest, "INVALID_INPUT"},
+		{"malformed body", "POST", "/api/start", `{"task":`, http.StatusBadRequest, "INVALID_INPUT"},
+		{"unknown field", "POST", "/api/start", `{"name": "Write report"}`, http.StatusBadRequest, "INVALID_INPUT"},
+		{"unknown project", "POST", "/api/start", `{"task": "Deploy", "project": "acme"}`, http.StatusNotFound, "NOT_FOUND"},
+		{"bad time range", "GET", "/api/tasks?range=soon", "", http.StatusUnprocessableEntity, "VALIDATION_FAILED"},
+		{"bad sort order", "GET", "/api/tasks?sort=random", "", http.StatusBadRequest, "INVALID_INPUT"},
+		{"bad boolean", "GET", "/api/entries?include_archived=maybe", "", http.StatusBadRequest, "INVALID_INPUT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response errorResponse
+			assert.Equal(t, tt.status, do(t, server, tt.method, tt.target, tt.body, &response))
+			assert.Equal(t, tt.code, response.Error.Code)
+			assert.NotEmpty(t, response.Error.Message)
+		})
+	}
+
+	t.Run("wrong method", func(t *testing.T) {
+		assert.Equal(t, http.StatusMethodNotAllowed, do(t, server, "GET", "/api/start", "", nil))
+	})
+
+	t.Run("empty results are arrays", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, newRequest("GET", "/api/tasks?text=nothing", ""))
+		assert.Equal(t, "[]", strings.TrimSpace(recorder.Body.String()))
+	})
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"Validation error", errors.NewValidationError("task is already running", nil), http.StatusUnprocessableEntity},
+		{"Not found error", errors.NewNotFoundError("task", "42"), http.StatusNotFound},
+		{"Invalid input error", errors.NewInvalidInputError("task_id", "abc", "must be a number"), http.StatusBadRequest},
+		{"Database error", errors.NewDatabaseError("query", fmt.Errorf("locked")), http.StatusInternalServerError},
+		{"Timeout error", errors.NewTimeoutError("query", "5s"), http.StatusGatewayTimeout},
+		{"Permission error", errors.NewPermissionError("delete", "task"), http.StatusForbidden},
+		{"Wrapped error", fmt.Errorf("failed to resume: %w", errors.NewNotFoundError("task", "42")), http.StatusNotFound},
+		{"Regular error", fmt.Errorf("regular error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, httpStatus(tt.err))
+		})
+	}
+}
+
+func TestServer_RefusesRequestsFromOtherSites(t *testing.T) {
+	server, businessAPI := setupTestServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		header map[string]string
+		status int
+	}{
+		{"POST from another site", "POST", "/api/start", map[string]string{"Origin": "http://evil.example"}, http.StatusForbidden},
+		{"POST sent as a form", "POST", "/api/start", map[string]string{"Content-Type": "text/plain"}, http.StatusUnsupportedMediaType},
+		{"POST without a content type", "POST", "/api/stop", map[string]string{"Content-Type": ""}, http.StatusUnsupportedMediaType},
+		{"read through a rebound DNS name", "GET", "/api/entries", map[string]string{"Host": "evil.example:7070"}, http.StatusForbidden},
+		{"dashboard through a rebound DNS name", "GET", "/", map[string]string{"Host": "evil.example:7070"}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := newRequest(tt.method, tt.target, `{"task": "Write report"}`)
+			for name, value := range tt.header {
+				if name == "Host" {
+					request.Host = value
+					continue
+				}
+				request.Header.Set(name, value)
+			}
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+			assert.Equal(t, tt.status, recorder.Code)
+
+			var response errorResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.NotEmpty(t, response.Error.Message)
+		})
+	}
+
+	_, err := businessAPI.GetCurrentSession(context.Background())
+	assert.Error(t, err, "No task was started")
+
+	t.Run("accepts the dashboard's own requests", func(t *testing.T) {
+		for _, host := range []string{testAddr, "localhost:7070"} {
+			request := newRequest("POST", "/api/start", `{"task": "Write report"}`)
+			request.Host = host
+			request.Header.Set("Origin", "http://"+host)
+			request.Header.Set("Content-Type", "application/json; charset=utf-8")
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+			assert.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+		}
+	})
+}
+
+func TestServer_RequiresTheToken(t *testing.T) {
+	_, businessAPI := setupTestServer(t)
+	server := NewWithOptions(businessAPI, Options{Token: "secret"})
+	server.listenOn("0.0.0.0:7070")
+
+	send := func(authorization string) *httptest.ResponseRecorder {
+		request := newRequest("POST", "/api/start", `{"task": "Write report"}`)
+		request.Host = "tt.example.com:7070"
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	for _, authorization := range []string{"", "Bearer wrong", "secret", "Basic secret"} {
+		recorder := send(authorization)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code, authorization)
+		assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+	}
+	_, err := businessAPI.GetCurrentSession(context.Background())
+	assert.Error(t, err, "No task was started")
+
+	// Clients on other machines reach the server by names of their own
+	recorder := send("Bearer secret")
+	assert.Equal(t, http.StatusCreated, recorder.Code, recorder.Body.String())
+}
+
+func TestServer_Serve(t *testing.T) {
+	server, _ := setupTestServer(t)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx, listener) }()
+
+	response, err := http.Get("http://" + listener.Addr().String() + "/api/dashboard")
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	// An open event stream does not hold up the shutdown
+	stream, err := http.Get("http://" + listener.Addr().String() + "/api/events")
+	require.NoError(t, err)
+	defer stream.Body.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
+
+func TestServer_Events(t *testing.T) {
+	server, businessAPI := setupTestServer(t)
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, "GET", httpServer.URL+"/api/events", nil)
+	require.NoError(t, err)
+	request.Host = testAddr
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	// nextEvent reads the next event from the stream, returning its name and data
+	lines := bufio.NewScanner(response.Body)
+	nextEvent := func(t *testing.T) (string, api.Event) {
+		var name string
+		var event api.Event
+		for lines.Scan() {
+			line := lines.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event))
+			case line == "" && name != "":
+				return name, event
+			}
+		}
+		t.Fatalf("stream ended: %v", lines.Err())
+		return "", event
+	}
+
+	name, event := nextEvent(t)
+	assert.Equal(t, api.EventCurrent, name)
+	assert.Nil(t, event.Current)
+
+	// Changes made outside the server are streamed too
+	_, err = businessAPI.StartNewTask(context.Background(), "Write report")
+	require.NoError(t, err)
+	name, event = nextEvent(t)
+	assert.Equal(t, api.EventStarted, name)
+	require.NotNil(t, event.Current)
+	assert.Equal(t, "Write report", event.Current.Task.TaskName)
+
+	assert.Equal(t, http.StatusOK, do(t, server, "POST", "/api/stop", "", nil))
+	name, event = nextEvent(t)
+	assert.Equal(t, api.EventStopped, name)
+	assert.Nil(t, event.Current)
+}
+
+func TestServer_Dashboard(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	tests := []struct {
+		path        string
+		contentType string
+		contains    string
+	}{
+		{"/", "text/html", `<script src="dashboard.js">`},
+		{"/dashboard.js", "javascript", `new EventSource("/api/events")`},
+		{"/dashboard.css", "text/css", ".timeline"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, newRequest("GET", tt.path, ""))
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Contains(t, recorder.Header().Get("Content-Type"), tt.contentType)
+			assert.Contains(t, recorder.Body.String(), tt.contains)
+		})
+	}
+}
@@ -0,0 +1,303 @@
+"use strict";
+
+// The dashboard loads this week's entries and the running task from the tt API, and reloads
+// them whenever the event stream reports a change. Durations of running entries tick locally.
+
+const HOUR = 60 * 60 * 1000;
+const DAY = 24 * HOUR;
+const WEEKDAYS = ["Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"];
+
+const state = {
+  running: null, // The running session, or null
+  entries: [],   // This week's entries with their tasks
+};
+
+// request calls the API, turning error responses into exceptions carrying their message.
+// The server only accepts POSTs with a JSON body, so they always carry one.
+async function request(method, path, body) {
+  const options = { method, headers: {} };
+  if (method !== "GET") {
+    options.headers["Content-Type"] = "application/json";
+    options.body = JSON.stringify(body ?? {});
+  }
+  const response = await fetch(path, options);
+  const data = await response.json();
+  if (!response.ok) {
+    throw new Error(data.error ? data.error.message : response.statusText);
+  }
+  return data;
+}
+
+async function load() {
+  try {
+    const [dashboard, entries] = await Promise.all([
+      request("GET", "/api/dashboard?range=this-week"),
+      request("GET", "/api/entries?range=this-week"),
+    ]);
+    state.running = dashboard.running_task;
+    state.entries = entries;
+    showError("");
+    render();
+  } catch (err) {
+    showError(err.message);
+  }
+}
+
+// act runs an action such as starting a task, then reloads. It reports whether the action succeeded.
+async function act(method, path, body) {
+  try {
+    await request(method, path, body);
+  } catch (err) {
+    showError(err.message);
+    return false;
+  }
+  await load();
+  return true;
+}
+
+function showError(message) {
+  const error = document.getElementById("error");
+  error.textContent = message;
+  error.hidden = message === "";
+}
+
+// el creates an element with a class and text
+function el(tag, className, text) {
+  const element = document.createElement(tag);
+  if (className) {
+    element.className = className;
+  }
+  if (text !== undefined) {
+    element.textContent = text;
+  }
+  return element;
+}
+
+// formatDuration formats milliseconds like tt does: "45m" or "1h 30m"
+function formatDuration(ms) {
+  const minutes = Math.floor(Math.max(ms, 0) / 60000);
+  const hours = Math.floor(minutes / 60);
+  return hours > 0 ? `${hours}h ${minutes % 60}m` : `${minutes}m`;
+}
+
+// formatElapsed formats milliseconds as a running clock, h:mm:ss
+function formatElapsed(ms) {
+  const seconds = Math.floor(Math.max(ms, 0) / 1000);
+  const pad = (n) => String(n).padStart(2, "0");
+  return `${Math.floor(seconds / 3600)}:${pad(Math.floor(seconds / 60) % 60)}:${pad(seconds % 60)}`;
+}
+
+function formatClock(date) {
+  return date.toLocaleTimeString([], { hour: "2-digit", minute: "2-digit" });
+}
+
+// taskColor gives every task a stable color
+function taskColor(name) {
+  let hash = 0;
+  for (const char of name) {
+    hash = (hash * 31 + char.codePointAt(0)) >>> 0;
+  }
+  return `hsl(${hash % 360}, 60%, 55%)`;
+}
+
+function startOfDay(date) {
+  return new Date(date.getFullYear(), date.getMonth(), date.getDate());
+}
+
+// startOfWeek returns midnight on Monday, as weeks start on Monday in tt
+function startOfWeek(date) {
+  const day = startOfDay(date);
+  day.setDate(day.getDate() - ((day.getDay() + 6) % 7));
+  return day;
+}
+
+function addDays(date, days) {
+  const result = new Date(date);
+  result.setDate(result.getDate() + days);
+  return result;
+}
+
+// spans returns the entries as start and end dates, running entries ending now
+function spans(now) {
+  return state.entries.map((entry) => ({
+    name: entry.task.name,
+    start: new Date(entry.time_entry.start_time),
+    end: entry.time_entry.end_time ? new Date(entry.time_entry.end_time) : now,
+    running: !entry.time_entry.end_time,
+  }));
+}
+
+// overlap returns how long a span lies between from and to
+function overlap(span, from, to) {
+  return Math.max(0, Math.min(span.end, to) - Math.max(span.start, from));
+}
+
+function render() {
+  const now = new Date();
+  const all = spans(now);
+  renderTimer(now);
+  renderTotals(all, now);
+  renderTaskBars(all, now);
+  renderTimeline(all, now);
+}
+
+// tick updates the running clock and the totals; the charts, which hold buttons, are redrawn less often
+function tick() {
+  const now = new Date();
+  renderTimer(now);
+  renderTotals(spans(now), now);
+}
+
+function renderTimer(now) {
+  const running = state.running;
+  document.getElementById("running").hidden = !running;
+  document.getElementById("start-form").hidden = !!running;
+  if (!running) {
+    return;
+  }
+
+  const start = new Date(running.time_entry.start_time);
+  document.getElementById("running-task").textContent = running.task.name;
+  document.getElementById("running-elapsed").textContent = formatElapsed(now - start);
+  document.getElementById("running-since").textContent = `since ${formatClock(start)}`;
+}
+
+function renderTotals(all, now) {
+  const today = startOfDay(now);
+  const week = startOfWeek(now);
+  const tomorrow = addDays(today, 1);
+
+  let todayTotal = 0;
+  let weekTotal = 0;
+  const tasksToday = new Set();
+  for (const span of all) {
+    const todayPart = overlap(span, today, tomorrow);
+    todayTotal += todayPart;
+    weekTotal += overlap(span, week, tomorrow);
+    if (todayPart > 0) {
+      tasksToday.add(span.name);
+    }
+  }
+
+  document.getElementById("total-today").textContent = formatDuration(todayTotal);
+  document.getElementById("total-week").textContent = formatDuration(weekTotal);
+  document.getElementById("tasks-today").textContent = String(tasksToday.size);
+}
+
+function renderTaskBars(all, now) {
+  const week = startOfWeek(now);
+  const totals = new Map();
+  const taskIDs = new Map();
+  for (const entry of state.entries) {
+    taskIDs.set(entry.task.name, entry.task.id);
+  }
+  for (const span of all) {
+    totals.set(span.name, (totals.get(span.name) || 0) + overlap(span, week, now));
+  }
+
+  const rows = [...totals.entries()].filter(([, total]) => total > 0).sort((a, b) => b[1] - a[1]);
+  const container = document.getElementById("task-bars");
+  container.replaceChildren();
+  if (rows.length === 0) {
+    container.append(el("div", "empty", "No time tracked this week yet."));
+    return;
+  }
+
+  const longest = rows[0][1];
+  const runningName = state.running ? state.running.task.name : null;
+  for (const [name, total] of rows) {
+    const row = el("div", "bar-row");
+    const label = el("div", "bar-label", name);
+    label.title = name;
+
+    const track = el("div", "bar-track");
+    const bar = el("div", "bar");
+    bar.style.width = `${(total / longest) * 100}%`;
+    bar.style.background = taskColor(name);
+    track.append(bar);
+
+    const action = el("div");
+    if (name !== runningName) {
+      const resume = el("button", "resume", "Resume");
+      resume.type = "button";
+      resume.addEventListener("click", () => act("POST", `/api/tasks/${taskIDs.get(name)}/resume`));
+      action.append(resume);
+    }
+
+    row.append(label, track, el("div", "bar-value", formatDuration(total)), action);
+    container.append(row);
+  }
+}
+
+function renderTimeline(all, now) {
+  const container = document.getElementById("timeline");
+  container.replaceChildren();
+
+  const hours = el("div", "hours");
+  for (const hour of [0, 6, 12, 18, 24]) {
+    hours.append(el("span", "", String(hour).padStart(2, "0")));
+  }
+  container.append(hours);
+
+  const today = startOfDay(now);
+  for (let day = startOfWeek(now); day <= today; day = addDays(day, 1)) {
+    const next = addDays(day, 1);
+    const length = next - day; // Not always 24 hours, when daylight saving time starts or ends
+
+    const row = el("div", day.getTime() === today.getTime() ? "day-row today" : "day-row");
+    const track = el("div", "day-track");
+    let total = 0;
+    for (const span of all) {
+      const part = overlap(span, day, next);
+      if (part === 0) {
+        continue;
+      }
+      total += part;
+
+      const start = Math.max(span.start, day);
+      const block = el("div", span.running ? "block running" : "block");
+      block.style.left = `${((start - day) / length) * 100}%`;
+      block.style.width = `${(part / length) * 100}%`;
+      block.style.background = taskColor(span.name);
+      block.title = `${span.name}: ${formatClock(new Date(start))}–${formatClock(new Date(start + part))} (${formatDuration(part)})`;
+      track.append(block);
+    }
+
+    row.append(el("div", "", `${WEEKDAYS[day.getDay()]} ${day.getDate()}`), track, el("div", "bar-value", formatDuration(total)));
+    container.append(row);
+  }
+}
+
+// watch reloads on every change reported by the server, by this dashboard or any other tt process
+function watch() {
+  const status = document.getElementById("status");
+  const source = new EventSource("/api/events");
+  source.onopen = () => {
+    status.textContent = "live";
+  };
+  source.onerror = () => {
+    status.textContent = "reconnecting…";
+  };
+  for (const type of ["current", "started", "stopped", "resumed", "task_renamed", "deleted", "undone"]) {
+    source.addEventListener(type, load);
+  }
+}
+
+document.getElementById("start-form").addEventListener("submit", (event) => {
+  event.preventDefault();
+  const input = document.getElementById("start-task");
+  const task = input.value.trim();
+  if (task !== "") {
+    act("POST", "/api/start", { task }).then((started) => {
+      if (started) {
+        input.value = "";
+      }
+    });
+  }
+});
+document.getElementById("stop").addEventListener("click", () => act("POST", "/api/stop"));
+
+setInterval(tick, 1000);
+setInterval(render, 60 * 1000);
+load();
+watch();
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+)
+
+// Backup files are named tt-<date>.db for automatic backups, tt-<date>-<time>.db for the others
+// and tt-before-restore-<date>-<time>.db for the copy taken before a restore
+const (
+	backupPrefix        = "tt-"
+	backupSuffix        = ".db"
+	backupDayLayout     = "2006-01-02"
+	backupTimeLayout    = "2006-01-02-150405"
+	beforeRestorePrefix = "tt-before-restore-"
+)
+
+// backupServiceImpl implements the BackupService interface
+type backupServiceImpl struct {
+	repo sqlite.Repository
+}
+
+// NewBackupService creates a new BackupService instance
+func NewBackupService(repo sqlite.Repository) BackupService {
+	return &backupServiceImpl{repo: repo}
+}
+
+// CreateBackup copies the database to path, or to a file named after the current time in dir when path is empty
+func (b *backupServiceImpl) CreateBackup(ctx context.Context, dir string, path string) (*BackupInfo, error) {
+	if path == "" {
+		path = timestampedBackupPath(dir, backupPrefix)
+	}
+	return b.backupTo(ctx, path)
+}
+
+// AutoBackup takes today's automatic backup in dir unless it exists, then deletes the automatic backups
+// outside the retention. Only the first call of a day does any work.
+func (b *backupServiceImpl) AutoBackup(ctx context.Context, dir string, retention BackupRetention) (*AutoBackup, error) {
+	if retention.KeepDaily < 1 {
+		return nil, errors.NewInvalidInputError("keep_daily", retention.KeepDaily, "must keep at least one daily backup")
+	}
+	if retention.KeepWeekly < 0 {
+		return nil, errors.NewInvalidInputError("keep_weekly", retention.KeepWeekly, "cannot be negative")
+	}
+
+	result := &AutoBackup{Pruned: []*BackupInfo{}}
+	path := filepath.Join(dir, backupPrefix+time.Now().Format(backupDayLayout)+backupSuffix)
+	if _, err := os.Stat(path); err == nil {
+		return result, nil
+	}
+
+	created, err := b.backupTo(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	result.Created = created
+
+	backups, err := b.ListBackups(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, backup := range backupsToPrune(backups, retention) {
+		if err := os.Remove(backup.Path); err != nil {
+			return nil, fmt.Errorf("failed to delete old backup %s: %w", backup.Path, err)
+		}
+		result.Pruned = append(result.Pruned, backup)
+	}
+	return result, nil
+}
+
+// ListBackups returns the backups in dir, newest first. A missing dir has no backups.
+func (b *backupServiceImpl) ListBackups(ctx context.Context, dir string) ([]*BackupInfo, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*BackupInfo{}, nil
+		}
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	backups := []*BackupInfo{}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupSuffix) {
+			continue
+		}
+		backup, err := backupInfo(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+	sort.SliceStable(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+// RestoreBackup replaces the database with the backup at path, a file name alone also being looked up in dir.
+// The database is first backed up to dir, so restoring that backup takes the restore back.
+func (b *backupServiceImpl) RestoreBackup(ctx context.Context, dir string, path string) (*BackupRestore, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) && filepath.Base(path) == path {
+		if _, err := os.Stat(filepath.Join(dir, path)); err == nil {
+			path = filepath.Join(dir, path)
+		}
+	}
+	restored, err := backupInfo(path)
+	if err != nil {
+		return nil, err
+	}
+
+	safety, err := b.backupTo(ctx, timestampedBackupPath(dir, beforeRestorePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up the database before restoring: %w", err)
+	}
+	if err := b.repo.Restore(ctx, path); err != nil {
+		os.Remove(safety.Path)
+		return nil, err
+	}
+	return &BackupRestore{Restored: restored, SafetyBackup: safety}, nil
+}
+
+// backupTo backs the database up to path, creating its directory if needed
+func (b *backupServiceImpl) backupTo(ctx context.Context, path string) (*BackupInfo, error) {
+	// Backups hold the whole time database, so their directory is private to the user
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	if err := b.repo.Backup(ctx, path); err != nil {
+		return nil, err
+	}
+	return backupInfo(path)
+}
+
+// timestampedBackupPath names a backup in dir after the current time, numbering it when that name is taken
+func timestampedBackupPath(dir string, prefix string) string {
+	name := prefix + time.Now().Format(backupTimeLayout)
+	path := filepath.Join(dir, name+backupSuffix)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-%d%s", name, n, backupSuffix))
+	}
+}
+
+// backupInfo describes the backup file at path
+func backupInfo(path string) (*BackupInfo, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewNotFoundError("backup", path)
+		}
+		return nil, fmt.Errorf("failed to read backup %s: %w", path, err)
+	}
+	_, automatic := automaticBackupDay(filepath.Base(path))
+	return &BackupInfo{Path: path, Size: stat.Size(), CreatedAt: stat.ModTime(), Automatic: automatic}, nil
+}
+
+// automaticBackupDay returns the day an automatic backup was taken, from a file name such as tt-2026-10-16.db
+func automaticBackupDay(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupSuffix) {
+		return time.Time{}, false
+	}
+	day, err := time.ParseInLocation(backupDayLayout, strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), backupSuffix), time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+// backupsToPrune returns the automatic backups outside the retention: those not among the KeepDaily most
+// recent, unless they are the newest backup of one of the KeepWeekly most recent weeks with a backup.
+// Other backups are never pruned.
+func backupsToPrune(backups []*BackupInfo, retention BackupRetention) []*BackupInfo {
+	type datedBackup struct {
+		backup *BackupInfo
+		day    time.Time
+	}
+	var dated []datedBackup
+	for _, backup := range backups {
+		if day, ok := automaticBackupDay(filepath.Base(backup.Path)); ok {
+			dated = append(dated, datedBackup{backup: backup, day: day})
+		}
+	}
+	sort.SliceStable(dated, func(i, j int) bool { return dated[i].day.After(dated[j].day) })
+
+	keep := make(map[string]bool)
+	weeks := make(map[string]bool)
+	for i, candidate := range dated {
+		if i < retention.KeepDaily {
+			keep[candidate.backup.Path] = true
+		}
+		year, week := candidate.day.ISOWeek()
+		key := fmt.Sprintf("%d-%d", year, week)
+		if !weeks[key] && len(weeks) < retention.KeepWeekly {
+			keep[candidate.backup.Path] = true
+		}
+		weeks[key] = true
+	}
+
+	var prune []*BackupInfo
+	for _, candidate := range dated {
+		if !keep[candidate.backup.Path] {
+			prune = append(prune, candidate.backup)
+		}
+	}
+	return prune
+}
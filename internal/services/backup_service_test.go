@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupService(t *testing.T) {
+	ctx := context.Background()
+
+	// A database holding one task, with an empty backup directory next to it
+	setup := func(t *testing.T) (BackupService, sqlite.Repository, string) {
+		dir := t.TempDir()
+		repo, err := sqlite.New(filepath.Join(dir, "tt.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { repo.Close() })
+		require.NoError(t, repo.CreateTask(ctx, &sqlite.Task{TaskName: "Email"}))
+		return NewBackupService(repo), repo, filepath.Join(dir, "backups")
+	}
+
+	// addBackup creates a placeholder automatic backup for the day days ago
+	addBackup := func(t *testing.T, dir string, daysAgo int) string {
+		require.NoError(t, os.MkdirAll(dir, 0700))
+		path := filepath.Join(dir, "tt-"+time.Now().AddDate(0, 0, -daysAgo).Format("2006-01-02")+".db")
+		require.NoError(t, os.WriteFile(path, []byte("backup"), 0600))
+		return path
+	}
+
+	t.Run("backs up to a timestamped file in the backup directory", func(t *testing.T) {
+		service, _, dir := setup(t)
+
+		backup, err := service.CreateBackup(ctx, dir, "")
+		require.NoError(t, err)
+		assert.Equal(t, dir, filepath.Dir(backup.Path))
+		assert.Regexp(t, `^tt-\d{4}-\d{2}-\d{2}-\d{6}\.db$`, filepath.Base(backup.Path))
+		assert.False(t, backup.Automatic)
+		assert.Positive(t, backup.Size)
+	})
+
+	t.Run("backs up to the given path", func(t *testing.T) {
+		service, _, dir := setup(t)
+		path := filepath.Join(t.TempDir(), "copy.db")
+
+		backup, err := service.CreateBackup(ctx, dir, path)
+		require.NoError(t, err)
+		assert.Equal(t, path, backup.Path)
+
+		_, err = service.CreateBackup(ctx, dir, path)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput), "An existing file is never overwritten")
+	})
+
+	t.Run("takes one automatic backup a day", func(t *testing.T) {
+		service, _, dir := setup(t)
+		retention := BackupRetention{KeepDaily: 7, KeepWeekly: 4}
+
+		first, err := service.AutoBackup(ctx, dir, retention)
+		require.NoError(t, err)
+		require.NotNil(t, first.Created)
+		assert.Equal(t, "tt-"+time.Now().Format("2006-01-02")+".db", filepath.Base(first.Created.Path))
+		assert.True(t, first.Created.Automatic)
+
+		second, err := service.AutoBackup(ctx, dir, retention)
+		require.NoError(t, err)
+		assert.Nil(t, second.Created)
+		assert.Empty(t, second.Pruned)
+	})
+
+	t.Run("prunes automatic backups outside the retention", func(t *testing.T) {
+		service, _, dir := setup(t)
+		var old []string
+		for daysAgo := 1; daysAgo <= 60; daysAgo++ {
+			old = append(old, addBackup(t, dir, daysAgo))
+		}
+		manual, err := service.CreateBackup(ctx, dir, "")
+		require.NoError(t, err)
+
+		result, err := service.AutoBackup(ctx, dir, BackupRetention{KeepDaily: 3, KeepWeekly: 2})
+		require.NoError(t, err)
+		require.NotNil(t, result.Created)
+
+		backups, err := service.ListBackups(ctx, dir)
+		require.NoError(t, err)
+		kept := make(map[string]bool)
+		automatic := 0
+		for _, backup := range backups {
+			kept[backup.Path] = true
+			if backup.Automatic {
+				automatic++
+			}
+		}
+		assert.True(t, kept[result.Created.Path])
+		assert.True(t, kept[old[0]], "The last three days are kept")
+		assert.True(t, kept[old[1]])
+		assert.False(t, kept[old[59]], "Backups older than the retention are deleted")
+		assert.True(t, kept[manual.Path], "Backups taken by hand are never pruned")
+
+		// Three days plus the newest backup of last week, unless that is yesterday
+		assert.Contains(t, []int{3, 4}, automatic)
+		assert.Len(t, result.Pruned, 61-automatic)
+	})
+
+	t.Run("rejects a retention without daily backups", func(t *testing.T) {
+		service, _, dir := setup(t)
+		_, err := service.AutoBackup(ctx, dir, BackupRetention{KeepDaily: 0})
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+	})
+
+	t.Run("lists nothing before the first backup", func(t *testing.T) {
+		service, _, dir := setup(t)
+		backups, err := service.ListBackups(ctx, dir)
+		require.NoError(t, err)
+		assert.Empty(t, backups)
+	})
+
+	t.Run("restores a backup after backing up the database", func(t *testing.T) {
+		service, repo, dir := setup(t)
+		backup, err := service.CreateBackup(ctx, dir, "")
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateTask(ctx, &sqlite.Task{TaskName: "Code review"}))
+
+		restore, err := service.RestoreBackup(ctx, dir, filepath.Base(backup.Path))
+		require.NoError(t, err)
+		assert.Equal(t, backup.Path, restore.Restored.Path, "A file name alone is looked up in the backup directory")
+		assert.Contains(t, filepath.Base(restore.SafetyBackup.Path), "tt-before-restore-")
+
+		tasks, err := repo.ListTasks(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 1)
+
+		// Restoring the safety backup takes the restore back
+		_, err = service.RestoreBackup(ctx, dir, restore.SafetyBackup.Path)
+		require.NoError(t, err)
+		tasks, err = repo.ListTasks(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+	})
+
+	t.Run("keeps no safety backup when the restore fails", func(t *testing.T) {
+		service, _, dir := setup(t)
+		broken := addBackup(t, dir, 1)
+
+		_, err := service.RestoreBackup(ctx, dir, broken)
+		require.Error(t, err)
+		backups, err := service.ListBackups(ctx, dir)
+		require.NoError(t, err)
+		assert.Len(t, backups, 1)
+
+		_, err = service.RestoreBackup(ctx, dir, "missing.db")
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeNotFound))
+	})
+}
+
+func TestBackupsToPrune(t *testing.T) {
+	day := func(date string) *BackupInfo {
+		return &BackupInfo{Path: "/backups/tt-" + date + ".db", Automatic: true}
+	}
+	// Wednesday 2026-10-14 back to Thursday 2026-10-01, two backups in the week of 2026-10-05
+	backups := []*BackupInfo{
+		day("2026-10-14"), day("2026-10-13"), day("2026-10-12"),
+		day("2026-10-09"), day("2026-10-06"),
+		day("2026-10-02"), day("2026-10-01"),
+		{Path: "/backups/tt-2026-09-01-120000.db"},
+	}
+
+	paths := func(backups []*BackupInfo) []string {
+		var result []string
+		for _, backup := range backups {
+			result = append(result, filepath.Base(backup.Path))
+		}
+		return result
+	}
+
+	t.Run("keeps the daily and the newest backup of each recent week", func(t *testing.T) {
+		prune := backupsToPrune(backups, BackupRetention{KeepDaily: 2, KeepWeekly: 2})
+		assert.Equal(t, []string{"tt-2026-10-12.db", "tt-2026-10-06.db", "tt-2026-10-02.db", "tt-2026-10-01.db"}, paths(prune))
+	})
+
+	t.Run("keeps only daily backups without weekly ones", func(t *testing.T) {
+		prune := backupsToPrune(backups, BackupRetention{KeepDaily: 5})
+		assert.Equal(t, []string{"tt-2026-10-02.db", "tt-2026-10-01.db"}, paths(prune))
+	})
+
+	t.Run("keeps everything within the retention", func(t *testing.T) {
+		assert.Empty(t, backupsToPrune(backups, BackupRetention{KeepDaily: 7, KeepWeekly: 3}))
+	})
+}
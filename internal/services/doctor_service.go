@@ -0,0 +1,476 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+)
+
+// FixStrategies lists every strategy the consistency check can apply, in the order it applies them
+var FixStrategies = []string{FixStopExtras, FixMergeDuplicates, FixTrimOverlaps}
+
+// doctorServiceImpl implements the DoctorService interface
+type doctorServiceImpl struct {
+	repo        sqlite.Repository
+	timeService TimeService
+}
+
+// NewDoctorService creates a new DoctorService instance
+func NewDoctorService(repo sqlite.Repository) DoctorService {
+	return &doctorServiceImpl{
+		repo:        repo,
+		timeService: NewTimeService(repo),
+	}
+}
+
+// CheckConsistency scans every task and time entry for problems. With fix strategies it applies them
+// in a single journaled operation, so tt undo reverts them, then reports the problems left and those fixed.
+func (d *doctorServiceImpl) CheckConsistency(ctx context.Context, opts DoctorOptions) (*DoctorReport, error) {
+	if opts.MaxDuration < 0 {
+		return nil, errors.NewInvalidInputError("max_duration", opts.MaxDuration.String(), "cannot be negative")
+	}
+	strategies := make(map[string]bool)
+	for _, strategy := range opts.Fix {
+		if !isFixStrategy(strategy) {
+			return nil, errors.NewInvalidInputError("fix", strategy, "expected "+strings.Join(FixStrategies, ", "))
+		}
+		strategies[strategy] = true
+	}
+
+	before, err := d.diagnose(ctx, opts.MaxDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	fixable := false
+	for _, problem := range before.Problems {
+		fixable = fixable || strategies[problem.Fix]
+	}
+	if !fixable {
+		return before, nil
+	}
+
+	err = recordOperation(ctx, d.repo, OperationDoctor, func(repo sqlite.Repository) (string, error) {
+		tx := &doctorServiceImpl{repo: repo, timeService: NewTimeService(repo)}
+		return tx.applyFixes(ctx, strategies)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := d.diagnose(ctx, opts.MaxDuration)
+	if err != nil {
+		return nil, err
+	}
+	remaining := make(map[string]bool)
+	for _, problem := range after.Problems {
+		remaining[problemKey(problem)] = true
+	}
+	for _, problem := range before.Problems {
+		if strategies[problem.Fix] && !remaining[problemKey(problem)] {
+			after.Fixed = append(after.Fixed, problem)
+		}
+	}
+	return after, nil
+}
+
+// diagnose lists the problems in the repository, grouped by kind
+func (d *doctorServiceImpl) diagnose(ctx context.Context, maxDuration time.Duration) (*DoctorReport, error) {
+	tasks, err := d.repo.ListTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := d.repo.ListTimeEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(tasks))
+	for _, task := range tasks {
+		names[task.ID] = task.TaskName
+	}
+	describe := func(entry *sqlite.TimeEntry) string {
+		return fmt.Sprintf("entry %d (%s)", entry.ID, d.describeEntry(entry, names))
+	}
+
+	now := time.Now()
+	report := &DoctorReport{CheckedTasks: len(tasks), CheckedEntries: len(entries), Problems: []*DoctorProblem{}}
+	add := func(problem *DoctorProblem) {
+		report.Problems = append(report.Problems, problem)
+	}
+
+	var running []*sqlite.TimeEntry
+	for _, entry := range entries {
+		if entry.EndTime == nil {
+			running = append(running, entry)
+		}
+	}
+	if len(running) > 1 {
+		descriptions := make([]string, len(running))
+		ids := make([]int64, len(running))
+		for i, entry := range running {
+			descriptions[i] = describe(entry)
+			ids[i] = entry.ID
+		}
+		add(&DoctorProblem{
+			Kind:        ProblemMultipleRunning,
+			Description: fmt.Sprintf("%d entries are running: %s", len(running), joinWithAnd(descriptions)),
+			EntryIDs:    ids,
+			Fix:         FixStopExtras,
+		})
+	}
+
+	for _, entry := range entries {
+		if entry.EndTime != nil && entry.EndTime.Before(entry.StartTime) {
+			add(&DoctorProblem{
+				Kind: ProblemEndBeforeStart,
+				Description: fmt.Sprintf("Entry %d of %q ends at %s, before it starts at %s", entry.ID, d.taskName(entry, names),
+					formatDoctorTime(*entry.EndTime), formatDoctorTime(entry.StartTime)),
+				EntryIDs: []int64{entry.ID},
+			})
+		}
+	}
+
+	for _, overlap := range findOverlaps(entries, now) {
+		problem := &DoctorProblem{
+			Kind: ProblemOverlap,
+			Description: fmt.Sprintf("%s overlaps %s by %s", capitalize(describe(overlap.earlier)), describe(overlap.later),
+				d.timeService.FormatDuration(overlap.duration(now))),
+			EntryIDs: []int64{overlap.earlier.ID, overlap.later.ID},
+		}
+		if overlap.trimmable() {
+			problem.Fix = FixTrimOverlaps
+		}
+		add(problem)
+	}
+
+	if maxDuration > 0 {
+		for _, entry := range entries {
+			end := now
+			if entry.EndTime != nil {
+				end = *entry.EndTime
+			}
+			if duration := end.Sub(entry.StartTime); duration > maxDuration {
+				verb := "lasts"
+				if entry.EndTime == nil {
+					verb = "has been running for"
+				}
+				add(&DoctorProblem{
+					Kind: ProblemTooLong,
+					Description: fmt.Sprintf("Entry %d of %q %s %s, longer than the maximum of %s", entry.ID, d.taskName(entry, names),
+						verb, d.timeService.FormatDuration(duration), d.timeService.FormatDuration(maxDuration)),
+					EntryIDs: []int64{entry.ID},
+				})
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if _, exists := names[entry.TaskID]; !exists {
+			add(&DoctorProblem{
+				Kind:        ProblemOrphanedEntry,
+				Description: fmt.Sprintf("Entry %d belongs to task %d, which does not exist", entry.ID, entry.TaskID),
+				EntryIDs:    []int64{entry.ID},
+				TaskIDs:     []int64{entry.TaskID},
+			})
+		}
+	}
+
+	for _, group := range duplicateTasks(tasks) {
+		descriptions := make([]string, len(group))
+		ids := make([]int64, len(group))
+		for i, task := range group {
+			descriptions[i] = fmt.Sprintf("%d (%q)", task.ID, task.TaskName)
+			ids[i] = task.ID
+		}
+		add(&DoctorProblem{
+			Kind:        ProblemDuplicateTask,
+			Description: fmt.Sprintf("Tasks %s share a name", joinWithAnd(descriptions)),
+			TaskIDs:     ids,
+			Fix:         FixMergeDuplicates,
+		})
+	}
+
+	return report, nil
+}
+
+// applyFixes applies the chosen strategies within a journaled operation, returning its description.
+// Extra running entries are stopped first, so trimming overlaps never has to choose between running entries.
+func (d *doctorServiceImpl) applyFixes(ctx context.Context, strategies map[string]bool) (string, error) {
+	var done []string
+	if strategies[FixStopExtras] {
+		stopped, err := d.stopExtras(ctx)
+		if err != nil {
+			return "", err
+		}
+		if stopped > 0 {
+			done = append(done, fmt.Sprintf("stopped %s", pluralize(stopped, "extra running entry", "extra running entries")))
+		}
+	}
+	if strategies[FixMergeDuplicates] {
+		merged, err := d.mergeDuplicates(ctx)
+		if err != nil {
+			return "", err
+		}
+		if merged > 0 {
+			done = append(done, fmt.Sprintf("merged %s", pluralize(merged, "duplicate task", "duplicate tasks")))
+		}
+	}
+	if strategies[FixTrimOverlaps] {
+		trimmed, err := d.trimOverlaps(ctx)
+		if err != nil {
+			return "", err
+		}
+		if trimmed > 0 {
+			done = append(done, fmt.Sprintf("trimmed %s", pluralize(trimmed, "overlapping entry", "overlapping entries")))
+		}
+	}
+	return "Doctor " + joinWithAnd(done), nil
+}
+
+// stopExtras stops every running entry except the most recently started one, at the time that one started
+func (d *doctorServiceImpl) stopExtras(ctx context.Context) (int, error) {
+	entries, err := d.repo.ListTimeEntries(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var running []*sqlite.TimeEntry
+	for _, entry := range entries {
+		if entry.EndTime == nil {
+			running = append(running, entry)
+		}
+	}
+	if len(running) < 2 {
+		return 0, nil
+	}
+
+	latest := running[len(running)-1]
+	for _, entry := range running[:len(running)-1] {
+		end := latest.StartTime
+		entry.EndTime = &end
+		if err := d.repo.UpdateTimeEntry(ctx, entry); err != nil {
+			return 0, err
+		}
+	}
+	return len(running) - 1, nil
+}
+
+// mergeDuplicates moves the entries of tasks sharing a name into the oldest of them and deletes the others
+func (d *doctorServiceImpl) mergeDuplicates(ctx context.Context) (int, error) {
+	tasks, err := d.repo.ListTasks(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	merged := 0
+	for _, group := range duplicateTasks(tasks) {
+		into := group[0]
+		for _, from := range group[1:] {
+			if _, err := d.repo.MergeTasks(ctx, from.ID, into.ID); err != nil {
+				return 0, err
+			}
+			merged++
+		}
+	}
+	return merged, nil
+}
+
+// trimOverlaps ends each entry when the next overlapping one starts, walking the entries in order of start time.
+// Entries starting at the same time, or both still running, are left for the user to sort out.
+func (d *doctorServiceImpl) trimOverlaps(ctx context.Context) (int, error) {
+	entries, err := d.repo.ListTimeEntries(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	trimmed := 0
+	var furthest *sqlite.TimeEntry
+	for _, entry := range sortedValidEntries(entries) {
+		if furthest != nil {
+			overlap := &entryOverlap{earlier: furthest, later: entry}
+			if overlap.overlaps(now) && overlap.trimmable() {
+				end := entry.StartTime
+				furthest.EndTime = &end
+				if err := d.repo.UpdateTimeEntry(ctx, furthest); err != nil {
+					return 0, err
+				}
+				trimmed++
+			}
+		}
+		if furthest == nil || entryEnd(entry, now).After(entryEnd(furthest, now)) {
+			furthest = entry
+		}
+	}
+	return trimmed, nil
+}
+
+// describeEntry names an entry's task and the time it covers, e.g. "Email", 2026-10-15 09:00 to 10:30
+func (d *doctorServiceImpl) describeEntry(entry *sqlite.TimeEntry, names map[int64]string) string {
+	end := "running"
+	if entry.EndTime != nil {
+		end = entry.EndTime.Local().Format("15:04")
+		if !sameDay(entry.StartTime, *entry.EndTime) {
+			end = formatDoctorTime(*entry.EndTime)
+		}
+	}
+	return fmt.Sprintf("%q, %s to %s", d.taskName(entry, names), formatDoctorTime(entry.StartTime), end)
+}
+
+// taskName returns the name of an entry's task, or its ID when the task does not exist
+func (d *doctorServiceImpl) taskName(entry *sqlite.TimeEntry, names map[int64]string) string {
+	if name, exists := names[entry.TaskID]; exists {
+		return name
+	}
+	return fmt.Sprintf("task %d", entry.TaskID)
+}
+
+// entryOverlap is a pair of entries covering the same time, the earlier one starting first
+type entryOverlap struct {
+	earlier *sqlite.TimeEntry
+	later   *sqlite.TimeEntry
+}
+
+// overlaps reports whether the earlier entry is still going when the later one starts
+func (o *entryOverlap) overlaps(now time.Time) bool {
+	return entryEnd(o.earlier, now).After(o.later.StartTime)
+}
+
+// duration returns how long both entries cover
+func (o *entryOverlap) duration(now time.Time) time.Duration {
+	end := entryEnd(o.earlier, now)
+	if laterEnd := entryEnd(o.later, now); laterEnd.Before(end) {
+		end = laterEnd
+	}
+	return end.Sub(o.later.StartTime)
+}
+
+// trimmable reports whether ending the earlier entry when the later one starts leaves it any time,
+// and is not a choice between two running entries
+func (o *entryOverlap) trimmable() bool {
+	return o.later.StartTime.After(o.earlier.StartTime) && (o.earlier.EndTime != nil || o.later.EndTime != nil)
+}
+
+// findOverlaps pairs every entry with the earlier entry reaching furthest into it, when they overlap.
+// Running entries count until now; pairs of running entries are left to the multiple running check.
+func findOverlaps(entries []*sqlite.TimeEntry, now time.Time) []*entryOverlap {
+	var overlaps []*entryOverlap
+	var furthest *sqlite.TimeEntry
+	for _, entry := range sortedValidEntries(entries) {
+		if furthest != nil {
+			overlap := &entryOverlap{earlier: furthest, later: entry}
+			if overlap.overlaps(now) && (furthest.EndTime != nil || entry.EndTime != nil) {
+				overlaps = append(overlaps, overlap)
+			}
+		}
+		if furthest == nil || entryEnd(entry, now).After(entryEnd(furthest, now)) {
+			furthest = entry
+		}
+	}
+	return overlaps
+}
+
+// sortedValidEntries returns the entries that do not end before they start, in order of start time
+func sortedValidEntries(entries []*sqlite.TimeEntry) []*sqlite.TimeEntry {
+	var valid []*sqlite.TimeEntry
+	for _, entry := range entries {
+		if entry.EndTime == nil || !entry.EndTime.Before(entry.StartTime) {
+			valid = append(valid, entry)
+		}
+	}
+	sort.SliceStable(valid, func(i, j int) bool {
+		if valid[i].StartTime.Equal(valid[j].StartTime) {
+			return valid[i].ID < valid[j].ID
+		}
+		return valid[i].StartTime.Before(valid[j].StartTime)
+	})
+	return valid
+}
+
+// duplicateTasks groups the tasks whose names only differ in case or surrounding spaces, oldest first
+func duplicateTasks(tasks []*sqlite.Task) [][]*sqlite.Task {
+	byName := make(map[string][]*sqlite.Task)
+	var keys []string
+	for _, task := range tasks {
+		key := strings.ToLower(strings.TrimSpace(task.TaskName))
+		if _, seen := byName[key]; !seen {
+			keys = append(keys, key)
+		}
+		byName[key] = append(byName[key], task)
+	}
+
+	var groups [][]*sqlite.Task
+	for _, key := range keys {
+		group := byName[key]
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0].ID < groups[j][0].ID })
+	return groups
+}
+
+// entryEnd returns when an entry ends, now for a running entry
+func entryEnd(entry *sqlite.TimeEntry, now time.Time) time.Time {
+	if entry.EndTime == nil {
+		return now
+	}
+	return *entry.EndTime
+}
+
+// problemKey identifies a problem across checks, so problems found before and after fixing can be compared
+func problemKey(problem *DoctorProblem) string {
+	return fmt.Sprintf("%s %v %v", problem.Kind, problem.EntryIDs, problem.TaskIDs)
+}
+
+// isFixStrategy reports whether name is one of FixStrategies
+func isFixStrategy(name string) bool {
+	for _, strategy := range FixStrategies {
+		if name == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+// formatDoctorTime formats a time in problem descriptions
+func formatDoctorTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// sameDay reports whether two times fall on the same local date
+func sameDay(a, b time.Time) bool {
+	a, b = a.Local(), b.Local()
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// joinWithAnd joins items as "a, b and c"
+func joinWithAnd(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// pluralize formats a count with the singular or plural form of a noun
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
+
+// capitalize upper-cases the first letter of text
+func capitalize(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctorService_CheckConsistency(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	setup := func(t *testing.T) (DoctorService, sqlite.Repository) {
+		repo, err := sqlite.New(":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { repo.Close() })
+		return NewDoctorService(repo), repo
+	}
+	createTask := func(t *testing.T, repo sqlite.Repository, name string) int64 {
+		task := &sqlite.Task{TaskName: name}
+		require.NoError(t, repo.CreateTask(ctx, task))
+		return task.ID
+	}
+	createEntry := func(t *testing.T, repo sqlite.Repository, taskID int64, start time.Time, end *time.Time) int64 {
+		entry := &sqlite.TimeEntry{TaskID: taskID, StartTime: start, EndTime: end}
+		require.NoError(t, repo.CreateTimeEntry(ctx, entry))
+		return entry.ID
+	}
+	kinds := func(report *DoctorReport) []string {
+		var result []string
+		for _, problem := range report.Problems {
+			result = append(result, problem.Kind)
+		}
+		return result
+	}
+
+	t.Run("should find nothing in a consistent database", func(t *testing.T) {
+		service, repo := setup(t)
+		email := createTask(t, repo, "Email")
+		createEntry(t, repo, email, at(9, 0), timePtr(at(10, 0)))
+		createEntry(t, repo, email, at(10, 0), timePtr(at(11, 0)))
+
+		report, err := service.CheckConsistency(ctx, DoctorOptions{MaxDuration: 24 * time.Hour})
+		require.NoError(t, err)
+		assert.Empty(t, report.Problems)
+		assert.Equal(t, 1, report.CheckedTasks)
+		assert.Equal(t, 2, report.CheckedEntries)
+	})
+
+	t.Run("should report every kind of problem with its ids", func(t *testing.T) {
+		service, repo := setup(t)
+		email := createTask(t, repo, "Email")
+		review := createTask(t, repo, "Code review")
+		duplicate := createTask(t, repo, " email")
+
+		first := createEntry(t, repo, email, at(9, 0), timePtr(at(10, 30)))
+		second := createEntry(t, repo, review, at(10, 0), timePtr(at(11, 0)))
+		backwards := createEntry(t, repo, review, at(13, 0), timePtr(at(12, 0)))
+		long := createEntry(t, repo, email, at(0, 0).AddDate(0, 0, -3), timePtr(at(6, 0).AddDate(0, 0, -2)))
+		orphan := createEntry(t, repo, 99, at(7, 0), timePtr(at(7, 30)))
+		runningA := createEntry(t, repo, email, time.Now().Add(-2*time.Hour), nil)
+		runningB := createEntry(t, repo, review, time.Now().Add(-time.Hour), nil)
+
+		report, err := service.CheckConsistency(ctx, DoctorOptions{MaxDuration: 24 * time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, []string{ProblemMultipleRunning, ProblemEndBeforeStart, ProblemOverlap, ProblemTooLong, ProblemOrphanedEntry, ProblemDuplicateTask}, kinds(report))
+
+		problems := report.Problems
+		assert.Equal(t, []int64{runningA, runningB}, problems[0].EntryIDs)
+		assert.Equal(t, FixStopExtras, problems[0].Fix)
+
+		assert.Equal(t, []int64{backwards}, problems[1].EntryIDs)
+		assert.Empty(t, problems[1].Fix, "Entries ending before they start need fixing by hand")
+
+		assert.Equal(t, []int64{first, second}, problems[2].EntryIDs)
+		assert.Equal(t, FixTrimOverlaps, problems[2].Fix)
+		assert.Contains(t, problems[2].Description, `Entry `)
+		assert.Contains(t, problems[2].Description, `"Email"`)
+		assert.Contains(t, problems[2].Description, "by 30m")
+
+		assert.Equal(t, []int64{long}, problems[3].EntryIDs)
+		assert.Contains(t, problems[3].Description, "lasts 30h 0m, longer than the maximum of 24h 0m")
+
+		assert.Equal(t, []int64{orphan}, problems[4].EntryIDs)
+		assert.Equal(t, []int64{99}, problems[4].TaskIDs)
+		assert.Contains(t, problems[4].Description, "task 99, which does not exist")
+
+		assert.Equal(t, []int64{email, duplicate}, problems[5].TaskIDs)
+		assert.Equal(t, FixMergeDuplicates, problems[5].Fix)
+	})
+
+	t.Run("should skip the duration check without a maximum", func(t *testing.T) {
+		service, repo := setup(t)
+		email := createTask(t, repo, "Email")
+		createEntry(t, repo, email, at(0, 0).AddDate(0, 0, -3), timePtr(at(6, 0)))
+
+		report, err := service.CheckConsistency(ctx, DoctorOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, report.Problems)
+	})
+
+	t.Run("should apply the fixes in a single undoable operation", func(t *testing.T) {
+		service, repo := setup(t)
+		email := createTask(t, repo, "Email")
+		review := createTask(t, repo, "Code review")
+		duplicate := createTask(t, repo, "EMAIL")
+
+		first := createEntry(t, repo, email, at(9, 0), timePtr(at(12, 0)))
+		second := createEntry(t, repo, review, at(10, 0), timePtr(at(11, 0)))
+		third := createEntry(t, repo, duplicate, at(10, 30), timePtr(at(11, 30)))
+		runningA := createEntry(t, repo, email, time.Now().Add(-2*time.Hour), nil)
+		runningB := createEntry(t, repo, review, time.Now().Add(-time.Hour), nil)
+
+		report, err := service.CheckConsistency(ctx, DoctorOptions{Fix: FixStrategies})
+		require.NoError(t, err)
+		assert.Empty(t, report.Problems)
+		assert.Equal(t, []string{ProblemMultipleRunning, ProblemOverlap, ProblemOverlap, ProblemDuplicateTask}, kinds(&DoctorReport{Problems: report.Fixed}))
+		assert.Equal(t, 2, report.CheckedTasks)
+
+		// Each entry ends when the next one starts
+		ends := map[int64]time.Time{first: at(10, 0), second: at(10, 30), third: at(11, 30)}
+		for id, end := range ends {
+			entry, err := repo.GetTimeEntry(ctx, id)
+			require.NoError(t, err)
+			require.NotNil(t, entry.EndTime)
+			assert.True(t, end.Equal(*entry.EndTime), "entry %d ends at %v, want %v", id, entry.EndTime, end)
+		}
+		stopped, err := repo.GetTimeEntry(ctx, runningA)
+		require.NoError(t, err)
+		latest, err := repo.GetTimeEntry(ctx, runningB)
+		require.NoError(t, err)
+		require.NotNil(t, stopped.EndTime)
+		assert.True(t, latest.StartTime.Equal(*stopped.EndTime))
+		assert.Nil(t, latest.EndTime)
+
+		merged, err := repo.GetTimeEntry(ctx, third)
+		require.NoError(t, err)
+		assert.Equal(t, email, merged.TaskID)
+
+		journal := NewJournalService(repo)
+		operations, err := journal.ListOperations(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, operations, 1)
+		assert.Equal(t, OperationDoctor, operations[0].Kind)
+		assert.Equal(t, "Doctor stopped 1 extra running entry, merged 1 duplicate task and trimmed 2 overlapping entries", operations[0].Description)
+
+		_, err = journal.UndoOperations(ctx, 1)
+		require.NoError(t, err)
+		report, err = service.CheckConsistency(ctx, DoctorOptions{})
+		require.NoError(t, err)
+		assert.Len(t, report.Problems, 4, "Undo brings every problem back")
+	})
+
+	t.Run("should only apply the chosen strategies", func(t *testing.T) {
+		service, repo := setup(t)
+		email := createTask(t, repo, "Email")
+		createTask(t, repo, "email")
+		createEntry(t, repo, email, at(9, 0), timePtr(at(12, 0)))
+		createEntry(t, repo, email, at(10, 0), timePtr(at(11, 0)))
+
+		report, err := service.CheckConsistency(ctx, DoctorOptions{Fix: []string{FixMergeDuplicates}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{ProblemOverlap}, kinds(report))
+		require.Len(t, report.Fixed, 1)
+		assert.Equal(t, ProblemDuplicateTask, report.Fixed[0].Kind)
+	})
+
+	t.Run("should leave overlaps it cannot trim", func(t *testing.T) {
+		service, repo := setup(t)
+		email := createTask(t, repo, "Email")
+		createEntry(t, repo, email, at(9, 0), timePtr(at(10, 0)))
+		createEntry(t, repo, email, at(9, 0), timePtr(at(9, 30)))
+
+		report, err := service.CheckConsistency(ctx, DoctorOptions{Fix: FixStrategies})
+		require.NoError(t, err)
+		require.Equal(t, []string{ProblemOverlap}, kinds(report))
+		assert.Empty(t, report.Problems[0].Fix, "Entries starting together need fixing by hand")
+		assert.Empty(t, report.Fixed)
+
+		operations, err := NewJournalService(repo).ListOperations(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, operations, "Nothing was fixed, so nothing is journaled")
+	})
+
+	t.Run("should reject unknown strategies", func(t *testing.T) {
+		service, _ := setup(t)
+		_, err := service.CheckConsistency(ctx, DoctorOptions{Fix: []string{"delete-everything"}})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+		assert.Contains(t, err.Error(), "trim-overlaps")
+	})
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"time-tracker/internal/repository/sqlite"
+)
+
+// Types of events sent to subscribers
+const (
+	EventCurrent     = "current" // Sent once to each new subscriber with the running session
+	EventStarted     = "started"
+	EventStopped     = "stopped"
+	EventResumed     = "resumed"
+	EventTaskRenamed = "task_renamed"
+	EventDeleted     = "deleted"
+	EventUndone      = "undone" // A journaled operation was undone; anything it changed may have changed back
+)
+
+// eventTypes maps the kinds of journaled operations to the events they are reported as.
+// Operations of other kinds are not reported.
+var eventTypes = map[string]string{
+	OperationStart:     EventStarted,
+	OperationStop:      EventStopped,
+	OperationPause:     EventStopped,
+	OperationForgotten: EventStopped, // The forgotten entry was stopped or capped
+	OperationDoctor:    EventStopped, // Extra running entries may have been stopped
+	OperationResume:    EventResumed,
+	OperationContinue:  EventResumed,
+	OperationRename:    EventTaskRenamed,
+	OperationMerge:     EventDeleted, // The merged task is deleted
+	OperationDelete:    EventDeleted,
+}
+
+// defaultEventPollInterval is how often the journal is checked for new operations while anyone is subscribed
+const defaultEventPollInterval = 500 * time.Millisecond
+
+// eventBufferSize is the number of events kept for a subscriber that is not reading; later events are dropped
+const eventBufferSize = 16
+
+// eventServiceImpl implements the EventService interface. Every change goes through the operations journal,
+// so the events are read from there, which also picks up changes made by other tt processes.
+type eventServiceImpl struct {
+	repo         sqlite.Repository
+	taskService  TaskService
+	pollInterval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[chan *Event]struct{}
+	lastID      int64              // ID of the last journaled operation looked at
+	undone      map[int64]bool     // IDs of the journaled operations known to be undone
+	stopPolling context.CancelFunc // Stops the poller, nil while nobody is subscribed
+}
+
+// NewEventService creates a new EventService instance
+func NewEventService(repo sqlite.Repository, taskService TaskService) EventService {
+	return &eventServiceImpl{
+		repo:         repo,
+		taskService:  taskService,
+		pollInterval: defaultEventPollInterval,
+		subscribers:  make(map[chan *Event]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving a current event with the running session, then an event for
+// every change from now on. The channel is closed once ctx is done.
+func (e *eventServiceImpl) Subscribe(ctx context.Context) (<-chan *Event, error) {
+	current, err := e.taskService.GetCurrentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopPolling == nil {
+		// Undoing marks operations rather than journaling one, so the undone ones are remembered too
+		operations, err := e.repo.ListOperations(ctx, 0, true)
+		if err != nil {
+			return nil, err
+		}
+		e.lastID = 0
+		if len(operations) > 0 {
+			e.lastID = operations[0].ID
+		}
+		e.undone = make(map[int64]bool)
+		for _, operation := range operations {
+			if operation.UndoneAt != nil {
+				e.undone[operation.ID] = true
+			}
+		}
+
+		pollCtx, cancel := context.WithCancel(context.Background())
+		e.stopPolling = cancel
+		go e.poll(pollCtx)
+	}
+
+	events := make(chan *Event, eventBufferSize)
+	events <- &Event{Type: EventCurrent, Time: time.Now(), Current: current}
+	e.subscribers[events] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		e.unsubscribe(events)
+	}()
+	return events, nil
+}
+
+// unsubscribe closes a subscriber's channel, stopping the poller after the last one
+func (e *eventServiceImpl) unsubscribe(events chan *Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.subscribers, events)
+	close(events)
+	if len(e.subscribers) == 0 && e.stopPolling != nil {
+		e.stopPolling()
+		e.stopPolling = nil
+	}
+}
+
+// poll checks the journal for new operations until ctx is cancelled
+func (e *eventServiceImpl) poll(ctx context.Context) {
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Errors such as a database locked by another process are retried on the next tick
+			e.publishNewOperations(ctx)
+		}
+	}
+}
+
+// publishNewOperations sends an event for each operation journaled or undone since the last check
+func (e *eventServiceImpl) publishNewOperations(ctx context.Context) error {
+	e.mu.Lock()
+	lastID := e.lastID
+	e.mu.Unlock()
+
+	operations, err := e.repo.ListOperationsAfter(ctx, lastID)
+	if err != nil {
+		return err
+	}
+	journaled, err := e.repo.ListOperations(ctx, 0, true)
+	if err != nil {
+		return err
+	}
+
+	var events []*Event
+	for _, operation := range operations {
+		if eventType, ok := eventTypes[operation.Kind]; ok {
+			events = append(events, &Event{
+				ID:          operation.ID,
+				Type:        eventType,
+				Description: operation.Description,
+				Time:        operation.CreatedAt,
+			})
+		}
+	}
+
+	// Operations are undone newest first, the order they are listed in
+	e.mu.Lock()
+	var undone []int64
+	for _, operation := range journaled {
+		if operation.UndoneAt != nil && !e.undone[operation.ID] {
+			undone = append(undone, operation.ID)
+			events = append(events, &Event{
+				ID:          operation.ID,
+				Type:        EventUndone,
+				Description: "Undone: " + operation.Description,
+				Time:        *operation.UndoneAt,
+			})
+		}
+	}
+	e.mu.Unlock()
+
+	if len(operations) == 0 && len(undone) == 0 {
+		return nil
+	}
+
+	if len(events) > 0 {
+		current, err := e.taskService.GetCurrentSession(ctx)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			event.Current = current
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.lastID != lastID {
+		return nil // Subscribers came and went meanwhile and the journal position was reset
+	}
+	if len(operations) > 0 {
+		e.lastID = operations[len(operations)-1].ID
+	}
+	for _, id := range undone {
+		e.undone[id] = true
+	}
+	for _, event := range events {
+		for subscriber := range e.subscribers {
+			select {
+			case subscriber <- event:
+			default: // The subscriber is not keeping up
+			}
+		}
+	}
+	return nil
+}
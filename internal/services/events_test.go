@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"time-tracker/internal/repository/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventService_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	// A database file rather than :memory:, as the poller may use a connection of its own
+	setup := func(t *testing.T) (TaskService, *eventServiceImpl, string) {
+		path := filepath.Join(t.TempDir(), "tt.db")
+		repo, err := sqlite.New(path)
+		require.NoError(t, err)
+		t.Cleanup(func() { repo.Close() })
+
+		tasks := NewTaskService(repo, NewTimeService(repo))
+		events := NewEventService(repo, tasks).(*eventServiceImpl)
+		events.pollInterval = 10 * time.Millisecond
+		return tasks, events, path
+	}
+
+	next := func(t *testing.T, events <-chan *Event) *Event {
+		select {
+		case event := <-events:
+			require.NotNil(t, event, "channel closed")
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+			return nil
+		}
+	}
+
+	t.Run("should report the running session, then each change", func(t *testing.T) {
+		tasks, events, _ := setup(t)
+		_, err := tasks.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+
+		subscribeCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		received, err := events.Subscribe(subscribeCtx)
+		require.NoError(t, err)
+
+		event := next(t, received)
+		assert.Equal(t, EventCurrent, event.Type)
+		require.NotNil(t, event.Current)
+		assert.Equal(t, "Write report", event.Current.Task.TaskName)
+
+		// Operations journaled before subscribing are not reported again
+		stopped, err := tasks.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+		event = next(t, received)
+		assert.Equal(t, EventStopped, event.Type)
+		assert.Nil(t, event.Current)
+		assert.Greater(t, event.ID, int64(0))
+
+		_, err = tasks.ResumeTask(ctx, stopped[0].TaskID)
+		require.NoError(t, err)
+		event = next(t, received)
+		assert.Equal(t, EventResumed, event.Type)
+		require.NotNil(t, event.Current)
+
+		_, err = tasks.UpdateTask(ctx, stopped[0].TaskID, "Write final report")
+		require.NoError(t, err)
+		assert.Equal(t, EventTaskRenamed, next(t, received).Type)
+
+		require.NoError(t, tasks.DeleteTaskWithEntries(ctx, stopped[0].TaskID))
+		event = next(t, received)
+		assert.Equal(t, EventDeleted, event.Type)
+		assert.Contains(t, event.Description, "Write final report")
+	})
+
+	t.Run("should report every change to running timers and tasks", func(t *testing.T) {
+		tasks, events, _ := setup(t)
+		received, err := events.Subscribe(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, EventCurrent, next(t, received).Type)
+
+		// A forgotten timer stopped at a time the user typed
+		start := time.Now().Add(-16 * time.Hour)
+		session, err := tasks.StartNewTaskWithOptions(ctx, "Email", StartOptions{At: &start})
+		require.NoError(t, err)
+		assert.Equal(t, EventStarted, next(t, received).Type)
+		end := start.Add(time.Hour)
+		_, err = tasks.ResolveForgottenTimer(ctx, session.TimeEntry.ID, ForgottenTimerResolution{Action: ForgottenTimerStop, At: &end})
+		require.NoError(t, err)
+		event := next(t, received)
+		assert.Equal(t, EventStopped, event.Type)
+		assert.Nil(t, event.Current)
+
+		// A task merged into another, which deletes it
+		mail, err := tasks.CreateTask(ctx, "Mail")
+		require.NoError(t, err)
+		_, err = tasks.MergeTasks(ctx, mail.ID, session.Task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, EventDeleted, next(t, received).Type)
+
+		// Extra running timers stopped by the consistency check
+		for _, minutes := range []int{30, 10} {
+			entry := &sqlite.TimeEntry{TaskID: session.Task.ID, StartTime: time.Now().Add(-time.Duration(minutes) * time.Minute)}
+			require.NoError(t, events.repo.CreateTimeEntry(ctx, entry))
+		}
+		_, err = NewDoctorService(events.repo).CheckConsistency(ctx, DoctorOptions{Fix: []string{FixStopExtras}})
+		require.NoError(t, err)
+		event = next(t, received)
+		assert.Equal(t, EventStopped, event.Type)
+		require.NotNil(t, event.Current, "The latest entry keeps running")
+
+		// Undoing the consistency check, which starts both timers again
+		undone, err := NewJournalService(events.repo).UndoOperations(ctx, 1)
+		require.NoError(t, err)
+		event = next(t, received)
+		assert.Equal(t, EventUndone, event.Type)
+		assert.Equal(t, undone[0].ID, event.ID)
+		assert.Equal(t, "Undone: "+undone[0].Description, event.Description)
+
+		// Undoing is reported once
+		_, err = tasks.UpdateTask(ctx, session.Task.ID, "Email and chat")
+		require.NoError(t, err)
+		assert.Equal(t, EventTaskRenamed, next(t, received).Type)
+	})
+
+	t.Run("should skip operations that are not reported", func(t *testing.T) {
+		tasks, events, _ := setup(t)
+		received, err := events.Subscribe(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, EventCurrent, next(t, received).Type)
+
+		_, err = tasks.CreateTask(ctx, "Write report")
+		require.NoError(t, err)
+		_, err = tasks.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		assert.Equal(t, EventStarted, next(t, received).Type)
+	})
+
+	t.Run("should see changes made by other processes", func(t *testing.T) {
+		_, events, path := setup(t)
+		received, err := events.Subscribe(ctx)
+		require.NoError(t, err)
+		next(t, received)
+
+		repo, err := sqlite.New(path)
+		require.NoError(t, err)
+		defer repo.Close()
+		other := NewTaskService(repo, NewTimeService(repo))
+		_, err = other.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		assert.Equal(t, EventStarted, next(t, received).Type)
+	})
+
+	t.Run("should close the channel and stop polling when the context ends", func(t *testing.T) {
+		_, events, _ := setup(t)
+		subscribeCtx, cancel := context.WithCancel(ctx)
+		received, err := events.Subscribe(subscribeCtx)
+		require.NoError(t, err)
+		next(t, received)
+
+		cancel()
+		select {
+		case _, open := <-received:
+			assert.False(t, open)
+		case <-time.After(5 * time.Second):
+			t.Fatal("channel not closed")
+		}
+
+		events.mu.Lock()
+		defer events.mu.Unlock()
+		assert.Nil(t, events.stopPolling)
+		assert.Empty(t, events.subscribers)
+	})
+}
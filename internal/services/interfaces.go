@@ -30,14 +30,19 @@ type TaskActivity struct {
 
 // TaskSummary represents comprehensive analysis of a specific task
 type TaskSummary struct {
-	Task         *domain.Task        `json:"task"`
-	TimeEntries  []*domain.TimeEntry `json:"time_entries"`
-	TotalTime    string              `json:"total_time"`
-	SessionCount int                 `json:"session_count"`
-	RunningCount int                 `json:"running_count"`
-	FirstEntry   time.Time           `json:"first_entry"`
-	LastEntry    time.Time           `json:"last_entry"`
-	IsRunning    bool                `json:"is_running"`
+	Task           *domain.Task        `json:"task"`
+	TimeEntries    []*domain.TimeEntry `json:"time_entries"`
+	TotalTime      string              `json:"total_time"`
+	SessionCount   int                 `json:"session_count"` // Segments linked by pauses count as one session
+	RunningCount   int                 `json:"running_count"`
+	PauseCount     int                 `json:"pause_count"`
+	PausedDuration time.Duration       `json:"-"`
+	PausedTime     string              `json:"paused_time"` // Human-readable time spent between linked segments
+	FirstEntry     time.Time           `json:"first_entry"`
+	LastEntry      time.Time           `json:"last_entry"`
+	IsRunning      bool                `json:"is_running"`
+	IsPaused       bool                `json:"is_paused"`
+	Tags           []string            `json:"tags,omitempty"` // Every tag used on the task's entries
 }
 
 // DashboardData represents all data needed for a dashboard view
@@ -59,15 +64,294 @@ type DayStatistics struct {
 type TimeEntryWithTask struct {
 	TimeEntry *domain.TimeEntry `json:"time_entry"`
 	Task      *domain.Task      `json:"task"`
+	Project   string            `json:"project,omitempty"` // Path of the task's project, e.g. "acme/website"
 	Duration  string            `json:"duration"`
 }
 
+// ProjectInfo represents a project together with its full path
+type ProjectInfo struct {
+	Project  *domain.Project `json:"project"`
+	Path     string          `json:"path"`
+	Archived bool            `json:"archived"`
+}
+
+// ProjectTotal represents the time spent on a project, including its sub-projects
+type ProjectTotal struct {
+	Project      string        `json:"project"` // Project path, empty for entries without a project
+	Depth        int           `json:"depth"`   // Number of ancestors, for indenting the rollup
+	SessionCount int           `json:"session_count"`
+	Duration     time.Duration `json:"-"`
+	TotalSeconds int64         `json:"total_seconds"`
+	Total        string        `json:"total"`
+}
+
+// Report periods accepted by GetReport
+const (
+	ReportPeriodDay   = "day"
+	ReportPeriodWeek  = "week"
+	ReportPeriodMonth = "month"
+)
+
+// Report groupings accepted by GetReport
+const (
+	ReportGroupByTask    = "task"
+	ReportGroupByProject = "project"
+	ReportGroupByTag     = "tag"
+	ReportGroupByDay     = "day"
+)
+
+// ReportOptions selects the period of a report and how its time is grouped
+type ReportOptions struct {
+	Period  string    `json:"period"`   // day, week or month
+	Date    time.Time `json:"date"`     // Any time within the period; the zero time means now
+	GroupBy string    `json:"group_by"` // task (default), project, tag or day
+}
+
+// ReportRow holds the time of one group in a report, per day of the period
+type ReportRow struct {
+	Key          string          `json:"key"` // Task name, project path, tag or ISO date; empty for time without a project or tag
+	Days         []time.Duration `json:"-"`   // Time per day, in the order of Report.Days
+	DaySeconds   []int64         `json:"day_seconds"`
+	Duration     time.Duration   `json:"-"`
+	TotalSeconds int64           `json:"total_seconds"`
+	Total        string          `json:"total"`
+	Share        float64         `json:"share"`   // Percentage of the report's total
+	Running      bool            `json:"running"` // Includes time of the running entry
+}
+
+// Report totals the time tracked per group and per day over a calendar day, week or month.
+// Entries spanning midnight are split across days and the running entry counts up to now.
+type Report struct {
+	Period          string          `json:"period"`
+	GroupBy         string          `json:"group_by"`
+	Start           time.Time       `json:"start"`
+	End             time.Time       `json:"end"`
+	Days            []time.Time     `json:"days"` // Midnight of every day of the period
+	Rows            []*ReportRow    `json:"rows"` // Longest total first, or in date order when grouped by day
+	DayTotals       []time.Duration `json:"-"`
+	DayTotalSeconds []int64         `json:"day_total_seconds"`
+	Duration        time.Duration   `json:"-"` // Entries with several tags count once here but towards each of their tags
+	TotalSeconds    int64           `json:"total_seconds"`
+	Total           string          `json:"total"`
+	RunningTask     string          `json:"running_task,omitempty"` // Task of the running entry, if it falls in the period
+	RunningDuration time.Duration   `json:"-"`
+	RunningSeconds  int64           `json:"running_seconds,omitempty"` // Partial time of the running entry within the period
+}
+
+// TimelineSegment is the part of a time entry falling within the day of a timeline
+type TimelineSegment struct {
+	EntryID  int64     `json:"entry_id"`
+	Start    time.Time `json:"start"` // Clipped to the day
+	End      time.Time `json:"end"`   // Clipped to the day; now for the running entry
+	Running  bool      `json:"running"`
+	Overlaps bool      `json:"overlaps"` // Overlaps another entry of the day
+}
+
+// TimelineRow holds a task's segments on the day of a timeline, in time order
+type TimelineRow struct {
+	Task         *domain.Task       `json:"task"`
+	Segments     []*TimelineSegment `json:"segments"`
+	Duration     time.Duration      `json:"-"`
+	TotalSeconds int64              `json:"total_seconds"`
+	Total        string             `json:"total"`
+}
+
+// Timeline lays out the sessions of one day per task, to show gaps and context switches
+type Timeline struct {
+	Start        time.Time      `json:"start"` // Midnight starting the day
+	End          time.Time      `json:"end"`   // Midnight ending the day
+	Rows         []*TimelineRow `json:"rows"`  // In order of each task's first segment
+	SessionCount int            `json:"session_count"`
+	Switches     int            `json:"switches"` // Times work moved from one task to another
+	Duration     time.Duration  `json:"-"`
+	TotalSeconds int64          `json:"total_seconds"`
+	Total        string         `json:"total"`
+}
+
+// StartOptions holds optional settings for starting a task
+type StartOptions struct {
+	Project string     `json:"project,omitempty"` // Path of an existing project to file the task under
+	Tags    []string   `json:"tags,omitempty"`    // Tags for the new time entry, with or without a + or # prefix
+	Note    string     `json:"note,omitempty"`    // Note for the new time entry
+	At      *time.Time `json:"at,omitempty"`      // When work started, if not now; not before the previous entry's end
+}
+
+// ForgottenTimer describes a running entry that has gone on so long it was probably left running by mistake
+type ForgottenTimer struct {
+	Task           *domain.Task      `json:"task"`
+	TimeEntry      *domain.TimeEntry `json:"time_entry"`
+	Running        time.Duration     `json:"-"` // Time since the entry started
+	RunningSeconds int64             `json:"running_seconds"`
+	LastActivity   *time.Time        `json:"last_activity,omitempty"` // Last time someone was seen at work while the entry ran, nil when never
+}
+
+// Ways of resolving a forgotten timer
+const (
+	ForgottenTimerStop = "stop" // Stop the entry at a time the user confirmed, usually its last activity
+	ForgottenTimerCap  = "cap"  // Stop the entry once it reached the maximum entry duration
+	ForgottenTimerKeep = "keep" // Keep the entry running; it counts as forgotten again once the threshold passes anew
+)
+
+// ForgottenTimerResolution describes what to do with a forgotten timer
+type ForgottenTimerResolution struct {
+	Action string     `json:"action"`       // One of ForgottenTimerStop, ForgottenTimerCap or ForgottenTimerKeep
+	At     *time.Time `json:"at,omitempty"` // When to stop the entry; required to stop or cap
+}
+
+// Kinds of problems found by the consistency check
+const (
+	ProblemOverlap         = "overlap"          // Two entries cover the same time
+	ProblemMultipleRunning = "multiple_running" // More than one entry is running
+	ProblemEndBeforeStart  = "end_before_start" // An entry ends before it starts
+	ProblemTooLong         = "too_long"         // An entry lasts longer than the maximum duration
+	ProblemOrphanedEntry   = "orphaned_entry"   // An entry belongs to a task that does not exist
+	ProblemDuplicateTask   = "duplicate_task"   // Several tasks share a name, ignoring case and surrounding spaces
+)
+
+// Strategies the consistency check can apply to fix problems
+const (
+	FixTrimOverlaps    = "trim-overlaps"    // End the earlier of two overlapping entries when the later one starts
+	FixStopExtras      = "stop-extras"      // Stop every running entry but the latest when the latest started
+	FixMergeDuplicates = "merge-duplicates" // Merge tasks sharing a name into the oldest of them
+)
+
+// DoctorOptions holds the settings of a consistency check
+type DoctorOptions struct {
+	MaxDuration time.Duration `json:"max_duration"`  // Longest an entry may last; 0 skips the check
+	Fix         []string      `json:"fix,omitempty"` // Strategies to apply, in a single transaction
+}
+
+// DoctorProblem describes one inconsistency in the time database
+type DoctorProblem struct {
+	Kind        string  `json:"kind"` // One of the Problem constants
+	Description string  `json:"description"`
+	EntryIDs    []int64 `json:"entry_ids,omitempty"`
+	TaskIDs     []int64 `json:"task_ids,omitempty"`
+	Fix         string  `json:"fix,omitempty"` // Strategy that fixes the problem, empty when it needs fixing by hand
+}
+
+// DoctorReport lists the problems found by a consistency check, and those it fixed
+type DoctorReport struct {
+	CheckedTasks   int              `json:"checked_tasks"`
+	CheckedEntries int              `json:"checked_entries"`
+	Problems       []*DoctorProblem `json:"problems"`        // Problems left after any fixes
+	Fixed          []*DoctorProblem `json:"fixed,omitempty"` // Problems the fixes resolved, as found before fixing
+}
+
+// BackupInfo describes a backup of the time database
+type BackupInfo struct {
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+	Automatic bool      `json:"automatic"` // Daily backup subject to the retention policy
+}
+
+// BackupRetention says which automatic backups to keep; the others are deleted
+type BackupRetention struct {
+	KeepDaily  int `json:"keep_daily"`  // Keep the backups of the last KeepDaily days that have one
+	KeepWeekly int `json:"keep_weekly"` // Also keep the newest backup of each of the last KeepWeekly weeks that have one
+}
+
+// AutoBackup is the outcome of the automatic daily backup
+type AutoBackup struct {
+	Created *BackupInfo   `json:"created,omitempty"` // nil when today's backup already existed
+	Pruned  []*BackupInfo `json:"pruned"`            // Automatic backups deleted under the retention policy
+}
+
+// BackupRestore is the outcome of restoring a backup
+type BackupRestore struct {
+	Restored     *BackupInfo `json:"restored"`
+	SafetyBackup *BackupInfo `json:"safety_backup"` // Backup of the database as it was before the restore
+}
+
+// TimeEntryFilter describes a time entry search as entered by the user
+type TimeEntryFilter struct {
+	TimeRange       string   `json:"time_range,omitempty"`       // Time range expression such as "2w" or "last-month"
+	Text            string   `json:"text,omitempty"`             // Text the task name or the entry's note must contain
+	Project         string   `json:"project,omitempty"`          // Project path; entries of sub-projects are included
+	Tags            []string `json:"tags,omitempty"`             // Tags every entry must carry
+	ExcludeTags     []string `json:"exclude_tags,omitempty"`     // Tags no entry may carry
+	IncludeArchived bool     `json:"include_archived,omitempty"` // Also find archived tasks
+}
+
+// TimeEntryUpdate describes changes to an existing time entry; nil fields are left unchanged
+type TimeEntryUpdate struct {
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	TaskName  *string    `json:"task_name,omitempty"`
+	Note      *string    `json:"note,omitempty"` // An empty note removes the existing one
+}
+
+// TimeEntryEdit represents a time entry before and after an edit
+type TimeEntryEdit struct {
+	Before *TimeEntryWithTask `json:"before"`
+	After  *TimeEntryWithTask `json:"after"`
+}
+
+// TaskMerge represents the result of folding one task into another
+type TaskMerge struct {
+	From         *domain.Task `json:"from"`
+	Into         *domain.Task `json:"into"`
+	MovedEntries int          `json:"moved_entries"`
+}
+
+// ImportEntry represents a time entry read from an import file
+type ImportEntry struct {
+	TaskName  string     `json:"task_name"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	Project   string     `json:"project,omitempty"` // Path of the task's project, created when missing
+	Tags      []string   `json:"tags,omitempty"`
+}
+
+// Operation represents a change recorded in the operations journal
+type Operation struct {
+	ID          int64      `json:"id"`
+	Kind        string     `json:"kind"` // What was done, such as "start" or "delete"
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UndoneAt    *time.Time `json:"undone_at,omitempty"`
+	Changes     int        `json:"changes"` // Number of rows the operation changed
+}
+
+// Event reports a change to the tracking state, such as a task being started or stopped
+type Event struct {
+	ID          int64        `json:"id,omitempty"` // ID of the journaled operation behind the event, 0 for the current event
+	Type        string       `json:"type"`         // One of the Event constants, such as "started"
+	Description string       `json:"description,omitempty"`
+	Time        time.Time    `json:"time"`
+	Current     *TaskSession `json:"current"` // The running session when the event was delivered, nil when nothing runs
+}
+
+// ImportResult summarises what an import added and skipped
+type ImportResult struct {
+	Imported        int          `json:"imported"`
+	Duplicates      int          `json:"duplicates"`
+	Skipped         []ImportSkip `json:"skipped"` // Entries left out for a reason other than being duplicates
+	CreatedTasks    []string     `json:"created_tasks"`
+	CreatedProjects []string     `json:"created_projects"`
+	DryRun          bool         `json:"dry_run"`
+}
+
+// ImportSkip describes an imported entry that was left out
+type ImportSkip struct {
+	Entry     int       `json:"entry"` // Position of the entry in the import, from 1
+	TaskName  string    `json:"task_name"`
+	StartTime time.Time `json:"start_time"`
+	Reason    string    `json:"reason"`
+}
+
 // SearchCriteria represents criteria for searching tasks and time entries
 type SearchCriteria struct {
-	TimeRange   *TimeRange `json:"time_range,omitempty"`
-	TextFilter  string     `json:"text_filter,omitempty"`
-	TaskID      *int64     `json:"task_id,omitempty"`
-	RunningOnly bool       `json:"running_only,omitempty"`
+	TimeRange       *TimeRange `json:"time_range,omitempty"`
+	TextFilter      string     `json:"text_filter,omitempty"` // Matches task names and entry notes
+	TaskID          *int64     `json:"task_id,omitempty"`
+	ProjectIDs      []int64    `json:"project_ids,omitempty"`
+	Tags            []string   `json:"tags,omitempty"`         // Only entries carrying every one of these tags
+	ExcludeTags     []string   `json:"exclude_tags,omitempty"` // Only entries carrying none of these tags
+	RunningOnly     bool       `json:"running_only,omitempty"`
+	IncludeArchived bool       `json:"include_archived,omitempty"` // Archived tasks are left out of SearchTasks unless set
 }
 
 // SortOrder defines how task results should be sorted
@@ -104,7 +388,13 @@ type TimeService interface {
 	// Running task management
 	GetRunningEntries(ctx context.Context) ([]*domain.TimeEntry, error)
 	StopRunningEntries(ctx context.Context) ([]*domain.TimeEntry, error)
+	StopRunningEntriesAt(ctx context.Context, at time.Time) ([]*domain.TimeEntry, error)
 	CreateTimeEntry(ctx context.Context, taskID int64) (*domain.TimeEntry, error)
+	CreateTimeEntryAt(ctx context.Context, taskID int64, start time.Time) (*domain.TimeEntry, error)
+	CreateCompletedTimeEntry(ctx context.Context, taskID int64, start time.Time, end time.Time) (*domain.TimeEntry, error)
+	GetTimeEntry(ctx context.Context, id int64) (*domain.TimeEntry, error)
+	UpdateTimeEntry(ctx context.Context, entry *domain.TimeEntry) (*domain.TimeEntry, error)
+	FindOverlappingEntries(ctx context.Context, start time.Time, end *time.Time, excludeID int64) ([]*domain.TimeEntry, error)
 	
 	// Time range operations
 	IsToday(t time.Time) bool
@@ -117,17 +407,47 @@ type TaskService interface {
 	// Task CRUD operations
 	CreateTask(ctx context.Context, name string) (*domain.Task, error)
 	GetTask(ctx context.Context, id int64) (*domain.Task, error)
+	GetTaskByName(ctx context.Context, name string) (*domain.Task, error)
 	UpdateTask(ctx context.Context, id int64, name string) (*domain.Task, error)
 	DeleteTaskWithEntries(ctx context.Context, id int64) error
+	FindOrCreateTask(ctx context.Context, name string) (*domain.Task, error)
+	MergeTasks(ctx context.Context, fromID int64, intoID int64) (*TaskMerge, error)
+	ImportTimeEntries(ctx context.Context, entries []ImportEntry, dryRun bool) (*ImportResult, error)
+	
+	// Archiving operations
+	ArchiveTask(ctx context.Context, id int64) (*domain.Task, error)
+	UnarchiveTask(ctx context.Context, id int64) (*domain.Task, error)
+	ArchiveInactiveTasks(ctx context.Context, before time.Time) ([]*domain.Task, error)
 	
 	// Task workflow operations
 	StartNewTask(ctx context.Context, name string) (*TaskSession, error)
+	StartNewTaskWithOptions(ctx context.Context, name string, opts StartOptions) (*TaskSession, error)
 	ResumeTask(ctx context.Context, id int64) (*TaskSession, error)
+	ResumeTaskAt(ctx context.Context, id int64, at time.Time) (*TaskSession, error)
+	AddTimeEntry(ctx context.Context, name string, start time.Time, end time.Time) (*TaskSession, error)
+	EditTimeEntry(ctx context.Context, entryID int64, update TimeEntryUpdate) (*TimeEntryEdit, error)
 	GetCurrentSession(ctx context.Context) (*TaskSession, error)
+	PauseTask(ctx context.Context) (*TaskSession, error)
+	ContinueTask(ctx context.Context) (*TaskSession, error)
 	
 	// Task session management
 	CreateTaskSession(task *domain.Task, entry *domain.TimeEntry) *TaskSession
 	StopAllRunningTasks(ctx context.Context) ([]*domain.TimeEntry, error)
+	StopAllRunningTasksAt(ctx context.Context, at time.Time) ([]*domain.TimeEntry, error)
+	FindForgottenTimer(ctx context.Context, after time.Duration) (*ForgottenTimer, error)
+	ResolveForgottenTimer(ctx context.Context, entryID int64, resolution ForgottenTimerResolution) (*TimeEntryWithTask, error)
+}
+
+// ProjectService handles the client and project hierarchy above tasks
+type ProjectService interface {
+	// Project CRUD operations
+	CreateProject(ctx context.Context, path string) (*ProjectInfo, error)
+	FindProject(ctx context.Context, path string) (*ProjectInfo, error)
+	ListProjects(ctx context.Context, includeArchived bool) ([]*ProjectInfo, error)
+	ArchiveProject(ctx context.Context, path string) (*ProjectInfo, error)
+	
+	// Hierarchy operations
+	ResolveProjectIDs(ctx context.Context, path string) ([]int64, error)
 }
 
 // SearchService handles search and discovery operations
@@ -160,14 +480,46 @@ type ReportingService interface {
 	
 	// Aggregation operations
 	AggregateTaskData(entries []*domain.TimeEntry) map[int64]*TaskActivity
+	RollupByProject(entries []*TimeEntryWithTask) []*ProjectTotal
+	GetReport(ctx context.Context, opts ReportOptions) (*Report, error)
+	BuildReport(entries []*TimeEntryWithTask, opts ReportOptions, now time.Time) (*Report, error)
+	GetTimeline(ctx context.Context, date time.Time) (*Timeline, error)
+	BuildTimeline(entries []*TimeEntryWithTask, date time.Time, now time.Time) *Timeline
 	CalculateTotalDuration(entries []*domain.TimeEntry) time.Duration
 	FormatStatistics(stats *ActivityAnalysis) *DayStatistics
 }
 
+// JournalService handles the operations journal behind undo and history
+type JournalService interface {
+	ListOperations(ctx context.Context, limit int) ([]*Operation, error)
+	UndoOperations(ctx context.Context, count int) ([]*Operation, error)
+}
+
+// DoctorService checks the time database for inconsistencies and fixes them
+type DoctorService interface {
+	CheckConsistency(ctx context.Context, opts DoctorOptions) (*DoctorReport, error)
+}
+
+// BackupService copies the time database to backup files and restores it from them
+type BackupService interface {
+	CreateBackup(ctx context.Context, dir string, path string) (*BackupInfo, error)
+	AutoBackup(ctx context.Context, dir string, retention BackupRetention) (*AutoBackup, error)
+	ListBackups(ctx context.Context, dir string) ([]*BackupInfo, error)
+	RestoreBackup(ctx context.Context, dir string, path string) (*BackupRestore, error)
+}
+
+// EventService broadcasts changes to the tracking state to subscribers
+type EventService interface {
+	Subscribe(ctx context.Context) (<-chan *Event, error)
+}
+
 // ServiceContainer manages all services and their dependencies
 type ServiceContainer struct {
 	TimeService      TimeService
 	TaskService      TaskService
+	ProjectService   ProjectService
 	SearchService    SearchService
 	ReportingService ReportingService
+	JournalService   JournalService
+	EventService     EventService
 }
\ No newline at end of file
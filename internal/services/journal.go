@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+)
+
+// maxJournaledOperations is the number of operations kept in the journal; older ones can no longer be undone
+const maxJournaledOperations = 500
+
+// Kinds of journaled operations, named after the commands that perform them
+const (
+	OperationStart     = "start"
+	OperationResume    = "resume"
+	OperationAdd       = "add"
+	OperationEdit      = "edit"
+	OperationStop      = "stop"
+	OperationPause     = "pause"
+	OperationContinue  = "continue"
+	OperationCreate    = "create"
+	OperationRename    = "rename"
+	OperationMerge     = "merge"
+	OperationDelete    = "delete"
+	OperationImport    = "import"
+	OperationProject   = "project"
+	OperationArchive   = "archive"
+	OperationUnarchive = "unarchive"
+	OperationForgotten = "forgotten" // A forgotten running entry was stopped, capped or kept running
+	OperationDoctor    = "doctor"    // Problems found by the consistency check were fixed
+)
+
+// recordOperation runs fn in a transaction with a repository that records every change made through it,
+// then stores those changes in the journal as a single operation of the given kind, described by the text
+// fn returns. Operations that change nothing are not journaled. When repo is already recording,
+// fn joins the operation being recorded.
+func recordOperation(ctx context.Context, repo sqlite.Repository, kind string, fn func(repo sqlite.Repository) (string, error)) error {
+	if _, recording := repo.(*changeRecorder); recording {
+		_, err := fn(repo)
+		return err
+	}
+
+	return repo.WithTransaction(ctx, func(txRepo sqlite.Repository) error {
+		recorder := &changeRecorder{Repository: txRepo}
+		description, err := fn(recorder)
+		if err != nil {
+			return err
+		}
+		if len(recorder.changes) == 0 {
+			return nil
+		}
+
+		operation := &sqlite.Operation{Kind: kind, Description: description, CreatedAt: time.Now()}
+		if err := txRepo.CreateOperation(ctx, operation, recorder.changes); err != nil {
+			return err
+		}
+		return txRepo.PruneOperations(ctx, maxJournaledOperations)
+	})
+}
+
+// changeRecorder is a repository that remembers the contents of every row before changing it,
+// so the changes can be reverted later
+type changeRecorder struct {
+	sqlite.Repository
+	changes []*sqlite.OperationChange
+}
+
+// record remembers a changed row; a nil before means the row was created
+func (r *changeRecorder) record(table string, rowID int64, before interface{}) error {
+	change := &sqlite.OperationChange{TableName: table, RowID: rowID}
+	if before != nil {
+		data, err := json.Marshal(before)
+		if err != nil {
+			return fmt.Errorf("failed to record %s %d: %w", table, rowID, err)
+		}
+		beforeJSON := string(data)
+		change.BeforeJSON = &beforeJSON
+	}
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+// recordTimeEntry remembers a time entry before it changes
+func (r *changeRecorder) recordTimeEntry(ctx context.Context, id int64) error {
+	entry, err := r.Repository.GetTimeEntry(ctx, id)
+	if err != nil {
+		return err
+	}
+	return r.record(sqlite.TableTimeEntries, id, entry)
+}
+
+// recordTags remembers the tags of a time entry before they change
+func (r *changeRecorder) recordTags(ctx context.Context, entryID int64) error {
+	links, err := r.Repository.ListTimeEntryTags(ctx, []int64{entryID})
+	if err != nil {
+		return err
+	}
+	tags := make([]string, 0, len(links))
+	for _, link := range links {
+		tags = append(tags, link.Name)
+	}
+	return r.record(sqlite.TableTimeEntryTags, entryID, tags)
+}
+
+// WithTransaction keeps recording inside nested transactions, which join the one being recorded
+func (r *changeRecorder) WithTransaction(ctx context.Context, fn func(repo sqlite.Repository) error) error {
+	return r.Repository.WithTransaction(ctx, func(sqlite.Repository) error {
+		return fn(r)
+	})
+}
+
+func (r *changeRecorder) CreateTimeEntry(ctx context.Context, entry *sqlite.TimeEntry) error {
+	if err := r.Repository.CreateTimeEntry(ctx, entry); err != nil {
+		return err
+	}
+	return r.record(sqlite.TableTimeEntries, entry.ID, nil)
+}
+
+func (r *changeRecorder) CreateTask(ctx context.Context, task *sqlite.Task) error {
+	if err := r.Repository.CreateTask(ctx, task); err != nil {
+		return err
+	}
+	return r.record(sqlite.TableTasks, task.ID, nil)
+}
+
+func (r *changeRecorder) CreateProject(ctx context.Context, project *sqlite.Project) error {
+	if err := r.Repository.CreateProject(ctx, project); err != nil {
+		return err
+	}
+	return r.record(sqlite.TableProjects, project.ID, nil)
+}
+
+func (r *changeRecorder) UpdateTimeEntry(ctx context.Context, entry *sqlite.TimeEntry) error {
+	if err := r.recordTimeEntry(ctx, entry.ID); err != nil {
+		return err
+	}
+	return r.Repository.UpdateTimeEntry(ctx, entry)
+}
+
+func (r *changeRecorder) UpdateTask(ctx context.Context, task *sqlite.Task) error {
+	before, err := r.Repository.GetTask(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.record(sqlite.TableTasks, task.ID, before); err != nil {
+		return err
+	}
+	return r.Repository.UpdateTask(ctx, task)
+}
+
+func (r *changeRecorder) UpdateProject(ctx context.Context, project *sqlite.Project) error {
+	before, err := r.Repository.GetProject(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.record(sqlite.TableProjects, project.ID, before); err != nil {
+		return err
+	}
+	return r.Repository.UpdateProject(ctx, project)
+}
+
+func (r *changeRecorder) SetTimeEntryTags(ctx context.Context, entryID int64, tags []string) error {
+	if err := r.recordTags(ctx, entryID); err != nil {
+		return err
+	}
+	return r.Repository.SetTimeEntryTags(ctx, entryID, tags)
+}
+
+// MergeTasks records the entries moved off the merged task and the task itself, which is deleted
+func (r *changeRecorder) MergeTasks(ctx context.Context, fromID int64, intoID int64) (int64, error) {
+	entries, err := r.Repository.SearchTimeEntries(ctx, sqlite.SearchOptions{TaskID: &fromID})
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		if err := r.record(sqlite.TableTimeEntries, entry.ID, entry); err != nil {
+			return 0, err
+		}
+	}
+	task, err := r.Repository.GetTask(ctx, fromID)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.record(sqlite.TableTasks, fromID, task); err != nil {
+		return 0, err
+	}
+	return r.Repository.MergeTasks(ctx, fromID, intoID)
+}
+
+// DeleteTimeEntry records the entry, its tags and the segments continuing it, which are unlinked
+func (r *changeRecorder) DeleteTimeEntry(ctx context.Context, id int64) error {
+	if err := r.recordTags(ctx, id); err != nil {
+		return err
+	}
+	entries, err := r.Repository.ListTimeEntries(ctx)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.ContinuesID != nil && *entry.ContinuesID == id {
+			if err := r.record(sqlite.TableTimeEntries, entry.ID, entry); err != nil {
+				return err
+			}
+		}
+	}
+	if err := r.recordTimeEntry(ctx, id); err != nil {
+		return err
+	}
+	return r.Repository.DeleteTimeEntry(ctx, id)
+}
+
+func (r *changeRecorder) DeleteTask(ctx context.Context, id int64) error {
+	before, err := r.Repository.GetTask(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.record(sqlite.TableTasks, id, before); err != nil {
+		return err
+	}
+	return r.Repository.DeleteTask(ctx, id)
+}
+
+func (r *changeRecorder) DeleteProject(ctx context.Context, id int64) error {
+	before, err := r.Repository.GetProject(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.record(sqlite.TableProjects, id, before); err != nil {
+		return err
+	}
+	return r.Repository.DeleteProject(ctx, id)
+}
+
+// journalServiceImpl implements the JournalService interface
+type journalServiceImpl struct {
+	repo sqlite.Repository
+}
+
+// NewJournalService creates a new JournalService instance
+func NewJournalService(repo sqlite.Repository) JournalService {
+	return &journalServiceImpl{repo: repo}
+}
+
+// ListOperations returns up to limit journaled operations, newest first, including undone ones
+func (j *journalServiceImpl) ListOperations(ctx context.Context, limit int) ([]*Operation, error) {
+	if limit <= 0 {
+		return nil, errors.NewInvalidInputError("limit", fmt.Sprintf("%d", limit), "must be a positive number")
+	}
+
+	dbOperations, err := j.repo.ListOperations(ctx, limit, true)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]*Operation, len(dbOperations))
+	for i, dbOperation := range dbOperations {
+		operations[i] = newOperation(dbOperation)
+	}
+	return operations, nil
+}
+
+// UndoOperations reverts the last count operations that have not been undone yet, newest first, in a single
+// transaction. Deleted rows come back with their original IDs. It returns the operations that were undone.
+func (j *journalServiceImpl) UndoOperations(ctx context.Context, count int) ([]*Operation, error) {
+	if count <= 0 {
+		return nil, errors.NewInvalidInputError("count", fmt.Sprintf("%d", count), "must be a positive number")
+	}
+
+	var undone []*Operation
+	err := j.repo.WithTransaction(ctx, func(repo sqlite.Repository) error {
+		dbOperations, err := repo.ListOperations(ctx, count, false)
+		if err != nil {
+			return err
+		}
+		if len(dbOperations) == 0 {
+			return errors.NewValidationError("nothing to undo", nil)
+		}
+
+		now := time.Now()
+		for _, dbOperation := range dbOperations {
+			if err := revertOperation(ctx, repo, dbOperation.ID); err != nil {
+				return fmt.Errorf("failed to undo %q: %w", dbOperation.Description, err)
+			}
+			if err := repo.MarkOperationUndone(ctx, dbOperation.ID, now); err != nil {
+				return err
+			}
+			dbOperation.UndoneAt = &now
+			undone = append(undone, newOperation(dbOperation))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return undone, nil
+}
+
+// revertOperation puts back every row changed by an operation, undoing the changes from last to first
+func revertOperation(ctx context.Context, repo sqlite.Repository, operationID int64) error {
+	changes, err := repo.ListOperationChanges(ctx, operationID)
+	if err != nil {
+		return err
+	}
+
+	for i := len(changes) - 1; i >= 0; i-- {
+		if err := revertChange(ctx, repo, changes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revertChange deletes a row the operation created, or restores the row as it was before
+func revertChange(ctx context.Context, repo sqlite.Repository, change *sqlite.OperationChange) error {
+	if change.BeforeJSON == nil {
+		switch change.TableName {
+		case sqlite.TableTimeEntries:
+			return ignoreNotFound(repo.DeleteTimeEntry(ctx, change.RowID))
+		case sqlite.TableTasks:
+			return ignoreNotFound(repo.DeleteTask(ctx, change.RowID))
+		case sqlite.TableProjects:
+			return ignoreNotFound(repo.DeleteProject(ctx, change.RowID))
+		}
+		return fmt.Errorf("cannot revert the creation of a row in %s", change.TableName)
+	}
+
+	data := []byte(*change.BeforeJSON)
+	switch change.TableName {
+	case sqlite.TableTimeEntries:
+		var entry sqlite.TimeEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("failed to read journaled time entry %d: %w", change.RowID, err)
+		}
+		return repo.RestoreTimeEntry(ctx, &entry)
+	case sqlite.TableTasks:
+		var task sqlite.Task
+		if err := json.Unmarshal(data, &task); err != nil {
+			return fmt.Errorf("failed to read journaled task %d: %w", change.RowID, err)
+		}
+		return repo.RestoreTask(ctx, &task)
+	case sqlite.TableProjects:
+		var project sqlite.Project
+		if err := json.Unmarshal(data, &project); err != nil {
+			return fmt.Errorf("failed to read journaled project %d: %w", change.RowID, err)
+		}
+		return repo.RestoreProject(ctx, &project)
+	case sqlite.TableTimeEntryTags:
+		var tags []string
+		if err := json.Unmarshal(data, &tags); err != nil {
+			return fmt.Errorf("failed to read journaled tags of time entry %d: %w", change.RowID, err)
+		}
+		return repo.SetTimeEntryTags(ctx, change.RowID, tags)
+	}
+	return fmt.Errorf("cannot revert a change to %s", change.TableName)
+}
+
+// ignoreNotFound treats rows that are already gone as deleted
+func ignoreNotFound(err error) error {
+	if errors.IsErrorType(err, errors.ErrorTypeNotFound) {
+		return nil
+	}
+	return err
+}
+
+// newOperation converts a journaled operation to its service representation
+func newOperation(dbOperation *sqlite.Operation) *Operation {
+	return &Operation{
+		ID:          dbOperation.ID,
+		Kind:        dbOperation.Kind,
+		Description: dbOperation.Description,
+		CreatedAt:   dbOperation.CreatedAt,
+		UndoneAt:    dbOperation.UndoneAt,
+		Changes:     dbOperation.ChangeCount,
+	}
+}
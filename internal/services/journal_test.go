@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalService_UndoOperations(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (TaskService, JournalService, sqlite.Repository) {
+		repo, err := sqlite.New(":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { repo.Close() })
+		return NewTaskService(repo, NewTimeService(repo)), NewJournalService(repo), repo
+	}
+
+	t.Run("should restore a deleted task and its entries with their IDs and tags", func(t *testing.T) {
+		tasks, journal, repo := setup(t)
+
+		first, err := tasks.StartNewTaskWithOptions(ctx, "Write report", StartOptions{Tags: []string{"billable"}, Note: "draft"})
+		require.NoError(t, err)
+		second, err := tasks.AddTimeEntry(ctx, "Write report", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, tasks.DeleteTaskWithEntries(ctx, first.Task.ID))
+
+		undone, err := journal.UndoOperations(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, undone, 1)
+		assert.Equal(t, OperationDelete, undone[0].Kind)
+		assert.Equal(t, `Deleted task "Write report" and 2 time entries`, undone[0].Description)
+		assert.NotNil(t, undone[0].UndoneAt)
+
+		task, err := repo.GetTask(ctx, first.Task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Write report", task.TaskName)
+
+		restored, err := repo.GetTimeEntry(ctx, first.TimeEntry.ID)
+		require.NoError(t, err)
+		assert.Equal(t, first.Task.ID, restored.TaskID)
+		assert.Nil(t, restored.EndTime)
+		assert.Equal(t, "draft", restored.Note)
+		_, err = repo.GetTimeEntry(ctx, second.TimeEntry.ID)
+		require.NoError(t, err)
+
+		tags, err := repo.ListTimeEntryTags(ctx, []int64{first.TimeEntry.ID})
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+		assert.Equal(t, "billable", tags[0].Name)
+	})
+
+	t.Run("should undo a stop and then the start", func(t *testing.T) {
+		tasks, journal, repo := setup(t)
+
+		started, err := tasks.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		_, err = tasks.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+
+		_, err = journal.UndoOperations(ctx, 1)
+		require.NoError(t, err)
+		entry, err := repo.GetTimeEntry(ctx, started.TimeEntry.ID)
+		require.NoError(t, err)
+		assert.Nil(t, entry.EndTime)
+
+		_, err = journal.UndoOperations(ctx, 1)
+		require.NoError(t, err)
+		_, err = repo.GetTimeEntry(ctx, started.TimeEntry.ID)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeNotFound))
+		_, err = repo.GetTask(ctx, started.Task.ID)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeNotFound))
+	})
+
+	t.Run("should move entries back after undoing a merge", func(t *testing.T) {
+		tasks, journal, repo := setup(t)
+
+		from, err := tasks.AddTimeEntry(ctx, "Code reviw", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		into, err := tasks.AddTimeEntry(ctx, "Code review", start.Add(2*time.Hour), start.Add(3*time.Hour))
+		require.NoError(t, err)
+		_, err = tasks.MergeTasks(ctx, from.Task.ID, into.Task.ID)
+		require.NoError(t, err)
+
+		_, err = journal.UndoOperations(ctx, 1)
+		require.NoError(t, err)
+
+		task, err := repo.GetTask(ctx, from.Task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Code reviw", task.TaskName)
+		entry, err := repo.GetTimeEntry(ctx, from.TimeEntry.ID)
+		require.NoError(t, err)
+		assert.Equal(t, from.Task.ID, entry.TaskID)
+	})
+
+	t.Run("should undo several operations newest first and skip undone ones", func(t *testing.T) {
+		tasks, journal, repo := setup(t)
+
+		for _, name := range []string{"First", "Second", "Third"} {
+			_, err := tasks.CreateTask(ctx, name)
+			require.NoError(t, err)
+		}
+
+		undone, err := journal.UndoOperations(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, `Created task "Third"`, undone[0].Description)
+
+		undone, err = journal.UndoOperations(ctx, 5)
+		require.NoError(t, err)
+		require.Len(t, undone, 2)
+		assert.Equal(t, `Created task "Second"`, undone[0].Description)
+		assert.Equal(t, `Created task "First"`, undone[1].Description)
+
+		remaining, err := repo.ListTasks(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+
+		_, err = journal.UndoOperations(ctx, 1)
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "nothing to undo")
+	})
+
+	t.Run("should reject a count below one", func(t *testing.T) {
+		_, journal, _ := setup(t)
+
+		_, err := journal.UndoOperations(ctx, 0)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+	})
+}
+
+func TestJournalService_ListOperations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should list operations newest first including undone ones", func(t *testing.T) {
+		repo, err := sqlite.New(":memory:")
+		require.NoError(t, err)
+		defer repo.Close()
+		tasks, journal := NewTaskService(repo, NewTimeService(repo)), NewJournalService(repo)
+
+		started, err := tasks.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		_, err = tasks.UpdateTask(ctx, started.Task.ID, "Write the report")
+		require.NoError(t, err)
+		_, err = journal.UndoOperations(ctx, 1)
+		require.NoError(t, err)
+
+		operations, err := journal.ListOperations(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, operations, 2)
+		assert.Equal(t, OperationRename, operations[0].Kind)
+		assert.NotNil(t, operations[0].UndoneAt)
+		assert.Equal(t, OperationStart, operations[1].Kind)
+		assert.Equal(t, `Started "Write report"`, operations[1].Description)
+		assert.Nil(t, operations[1].UndoneAt)
+		assert.Equal(t, 2, operations[1].Changes)
+
+		operations, err = journal.ListOperations(ctx, 1)
+		require.NoError(t, err)
+		assert.Len(t, operations, 1)
+	})
+
+	t.Run("should not journal dry-run imports or failed operations", func(t *testing.T) {
+		repo, err := sqlite.New(":memory:")
+		require.NoError(t, err)
+		defer repo.Close()
+		tasks, journal := NewTaskService(repo, NewTimeService(repo)), NewJournalService(repo)
+
+		start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+		end := start.Add(time.Hour)
+		_, err = tasks.ImportTimeEntries(ctx, []ImportEntry{{TaskName: "Imported", StartTime: start, EndTime: &end}}, true)
+		require.NoError(t, err)
+		_, err = tasks.CreateTask(ctx, "")
+		require.Error(t, err)
+
+		operations, err := journal.ListOperations(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, operations)
+	})
+}
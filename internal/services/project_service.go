@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+)
+
+// projectServiceImpl implements the ProjectService interface
+type projectServiceImpl struct {
+	repo   sqlite.Repository
+	mapper *domain.Mapper
+}
+
+// NewProjectService creates a new ProjectService instance
+func NewProjectService(repo sqlite.Repository) ProjectService {
+	return &projectServiceImpl{
+		repo:   repo,
+		mapper: domain.NewMapper(),
+	}
+}
+
+// CreateProject creates the project at path, creating any missing parents (such as the client) along the way
+func (p *projectServiceImpl) CreateProject(ctx context.Context, path string) (*ProjectInfo, error) {
+	var info *ProjectInfo
+	err := p.journal(ctx, func(tx *projectServiceImpl) (string, error) {
+		var err error
+		info, err = tx.createProject(ctx, path)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Created project %q", info.Path), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// createProject does the work of CreateProject within a journaled operation
+func (p *projectServiceImpl) createProject(ctx context.Context, path string) (*ProjectInfo, error) {
+	segments, err := splitProjectPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := loadProjectTree(ctx, p.repo)
+	if err != nil {
+		return nil, err
+	}
+	if existing := tree.find(segments); existing != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("project %q already exists", tree.path(existing.ID)), nil)
+	}
+
+	var parentID *int64
+	for _, name := range segments {
+		if existing := tree.child(parentID, name); existing != nil {
+			if tree.isArchived(existing.ID) {
+				return nil, errors.NewValidationError(fmt.Sprintf("project %q is archived", tree.path(existing.ID)), nil)
+			}
+			parentID = &existing.ID
+			continue
+		}
+
+		dbProject := &sqlite.Project{Name: name, ParentID: parentID}
+		if err := p.repo.CreateProject(ctx, dbProject); err != nil {
+			return nil, err
+		}
+		tree.add(dbProject)
+		parentID = &dbProject.ID
+	}
+
+	return p.projectInfo(tree, tree.byID[*parentID]), nil
+}
+
+// FindProject returns the project at path
+func (p *projectServiceImpl) FindProject(ctx context.Context, path string) (*ProjectInfo, error) {
+	tree, dbProject, err := p.findProject(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return p.projectInfo(tree, dbProject), nil
+}
+
+// ListProjects returns all projects sorted by path, leaving out archived ones unless includeArchived is set
+func (p *projectServiceImpl) ListProjects(ctx context.Context, includeArchived bool) ([]*ProjectInfo, error) {
+	tree, err := loadProjectTree(ctx, p.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]*ProjectInfo, 0, len(tree.byID))
+	for _, dbProject := range tree.byID {
+		info := p.projectInfo(tree, dbProject)
+		if info.Archived && !includeArchived {
+			continue
+		}
+		projects = append(projects, info)
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projectPathLess(projects[i].Path, projects[j].Path)
+	})
+	return projects, nil
+}
+
+// ArchiveProject archives the project at path. Its sub-projects are treated as archived too.
+func (p *projectServiceImpl) ArchiveProject(ctx context.Context, path string) (*ProjectInfo, error) {
+	var info *ProjectInfo
+	err := p.journal(ctx, func(tx *projectServiceImpl) (string, error) {
+		var err error
+		info, err = tx.archiveProject(ctx, path)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Archived project %q", info.Path), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// archiveProject does the work of ArchiveProject within a journaled operation
+func (p *projectServiceImpl) archiveProject(ctx context.Context, path string) (*ProjectInfo, error) {
+	tree, dbProject, err := p.findProject(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbProject.ArchivedAt == nil {
+		archivedAt := time.Now()
+		dbProject.ArchivedAt = &archivedAt
+		if err := p.repo.UpdateProject(ctx, dbProject); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.projectInfo(tree, dbProject), nil
+}
+
+// journal runs fn as a single operation of the operations journal, on a copy of the service
+// whose changes are recorded so the operation can be undone
+func (p *projectServiceImpl) journal(ctx context.Context, fn func(tx *projectServiceImpl) (string, error)) error {
+	return recordOperation(ctx, p.repo, OperationProject, func(repo sqlite.Repository) (string, error) {
+		return fn(&projectServiceImpl{repo: repo, mapper: p.mapper})
+	})
+}
+
+// ResolveProjectIDs returns the ID of the project at path followed by the IDs of all its sub-projects
+func (p *projectServiceImpl) ResolveProjectIDs(ctx context.Context, path string) ([]int64, error) {
+	tree, dbProject, err := p.findProject(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return tree.descendants(dbProject.ID), nil
+}
+
+// findProject loads the project tree and looks up the project at path
+func (p *projectServiceImpl) findProject(ctx context.Context, path string) (*projectTree, *sqlite.Project, error) {
+	segments, err := splitProjectPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tree, err := loadProjectTree(ctx, p.repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbProject := tree.find(segments)
+	if dbProject == nil {
+		return nil, nil, errors.NewNotFoundError("project", strings.Join(segments, domain.ProjectPathSeparator))
+	}
+	return tree, dbProject, nil
+}
+
+// projectInfo converts a database project to a ProjectInfo with its path and effective archive state
+func (p *projectServiceImpl) projectInfo(tree *projectTree, dbProject *sqlite.Project) *ProjectInfo {
+	domainProject := p.mapper.Project.FromDatabase(*dbProject)
+	return &ProjectInfo{
+		Project:  &domainProject,
+		Path:     tree.path(dbProject.ID),
+		Archived: tree.isArchived(dbProject.ID),
+	}
+}
+
+// splitProjectPath splits "acme/website" into its trimmed segments, rejecting empty ones
+func splitProjectPath(path string) ([]string, error) {
+	segments := strings.Split(strings.TrimSpace(path), domain.ProjectPathSeparator)
+	for i, segment := range segments {
+		segments[i] = strings.TrimSpace(segment)
+		if segments[i] == "" {
+			return nil, errors.NewInvalidInputError("project", path, "expected a project name or a path such as client/project")
+		}
+	}
+	return segments, nil
+}
+
+// projectPathLess orders project paths segment by segment, so sub-projects directly follow their parent
+func projectPathLess(a, b string) bool {
+	aSegments := strings.Split(a, domain.ProjectPathSeparator)
+	bSegments := strings.Split(b, domain.ProjectPathSeparator)
+	for i := 0; i < len(aSegments) && i < len(bSegments); i++ {
+		if aSegments[i] != bSegments[i] {
+			return aSegments[i] < bSegments[i]
+		}
+	}
+	return len(aSegments) < len(bSegments)
+}
+
+// projectTree indexes all projects so paths can be resolved without a query per level
+type projectTree struct {
+	byID     map[int64]*sqlite.Project
+	children map[int64][]*sqlite.Project // Keyed by parent ID, 0 for top-level projects
+}
+
+// loadProjectTree reads every project from the repository
+func loadProjectTree(ctx context.Context, repo sqlite.Repository) (*projectTree, error) {
+	dbProjects, err := repo.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &projectTree{
+		byID:     make(map[int64]*sqlite.Project, len(dbProjects)),
+		children: make(map[int64][]*sqlite.Project),
+	}
+	for _, dbProject := range dbProjects {
+		tree.add(dbProject)
+	}
+	return tree, nil
+}
+
+// add inserts a project into the tree
+func (t *projectTree) add(dbProject *sqlite.Project) {
+	t.byID[dbProject.ID] = dbProject
+	parentKey := int64(0)
+	if dbProject.ParentID != nil {
+		parentKey = *dbProject.ParentID
+	}
+	t.children[parentKey] = append(t.children[parentKey], dbProject)
+}
+
+// child returns the direct child of parentID with the given name, or nil
+func (t *projectTree) child(parentID *int64, name string) *sqlite.Project {
+	parentKey := int64(0)
+	if parentID != nil {
+		parentKey = *parentID
+	}
+	for _, dbProject := range t.children[parentKey] {
+		if dbProject.Name == name {
+			return dbProject
+		}
+	}
+	return nil
+}
+
+// find walks the path segments from the top level down, returning nil if any level is missing
+func (t *projectTree) find(segments []string) *sqlite.Project {
+	var current *sqlite.Project
+	var parentID *int64
+	for _, name := range segments {
+		current = t.child(parentID, name)
+		if current == nil {
+			return nil
+		}
+		parentID = &current.ID
+	}
+	return current
+}
+
+// path returns the slash-separated names from the top-level project down to id
+func (t *projectTree) path(id int64) string {
+	var names []string
+	for dbProject := t.byID[id]; dbProject != nil; {
+		names = append([]string{dbProject.Name}, names...)
+		if dbProject.ParentID == nil {
+			break
+		}
+		dbProject = t.byID[*dbProject.ParentID]
+	}
+	return strings.Join(names, domain.ProjectPathSeparator)
+}
+
+// isArchived reports whether the project or any of its ancestors has been archived
+func (t *projectTree) isArchived(id int64) bool {
+	for dbProject := t.byID[id]; dbProject != nil; {
+		if dbProject.ArchivedAt != nil {
+			return true
+		}
+		if dbProject.ParentID == nil {
+			break
+		}
+		dbProject = t.byID[*dbProject.ParentID]
+	}
+	return false
+}
+
+// descendants returns id followed by the IDs of every project below it
+func (t *projectTree) descendants(id int64) []int64 {
+	ids := []int64{id}
+	for _, child := range t.children[id] {
+		ids = append(ids, t.descendants(child.ID)...)
+	}
+	return ids
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectService_CreateProject(t *testing.T) {
+	tests := []struct {
+		name           string
+		existing       []string
+		path           string
+		expectedPath   string
+		expectedPaths  []string
+		errorAssertion func(t *testing.T, err error)
+	}{
+		{
+			name:          "should create a top-level project",
+			path:          "internal",
+			expectedPath:  "internal",
+			expectedPaths: []string{"internal"},
+		},
+		{
+			name:          "should create the client along with the project",
+			path:          "acme/website",
+			expectedPath:  "acme/website",
+			expectedPaths: []string{"acme", "acme/website"},
+		},
+		{
+			name:          "should add a project to an existing client",
+			existing:      []string{"acme/website"},
+			path:          " acme / mobile ",
+			expectedPath:  "acme/mobile",
+			expectedPaths: []string{"acme", "acme/mobile", "acme/website"},
+		},
+		{
+			name:     "should reject an existing project",
+			existing: []string{"acme/website"},
+			path:     "acme/website",
+			errorAssertion: func(t *testing.T, err error) {
+				assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+				assert.Contains(t, err.Error(), "already exists")
+			},
+		},
+		{
+			name: "should reject empty path segments",
+			path: "acme//website",
+			errorAssertion: func(t *testing.T, err error) {
+				assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := setupProjectService(t)
+			ctx := context.Background()
+			for _, path := range tt.existing {
+				_, err := service.CreateProject(ctx, path)
+				require.NoError(t, err)
+			}
+
+			// Act
+			result, err := service.CreateProject(ctx, tt.path)
+
+			// Assert
+			if tt.errorAssertion != nil {
+				require.Error(t, err)
+				tt.errorAssertion(t, err)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPath, result.Path)
+
+			projects, err := service.ListProjects(ctx, false)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPaths, projectPaths(projects))
+		})
+	}
+}
+
+func TestProjectService_ArchiveProject(t *testing.T) {
+	service := setupProjectService(t)
+	ctx := context.Background()
+	for _, path := range []string{"acme/website", "acme/mobile", "internal"} {
+		_, err := service.CreateProject(ctx, path)
+		require.NoError(t, err)
+	}
+
+	archived, err := service.ArchiveProject(ctx, "acme/mobile")
+	require.NoError(t, err)
+	assert.True(t, archived.Archived)
+	require.NotNil(t, archived.Project.ArchivedAt)
+
+	// Archiving again keeps the original timestamp
+	again, err := service.ArchiveProject(ctx, "acme/mobile")
+	require.NoError(t, err)
+	assert.Equal(t, archived.Project.ArchivedAt.Unix(), again.Project.ArchivedAt.Unix())
+
+	active, err := service.ListProjects(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "acme/website", "internal"}, projectPaths(active))
+
+	all, err := service.ListProjects(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "acme/mobile", "acme/website", "internal"}, projectPaths(all))
+
+	// Archiving a client archives its projects too
+	_, err = service.ArchiveProject(ctx, "acme")
+	require.NoError(t, err)
+	active, err = service.ListProjects(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"internal"}, projectPaths(active))
+
+	_, err = service.CreateProject(ctx, "acme/intranet")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "archived")
+
+	_, err = service.ArchiveProject(ctx, "globex")
+	require.Error(t, err)
+	assert.True(t, errors.IsErrorType(err, errors.ErrorTypeNotFound))
+}
+
+func TestProjectService_ResolveProjectIDs(t *testing.T) {
+	service := setupProjectService(t)
+	ctx := context.Background()
+	website, err := service.CreateProject(ctx, "acme/website")
+	require.NoError(t, err)
+	landing, err := service.CreateProject(ctx, "acme/website/landing")
+	require.NoError(t, err)
+	internal, err := service.CreateProject(ctx, "internal")
+	require.NoError(t, err)
+	acme, err := service.FindProject(ctx, "acme")
+	require.NoError(t, err)
+
+	ids, err := service.ResolveProjectIDs(ctx, "acme")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{acme.Project.ID, website.Project.ID, landing.Project.ID}, ids)
+	assert.NotContains(t, ids, internal.Project.ID)
+
+	ids, err = service.ResolveProjectIDs(ctx, "acme/website/landing")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{landing.Project.ID}, ids)
+
+	_, err = service.ResolveProjectIDs(ctx, "acme/mobile")
+	require.Error(t, err)
+	assert.True(t, errors.IsErrorType(err, errors.ErrorTypeNotFound))
+}
+
+func TestProjectPathLess(t *testing.T) {
+	assert.True(t, projectPathLess("acme", "acme/website"))
+	assert.True(t, projectPathLess("acme/website", "acme-co"))
+	assert.False(t, projectPathLess("acme/website", "acme"))
+	assert.False(t, projectPathLess("acme", "acme"))
+}
+
+// Helper functions
+func setupProjectService(t *testing.T) ProjectService {
+	repo, err := sqlite.New(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+
+	return NewProjectService(repo)
+}
+
+func projectPaths(projects []*ProjectInfo) []string {
+	paths := make([]string, 0, len(projects))
+	for _, project := range projects {
+		paths = append(paths, project.Path)
+	}
+	return paths
+}
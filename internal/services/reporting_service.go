@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 	"time-tracker/internal/domain"
+	"time-tracker/internal/errors"
 	"time-tracker/internal/repository/sqlite"
 )
 
@@ -56,10 +59,15 @@ func (r *reportingServiceImpl) GetTaskSummary(ctx context.Context, id int64) (*T
 		domainEntry := r.mapper.TimeEntry.FromDatabase(*dbEntry)
 		timeEntries[i] = &domainEntry
 	}
+	if err := attachTags(ctx, r.repo, timeEntries); err != nil {
+		return nil, err
+	}
 
-	// Calculate summary statistics
-	sessionCount := len(timeEntries)
+	// Calculate summary statistics; segments continuing an earlier entry belong to its session
+	pauseCount, pausedDuration := countPauses(timeEntries)
+	sessionCount := len(timeEntries) - pauseCount
 	runningCount := 0
+	isPaused := false
 	isRunning := false
 	var firstEntry, lastEntry time.Time
 	var totalDuration time.Duration
@@ -73,6 +81,10 @@ func (r *reportingServiceImpl) GetTaskSummary(ctx context.Context, id int64) (*T
 			lastEntry = entry.StartTime
 		}
 
+		if entry.Paused {
+			isPaused = true
+		}
+
 		// Check if running
 		if entry.EndTime == nil {
 			runningCount++
@@ -86,17 +98,47 @@ func (r *reportingServiceImpl) GetTaskSummary(ctx context.Context, id int64) (*T
 	totalTime := r.timeService.FormatDuration(totalDuration)
 
 	return &TaskSummary{
-		Task:         task,
-		TimeEntries:  timeEntries,
-		TotalTime:    totalTime,
-		SessionCount: sessionCount,
-		RunningCount: runningCount,
-		FirstEntry:   firstEntry,
-		LastEntry:    lastEntry,
-		IsRunning:    isRunning,
+		Task:           task,
+		TimeEntries:    timeEntries,
+		TotalTime:      totalTime,
+		SessionCount:   sessionCount,
+		RunningCount:   runningCount,
+		PauseCount:     pauseCount,
+		PausedDuration: pausedDuration,
+		PausedTime:     r.timeService.FormatDuration(pausedDuration),
+		FirstEntry:     firstEntry,
+		LastEntry:      lastEntry,
+		IsRunning:      isRunning,
+		IsPaused:       isPaused,
+		Tags:           collectTags(timeEntries),
 	}, nil
 }
 
+// countPauses counts the entries continuing another entry of the list and the time between the linked segments
+func countPauses(entries []*domain.TimeEntry) (int, time.Duration) {
+	byID := make(map[int64]*domain.TimeEntry, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+	}
+
+	count := 0
+	var paused time.Duration
+	for _, entry := range entries {
+		if entry.ContinuesID == nil {
+			continue
+		}
+		previous, exists := byID[*entry.ContinuesID]
+		if !exists || previous.EndTime == nil {
+			continue
+		}
+		count++
+		if gap := entry.StartTime.Sub(*previous.EndTime); gap > 0 {
+			paused += gap
+		}
+	}
+	return count, paused
+}
+
 // AnalyzeTaskActivity analyzes time entries and returns detailed activity statistics
 func (r *reportingServiceImpl) AnalyzeTaskActivity(entries []*domain.TimeEntry) *ActivityAnalysis {
 	if len(entries) == 0 {
@@ -288,6 +330,322 @@ func (r *reportingServiceImpl) AggregateTaskData(entries []*domain.TimeEntry) ma
 	return taskMap
 }
 
+// RollupByProject totals time entries per project, counting each entry towards its project and every
+// parent of it, so "acme" includes "acme/website". Entries without a project are totalled last under "".
+func (r *reportingServiceImpl) RollupByProject(entries []*TimeEntryWithTask) []*ProjectTotal {
+	totals := make(map[string]*ProjectTotal)
+	addTo := func(path string, depth int, duration time.Duration) {
+		total, exists := totals[path]
+		if !exists {
+			total = &ProjectTotal{Project: path, Depth: depth}
+			totals[path] = total
+		}
+		total.SessionCount++
+		total.Duration += duration
+	}
+
+	for _, entry := range entries {
+		duration := r.CalculateTotalDuration([]*domain.TimeEntry{entry.TimeEntry})
+		if entry.Project == "" {
+			addTo("", 0, duration)
+			continue
+		}
+
+		segments := strings.Split(entry.Project, domain.ProjectPathSeparator)
+		for depth := range segments {
+			addTo(strings.Join(segments[:depth+1], domain.ProjectPathSeparator), depth, duration)
+		}
+	}
+
+	result := make([]*ProjectTotal, 0, len(totals))
+	for _, total := range totals {
+		total.TotalSeconds = int64(total.Duration.Seconds())
+		total.Total = r.timeService.FormatDuration(total.Duration)
+		result = append(result, total)
+	}
+
+	// Sort by path so sub-projects follow their parent, with unassigned time last
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Project == "") != (result[j].Project == "") {
+			return result[j].Project == ""
+		}
+		return projectPathLess(result[i].Project, result[j].Project)
+	})
+	return result
+}
+
+// GetReport totals the time tracked in the day, week or month around opts.Date, counting the running entry up to now
+func (r *reportingServiceImpl) GetReport(ctx context.Context, opts ReportOptions) (*Report, error) {
+	now := time.Now()
+	if opts.Date.IsZero() {
+		opts.Date = now
+	}
+	period, err := reportPeriod(opts.Period, opts.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := r.searchOverlappingEntries(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+	return r.BuildReport(entries, opts, now)
+}
+
+// searchOverlappingEntries returns the entries that may overlap the time range, including those started before it
+func (r *reportingServiceImpl) searchOverlappingEntries(ctx context.Context, timeRange *TimeRange) ([]*TimeEntryWithTask, error) {
+	// Entries are found by start time, so look back a day for those running past midnight into the range
+	entries, err := r.searchService.SearchTimeEntries(ctx, SearchCriteria{
+		TimeRange: &TimeRange{Start: timeRange.Start.AddDate(0, 0, -1), End: timeRange.End},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The running entry may have started earlier still
+	running, err := r.searchService.SearchTimeEntries(ctx, SearchCriteria{RunningOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int64]bool, len(entries))
+	for _, entry := range entries {
+		seen[entry.TimeEntry.ID] = true
+	}
+	for _, entry := range running {
+		if !seen[entry.TimeEntry.ID] {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// BuildReport totals the entries per group and per day of the period around opts.Date, clipping them to the period.
+// Running entries count up to now. With tag grouping, an entry with several tags counts towards each of them
+// and time without tags is grouped under "".
+func (r *reportingServiceImpl) BuildReport(entries []*TimeEntryWithTask, opts ReportOptions, now time.Time) (*Report, error) {
+	if opts.Date.IsZero() {
+		opts.Date = now
+	}
+	period, err := reportPeriod(opts.Period, opts.Date)
+	if err != nil {
+		return nil, err
+	}
+	groupBy := strings.ToLower(strings.TrimSpace(opts.GroupBy))
+	switch groupBy {
+	case "":
+		groupBy = ReportGroupByTask
+	case ReportGroupByTask, ReportGroupByProject, ReportGroupByTag, ReportGroupByDay:
+	default:
+		return nil, errors.NewInvalidInputError("group_by", opts.GroupBy, "expected task, project, tag or day")
+	}
+
+	report := &Report{
+		Period:  strings.ToLower(strings.TrimSpace(opts.Period)),
+		GroupBy: groupBy,
+		Start:   period.Start,
+		End:     period.End,
+	}
+	for day := period.Start; day.Before(period.End); day = day.AddDate(0, 0, 1) {
+		report.Days = append(report.Days, day)
+	}
+	report.DayTotals = make([]time.Duration, len(report.Days))
+
+	rows := make(map[string]*ReportRow)
+	addTo := func(key string, day int, duration time.Duration, running bool) {
+		row, exists := rows[key]
+		if !exists {
+			row = &ReportRow{Key: key, Days: make([]time.Duration, len(report.Days))}
+			rows[key] = row
+		}
+		row.Days[day] += duration
+		row.Duration += duration
+		row.Running = row.Running || running
+	}
+
+	for _, entry := range entries {
+		start := entry.TimeEntry.StartTime
+		end := now
+		if entry.TimeEntry.EndTime != nil {
+			end = *entry.TimeEntry.EndTime
+		}
+		running := entry.TimeEntry.EndTime == nil
+
+		for i, day := range report.Days {
+			// Clip the entry to the day
+			segmentStart, segmentEnd := start, end
+			if segmentStart.Before(day) {
+				segmentStart = day
+			}
+			if dayEnd := day.AddDate(0, 0, 1); segmentEnd.After(dayEnd) {
+				segmentEnd = dayEnd
+			}
+			duration := segmentEnd.Sub(segmentStart)
+			if duration <= 0 {
+				continue
+			}
+
+			report.DayTotals[i] += duration
+			report.Duration += duration
+			if running {
+				report.RunningTask = entry.Task.TaskName
+				report.RunningDuration += duration
+			}
+
+			switch groupBy {
+			case ReportGroupByTask:
+				addTo(entry.Task.TaskName, i, duration, running)
+			case ReportGroupByProject:
+				addTo(entry.Project, i, duration, running)
+			case ReportGroupByTag:
+				if len(entry.TimeEntry.Tags) == 0 {
+					addTo("", i, duration, running)
+				}
+				for _, tag := range domain.UniqueTags(entry.TimeEntry.Tags) {
+					addTo(tag, i, duration, running)
+				}
+			case ReportGroupByDay:
+				addTo(day.Format(dateLayout), i, duration, running)
+			}
+		}
+	}
+
+	report.DayTotalSeconds = make([]int64, len(report.DayTotals))
+	for i, total := range report.DayTotals {
+		report.DayTotalSeconds[i] = int64(total.Seconds())
+	}
+	report.TotalSeconds = int64(report.Duration.Seconds())
+	report.Total = r.timeService.FormatDuration(report.Duration)
+	report.RunningSeconds = int64(report.RunningDuration.Seconds())
+
+	report.Rows = make([]*ReportRow, 0, len(rows))
+	for _, row := range rows {
+		row.DaySeconds = make([]int64, len(row.Days))
+		for i, duration := range row.Days {
+			row.DaySeconds[i] = int64(duration.Seconds())
+		}
+		row.TotalSeconds = int64(row.Duration.Seconds())
+		row.Total = r.timeService.FormatDuration(row.Duration)
+		if report.Duration > 0 {
+			row.Share = float64(row.Duration) / float64(report.Duration) * 100
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	// Longest first, or by date for days, with time lacking a project or tag last
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if (a.Key == "") != (b.Key == "") {
+			return b.Key == ""
+		}
+		if groupBy != ReportGroupByDay && a.Duration != b.Duration {
+			return a.Duration > b.Duration
+		}
+		return a.Key < b.Key
+	})
+	return report, nil
+}
+
+// GetTimeline lays out the sessions of the day containing date per task, counting the running entry up to now
+func (r *reportingServiceImpl) GetTimeline(ctx context.Context, date time.Time) (*Timeline, error) {
+	entries, err := r.searchOverlappingEntries(ctx, r.timeService.GetDateRange(date))
+	if err != nil {
+		return nil, err
+	}
+	return r.BuildTimeline(entries, date, time.Now()), nil
+}
+
+// BuildTimeline clips the entries to the day containing date and lays them out per task.
+// Running entries end at now; entries overlapping another one are flagged.
+func (r *reportingServiceImpl) BuildTimeline(entries []*TimeEntryWithTask, date time.Time, now time.Time) *Timeline {
+	day := r.timeService.GetDateRange(date)
+	timeline := &Timeline{Start: day.Start, End: day.End, Rows: []*TimelineRow{}}
+
+	rows := make(map[int64]*TimelineRow)
+	var segments []*TimelineSegment
+	taskOf := make(map[*TimelineSegment]int64)
+	for _, entry := range entries {
+		start, end := entry.TimeEntry.StartTime, now
+		if entry.TimeEntry.EndTime != nil {
+			end = *entry.TimeEntry.EndTime
+		}
+		if start.Before(day.Start) {
+			start = day.Start
+		}
+		if end.After(day.End) {
+			end = day.End
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		segment := &TimelineSegment{
+			EntryID: entry.TimeEntry.ID,
+			Start:   start,
+			End:     end,
+			Running: entry.TimeEntry.EndTime == nil,
+		}
+		row, exists := rows[entry.Task.ID]
+		if !exists {
+			row = &TimelineRow{Task: entry.Task}
+			rows[entry.Task.ID] = row
+			timeline.Rows = append(timeline.Rows, row)
+		}
+		row.Segments = append(row.Segments, segment)
+		row.Duration += end.Sub(start)
+		segments = append(segments, segment)
+		taskOf[segment] = entry.Task.ID
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].Start.Before(segments[j].Start)
+	})
+	for i, segment := range segments {
+		// Segments are sorted by start, so only the following ones starting before this one ends overlap it
+		for _, later := range segments[i+1:] {
+			if !later.Start.Before(segment.End) {
+				break
+			}
+			segment.Overlaps, later.Overlaps = true, true
+		}
+		if i > 0 && taskOf[segments[i-1]] != taskOf[segment] {
+			timeline.Switches++
+		}
+	}
+	timeline.SessionCount = len(segments)
+
+	for _, row := range timeline.Rows {
+		sort.SliceStable(row.Segments, func(i, j int) bool {
+			return row.Segments[i].Start.Before(row.Segments[j].Start)
+		})
+		row.TotalSeconds = int64(row.Duration.Seconds())
+		row.Total = r.timeService.FormatDuration(row.Duration)
+		timeline.Duration += row.Duration
+	}
+	sort.SliceStable(timeline.Rows, func(i, j int) bool {
+		return timeline.Rows[i].Segments[0].Start.Before(timeline.Rows[j].Segments[0].Start)
+	})
+	timeline.TotalSeconds = int64(timeline.Duration.Seconds())
+	timeline.Total = r.timeService.FormatDuration(timeline.Duration)
+	return timeline
+}
+
+// reportPeriod returns the calendar day, week or month containing date
+func reportPeriod(period string, date time.Time) (*TimeRange, error) {
+	switch strings.ToLower(strings.TrimSpace(period)) {
+	case ReportPeriodDay:
+		start := startOfDay(date)
+		return &TimeRange{Start: start, End: start.AddDate(0, 0, 1)}, nil
+	case ReportPeriodWeek:
+		start := startOfWeek(date)
+		return &TimeRange{Start: start, End: start.AddDate(0, 0, 7)}, nil
+	case ReportPeriodMonth:
+		start := startOfMonth(date)
+		return &TimeRange{Start: start, End: start.AddDate(0, 1, 0)}, nil
+	default:
+		return nil, errors.NewInvalidInputError("period", period, "expected day, week or month")
+	}
+}
+
 // CalculateTotalDuration calculates total duration across all time entries
 func (r *reportingServiceImpl) CalculateTotalDuration(entries []*domain.TimeEntry) time.Duration {
 	var totalDuration time.Duration
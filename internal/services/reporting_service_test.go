@@ -105,6 +105,63 @@ func TestReportingService_GetTaskSummary(t *testing.T) {
 	}
 }
 
+func TestReportingService_GetTaskSummaryTags(t *testing.T) {
+	// Arrange
+	end := time.Now().Add(-1 * time.Hour)
+	tasks := []*domain.Task{{TaskName: "Support"}}
+	entries := []*domain.TimeEntry{
+		{TaskID: 1, StartTime: end.Add(-2 * time.Hour), EndTime: timePtr(end.Add(-1 * time.Hour))},
+		{TaskID: 1, StartTime: end.Add(-30 * time.Minute), EndTime: timePtr(end)},
+	}
+	service, repo := setupReportingServiceWithData(t, tasks, entries)
+	defer repo.Close()
+	ctx := context.Background()
+	require.NoError(t, repo.SetTimeEntryTags(ctx, entries[0].ID, []string{"billable", "support"}))
+	require.NoError(t, repo.SetTimeEntryTags(ctx, entries[1].ID, []string{"support"}))
+
+	// Act
+	summary, err := service.GetTaskSummary(ctx, tasks[0].ID)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, summary.TimeEntries, 2)
+	assert.Equal(t, []string{"billable", "support"}, summary.TimeEntries[0].Tags)
+	assert.Equal(t, []string{"support"}, summary.TimeEntries[1].Tags)
+	assert.Equal(t, []string{"billable", "support"}, summary.Tags)
+}
+
+func TestReportingService_GetTaskSummaryPauses(t *testing.T) {
+	// Arrange: one session paused twice, 5m and 7m, followed by an unrelated session
+	start := time.Now().Add(-5 * time.Hour)
+	tasks := []*domain.Task{{TaskName: "Write report"}}
+	entries := []*domain.TimeEntry{
+		{TaskID: 1, StartTime: start, EndTime: timePtr(start.Add(20 * time.Minute))},
+		{TaskID: 1, StartTime: start.Add(25 * time.Minute), EndTime: timePtr(start.Add(40 * time.Minute))},
+		{TaskID: 1, StartTime: start.Add(47 * time.Minute), EndTime: timePtr(start.Add(60 * time.Minute))},
+		{TaskID: 1, StartTime: start.Add(3 * time.Hour), EndTime: timePtr(start.Add(4 * time.Hour))},
+	}
+	service, repo := setupReportingServiceWithData(t, tasks, entries)
+	defer repo.Close()
+	ctx := context.Background()
+	for i := 1; i <= 2; i++ {
+		dbEntry, err := repo.GetTimeEntry(ctx, entries[i].ID)
+		require.NoError(t, err)
+		dbEntry.ContinuesID = &entries[i-1].ID
+		require.NoError(t, repo.UpdateTimeEntry(ctx, dbEntry))
+	}
+
+	// Act
+	summary, err := service.GetTaskSummary(ctx, tasks[0].ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.SessionCount)
+	assert.Equal(t, 2, summary.PauseCount)
+	assert.Equal(t, 12*time.Minute, summary.PausedDuration)
+	assert.Equal(t, "12m", summary.PausedTime)
+	assert.False(t, summary.IsPaused)
+}
+
 func TestReportingService_AnalyzeTaskActivity(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -509,6 +566,52 @@ func TestReportingService_CalculateTotalDuration(t *testing.T) {
 	}
 }
 
+func TestReportingService_RollupByProject(t *testing.T) {
+	// Arrange
+	service := setupReportingService(t)
+	end := time.Now().Add(-1 * time.Hour)
+	entry := func(project string, duration time.Duration) *TimeEntryWithTask {
+		return &TimeEntryWithTask{
+			TimeEntry: &domain.TimeEntry{StartTime: end.Add(-duration), EndTime: timePtr(end)},
+			Task:      &domain.Task{TaskName: "Task"},
+			Project:   project,
+		}
+	}
+	entries := []*TimeEntryWithTask{
+		entry("acme/website", 1*time.Hour),
+		entry("", 15*time.Minute),
+		entry("acme/mobile", 30*time.Minute),
+		entry("acme/website", 30*time.Minute),
+		entry("internal", 45*time.Minute),
+	}
+
+	// Act
+	result := service.RollupByProject(entries)
+
+	// Assert
+	expected := []struct {
+		project  string
+		depth    int
+		sessions int
+		duration time.Duration
+	}{
+		{"acme", 0, 3, 2 * time.Hour},
+		{"acme/mobile", 1, 1, 30 * time.Minute},
+		{"acme/website", 1, 2, 90 * time.Minute},
+		{"internal", 0, 1, 45 * time.Minute},
+		{"", 0, 1, 15 * time.Minute},
+	}
+	require.Len(t, result, len(expected))
+	for i, want := range expected {
+		assert.Equal(t, want.project, result[i].Project)
+		assert.Equal(t, want.depth, result[i].Depth, want.project)
+		assert.Equal(t, want.sessions, result[i].SessionCount, want.project)
+		assert.Equal(t, want.duration, result[i].Duration, want.project)
+		assert.Equal(t, int64(want.duration.Seconds()), result[i].TotalSeconds, want.project)
+		assert.NotEmpty(t, result[i].Total)
+	}
+}
+
 func TestReportingService_FormatStatistics(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -546,6 +649,162 @@ func TestReportingService_FormatStatistics(t *testing.T) {
 	}
 }
 
+func TestReportingService_BuildReport(t *testing.T) {
+	service := setupReportingService(t)
+	// Thursday 2026-10-15, 12:00
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.Local)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.Local)
+	}
+	entry := func(task, project string, tags []string, start time.Time, end *time.Time) *TimeEntryWithTask {
+		return &TimeEntryWithTask{
+			TimeEntry: &domain.TimeEntry{StartTime: start, EndTime: end, Tags: tags},
+			Task:      &domain.Task{TaskName: task},
+			Project:   project,
+		}
+	}
+	entries := []*TimeEntryWithTask{
+		// Sunday before the week, spilling 1h past midnight into Monday
+		entry("Deploy", "acme/ops", []string{"billable"}, at(11, 23, 0), timePtr(at(12, 1, 0))),
+		entry("Write docs", "acme/website", []string{"billable", "docs"}, at(12, 9, 0), timePtr(at(12, 11, 0))),
+		entry("Write docs", "acme/website", nil, at(14, 14, 0), timePtr(at(14, 15, 0))),
+		// Running for 30 minutes
+		entry("Code review", "", nil, at(15, 11, 30), nil),
+	}
+
+	t.Run("groups by task per day of the week", func(t *testing.T) {
+		report, err := service.BuildReport(entries, ReportOptions{Period: ReportPeriodWeek}, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, ReportGroupByTask, report.GroupBy)
+		assert.Equal(t, at(12, 0, 0), report.Start)
+		assert.Equal(t, at(19, 0, 0), report.End)
+		require.Len(t, report.Days, 7)
+		assert.Equal(t, 4*time.Hour+30*time.Minute, report.Duration)
+		assert.Equal(t, []time.Duration{3 * time.Hour, 0, time.Hour, 30 * time.Minute, 0, 0, 0}, report.DayTotals)
+		assert.Equal(t, "Code review", report.RunningTask)
+		assert.Equal(t, 30*time.Minute, report.RunningDuration)
+
+		require.Len(t, report.Rows, 3)
+		assert.Equal(t, "Write docs", report.Rows[0].Key)
+		assert.Equal(t, 3*time.Hour, report.Rows[0].Duration)
+		assert.Equal(t, []int64{7200, 0, 3600, 0, 0, 0, 0}, report.Rows[0].DaySeconds)
+		assert.InDelta(t, 66.67, report.Rows[0].Share, 0.01)
+		assert.Equal(t, "Deploy", report.Rows[1].Key)
+		assert.Equal(t, time.Hour, report.Rows[1].Duration)
+		assert.Equal(t, "Code review", report.Rows[2].Key)
+		assert.True(t, report.Rows[2].Running)
+		assert.Equal(t, "30m", report.Rows[2].Total)
+	})
+
+	t.Run("groups by project, tag and day", func(t *testing.T) {
+		keys := func(groupBy string) map[string]time.Duration {
+			report, err := service.BuildReport(entries, ReportOptions{Period: ReportPeriodWeek, GroupBy: groupBy}, now)
+			require.NoError(t, err)
+			result := make(map[string]time.Duration)
+			for _, row := range report.Rows {
+				result[row.Key] = row.Duration
+			}
+			return result
+		}
+
+		assert.Equal(t, map[string]time.Duration{"acme/website": 3 * time.Hour, "acme/ops": time.Hour, "": 30 * time.Minute}, keys(ReportGroupByProject))
+		assert.Equal(t, map[string]time.Duration{"billable": 3 * time.Hour, "docs": 2 * time.Hour, "": 90 * time.Minute}, keys(ReportGroupByTag))
+		assert.Equal(t, map[string]time.Duration{"2026-10-12": 3 * time.Hour, "2026-10-14": time.Hour, "2026-10-15": 30 * time.Minute}, keys(ReportGroupByDay))
+	})
+
+	t.Run("covers a day or a month around the date", func(t *testing.T) {
+		report, err := service.BuildReport(entries, ReportOptions{Period: "day", Date: at(11, 8, 0)}, now)
+		require.NoError(t, err)
+		require.Len(t, report.Days, 1)
+		assert.Equal(t, time.Hour, report.Duration)
+		assert.Empty(t, report.RunningTask)
+
+		report, err = service.BuildReport(entries, ReportOptions{Period: "month"}, now)
+		require.NoError(t, err)
+		assert.Len(t, report.Days, 31)
+		assert.Equal(t, 5*time.Hour+30*time.Minute, report.Duration)
+	})
+
+	t.Run("rejects unknown periods and groupings", func(t *testing.T) {
+		_, err := service.BuildReport(entries, ReportOptions{Period: "year"}, now)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+
+		_, err = service.BuildReport(entries, ReportOptions{Period: "week", GroupBy: "client"}, now)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+	})
+}
+
+func TestReportingService_GetReport(t *testing.T) {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	lateYesterday := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 23, 0, 0, 0, time.Local)
+	tasks := []*domain.Task{{TaskName: "Night shift"}, {TaskName: "Running"}}
+	entries := []*domain.TimeEntry{
+		{TaskID: 1, StartTime: lateYesterday, EndTime: timePtr(lateYesterday.Add(2 * time.Hour))},
+		{TaskID: 2, StartTime: time.Now().Add(-72 * time.Hour)},
+	}
+	service, repo := setupReportingServiceWithData(t, tasks, entries)
+	defer repo.Close()
+
+	report, err := service.GetReport(context.Background(), ReportOptions{Period: ReportPeriodDay})
+	require.NoError(t, err)
+
+	rows := make(map[string]*ReportRow)
+	for _, row := range report.Rows {
+		rows[row.Key] = row
+	}
+	require.Contains(t, rows, "Night shift")
+	assert.Equal(t, time.Hour, rows["Night shift"].Duration)
+	require.Contains(t, rows, "Running")
+	assert.True(t, rows["Running"].Running)
+	assert.Equal(t, "Running", report.RunningTask)
+}
+
+func TestReportingService_BuildTimeline(t *testing.T) {
+	service := setupReportingService(t)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.Local)
+	}
+	now := at(15, 16, 0)
+	docs := &domain.Task{ID: 1, TaskName: "Write docs"}
+	review := &domain.Task{ID: 2, TaskName: "Code review"}
+	entry := func(id int64, task *domain.Task, start time.Time, end *time.Time) *TimeEntryWithTask {
+		return &TimeEntryWithTask{TimeEntry: &domain.TimeEntry{ID: id, TaskID: task.ID, StartTime: start, EndTime: end}, Task: task}
+	}
+	entries := []*TimeEntryWithTask{
+		entry(1, review, at(14, 23, 0), timePtr(at(15, 1, 0))),
+		entry(2, docs, at(15, 9, 0), timePtr(at(15, 10, 0))),
+		entry(3, review, at(15, 9, 30), timePtr(at(15, 11, 0))),
+		entry(4, docs, at(15, 13, 0), timePtr(at(15, 14, 0))),
+		entry(5, docs, at(15, 15, 0), nil),
+		entry(6, docs, at(14, 9, 0), timePtr(at(14, 10, 0))),
+	}
+
+	timeline := service.BuildTimeline(entries, at(15, 12, 0), now)
+
+	assert.Equal(t, at(15, 0, 0), timeline.Start)
+	assert.Equal(t, at(16, 0, 0), timeline.End)
+	assert.Equal(t, 5, timeline.SessionCount)
+	assert.Equal(t, 3, timeline.Switches)
+	assert.Equal(t, 5*time.Hour+30*time.Minute, timeline.Duration)
+
+	require.Len(t, timeline.Rows, 2)
+	reviewRow, docsRow := timeline.Rows[0], timeline.Rows[1]
+	assert.Equal(t, "Code review", reviewRow.Task.TaskName)
+	require.Len(t, reviewRow.Segments, 2)
+	assert.Equal(t, at(15, 0, 0), reviewRow.Segments[0].Start, "clipped to midnight")
+	assert.False(t, reviewRow.Segments[0].Overlaps)
+	assert.True(t, reviewRow.Segments[1].Overlaps)
+	assert.Equal(t, 2*time.Hour+30*time.Minute, reviewRow.Duration)
+
+	require.Len(t, docsRow.Segments, 3)
+	assert.True(t, docsRow.Segments[0].Overlaps)
+	assert.False(t, docsRow.Segments[1].Overlaps)
+	assert.True(t, docsRow.Segments[2].Running)
+	assert.Equal(t, now, docsRow.Segments[2].End)
+	assert.Equal(t, "3h 0m", docsRow.Total)
+}
+
 // Helper functions
 func setupReportingService(t *testing.T) ReportingService {
 	repo, err := sqlite.New(":memory:")
@@ -566,7 +825,7 @@ func setupReportingServiceWithData(t *testing.T, tasks []*domain.Task, entries [
 	
 	// Create tasks
 	for _, task := range tasks {
-		dbTask := &sqlite.Task{TaskName: task.TaskName}
+		dbTask := &sqlite.Task{TaskName: task.TaskName, ProjectID: task.ProjectID}
 		err := repo.CreateTask(ctx, dbTask)
 		require.NoError(t, err)
 		task.ID = dbTask.ID // Update with actual ID
@@ -38,6 +38,22 @@ func (s *searchServiceImpl) filterRunningEntries(entries []*sqlite.TimeEntry) []
 	return runningEntries
 }
 
+// matchesProjects checks if a task belongs to one of the given projects; an empty list matches every task
+func (s *searchServiceImpl) matchesProjects(projectID *int64, projectIDs []int64) bool {
+	if len(projectIDs) == 0 {
+		return true
+	}
+	if projectID == nil {
+		return false
+	}
+	for _, id := range projectIDs {
+		if id == *projectID {
+			return true
+		}
+	}
+	return false
+}
+
 // matchesTextFilter checks if a task name matches the text filter
 func (s *searchServiceImpl) matchesTextFilter(taskName, textFilter string) bool {
 	if textFilter == "" {
@@ -46,6 +62,17 @@ func (s *searchServiceImpl) matchesTextFilter(taskName, textFilter string) bool
 	return strings.Contains(strings.ToLower(taskName), strings.ToLower(textFilter))
 }
 
+// filterEntriesByNote keeps the entries whose note matches the text filter
+func (s *searchServiceImpl) filterEntriesByNote(entries []*sqlite.TimeEntry, textFilter string) []*sqlite.TimeEntry {
+	matching := make([]*sqlite.TimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Note != "" && s.matchesTextFilter(entry.Note, textFilter) {
+			matching = append(matching, entry)
+		}
+	}
+	return matching
+}
+
 // buildSearchOptions builds repository search options from criteria
 func (s *searchServiceImpl) buildSearchOptions(criteria SearchCriteria) sqlite.SearchOptions {
 	searchOpts := sqlite.SearchOptions{}
@@ -59,6 +86,13 @@ func (s *searchServiceImpl) buildSearchOptions(criteria SearchCriteria) sqlite.S
 		searchOpts.TaskID = criteria.TaskID
 	}
 	
+	if len(criteria.ProjectIDs) > 0 {
+		searchOpts.ProjectIDs = criteria.ProjectIDs
+	}
+	
+	searchOpts.Tags = filterTags(criteria.Tags)
+	searchOpts.ExcludeTags = filterTags(criteria.ExcludeTags)
+	
 	return searchOpts
 }
 
@@ -79,8 +113,13 @@ func (s *searchServiceImpl) SearchTasks(ctx context.Context, criteria SearchCrit
 			continue
 		}
 		
-		// Filter by text if specified
-		if !s.matchesTextFilter(dbTask.TaskName, criteria.TextFilter) {
+		// Leave archived tasks out unless asked for
+		if dbTask.ArchivedAt != nil && !criteria.IncludeArchived {
+			continue
+		}
+		
+		// Filter by project if specified
+		if !s.matchesProjects(dbTask.ProjectID, criteria.ProjectIDs) {
 			continue
 		}
 		
@@ -99,6 +138,11 @@ func (s *searchServiceImpl) SearchTasks(ctx context.Context, criteria SearchCrit
 			entries = s.filterRunningEntries(entries)
 		}
 		
+		// Filter by text if specified: tasks whose name doesn't match are kept for the entries whose note does
+		if !s.matchesTextFilter(dbTask.TaskName, criteria.TextFilter) {
+			entries = s.filterEntriesByNote(entries, criteria.TextFilter)
+		}
+		
 		// Skip tasks with no matching entries
 		if len(entries) == 0 {
 			continue
@@ -118,7 +162,8 @@ func (s *searchServiceImpl) SearchTimeEntries(ctx context.Context, criteria Sear
 	var err error
 	
 	// If no criteria specified, get all time entries
-	if criteria.TimeRange == nil && criteria.TaskID == nil && !criteria.RunningOnly {
+	if criteria.TimeRange == nil && criteria.TaskID == nil && len(criteria.ProjectIDs) == 0 &&
+		len(criteria.Tags) == 0 && len(criteria.ExcludeTags) == 0 && !criteria.RunningOnly {
 		entries, err = s.repo.ListTimeEntries(ctx)
 		if err != nil {
 			return nil, err
@@ -139,6 +184,12 @@ func (s *searchServiceImpl) SearchTimeEntries(ctx context.Context, criteria Sear
 		entries = s.filterRunningEntries(entries)
 	}
 	
+	// Load projects once to label entries with their project path
+	projects, err := loadProjectTree(ctx, s.repo)
+	if err != nil {
+		return nil, err
+	}
+	
 	// Convert to TimeEntryWithTask
 	result := make([]*TimeEntryWithTask, 0, len(entries))
 	
@@ -149,8 +200,9 @@ func (s *searchServiceImpl) SearchTimeEntries(ctx context.Context, criteria Sear
 			return nil, err
 		}
 		
-		// Filter by text if specified
-		if !s.matchesTextFilter(dbTask.TaskName, criteria.TextFilter) {
+		// Filter by text if specified, matching either the task name or the entry's note
+		if !s.matchesTextFilter(dbTask.TaskName, criteria.TextFilter) &&
+			(entry.Note == "" || !s.matchesTextFilter(entry.Note, criteria.TextFilter)) {
 			continue
 		}
 		
@@ -164,9 +216,21 @@ func (s *searchServiceImpl) SearchTimeEntries(ctx context.Context, criteria Sear
 			Task:      &domainTask,
 			Duration:  duration,
 		}
+		if dbTask.ProjectID != nil {
+			entryWithTask.Project = projects.path(*dbTask.ProjectID)
+		}
 		
 		result = append(result, entryWithTask)
 	}
+	
+	// Load the tags of the matching entries in one go
+	domainEntries := make([]*domain.TimeEntry, len(result))
+	for i, entryWithTask := range result {
+		domainEntries[i] = entryWithTask.TimeEntry
+	}
+	if err := attachTags(ctx, s.repo, domainEntries); err != nil {
+		return nil, err
+	}
 
 	return result, nil
 }
@@ -221,6 +221,183 @@ func TestSearchService_SearchTimeEntries(t *testing.T) {
 	}
 }
 
+func TestSearchService_SearchTimeEntriesByProject(t *testing.T) {
+	ctx := context.Background()
+	repo, err := sqlite.New(":memory:")
+	require.NoError(t, err)
+	defer repo.Close()
+
+	projects := NewProjectService(repo)
+	website, err := projects.CreateProject(ctx, "acme/website")
+	require.NoError(t, err)
+	internal, err := projects.CreateProject(ctx, "internal")
+	require.NoError(t, err)
+
+	timeService := NewTimeService(repo)
+	taskService := NewTaskService(repo, timeService)
+	service := NewSearchService(repo, timeService, taskService)
+
+	tasks := []*sqlite.Task{
+		{TaskName: "Landing page", ProjectID: &website.Project.ID},
+		{TaskName: "Planning", ProjectID: &internal.Project.ID},
+		{TaskName: "Email"},
+	}
+	for i, task := range tasks {
+		require.NoError(t, repo.CreateTask(ctx, task))
+		start := time.Now().Add(time.Duration(-3+i) * time.Hour)
+		require.NoError(t, repo.CreateTimeEntry(ctx, &sqlite.TimeEntry{
+			TaskID: task.ID, StartTime: start, EndTime: timePtr(start.Add(30 * time.Minute)),
+		}))
+	}
+
+	acmeIDs, err := projects.ResolveProjectIDs(ctx, "acme")
+	require.NoError(t, err)
+
+	// Act
+	result, err := service.SearchTimeEntries(ctx, SearchCriteria{ProjectIDs: acmeIDs})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Landing page", result[0].Task.TaskName)
+	assert.Equal(t, "acme/website", result[0].Project)
+
+	all, err := service.SearchTimeEntries(ctx, SearchCriteria{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	labels := make(map[string]string)
+	for _, entry := range all {
+		labels[entry.Task.TaskName] = entry.Project
+	}
+	assert.Equal(t, map[string]string{"Landing page": "acme/website", "Planning": "internal", "Email": ""}, labels)
+}
+
+func TestSearchService_SearchByTags(t *testing.T) {
+	ctx := context.Background()
+	service, repo := setupSearchServiceWithData(t, []*domain.Task{{TaskName: "Support"}, {TaskName: "Standup"}}, nil)
+	defer repo.Close()
+
+	entries := []struct {
+		taskID int64
+		tags   []string
+	}{
+		{taskID: 1, tags: []string{"billable", "support"}},
+		{taskID: 1, tags: []string{"support"}},
+		{taskID: 2, tags: []string{"meeting"}},
+	}
+	for i, entry := range entries {
+		start := time.Now().Add(time.Duration(-3+i) * time.Hour)
+		dbEntry := &sqlite.TimeEntry{TaskID: entry.taskID, StartTime: start, EndTime: timePtr(start.Add(30 * time.Minute))}
+		require.NoError(t, repo.CreateTimeEntry(ctx, dbEntry))
+		require.NoError(t, repo.SetTimeEntryTags(ctx, dbEntry.ID, entry.tags))
+	}
+
+	tests := []struct {
+		name          string
+		criteria      SearchCriteria
+		expectedTags  [][]string
+		expectedTasks int
+	}{
+		{
+			name:          "should include entries carrying the tag, with or without prefix",
+			criteria:      SearchCriteria{Tags: []string{"#Support"}},
+			expectedTags:  [][]string{{"billable", "support"}, {"support"}},
+			expectedTasks: 1,
+		},
+		{
+			name:          "should exclude entries carrying the tag",
+			criteria:      SearchCriteria{ExcludeTags: []string{"billable"}},
+			expectedTags:  [][]string{{"support"}, {"meeting"}},
+			expectedTasks: 2,
+		},
+		{
+			name:          "should match nothing for unknown tags",
+			criteria:      SearchCriteria{Tags: []string{"travel"}},
+			expectedTags:  [][]string{},
+			expectedTasks: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.SearchTimeEntries(ctx, tt.criteria)
+			require.NoError(t, err)
+			tags := make([][]string, 0, len(result))
+			for _, entry := range result {
+				tags = append(tags, entry.TimeEntry.Tags)
+			}
+			assert.Equal(t, tt.expectedTags, tags)
+
+			activities, err := service.SearchTasks(ctx, tt.criteria)
+			require.NoError(t, err)
+			assert.Len(t, activities, tt.expectedTasks)
+		})
+	}
+}
+
+func TestSearchService_SearchByNote(t *testing.T) {
+	ctx := context.Background()
+	service, repo := setupSearchServiceWithData(t, []*domain.Task{{TaskName: "Code review"}, {TaskName: "Support"}}, nil)
+	defer repo.Close()
+
+	entries := []struct {
+		taskID int64
+		note   string
+	}{
+		{taskID: 1, note: "reviewing PR 412"},
+		{taskID: 1, note: ""},
+		{taskID: 2, note: "customer asked about PR 412"},
+		{taskID: 2, note: "password reset"},
+	}
+	for i, entry := range entries {
+		start := time.Now().Add(time.Duration(-4+i) * time.Hour)
+		dbEntry := &sqlite.TimeEntry{TaskID: entry.taskID, StartTime: start, EndTime: timePtr(start.Add(30 * time.Minute)), Note: entry.note}
+		require.NoError(t, repo.CreateTimeEntry(ctx, dbEntry))
+	}
+
+	tests := []struct {
+		name          string
+		textFilter    string
+		expectedNotes []string
+		expectedTasks int
+	}{
+		{
+			name:          "should match notes case-insensitively across tasks",
+			textFilter:    "pr 412",
+			expectedNotes: []string{"reviewing PR 412", "customer asked about PR 412"},
+			expectedTasks: 2,
+		},
+		{
+			name:          "should keep every entry of a task whose name matches",
+			textFilter:    "review",
+			expectedNotes: []string{"reviewing PR 412", ""},
+			expectedTasks: 1,
+		},
+		{
+			name:          "should match nothing for unknown text",
+			textFilter:    "deployment",
+			expectedNotes: []string{},
+			expectedTasks: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.SearchTimeEntries(ctx, SearchCriteria{TextFilter: tt.textFilter})
+			require.NoError(t, err)
+			notes := make([]string, 0, len(result))
+			for _, entry := range result {
+				notes = append(notes, entry.TimeEntry.Note)
+			}
+			assert.Equal(t, tt.expectedNotes, notes)
+
+			activities, err := service.SearchTasks(ctx, SearchCriteria{TextFilter: tt.textFilter})
+			require.NoError(t, err)
+			assert.Len(t, activities, tt.expectedTasks)
+		})
+	}
+}
+
 func TestSearchService_FilterTasksByTime(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -535,7 +712,7 @@ func setupSearchServiceWithData(t *testing.T, tasks []*domain.Task, entries []*d
 	
 	// Create tasks
 	for _, task := range tasks {
-		dbTask := &sqlite.Task{TaskName: task.TaskName}
+		dbTask := &sqlite.Task{TaskName: task.TaskName, ProjectID: task.ProjectID}
 		err := repo.CreateTask(ctx, dbTask)
 		require.NoError(t, err)
 		task.ID = dbTask.ID // Update with actual ID
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"time-tracker/internal/domain"
+	"time-tracker/internal/errors"
+	"time-tracker/internal/repository/sqlite"
+)
+
+// normalizeTags validates tags given by the user, returning them without prefixes, sorted and de-duplicated
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name, valid := domain.NormalizeTag(tag)
+		if !valid {
+			return nil, errors.NewInvalidInputError("tag", tag, "tags must start with a letter and contain only letters, digits, '-' and '_'")
+		}
+		normalized = append(normalized, name)
+	}
+	return domain.UniqueTags(normalized), nil
+}
+
+// filterTags normalizes tags used to filter searches. Invalid tags are kept so they simply match nothing.
+func filterTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	filtered := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name, _ := domain.NormalizeTag(tag)
+		filtered = append(filtered, name)
+	}
+	return domain.UniqueTags(filtered)
+}
+
+// attachTags loads the tags of the given time entries from the repository
+func attachTags(ctx context.Context, repo sqlite.Repository, entries []*domain.TimeEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*domain.TimeEntry, len(entries))
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+		ids = append(ids, entry.ID)
+	}
+
+	tags, err := repo.ListTimeEntryTags(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if entry, exists := byID[tag.TimeEntryID]; exists {
+			entry.Tags = append(entry.Tags, tag.Name)
+		}
+	}
+	return nil
+}
+
+// collectTags returns the distinct tags used by any of the entries, sorted
+func collectTags(entries []*domain.TimeEntry) []string {
+	var tags []string
+	for _, entry := range entries {
+		tags = append(tags, entry.Tags...)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return domain.UniqueTags(tags)
+}
@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
 	"strings"
+	"time"
+	"unicode/utf8"
 	"time-tracker/internal/domain"
 	"time-tracker/internal/errors"
 	"time-tracker/internal/repository/sqlite"
@@ -36,6 +40,15 @@ func (t *taskServiceImpl) validateAndTrimTaskName(name string) (string, error) {
 	return trimmedName, nil
 }
 
+// validateNote normalizes a note given by the user and checks its length
+func (t *taskServiceImpl) validateNote(note string) (string, error) {
+	normalized := domain.NormalizeNote(note)
+	if utf8.RuneCountInString(normalized) > domain.MaxNoteLength {
+		return "", errors.NewInvalidInputError("note", normalized, fmt.Sprintf("notes can be at most %d characters", domain.MaxNoteLength))
+	}
+	return normalized, nil
+}
+
 // findTaskByName searches for a task by exact name match
 func (t *taskServiceImpl) findTaskByName(ctx context.Context, name string) (*domain.Task, error) {
 	dbTasks, err := t.repo.ListTasks(ctx)
@@ -56,6 +69,23 @@ func (t *taskServiceImpl) findTaskByName(ctx context.Context, name string) (*dom
 
 // CreateTask creates a new task with the given name
 func (t *taskServiceImpl) CreateTask(ctx context.Context, name string) (*domain.Task, error) {
+	var task *domain.Task
+	err := t.journal(ctx, OperationCreate, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		task, err = tx.createTask(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Created task %q", task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// createTask does the work of CreateTask within a journaled operation
+func (t *taskServiceImpl) createTask(ctx context.Context, name string) (*domain.Task, error) {
 	// Validate task name
 	trimmedName, err := t.validateAndTrimTaskName(name)
 	if err != nil {
@@ -94,8 +124,41 @@ func (t *taskServiceImpl) GetTask(ctx context.Context, id int64) (*domain.Task,
 	return &domainTask, nil
 }
 
+// GetTaskByName retrieves a task by its exact name
+func (t *taskServiceImpl) GetTaskByName(ctx context.Context, name string) (*domain.Task, error) {
+	trimmedName := strings.TrimSpace(name)
+	task, err := t.findTaskByName(ctx, trimmedName)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, errors.NewNotFoundError("task", trimmedName)
+	}
+	return task, nil
+}
+
 // UpdateTask updates a task's name
 func (t *taskServiceImpl) UpdateTask(ctx context.Context, id int64, name string) (*domain.Task, error) {
+	var task *domain.Task
+	err := t.journal(ctx, OperationRename, func(tx *taskServiceImpl) (string, error) {
+		before, err := tx.GetTask(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		task, err = tx.updateTask(ctx, id, name)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Renamed task %q to %q", before.TaskName, task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// updateTask does the work of UpdateTask within a journaled operation
+func (t *taskServiceImpl) updateTask(ctx context.Context, id int64, name string) (*domain.Task, error) {
 	// Validate task ID
 	if id <= 0 {
 		return nil, errors.NewValidationError("invalid task ID", nil)
@@ -108,16 +171,24 @@ func (t *taskServiceImpl) UpdateTask(ctx context.Context, id int64, name string)
 	}
 
 	// Check if task exists
-	_, err = t.repo.GetTask(ctx, id)
+	dbTask, err := t.repo.GetTask(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update task
-	dbTask := &sqlite.Task{
-		ID:       id,
-		TaskName: trimmedName,
+	// Reject names already used by another task; those should be merged instead
+	existing, err := t.findTaskByName(ctx, trimmedName)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != id {
+		return nil, errors.NewValidationError(
+			fmt.Sprintf("task name %q is already used by task %d, merge the tasks instead", trimmedName, existing.ID), nil,
+		).WithContext("task_id", existing.ID)
 	}
+
+	// Update task, keeping its project
+	dbTask.TaskName = trimmedName
 	
 	err = t.repo.UpdateTask(ctx, dbTask)
 	if err != nil {
@@ -129,17 +200,273 @@ func (t *taskServiceImpl) UpdateTask(ctx context.Context, id int64, name string)
 	return &domainTask, nil
 }
 
+// MergeTasks moves all time entries from one task into another and deletes the emptied task
+func (t *taskServiceImpl) MergeTasks(ctx context.Context, fromID int64, intoID int64) (*TaskMerge, error) {
+	var merge *TaskMerge
+	err := t.journal(ctx, OperationMerge, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		merge, err = tx.mergeTasks(ctx, fromID, intoID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Merged task %q into %q", merge.From.TaskName, merge.Into.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+// mergeTasks does the work of MergeTasks within a journaled operation
+func (t *taskServiceImpl) mergeTasks(ctx context.Context, fromID int64, intoID int64) (*TaskMerge, error) {
+	if fromID == intoID {
+		return nil, errors.NewValidationError("cannot merge a task into itself", nil)
+	}
+
+	// Both tasks must exist
+	from, err := t.GetTask(ctx, fromID)
+	if err != nil {
+		return nil, err
+	}
+	into, err := t.GetTask(ctx, intoID)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := t.repo.MergeTasks(ctx, from.ID, into.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskMerge{
+		From:         from,
+		Into:         into,
+		MovedEntries: int(moved),
+	}, nil
+}
+
+// errImportDryRun rolls back the import transaction once a dry run has been counted
+var errImportDryRun = stderrors.New("import dry run")
+
+// ImportTimeEntries adds imported time entries in a single transaction, creating missing tasks and projects
+// and skipping entries that already exist for the same task with the same start and end time. Entries still
+// running or overlapping tracked time are skipped too, and reported, as they would leave a second running
+// timer or time counted twice. Imported entries keep their tags, and file a task without a project under theirs.
+// A dry run performs the same checks and reports the same counts, then rolls everything back.
+func (t *taskServiceImpl) ImportTimeEntries(ctx context.Context, entries []ImportEntry, dryRun bool) (*ImportResult, error) {
+	// Dry runs change nothing, so there is nothing to journal
+	if dryRun {
+		return t.importTimeEntries(ctx, entries, true)
+	}
+
+	var result *ImportResult
+	err := t.journal(ctx, OperationImport, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		result, err = tx.importTimeEntries(ctx, entries, false)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Imported %d time entries", result.Imported), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importTimeEntries does the work of ImportTimeEntries within a journaled operation
+func (t *taskServiceImpl) importTimeEntries(ctx context.Context, entries []ImportEntry, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{Skipped: make([]ImportSkip, 0), CreatedTasks: make([]string, 0), CreatedProjects: make([]string, 0), DryRun: dryRun}
+
+	err := t.repo.WithTransaction(ctx, func(repo sqlite.Repository) error {
+		txTimeService := NewTimeService(repo)
+		txTaskService := NewTaskService(repo, txTimeService)
+
+		// Index existing tasks by name and existing entries by task and times
+		dbTasks, err := repo.ListTasks(ctx)
+		if err != nil {
+			return err
+		}
+		tasksByName := make(map[string]*sqlite.Task, len(dbTasks))
+		for _, dbTask := range dbTasks {
+			tasksByName[dbTask.TaskName] = dbTask
+		}
+
+		tree, err := loadProjectTree(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		dbEntries, err := repo.ListTimeEntries(ctx)
+		if err != nil {
+			return err
+		}
+		existing := make(map[string]bool, len(dbEntries))
+		for _, dbEntry := range dbEntries {
+			existing[importEntryKey(dbEntry.TaskID, dbEntry.StartTime, dbEntry.EndTime)] = true
+		}
+
+		for i, entry := range entries {
+			taskName := strings.TrimSpace(entry.TaskName)
+			dbTask, found := tasksByName[taskName]
+			if found && existing[importEntryKey(dbTask.ID, entry.StartTime, entry.EndTime)] {
+				result.Duplicates++
+				continue
+			}
+
+			skip := func(reason string) {
+				result.Skipped = append(result.Skipped, ImportSkip{Entry: i + 1, TaskName: taskName, StartTime: entry.StartTime, Reason: reason})
+			}
+			if entry.EndTime == nil {
+				skip("it is still running")
+				continue
+			}
+			// Entries ending before they start are reported by the validation below
+			if entry.EndTime.After(entry.StartTime) {
+				overlapping, err := txTimeService.FindOverlappingEntries(ctx, entry.StartTime, entry.EndTime, 0)
+				if err != nil {
+					return err
+				}
+				if len(overlapping) > 0 {
+					skip(fmt.Sprintf("it overlaps entry %d", overlapping[0].ID))
+					continue
+				}
+			}
+
+			if !found {
+				task, err := txTaskService.CreateTask(ctx, taskName)
+				if err != nil {
+					return importEntryError(i, err)
+				}
+				created := t.mapper.Task.ToDatabase(*task)
+				dbTask = &created
+				tasksByName[task.TaskName] = dbTask
+				result.CreatedTasks = append(result.CreatedTasks, task.TaskName)
+			}
+			taskID := dbTask.ID
+			key := importEntryKey(taskID, entry.StartTime, entry.EndTime)
+
+			if err := txTimeService.ValidateTimeEntry(taskID, entry.StartTime, entry.EndTime); err != nil {
+				return importEntryError(i, err)
+			}
+			note, err := t.validateNote(entry.Note)
+			if err != nil {
+				return importEntryError(i, err)
+			}
+			tags, err := normalizeTags(entry.Tags)
+			if err != nil {
+				return importEntryError(i, err)
+			}
+
+			// A task already filed under a project stays there
+			if entry.Project != "" && dbTask.ProjectID == nil {
+				projectID, err := importProject(ctx, repo, tree, entry.Project, result)
+				if err != nil {
+					return importEntryError(i, err)
+				}
+				dbTask.ProjectID = projectID
+				if err := repo.UpdateTask(ctx, dbTask); err != nil {
+					return err
+				}
+			}
+
+			dbEntry := &sqlite.TimeEntry{
+				TaskID:    taskID,
+				StartTime: entry.StartTime,
+				EndTime:   entry.EndTime,
+				Note:      note,
+			}
+			if err := repo.CreateTimeEntry(ctx, dbEntry); err != nil {
+				return err
+			}
+			if len(tags) > 0 {
+				if err := repo.SetTimeEntryTags(ctx, dbEntry.ID, tags); err != nil {
+					return err
+				}
+			}
+			existing[key] = true
+			result.Imported++
+		}
+
+		if dryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+	if err != nil && !stderrors.Is(err, errImportDryRun) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// importProject returns the ID of the project at path, creating it and any missing parents. Archived
+// projects are used as they are, since the imported time may well predate the archive.
+func importProject(ctx context.Context, repo sqlite.Repository, tree *projectTree, path string, result *ImportResult) (*int64, error) {
+	segments, err := splitProjectPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *int64
+	for _, name := range segments {
+		if existing := tree.child(parentID, name); existing != nil {
+			parentID = &existing.ID
+			continue
+		}
+
+		dbProject := &sqlite.Project{Name: name, ParentID: parentID}
+		if err := repo.CreateProject(ctx, dbProject); err != nil {
+			return nil, err
+		}
+		tree.add(dbProject)
+		parentID = &dbProject.ID
+		result.CreatedProjects = append(result.CreatedProjects, tree.path(dbProject.ID))
+	}
+	return parentID, nil
+}
+
+// importEntryKey identifies an entry by task and times at the one-second precision stored in the database
+func importEntryKey(taskID int64, start time.Time, end *time.Time) string {
+	endKey := "running"
+	if end != nil {
+		endKey = fmt.Sprintf("%d", end.Unix())
+	}
+	return fmt.Sprintf("%d/%d/%s", taskID, start.Unix(), endKey)
+}
+
+// importEntryError wraps an error with the position of the offending entry in the import
+func importEntryError(index int, err error) error {
+	reason := errors.GetUserMessage(err)
+	if validationErr, ok := err.(*validation.ValidationError); ok {
+		reason = validationErr.GetUserFriendlyMessage()
+	}
+	return errors.NewValidationError(fmt.Sprintf("entry %d cannot be imported: %s", index+1, reason), err).
+		WithContext("entry", index+1)
+}
+
 // DeleteTaskWithEntries deletes a task and all its time entries
 func (t *taskServiceImpl) DeleteTaskWithEntries(ctx context.Context, id int64) error {
+	return t.journal(ctx, OperationDelete, func(tx *taskServiceImpl) (string, error) {
+		task, deleted, err := tx.deleteTaskWithEntries(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Deleted task %q and %d time entries", task.TaskName, deleted), nil
+	})
+}
+
+// deleteTaskWithEntries does the work of DeleteTaskWithEntries within a journaled operation
+func (t *taskServiceImpl) deleteTaskWithEntries(ctx context.Context, id int64) (*domain.Task, int, error) {
 	// Validate task ID
 	if id <= 0 {
-		return errors.NewValidationError("invalid task ID", nil)
+		return nil, 0, errors.NewValidationError("invalid task ID", nil)
 	}
 
 	// Check if task exists
-	_, err := t.repo.GetTask(ctx, id)
+	task, err := t.GetTask(ctx, id)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	// Delete all time entries for this task
@@ -149,60 +476,332 @@ func (t *taskServiceImpl) DeleteTaskWithEntries(ctx context.Context, id int64) e
 	
 	entries, err := t.repo.SearchTimeEntries(ctx, searchOpts)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	for _, entry := range entries {
 		err = t.repo.DeleteTimeEntry(ctx, entry.ID)
 		if err != nil {
-			return err
+			return nil, 0, err
 		}
 	}
 
 	// Delete the task
-	return t.repo.DeleteTask(ctx, id)
+	if err := t.repo.DeleteTask(ctx, id); err != nil {
+		return nil, 0, err
+	}
+	return task, len(entries), nil
+}
+
+// FindOrCreateTask returns the task with the exact given name, creating it if it does not exist
+func (t *taskServiceImpl) FindOrCreateTask(ctx context.Context, name string) (*domain.Task, error) {
+	// Validate task name
+	trimmedName, err := t.validateAndTrimTaskName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to find existing task first
+	task, err := t.findTaskByName(ctx, trimmedName)
+	if err != nil {
+		return nil, err
+	}
+	if task != nil {
+		return task, nil
+	}
+
+	// Create new task if not found
+	return t.CreateTask(ctx, trimmedName)
+}
+
+// ArchiveTask archives a task so it no longer shows up in task searches; its time still counts in reports
+func (t *taskServiceImpl) ArchiveTask(ctx context.Context, id int64) (*domain.Task, error) {
+	var task *domain.Task
+	err := t.journal(ctx, OperationArchive, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		task, err = tx.setTaskArchived(ctx, id, true)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Archived task %q", task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// UnarchiveTask brings an archived task back into task searches
+func (t *taskServiceImpl) UnarchiveTask(ctx context.Context, id int64) (*domain.Task, error) {
+	var task *domain.Task
+	err := t.journal(ctx, OperationUnarchive, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		task, err = tx.setTaskArchived(ctx, id, false)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Unarchived task %q", task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// setTaskArchived does the work of ArchiveTask and UnarchiveTask within a journaled operation
+func (t *taskServiceImpl) setTaskArchived(ctx context.Context, id int64, archived bool) (*domain.Task, error) {
+	// Validate task ID
+	if id <= 0 {
+		return nil, errors.NewValidationError("invalid task ID", nil)
+	}
+
+	dbTask, err := t.repo.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if archived {
+		if dbTask.ArchivedAt != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("task %q is already archived", dbTask.TaskName), nil)
+		}
+		running, err := t.repo.SearchTimeEntries(ctx, sqlite.SearchOptions{TaskID: &id})
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range running {
+			if entry.EndTime == nil {
+				return nil, errors.NewValidationError(fmt.Sprintf("task %q is running; stop it before archiving", dbTask.TaskName), nil)
+			}
+		}
+		now := time.Now()
+		dbTask.ArchivedAt = &now
+	} else {
+		if dbTask.ArchivedAt == nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("task %q is not archived", dbTask.TaskName), nil)
+		}
+		dbTask.ArchivedAt = nil
+	}
+
+	if err := t.repo.UpdateTask(ctx, dbTask); err != nil {
+		return nil, err
+	}
+
+	domainTask := t.mapper.Task.FromDatabase(*dbTask)
+	return &domainTask, nil
+}
+
+// ArchiveInactiveTasks archives every task whose time entries all ended before the cutoff, returning the
+// tasks it archived. Tasks without entries and running tasks are left alone. This is housekeeping rather
+// than a user action, so it is not journaled: undoing it would only archive the tasks again on the next run.
+func (t *taskServiceImpl) ArchiveInactiveTasks(ctx context.Context, before time.Time) ([]*domain.Task, error) {
+	var archived []*domain.Task
+	err := t.repo.WithTransaction(ctx, func(repo sqlite.Repository) error {
+		dbTasks, err := repo.ListTasks(ctx)
+		if err != nil {
+			return err
+		}
+		dbEntries, err := repo.ListTimeEntries(ctx)
+		if err != nil {
+			return err
+		}
+
+		// A task was last touched when its latest entry ended; running entries keep it active
+		lastActive := make(map[int64]time.Time)
+		running := make(map[int64]bool)
+		for _, entry := range dbEntries {
+			if entry.EndTime == nil {
+				running[entry.TaskID] = true
+				continue
+			}
+			if entry.EndTime.After(lastActive[entry.TaskID]) {
+				lastActive[entry.TaskID] = *entry.EndTime
+			}
+		}
+
+		now := time.Now()
+		for _, dbTask := range dbTasks {
+			last, hasEntries := lastActive[dbTask.ID]
+			if dbTask.ArchivedAt != nil || !hasEntries || running[dbTask.ID] || !last.Before(before) {
+				continue
+			}
+			dbTask.ArchivedAt = &now
+			if err := repo.UpdateTask(ctx, dbTask); err != nil {
+				return err
+			}
+			domainTask := t.mapper.Task.FromDatabase(*dbTask)
+			archived = append(archived, &domainTask)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return archived, nil
+}
+
+// unarchiveForWork brings an archived task back when work on it starts again
+func (t *taskServiceImpl) unarchiveForWork(ctx context.Context, task *domain.Task) error {
+	if task.ArchivedAt == nil {
+		return nil
+	}
+	task.ArchivedAt = nil
+	dbTask := t.mapper.Task.ToDatabase(*task)
+	return t.repo.UpdateTask(ctx, &dbTask)
 }
 
 // StartNewTask creates or finds a task and starts a new time entry for it, stopping any running tasks
 func (t *taskServiceImpl) StartNewTask(ctx context.Context, name string) (*TaskSession, error) {
+	return t.StartNewTaskWithOptions(ctx, name, StartOptions{})
+}
+
+// StartNewTaskWithOptions works like StartNewTask, and when a project is given files the task under it
+func (t *taskServiceImpl) StartNewTaskWithOptions(ctx context.Context, name string, opts StartOptions) (*TaskSession, error) {
+	var session *TaskSession
+	err := t.journal(ctx, OperationStart, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		session, err = tx.startNewTask(ctx, name, opts)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Started %q", session.Task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// startNewTask does the work of StartNewTaskWithOptions within a journaled operation
+func (t *taskServiceImpl) startNewTask(ctx context.Context, name string, opts StartOptions) (*TaskSession, error) {
 	// Validate task name
 	trimmedName, err := t.validateAndTrimTaskName(name)
 	if err != nil {
 		return nil, err
 	}
 
-	// Stop all running tasks first
-	_, err = t.StopAllRunningTasks(ctx)
+	// Resolve the project before changing anything
+	var projectID *int64
+	if opts.Project != "" {
+		projectID, err = t.findActiveProjectID(ctx, opts.Project)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tags, err := normalizeTags(opts.Tags)
 	if err != nil {
 		return nil, err
 	}
 
-	// Try to find existing task first
-	task, err := t.findTaskByName(ctx, trimmedName)
+	note, err := t.validateNote(opts.Note)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create new task if not found
-	if task == nil {
-		task, err = t.CreateTask(ctx, trimmedName)
-		if err != nil {
+	at := time.Now()
+	if opts.At != nil {
+		at = *opts.At
+	}
+
+	// Stop all running tasks first, when the new one starts
+	_, err = t.StopAllRunningTasksAt(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the existing task or create a new one
+	task, err := t.FindOrCreateTask(ctx, trimmedName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Starting an archived task brings it back
+	if err := t.unarchiveForWork(ctx, task); err != nil {
+		return nil, err
+	}
+
+	// Move the task into the requested project
+	if projectID != nil && (task.ProjectID == nil || *task.ProjectID != *projectID) {
+		task.ProjectID = projectID
+		dbTask := t.mapper.Task.ToDatabase(*task)
+		if err := t.repo.UpdateTask(ctx, &dbTask); err != nil {
 			return nil, err
 		}
 	}
 
 	// Create new time entry
-	timeEntry, err := t.timeService.CreateTimeEntry(ctx, task.ID)
+	timeEntry, err := t.timeService.CreateTimeEntryAt(ctx, task.ID, at)
 	if err != nil {
 		return nil, err
 	}
 
+	// Tag the new time entry
+	if len(tags) > 0 {
+		if err := t.repo.SetTimeEntryTags(ctx, timeEntry.ID, tags); err != nil {
+			return nil, err
+		}
+		timeEntry.Tags = tags
+	}
+
+	// Annotate the new time entry
+	if note != "" {
+		timeEntry.Note = note
+		dbEntry := t.mapper.TimeEntry.ToDatabase(*timeEntry)
+		if err := t.repo.UpdateTimeEntry(ctx, &dbEntry); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create task session
 	return t.CreateTaskSession(task, timeEntry), nil
 }
 
+// findActiveProjectID looks up the project at path, rejecting archived projects
+func (t *taskServiceImpl) findActiveProjectID(ctx context.Context, path string) (*int64, error) {
+	segments, err := splitProjectPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := loadProjectTree(ctx, t.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	dbProject := tree.find(segments)
+	if dbProject == nil {
+		return nil, errors.NewNotFoundError("project", strings.Join(segments, domain.ProjectPathSeparator))
+	}
+	if tree.isArchived(dbProject.ID) {
+		return nil, errors.NewValidationError(fmt.Sprintf("project %q is archived", tree.path(dbProject.ID)), nil)
+	}
+	return &dbProject.ID, nil
+}
+
 // ResumeTask resumes work on an existing task by creating a new time entry, stopping any running tasks
 func (t *taskServiceImpl) ResumeTask(ctx context.Context, id int64) (*TaskSession, error) {
+	return t.ResumeTaskAt(ctx, id, time.Now())
+}
+
+// ResumeTaskAt works like ResumeTask, with work on the task resuming at the given time
+func (t *taskServiceImpl) ResumeTaskAt(ctx context.Context, id int64, at time.Time) (*TaskSession, error) {
+	var session *TaskSession
+	err := t.journal(ctx, OperationResume, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		session, err = tx.resumeTask(ctx, id, at)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Resumed %q", session.Task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// resumeTask does the work of ResumeTaskAt within a journaled operation
+func (t *taskServiceImpl) resumeTask(ctx context.Context, id int64, at time.Time) (*TaskSession, error) {
 	// Validate task ID
 	if id <= 0 {
 		return nil, errors.NewValidationError("invalid task ID", nil)
@@ -214,14 +813,19 @@ func (t *taskServiceImpl) ResumeTask(ctx context.Context, id int64) (*TaskSessio
 		return nil, err
 	}
 
-	// Stop all running tasks first
-	_, err = t.StopAllRunningTasks(ctx)
+	// Stop all running tasks first, when the task resumes
+	_, err = t.StopAllRunningTasksAt(ctx, at)
 	if err != nil {
 		return nil, err
 	}
 
+	// Resuming an archived task brings it back
+	if err := t.unarchiveForWork(ctx, task); err != nil {
+		return nil, err
+	}
+
 	// Create new time entry
-	timeEntry, err := t.timeService.CreateTimeEntry(ctx, task.ID)
+	timeEntry, err := t.timeService.CreateTimeEntryAt(ctx, task.ID, at)
 	if err != nil {
 		return nil, err
 	}
@@ -230,6 +834,157 @@ func (t *taskServiceImpl) ResumeTask(ctx context.Context, id int64) (*TaskSessio
 	return t.CreateTaskSession(task, timeEntry), nil
 }
 
+// AddTimeEntry records a completed time entry for a task after the fact, creating the task if needed
+func (t *taskServiceImpl) AddTimeEntry(ctx context.Context, name string, start time.Time, end time.Time) (*TaskSession, error) {
+	var session *TaskSession
+	err := t.journal(ctx, OperationAdd, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		session, err = tx.addTimeEntry(ctx, name, start, end)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Added entry %d to %q", session.TimeEntry.ID, session.Task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// addTimeEntry does the work of AddTimeEntry within a journaled operation
+func (t *taskServiceImpl) addTimeEntry(ctx context.Context, name string, start time.Time, end time.Time) (*TaskSession, error) {
+	// Validate task name
+	trimmedName, err := t.validateAndTrimTaskName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to find existing task first
+	task, err := t.findTaskByName(ctx, trimmedName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create new task if not found, remembering to remove it if the entry is rejected
+	createdTask := false
+	if task == nil {
+		task, err = t.CreateTask(ctx, trimmedName)
+		if err != nil {
+			return nil, err
+		}
+		createdTask = true
+	}
+
+	// Create the completed time entry
+	timeEntry, err := t.timeService.CreateCompletedTimeEntry(ctx, task.ID, start, end)
+	if err != nil {
+		if createdTask {
+			if deleteErr := t.repo.DeleteTask(ctx, task.ID); deleteErr != nil {
+				return nil, deleteErr
+			}
+		}
+		return nil, err
+	}
+
+	// Create task session
+	return t.CreateTaskSession(task, timeEntry), nil
+}
+
+// EditTimeEntry adjusts the times of an existing entry or reassigns it to another task
+func (t *taskServiceImpl) EditTimeEntry(ctx context.Context, entryID int64, update TimeEntryUpdate) (*TimeEntryEdit, error) {
+	var edit *TimeEntryEdit
+	err := t.journal(ctx, OperationEdit, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		edit, err = tx.editTimeEntry(ctx, entryID, update)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Edited entry %d of %q", entryID, edit.After.Task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edit, nil
+}
+
+// editTimeEntry does the work of EditTimeEntry within a journaled operation
+func (t *taskServiceImpl) editTimeEntry(ctx context.Context, entryID int64, update TimeEntryUpdate) (*TimeEntryEdit, error) {
+	// Get the entry and its current task
+	entry, err := t.timeService.GetTimeEntry(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := t.GetTask(ctx, entry.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	before := t.createTimeEntryWithTask(task, entry)
+
+	// Apply the requested changes to a copy of the entry
+	updated := *entry
+	if update.StartTime != nil {
+		updated.StartTime = *update.StartTime
+	}
+	if update.EndTime != nil {
+		endTime := *update.EndTime
+		updated.EndTime = &endTime
+	}
+	if update.Note != nil {
+		note, err := t.validateNote(*update.Note)
+		if err != nil {
+			return nil, err
+		}
+		updated.Note = note
+	}
+
+	// Resolve the target task, remembering to remove it if the edit is rejected
+	newTask := task
+	createdTask := false
+	if update.TaskName != nil {
+		trimmedName, err := t.validateAndTrimTaskName(*update.TaskName)
+		if err != nil {
+			return nil, err
+		}
+		newTask, err = t.findTaskByName(ctx, trimmedName)
+		if err != nil {
+			return nil, err
+		}
+		if newTask == nil {
+			newTask, err = t.CreateTask(ctx, trimmedName)
+			if err != nil {
+				return nil, err
+			}
+			createdTask = true
+		}
+		updated.TaskID = newTask.ID
+	}
+
+	// Save the entry
+	saved, err := t.timeService.UpdateTimeEntry(ctx, &updated)
+	if err != nil {
+		if createdTask {
+			if deleteErr := t.repo.DeleteTask(ctx, newTask.ID); deleteErr != nil {
+				return nil, deleteErr
+			}
+		}
+		return nil, err
+	}
+
+	return &TimeEntryEdit{
+		Before: before,
+		After:  t.createTimeEntryWithTask(newTask, saved),
+	}, nil
+}
+
+// createTimeEntryWithTask pairs a time entry with its task and formatted duration
+func (t *taskServiceImpl) createTimeEntryWithTask(task *domain.Task, entry *domain.TimeEntry) *TimeEntryWithTask {
+	return &TimeEntryWithTask{
+		TimeEntry: entry,
+		Task:      task,
+		Duration:  t.timeService.CalculateDuration(entry.StartTime, entry.EndTime),
+	}
+}
+
 // GetCurrentSession returns the currently running task session, if any
 func (t *taskServiceImpl) GetCurrentSession(ctx context.Context) (*TaskSession, error) {
 	// Get running time entries
@@ -244,6 +999,9 @@ func (t *taskServiceImpl) GetCurrentSession(ctx context.Context) (*TaskSession,
 
 	// Use the most recent running entry
 	entry := runningEntries[0]
+	if err := attachTags(ctx, t.repo, []*domain.TimeEntry{entry}); err != nil {
+		return nil, err
+	}
 	
 	// Get the task for this entry
 	task, err := t.GetTask(ctx, entry.TaskID)
@@ -255,6 +1013,144 @@ func (t *taskServiceImpl) GetCurrentSession(ctx context.Context) (*TaskSession,
 	return t.CreateTaskSession(task, entry), nil
 }
 
+// PauseTask ends the running entry as a pause, so ContinueTask can reopen the same task as a linked segment.
+// Only the most recent pause can be continued; pausing again forgets any earlier pause.
+func (t *taskServiceImpl) PauseTask(ctx context.Context) (*TaskSession, error) {
+	var session *TaskSession
+	err := t.journal(ctx, OperationPause, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		session, err = tx.pauseTask(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Paused %q", session.Task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// pauseTask does the work of PauseTask within a journaled operation
+func (t *taskServiceImpl) pauseTask(ctx context.Context) (*TaskSession, error) {
+	session, err := t.GetCurrentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, errors.NewValidationError("no task is running", nil)
+	}
+
+	stopped, err := t.timeService.StopRunningEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paused := session.TimeEntry
+	for _, entry := range stopped {
+		if entry.ID == session.TimeEntry.ID {
+			paused = entry
+		}
+	}
+
+	// Forget earlier pauses before marking this one
+	if err := t.clearPauses(ctx); err != nil {
+		return nil, err
+	}
+	paused.Paused = true
+	dbEntry := t.mapper.TimeEntry.ToDatabase(*paused)
+	if err := t.repo.UpdateTimeEntry(ctx, &dbEntry); err != nil {
+		return nil, err
+	}
+	if err := attachTags(ctx, t.repo, []*domain.TimeEntry{paused}); err != nil {
+		return nil, err
+	}
+
+	return t.CreateTaskSession(session.Task, paused), nil
+}
+
+// ContinueTask starts a new segment of the most recently paused entry, linked to it and carrying its tags,
+// stopping any running tasks
+func (t *taskServiceImpl) ContinueTask(ctx context.Context) (*TaskSession, error) {
+	var session *TaskSession
+	err := t.journal(ctx, OperationContinue, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		session, err = tx.continueTask(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Continued %q", session.Task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// continueTask does the work of ContinueTask within a journaled operation
+func (t *taskServiceImpl) continueTask(ctx context.Context) (*TaskSession, error) {
+	pausedEntries, err := t.repo.SearchTimeEntries(ctx, sqlite.SearchOptions{PausedOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(pausedEntries) == 0 {
+		return nil, errors.NewValidationError("no task is paused", nil)
+	}
+	paused := t.mapper.TimeEntry.FromDatabase(*pausedEntries[len(pausedEntries)-1])
+
+	task, err := t.GetTask(ctx, paused.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachTags(ctx, t.repo, []*domain.TimeEntry{&paused}); err != nil {
+		return nil, err
+	}
+
+	// Stop all running tasks first
+	_, err = t.StopAllRunningTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the new segment and link it to the paused one
+	timeEntry, err := t.timeService.CreateTimeEntry(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	timeEntry.ContinuesID = &paused.ID
+	dbEntry := t.mapper.TimeEntry.ToDatabase(*timeEntry)
+	if err := t.repo.UpdateTimeEntry(ctx, &dbEntry); err != nil {
+		return nil, err
+	}
+	if len(paused.Tags) > 0 {
+		if err := t.repo.SetTimeEntryTags(ctx, timeEntry.ID, paused.Tags); err != nil {
+			return nil, err
+		}
+		timeEntry.Tags = paused.Tags
+	}
+
+	if err := t.clearPauses(ctx); err != nil {
+		return nil, err
+	}
+
+	return t.CreateTaskSession(task, timeEntry), nil
+}
+
+// clearPauses marks every paused entry as no longer waiting to be continued
+func (t *taskServiceImpl) clearPauses(ctx context.Context) error {
+	pausedEntries, err := t.repo.SearchTimeEntries(ctx, sqlite.SearchOptions{PausedOnly: true})
+	if err != nil {
+		return err
+	}
+	for _, entry := range pausedEntries {
+		entry.Paused = false
+		if err := t.repo.UpdateTimeEntry(ctx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CreateTaskSession creates a TaskSession from a task and time entry
 func (t *taskServiceImpl) CreateTaskSession(task *domain.Task, entry *domain.TimeEntry) *TaskSession {
 	duration := t.timeService.CalculateDuration(entry.StartTime, entry.EndTime)
@@ -268,5 +1164,206 @@ func (t *taskServiceImpl) CreateTaskSession(task *domain.Task, entry *domain.Tim
 
 // StopAllRunningTasks stops all currently running tasks
 func (t *taskServiceImpl) StopAllRunningTasks(ctx context.Context) ([]*domain.TimeEntry, error) {
-	return t.timeService.StopRunningEntries(ctx)
+	return t.StopAllRunningTasksAt(ctx, time.Now())
+}
+
+// StopAllRunningTasksAt stops all currently running tasks at the given time
+func (t *taskServiceImpl) StopAllRunningTasksAt(ctx context.Context, at time.Time) ([]*domain.TimeEntry, error) {
+	var stopped []*domain.TimeEntry
+	err := t.journal(ctx, OperationStop, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		stopped, err = tx.timeService.StopRunningEntriesAt(ctx, at)
+		if err != nil || len(stopped) == 0 {
+			return "", err
+		}
+		if err := attachTags(ctx, tx.repo, stopped); err != nil {
+			return "", err
+		}
+		if len(stopped) > 1 {
+			return fmt.Sprintf("Stopped %d running entries", len(stopped)), nil
+		}
+		task, err := tx.GetTask(ctx, stopped[0].TaskID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Stopped %q", task.TaskName), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stopped, nil
+}
+
+// FindForgottenTimer returns the running entry that has gone on for longer than after, counting from when
+// the user last chose to keep it running, or nil when no entry looks forgotten
+func (t *taskServiceImpl) FindForgottenTimer(ctx context.Context, after time.Duration) (*ForgottenTimer, error) {
+	if after <= 0 {
+		return nil, errors.NewInvalidInputError("after", after.String(), "threshold must be positive")
+	}
+
+	runningEntries, err := t.timeService.GetRunningEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, entry := range runningEntries {
+		since := entry.StartTime
+		if entry.KeptRunningAt != nil && entry.KeptRunningAt.After(since) {
+			since = *entry.KeptRunningAt
+		}
+		if now.Sub(since) <= after {
+			continue
+		}
+
+		task, err := t.GetTask(ctx, entry.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		lastActivity, err := t.lastActivity(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		running := now.Sub(entry.StartTime)
+		return &ForgottenTimer{
+			Task:           task,
+			TimeEntry:      entry,
+			Running:        running,
+			RunningSeconds: int64(running.Seconds()),
+			LastActivity:   lastActivity,
+		}, nil
+	}
+	return nil, nil
+}
+
+// passiveOperations are journaled without showing that anyone was at work while a timer ran: starting,
+// resuming and continuing stop the running timer, so they only ever begin one, and archiving and resolving
+// forgotten timers are done by tt on its own
+var passiveOperations = map[string]bool{
+	OperationStart:     true,
+	OperationResume:    true,
+	OperationContinue:  true,
+	OperationArchive:   true,
+	OperationForgotten: true,
+}
+
+// lastActivity returns the last time someone was seen at work while entry ran: when they kept it running,
+// journaled a change other than the passive ones, such as a note or an added entry, or undid one.
+// It returns nil when nothing happened after the entry started.
+func (t *taskServiceImpl) lastActivity(ctx context.Context, entry *domain.TimeEntry) (*time.Time, error) {
+	var last *time.Time
+	seen := func(at time.Time) {
+		if at.After(entry.StartTime) && (last == nil || at.After(*last)) {
+			last = &at
+		}
+	}
+
+	if entry.KeptRunningAt != nil {
+		seen(*entry.KeptRunningAt)
+	}
+	operations, err := t.repo.ListOperations(ctx, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, operation := range operations {
+		if !passiveOperations[operation.Kind] {
+			seen(operation.CreatedAt)
+		}
+		if operation.UndoneAt != nil {
+			seen(*operation.UndoneAt)
+		}
+	}
+	return last, nil
+}
+
+// ResolveForgottenTimer stops a forgotten running entry at the given time, or records that the user
+// wants it kept running, journaling the decision so it can be undone
+func (t *taskServiceImpl) ResolveForgottenTimer(ctx context.Context, entryID int64, resolution ForgottenTimerResolution) (*TimeEntryWithTask, error) {
+	var result *TimeEntryWithTask
+	err := t.journal(ctx, OperationForgotten, func(tx *taskServiceImpl) (string, error) {
+		var err error
+		result, err = tx.resolveForgottenTimer(ctx, entryID, resolution)
+		if err != nil {
+			return "", err
+		}
+
+		name := result.Task.TaskName
+		switch resolution.Action {
+		case ForgottenTimerKeep:
+			running := result.TimeEntry.KeptRunningAt.Sub(result.TimeEntry.StartTime)
+			return fmt.Sprintf("Kept forgotten %q running after %s", name, t.timeService.FormatDuration(running)), nil
+		case ForgottenTimerCap:
+			return fmt.Sprintf("Capped forgotten %q at %s", name, result.Duration), nil
+		default:
+			return fmt.Sprintf("Stopped forgotten %q after %s", name, result.Duration), nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// resolveForgottenTimer does the work of ResolveForgottenTimer within a journaled operation
+func (t *taskServiceImpl) resolveForgottenTimer(ctx context.Context, entryID int64, resolution ForgottenTimerResolution) (*TimeEntryWithTask, error) {
+	entry, err := t.timeService.GetTimeEntry(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.EndTime != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("entry %d is not running", entryID), nil).WithContext("entry_id", entryID)
+	}
+
+	now := time.Now()
+	switch resolution.Action {
+	case ForgottenTimerKeep:
+		entry.KeptRunningAt = &now
+	case ForgottenTimerStop, ForgottenTimerCap:
+		if resolution.At == nil {
+			return nil, errors.NewInvalidInputError("at", "", "a stop time is required to "+resolution.Action+" a forgotten entry")
+		}
+		at := *resolution.At
+		if at.After(now) {
+			return nil, errors.NewValidationError("stop time cannot be in the future", nil).WithContext("at", at)
+		}
+		if at.Before(entry.StartTime) {
+			return nil, errors.NewValidationError(fmt.Sprintf("cannot stop entry %d at %s, before it started at %s",
+				entryID, at.Local().Format("2006-01-02 15:04:05"), entry.StartTime.Local().Format("2006-01-02 15:04:05")), nil).WithContext("entry_id", entryID)
+		}
+		entry.EndTime = &at
+	default:
+		return nil, errors.NewInvalidInputError("action", resolution.Action, "expected stop, cap or keep")
+	}
+
+	dbEntry := t.mapper.TimeEntry.ToDatabase(*entry)
+	if err := t.repo.UpdateTimeEntry(ctx, &dbEntry); err != nil {
+		return nil, err
+	}
+
+	task, err := t.GetTask(ctx, entry.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	return t.createTimeEntryWithTask(task, entry), nil
+}
+
+// withRepository returns the service working on repo instead of its own repository
+func (t *taskServiceImpl) withRepository(repo sqlite.Repository) *taskServiceImpl {
+	if repo == t.repo {
+		return t
+	}
+	return &taskServiceImpl{
+		repo:          repo,
+		timeService:   NewTimeService(repo),
+		mapper:        t.mapper,
+		taskValidator: t.taskValidator,
+	}
+}
+
+// journal runs fn as a single operation of the operations journal, on a copy of the service
+// whose changes are recorded so the operation can be undone
+func (t *taskServiceImpl) journal(ctx context.Context, kind string, fn func(tx *taskServiceImpl) (string, error)) error {
+	return recordOperation(ctx, t.repo, kind, func(repo sqlite.Repository) (string, error) {
+		return fn(t.withRepository(repo))
+	})
 }
\ No newline at end of file
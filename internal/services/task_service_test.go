@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 	"time-tracker/internal/domain"
@@ -178,6 +179,30 @@ func TestTaskService_UpdateTask(t *testing.T) {
 				assert.Contains(t, err.Error(), "name")
 			},
 		},
+		{
+			name:    "should reject name used by another task",
+			taskID:  1,
+			newName: "Other Task",
+			setupTasks: []*domain.Task{
+				{TaskName: "Original Task"},
+				{TaskName: "Other Task"},
+			},
+			errorAssertion: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				var appErr *errors.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.True(t, appErr.IsType(errors.ErrorTypeValidation))
+				assert.Contains(t, err.Error(), "merge")
+			},
+		},
+		{
+			name:    "should allow keeping the same name",
+			taskID:  1,
+			newName: "Original Task",
+			setupTasks: []*domain.Task{
+				{TaskName: "Original Task"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -286,6 +311,302 @@ func TestTaskService_DeleteTaskWithEntries(t *testing.T) {
 	}
 }
 
+func TestTaskService_GetTaskByName(t *testing.T) {
+	service, repo := setupTaskServiceWithData(t, []*domain.Task{{TaskName: "Code review"}}, nil)
+	defer repo.Close()
+	ctx := context.Background()
+
+	task, err := service.GetTaskByName(ctx, "  Code review ")
+	require.NoError(t, err)
+	assert.Equal(t, "Code review", task.TaskName)
+
+	_, err = service.GetTaskByName(ctx, "code review")
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.True(t, appErr.IsType(errors.ErrorTypeNotFound))
+}
+
+func TestTaskService_MergeTasks(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		fromIndex      int
+		intoIndex      int
+		fromID         int64 // Used instead of fromIndex when non-zero
+		expectedMoved  int
+		errorAssertion func(t *testing.T, err error)
+	}{
+		{
+			name:          "should move entries and delete source task",
+			fromIndex:     0,
+			intoIndex:     1,
+			expectedMoved: 2,
+		},
+		{
+			name:          "should delete source task without entries",
+			fromIndex:     2,
+			intoIndex:     1,
+			expectedMoved: 0,
+		},
+		{
+			name:      "should reject merging a task into itself",
+			fromIndex: 1,
+			intoIndex: 1,
+			errorAssertion: func(t *testing.T, err error) {
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.True(t, appErr.IsType(errors.ErrorTypeValidation))
+			},
+		},
+		{
+			name:      "should return not found error for non-existent source",
+			fromID:    999,
+			intoIndex: 1,
+			errorAssertion: func(t *testing.T, err error) {
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.True(t, appErr.IsType(errors.ErrorTypeNotFound))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tasks := []*domain.Task{
+				{TaskName: "code reveiw"},
+				{TaskName: "Code review"},
+				{TaskName: "Empty"},
+			}
+			service, repo := setupTaskServiceWithData(t, tasks, nil)
+			defer repo.Close()
+			ctx := context.Background()
+
+			for i, start := range []time.Time{now.Add(-3 * time.Hour), now.Add(-2 * time.Hour), now.Add(-1 * time.Hour)} {
+				taskID := tasks[0].ID
+				if i == 2 {
+					taskID = tasks[1].ID
+				}
+				err := repo.CreateTimeEntry(ctx, &sqlite.TimeEntry{TaskID: taskID, StartTime: start, EndTime: timePtr(start.Add(30 * time.Minute))})
+				require.NoError(t, err)
+			}
+
+			fromID := tt.fromID
+			if fromID == 0 {
+				fromID = tasks[tt.fromIndex].ID
+			}
+			intoID := tasks[tt.intoIndex].ID
+
+			// Act
+			result, err := service.MergeTasks(ctx, fromID, intoID)
+
+			// Assert
+			if tt.errorAssertion != nil {
+				tt.errorAssertion(t, err)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fromID, result.From.ID)
+			assert.Equal(t, intoID, result.Into.ID)
+			assert.Equal(t, tt.expectedMoved, result.MovedEntries)
+
+			_, err = service.GetTask(ctx, fromID)
+			var appErr *errors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.True(t, appErr.IsType(errors.ErrorTypeNotFound))
+
+			entries, err := repo.SearchTimeEntries(ctx, sqlite.SearchOptions{TaskID: &intoID})
+			require.NoError(t, err)
+			assert.Len(t, entries, tt.expectedMoved+1)
+		})
+	}
+}
+
+func TestTaskService_ImportTimeEntries(t *testing.T) {
+	base := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name                 string
+		entries              []ImportEntry
+		dryRun               bool
+		expectedImported     int
+		expectedDuplicates   int
+		expectedSkipped      []string // Reasons for leaving out entries, in import order
+		expectedCreatedTasks []string
+		expectedEntries      int
+		expectedTasks        int
+		errorAssertion       func(t *testing.T, err error)
+	}{
+		{
+			name: "should import entries and create missing tasks",
+			entries: []ImportEntry{
+				{TaskName: "Existing", StartTime: base.Add(2 * time.Hour), EndTime: timePtr(base.Add(3 * time.Hour))},
+				{TaskName: "Imported", StartTime: base.Add(4 * time.Hour), EndTime: timePtr(base.Add(5 * time.Hour))},
+				{TaskName: "Imported", StartTime: base.Add(6 * time.Hour), EndTime: timePtr(base.Add(7 * time.Hour))},
+			},
+			expectedImported:     3,
+			expectedCreatedTasks: []string{"Imported"},
+			expectedEntries:      4,
+			expectedTasks:        2,
+		},
+		{
+			name: "should skip entries that already exist",
+			entries: []ImportEntry{
+				{TaskName: "Existing", StartTime: base, EndTime: timePtr(base.Add(time.Hour))},
+				{TaskName: "Existing", StartTime: base.Add(2 * time.Hour), EndTime: timePtr(base.Add(3 * time.Hour))},
+				{TaskName: "Existing", StartTime: base.Add(2 * time.Hour), EndTime: timePtr(base.Add(3 * time.Hour))},
+			},
+			expectedImported:     1,
+			expectedDuplicates:   2,
+			expectedCreatedTasks: []string{},
+			expectedEntries:      2,
+			expectedTasks:        1,
+		},
+		{
+			name: "should skip entries still running",
+			entries: []ImportEntry{
+				{TaskName: "Imported", StartTime: base.Add(4 * time.Hour)},
+				{TaskName: "Existing", StartTime: base.Add(6 * time.Hour), EndTime: timePtr(base.Add(7 * time.Hour))},
+			},
+			expectedImported:     1,
+			expectedSkipped:      []string{"it is still running"},
+			expectedCreatedTasks: []string{},
+			expectedEntries:      2,
+			expectedTasks:        1,
+		},
+		{
+			name: "should skip entries overlapping tracked or imported time",
+			entries: []ImportEntry{
+				{TaskName: "Imported", StartTime: base.Add(30 * time.Minute), EndTime: timePtr(base.Add(90 * time.Minute))},
+				{TaskName: "Imported", StartTime: base.Add(2 * time.Hour), EndTime: timePtr(base.Add(3 * time.Hour))},
+				{TaskName: "Existing", StartTime: base.Add(150 * time.Minute), EndTime: timePtr(base.Add(4 * time.Hour))},
+			},
+			expectedImported:     1,
+			expectedSkipped:      []string{"it overlaps entry 1", "it overlaps entry 2"},
+			expectedCreatedTasks: []string{"Imported"},
+			expectedEntries:      2,
+			expectedTasks:        2,
+		},
+		{
+			name: "should report counts without writing on dry run",
+			entries: []ImportEntry{
+				{TaskName: "Imported", StartTime: base.Add(4 * time.Hour), EndTime: timePtr(base.Add(5 * time.Hour))},
+				{TaskName: "Existing", StartTime: base, EndTime: timePtr(base.Add(time.Hour))},
+				{TaskName: "Existing", StartTime: base.Add(6 * time.Hour)},
+			},
+			dryRun:               true,
+			expectedImported:     1,
+			expectedDuplicates:   1,
+			expectedSkipped:      []string{"it is still running"},
+			expectedCreatedTasks: []string{"Imported"},
+			expectedEntries:      1,
+			expectedTasks:        1,
+		},
+		{
+			name: "should roll back everything when an entry is invalid",
+			entries: []ImportEntry{
+				{TaskName: "Imported", StartTime: base.Add(4 * time.Hour), EndTime: timePtr(base.Add(5 * time.Hour))},
+				{TaskName: "Imported", StartTime: base.Add(7 * time.Hour), EndTime: timePtr(base.Add(6 * time.Hour))},
+			},
+			expectedEntries: 1,
+			expectedTasks:   1,
+			errorAssertion: func(t *testing.T, err error) {
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.True(t, appErr.IsType(errors.ErrorTypeValidation))
+				assert.Contains(t, err.Error(), "entry 2 cannot be imported")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tasks := []*domain.Task{{TaskName: "Existing"}}
+			entries := []*domain.TimeEntry{{TaskID: 1, StartTime: base, EndTime: timePtr(base.Add(time.Hour))}}
+			service, repo := setupTaskServiceWithData(t, tasks, entries)
+			defer repo.Close()
+			ctx := context.Background()
+
+			// Act
+			result, err := service.ImportTimeEntries(ctx, tt.entries, tt.dryRun)
+
+			// Assert
+			if tt.errorAssertion != nil {
+				tt.errorAssertion(t, err)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedImported, result.Imported)
+				assert.Equal(t, tt.expectedDuplicates, result.Duplicates)
+				reasons := []string{}
+				for _, skipped := range result.Skipped {
+					reasons = append(reasons, skipped.Reason)
+				}
+				assert.Equal(t, append([]string{}, tt.expectedSkipped...), reasons)
+				assert.Equal(t, tt.expectedCreatedTasks, result.CreatedTasks)
+				assert.Equal(t, tt.dryRun, result.DryRun)
+			}
+
+			dbEntries, err := repo.ListTimeEntries(ctx)
+			require.NoError(t, err)
+			assert.Len(t, dbEntries, tt.expectedEntries)
+			dbTasks, err := repo.ListTasks(ctx)
+			require.NoError(t, err)
+			assert.Len(t, dbTasks, tt.expectedTasks)
+		})
+	}
+}
+
+func TestTaskService_ImportTimeEntriesWithProjectsAndTags(t *testing.T) {
+	ctx := context.Background()
+	base := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+	service, repo := setupTaskServiceWithData(t, []*domain.Task{{TaskName: "Existing"}}, nil)
+	defer repo.Close()
+	other := &sqlite.Project{Name: "internal"}
+	require.NoError(t, repo.CreateProject(ctx, other))
+	existing, err := repo.GetTask(ctx, 1)
+	require.NoError(t, err)
+	existing.ProjectID = &other.ID
+	require.NoError(t, repo.UpdateTask(ctx, existing))
+
+	result, err := service.ImportTimeEntries(ctx, []ImportEntry{
+		{TaskName: "Landing page", StartTime: base, EndTime: timePtr(base.Add(time.Hour)), Project: "acme/website", Tags: []string{"billable", "#design"}},
+		{TaskName: "Existing", StartTime: base.Add(2 * time.Hour), EndTime: timePtr(base.Add(3 * time.Hour)), Project: "acme/website"},
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "acme/website"}, result.CreatedProjects)
+
+	tasks, err := repo.ListTasks(ctx)
+	require.NoError(t, err)
+	projects := make(map[string]*int64)
+	for _, task := range tasks {
+		projects[task.TaskName] = task.ProjectID
+	}
+	require.NotNil(t, projects["Landing page"])
+	project, err := repo.GetProject(ctx, *projects["Landing page"])
+	require.NoError(t, err)
+	assert.Equal(t, "website", project.Name)
+	assert.Equal(t, other.ID, *projects["Existing"], "A task already in a project stays there")
+
+	tags, err := repo.ListTimeEntryTags(ctx, []int64{1})
+	require.NoError(t, err)
+	var names []string
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	assert.Equal(t, []string{"billable", "design"}, names)
+
+	_, err = service.ImportTimeEntries(ctx, []ImportEntry{
+		{TaskName: "Landing page", StartTime: base.Add(4 * time.Hour), EndTime: timePtr(base.Add(5 * time.Hour)), Tags: []string{"not a tag"}},
+	}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "entry 1 cannot be imported")
+}
+
 func TestTaskService_StartNewTask(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -344,6 +665,334 @@ func TestTaskService_StartNewTask(t *testing.T) {
 	}
 }
 
+func TestTaskService_StartNewTaskWithOptions(t *testing.T) {
+	ctx := context.Background()
+	repo, err := sqlite.New(":memory:")
+	require.NoError(t, err)
+	defer repo.Close()
+
+	projects := NewProjectService(repo)
+	website, err := projects.CreateProject(ctx, "acme/website")
+	require.NoError(t, err)
+	_, err = projects.CreateProject(ctx, "acme/mobile")
+	require.NoError(t, err)
+	_, err = projects.ArchiveProject(ctx, "acme/mobile")
+	require.NoError(t, err)
+
+	service := NewTaskService(repo, NewTimeService(repo))
+
+	t.Run("should file the task under the project", func(t *testing.T) {
+		session, err := service.StartNewTaskWithOptions(ctx, "Landing page", StartOptions{Project: "acme/website"})
+		require.NoError(t, err)
+		require.NotNil(t, session.Task.ProjectID)
+		assert.Equal(t, website.Project.ID, *session.Task.ProjectID)
+
+		stored, err := service.GetTask(ctx, session.Task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.ProjectID)
+		assert.Equal(t, website.Project.ID, *stored.ProjectID)
+	})
+
+	t.Run("should keep the project when started again without one", func(t *testing.T) {
+		session, err := service.StartNewTask(ctx, "Landing page")
+		require.NoError(t, err)
+		require.NotNil(t, session.Task.ProjectID)
+		assert.Equal(t, website.Project.ID, *session.Task.ProjectID)
+	})
+
+	t.Run("should reject unknown projects without stopping the running task", func(t *testing.T) {
+		_, err := service.StartNewTaskWithOptions(ctx, "Other", StartOptions{Project: "globex"})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeNotFound))
+
+		current, err := service.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, current)
+		assert.Equal(t, "Landing page", current.Task.TaskName)
+	})
+
+	t.Run("should tag the new time entry", func(t *testing.T) {
+		session, err := service.StartNewTaskWithOptions(ctx, "Standup", StartOptions{Tags: []string{"#Meeting", "+billable", "meeting"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"billable", "meeting"}, session.TimeEntry.Tags)
+
+		tags, err := repo.ListTimeEntryTags(ctx, []int64{session.TimeEntry.ID})
+		require.NoError(t, err)
+		assert.Len(t, tags, 2)
+	})
+
+	t.Run("should return the tags of the current and stopped entries", func(t *testing.T) {
+		current, err := service.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, current)
+		assert.Equal(t, []string{"billable", "meeting"}, current.TimeEntry.Tags)
+
+		stopped, err := service.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+		require.Len(t, stopped, 1)
+		assert.Equal(t, []string{"billable", "meeting"}, stopped[0].Tags)
+	})
+
+	t.Run("should attach a note to the new time entry", func(t *testing.T) {
+		session, err := service.StartNewTaskWithOptions(ctx, "Code review", StartOptions{Note: "  reviewing\nPR 412 "})
+		require.NoError(t, err)
+		assert.Equal(t, "reviewing PR 412", session.TimeEntry.Note)
+
+		stored, err := repo.GetTimeEntry(ctx, session.TimeEntry.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "reviewing PR 412", stored.Note)
+	})
+
+	t.Run("should reject notes that are too long", func(t *testing.T) {
+		_, err := service.StartNewTaskWithOptions(ctx, "Code review", StartOptions{Note: strings.Repeat("x", domain.MaxNoteLength+1)})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+	})
+
+	t.Run("should reject invalid tags", func(t *testing.T) {
+		_, err := service.StartNewTaskWithOptions(ctx, "Standup", StartOptions{Tags: []string{"#2026"}})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+	})
+
+	t.Run("should reject archived projects", func(t *testing.T) {
+		_, err := service.StartNewTaskWithOptions(ctx, "App store", StartOptions{Project: "acme/mobile"})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "archived")
+	})
+}
+
+func TestTaskService_AddTimeEntry(t *testing.T) {
+	base := time.Now().Add(-6 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		taskName       string
+		start          time.Time
+		end            time.Time
+		setupTasks     []*domain.Task
+		setupEntries   []*domain.TimeEntry
+		expectedTasks  int
+		errorAssertion func(t *testing.T, err error)
+	}{
+		{
+			name:          "should add entry for new task",
+			taskName:      "Forgotten Meeting",
+			start:         base,
+			end:           base.Add(90 * time.Minute),
+			expectedTasks: 1,
+		},
+		{
+			name:     "should add entry for existing task",
+			taskName: "Existing Task",
+			start:    base,
+			end:      base.Add(time.Hour),
+			setupTasks: []*domain.Task{
+				{TaskName: "Existing Task"},
+			},
+			expectedTasks: 1,
+		},
+		{
+			name:     "should add entry adjacent to existing entry",
+			taskName: "Adjacent",
+			start:    base.Add(time.Hour),
+			end:      base.Add(2 * time.Hour),
+			setupTasks: []*domain.Task{
+				{TaskName: "Existing Task"},
+			},
+			setupEntries: []*domain.TimeEntry{
+				{TaskID: 1, StartTime: base, EndTime: timePtr(base.Add(time.Hour))},
+			},
+			expectedTasks: 2,
+		},
+		{
+			name:     "should reject overlapping entry and not create task",
+			taskName: "Overlapping",
+			start:    base.Add(30 * time.Minute),
+			end:      base.Add(90 * time.Minute),
+			setupTasks: []*domain.Task{
+				{TaskName: "Existing Task"},
+			},
+			setupEntries: []*domain.TimeEntry{
+				{TaskID: 1, StartTime: base, EndTime: timePtr(base.Add(time.Hour))},
+			},
+			expectedTasks: 1,
+			errorAssertion: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+				assert.Contains(t, err.Error(), "overlaps")
+			},
+		},
+		{
+			name:     "should reject overlap with running entry",
+			taskName: "Overlapping",
+			start:    base.Add(time.Hour),
+			end:      base.Add(2 * time.Hour),
+			setupTasks: []*domain.Task{
+				{TaskName: "Existing Task"},
+			},
+			setupEntries: []*domain.TimeEntry{
+				{TaskID: 1, StartTime: base, EndTime: nil},
+			},
+			expectedTasks: 1,
+			errorAssertion: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "overlaps")
+			},
+		},
+		{
+			name:          "should reject end before start",
+			taskName:      "Backwards",
+			start:         base.Add(time.Hour),
+			end:           base,
+			expectedTasks: 0,
+			errorAssertion: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "time_range")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, repo := setupTaskServiceWithData(t, tt.setupTasks, tt.setupEntries)
+			defer repo.Close()
+			ctx := context.Background()
+
+			// Act
+			result, err := service.AddTimeEntry(ctx, tt.taskName, tt.start, tt.end)
+
+			// Assert
+			if tt.errorAssertion != nil {
+				tt.errorAssertion(t, err)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				require.NotNil(t, result.TimeEntry.EndTime)
+
+				assert.Equal(t, tt.taskName, result.Task.TaskName)
+				assert.True(t, tt.start.Equal(result.TimeEntry.StartTime))
+				assert.True(t, tt.end.Equal(*result.TimeEntry.EndTime))
+				assert.NotContains(t, result.Duration, "running")
+			}
+
+			tasks, err := repo.ListTasks(ctx)
+			require.NoError(t, err)
+			assert.Len(t, tasks, tt.expectedTasks)
+		})
+	}
+}
+
+func TestTaskService_EditTimeEntry(t *testing.T) {
+	base := time.Now().Add(-6 * time.Hour).Truncate(time.Second)
+	stringPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name             string
+		update           TimeEntryUpdate
+		expectedTaskName string
+		expectedStart    time.Time
+		expectedEnd      time.Time
+		expectedNote     string
+		errorAssertion   func(t *testing.T, err error)
+	}{
+		{
+			name:             "should move start time earlier",
+			update:           TimeEntryUpdate{StartTime: timePtr(base.Add(-30 * time.Minute))},
+			expectedTaskName: "First Task",
+			expectedStart:    base.Add(-30 * time.Minute),
+			expectedEnd:      base.Add(time.Hour),
+		},
+		{
+			name:             "should reassign to existing task",
+			update:           TimeEntryUpdate{TaskName: stringPtr("Second Task")},
+			expectedTaskName: "Second Task",
+			expectedStart:    base,
+			expectedEnd:      base.Add(time.Hour),
+		},
+		{
+			name:             "should reassign to new task",
+			update:           TimeEntryUpdate{TaskName: stringPtr("Brand New Task")},
+			expectedTaskName: "Brand New Task",
+			expectedStart:    base,
+			expectedEnd:      base.Add(time.Hour),
+		},
+		{
+			name:             "should set a note",
+			update:           TimeEntryUpdate{Note: stringPtr(" reviewing PR 412 ")},
+			expectedTaskName: "First Task",
+			expectedStart:    base,
+			expectedEnd:      base.Add(time.Hour),
+			expectedNote:     "reviewing PR 412",
+		},
+		{
+			name:   "should reject overlap with following entry",
+			update: TimeEntryUpdate{EndTime: timePtr(base.Add(150 * time.Minute))},
+			errorAssertion: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "overlaps")
+			},
+		},
+		{
+			name:   "should reject end before start",
+			update: TimeEntryUpdate{EndTime: timePtr(base.Add(-time.Hour))},
+			errorAssertion: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "time_range")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tasks := []*domain.Task{{TaskName: "First Task"}, {TaskName: "Second Task"}}
+			entries := []*domain.TimeEntry{
+				{TaskID: 1, StartTime: base, EndTime: timePtr(base.Add(time.Hour))},
+				{TaskID: 2, StartTime: base.Add(2 * time.Hour), EndTime: timePtr(base.Add(3 * time.Hour))},
+			}
+			service, repo := setupTaskServiceWithData(t, tasks, entries)
+			defer repo.Close()
+			ctx := context.Background()
+
+			// Act
+			result, err := service.EditTimeEntry(ctx, entries[0].ID, tt.update)
+
+			// Assert
+			if tt.errorAssertion != nil {
+				tt.errorAssertion(t, err)
+				assert.Nil(t, result)
+
+				// The entry must be unchanged and no tasks left behind
+				stored, getErr := repo.GetTimeEntry(ctx, entries[0].ID)
+				require.NoError(t, getErr)
+				assert.True(t, base.Equal(stored.StartTime))
+				dbTasks, listErr := repo.ListTasks(ctx)
+				require.NoError(t, listErr)
+				assert.Len(t, dbTasks, 2)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, "First Task", result.Before.Task.TaskName)
+			assert.True(t, base.Equal(result.Before.TimeEntry.StartTime))
+			assert.Equal(t, tt.expectedTaskName, result.After.Task.TaskName)
+			assert.True(t, tt.expectedStart.Equal(result.After.TimeEntry.StartTime))
+			assert.True(t, tt.expectedEnd.Equal(*result.After.TimeEntry.EndTime))
+
+			stored, err := repo.GetTimeEntry(ctx, entries[0].ID)
+			require.NoError(t, err)
+			assert.Equal(t, result.After.Task.ID, stored.TaskID)
+			assert.True(t, tt.expectedStart.Equal(stored.StartTime))
+			assert.Equal(t, tt.expectedNote, stored.Note)
+		})
+	}
+}
+
 func TestTaskService_ResumeTask(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -405,6 +1054,305 @@ func TestTaskService_ResumeTask(t *testing.T) {
 	}
 }
 
+func TestTaskService_Backdating(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("should stop the running task when the backdated one starts", func(t *testing.T) {
+		service, repo := setupTaskServiceWithData(t, nil, nil)
+		defer repo.Close()
+		first, err := service.StartNewTaskWithOptions(ctx, "Email", StartOptions{At: timePtr(now.Add(-1 * time.Hour))})
+		require.NoError(t, err)
+
+		second, err := service.StartNewTaskWithOptions(ctx, "Code review", StartOptions{At: timePtr(now.Add(-10 * time.Minute))})
+		require.NoError(t, err)
+		assert.WithinDuration(t, now.Add(-10*time.Minute), second.TimeEntry.StartTime, time.Second)
+
+		entry, err := NewTimeService(repo).GetTimeEntry(ctx, first.TimeEntry.ID)
+		require.NoError(t, err)
+		require.NotNil(t, entry.EndTime)
+		assert.WithinDuration(t, now.Add(-10*time.Minute), *entry.EndTime, time.Second)
+	})
+
+	t.Run("should reject a start before the running task started", func(t *testing.T) {
+		service := setupTaskService(t)
+		_, err := service.StartNewTaskWithOptions(ctx, "Email", StartOptions{At: timePtr(now.Add(-10 * time.Minute))})
+		require.NoError(t, err)
+
+		_, err = service.StartNewTaskWithOptions(ctx, "Code review", StartOptions{At: timePtr(now.Add(-1 * time.Hour))})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+
+		session, err := service.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Email", session.Task.TaskName, "The running task should be left alone")
+	})
+
+	t.Run("should reject a start in the future", func(t *testing.T) {
+		service := setupTaskService(t)
+		_, err := service.StartNewTaskWithOptions(ctx, "Email", StartOptions{At: timePtr(now.Add(-10 * time.Minute))})
+		require.NoError(t, err)
+
+		_, err = service.StartNewTaskWithOptions(ctx, "Code review", StartOptions{At: timePtr(now.Add(time.Hour))})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "cannot be in the future")
+
+		session, err := service.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Email", session.Task.TaskName, "The running task should be left alone")
+	})
+
+	t.Run("should resume and stop at the given times", func(t *testing.T) {
+		service := setupTaskService(t)
+		started, err := service.StartNewTaskWithOptions(ctx, "Email", StartOptions{At: timePtr(now.Add(-2 * time.Hour))})
+		require.NoError(t, err)
+		_, err = service.StopAllRunningTasksAt(ctx, now.Add(-90*time.Minute))
+		require.NoError(t, err)
+
+		resumed, err := service.ResumeTaskAt(ctx, started.Task.ID, now.Add(-30*time.Minute))
+		require.NoError(t, err)
+		assert.WithinDuration(t, now.Add(-30*time.Minute), resumed.TimeEntry.StartTime, time.Second)
+
+		_, err = service.StopAllRunningTasksAt(ctx, now.Add(-45*time.Minute))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "before it started")
+
+		stopped, err := service.StopAllRunningTasksAt(ctx, now.Add(-5*time.Minute))
+		require.NoError(t, err)
+		require.Len(t, stopped, 1)
+		assert.WithinDuration(t, now.Add(-5*time.Minute), *stopped[0].EndTime, time.Second)
+	})
+
+	t.Run("should reject a resume before the previous entry ended", func(t *testing.T) {
+		service := setupTaskService(t)
+		started, err := service.StartNewTaskWithOptions(ctx, "Email", StartOptions{At: timePtr(now.Add(-2 * time.Hour))})
+		require.NoError(t, err)
+		_, err = service.StopAllRunningTasksAt(ctx, now.Add(-1*time.Hour))
+		require.NoError(t, err)
+
+		_, err = service.ResumeTaskAt(ctx, started.Task.ID, now.Add(-90*time.Minute))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ended at")
+	})
+}
+
+func TestTaskService_ForgottenTimer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	startForgotten := func(t *testing.T) (TaskService, sqlite.Repository, *TaskSession) {
+		service, repo := setupTaskServiceWithData(t, nil, nil)
+		t.Cleanup(func() { repo.Close() })
+		session, err := service.StartNewTaskWithOptions(ctx, "Email", StartOptions{At: timePtr(now.Add(-16 * time.Hour))})
+		require.NoError(t, err)
+		return service, repo, session
+	}
+
+	t.Run("should find an entry running longer than the threshold", func(t *testing.T) {
+		service, _, session := startForgotten(t)
+
+		forgotten, err := service.FindForgottenTimer(ctx, 12*time.Hour)
+		require.NoError(t, err)
+		require.NotNil(t, forgotten)
+		assert.Equal(t, session.TimeEntry.ID, forgotten.TimeEntry.ID)
+		assert.Equal(t, "Email", forgotten.Task.TaskName)
+		assert.InDelta(t, (16 * time.Hour).Seconds(), float64(forgotten.RunningSeconds), 5)
+		assert.Nil(t, forgotten.LastActivity, "Starting the entry is no sign of work after it started")
+
+		forgotten, err = service.FindForgottenTimer(ctx, 20*time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, forgotten, "An entry within the threshold is not forgotten")
+	})
+
+	t.Run("should report the last activity journaled while the entry ran", func(t *testing.T) {
+		service, repo, _ := startForgotten(t)
+		for _, operation := range []*sqlite.Operation{
+			{Kind: OperationEdit, Description: "Edited before the start", CreatedAt: now.Add(-20 * time.Hour)},
+			{Kind: OperationEdit, Description: "Noted", CreatedAt: now.Add(-13 * time.Hour)},
+			{Kind: OperationArchive, Description: "Archived inactive tasks", CreatedAt: now.Add(-time.Hour)},
+		} {
+			require.NoError(t, repo.CreateOperation(ctx, operation, nil))
+		}
+
+		forgotten, err := service.FindForgottenTimer(ctx, 12*time.Hour)
+		require.NoError(t, err)
+		require.NotNil(t, forgotten.LastActivity)
+		assert.WithinDuration(t, now.Add(-13*time.Hour), *forgotten.LastActivity, time.Second, "Archiving is done by tt on its own")
+	})
+
+	t.Run("should find nothing when no task is running", func(t *testing.T) {
+		service := setupTaskService(t)
+		forgotten, err := service.FindForgottenTimer(ctx, time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, forgotten)
+	})
+
+	t.Run("should stop the entry at the given time and journal it", func(t *testing.T) {
+		service, repo, session := startForgotten(t)
+
+		result, err := service.ResolveForgottenTimer(ctx, session.TimeEntry.ID, ForgottenTimerResolution{Action: ForgottenTimerStop, At: timePtr(now.Add(-8 * time.Hour))})
+		require.NoError(t, err)
+		require.NotNil(t, result.TimeEntry.EndTime)
+		assert.WithinDuration(t, now.Add(-8*time.Hour), *result.TimeEntry.EndTime, time.Second)
+
+		current, err := service.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, current)
+
+		operations, err := NewJournalService(repo).ListOperations(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, operations, 1)
+		assert.Equal(t, OperationForgotten, operations[0].Kind)
+		assert.Equal(t, `Stopped forgotten "Email" after 8h 0m`, operations[0].Description)
+	})
+
+	t.Run("should cap the entry", func(t *testing.T) {
+		service, repo, session := startForgotten(t)
+
+		_, err := service.ResolveForgottenTimer(ctx, session.TimeEntry.ID, ForgottenTimerResolution{Action: ForgottenTimerCap, At: timePtr(session.TimeEntry.StartTime.Add(10 * time.Hour))})
+		require.NoError(t, err)
+
+		operations, err := NewJournalService(repo).ListOperations(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, `Capped forgotten "Email" at 10h 0m`, operations[0].Description)
+	})
+
+	t.Run("should keep the entry running until the threshold passes again", func(t *testing.T) {
+		service, repo, session := startForgotten(t)
+
+		result, err := service.ResolveForgottenTimer(ctx, session.TimeEntry.ID, ForgottenTimerResolution{Action: ForgottenTimerKeep})
+		require.NoError(t, err)
+		assert.Nil(t, result.TimeEntry.EndTime)
+		require.NotNil(t, result.TimeEntry.KeptRunningAt)
+
+		forgotten, err := service.FindForgottenTimer(ctx, 12*time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, forgotten, "A kept entry is not forgotten until the threshold passes again")
+
+		operations, err := NewJournalService(repo).ListOperations(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, `Kept forgotten "Email" running after 16h 0m`, operations[0].Description)
+
+		// Undoing the decision makes the entry forgotten again
+		_, err = NewJournalService(repo).UndoOperations(ctx, 1)
+		require.NoError(t, err)
+		forgotten, err = service.FindForgottenTimer(ctx, 12*time.Hour)
+		require.NoError(t, err)
+		assert.NotNil(t, forgotten)
+	})
+
+	t.Run("should reject invalid resolutions", func(t *testing.T) {
+		service, _, session := startForgotten(t)
+		id := session.TimeEntry.ID
+
+		_, err := service.ResolveForgottenTimer(ctx, id, ForgottenTimerResolution{Action: ForgottenTimerStop})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a stop time is required")
+
+		_, err = service.ResolveForgottenTimer(ctx, id, ForgottenTimerResolution{Action: ForgottenTimerStop, At: timePtr(now.Add(time.Hour))})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be in the future")
+
+		_, err = service.ResolveForgottenTimer(ctx, id, ForgottenTimerResolution{Action: ForgottenTimerStop, At: timePtr(now.Add(-20 * time.Hour))})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "before it started")
+
+		_, err = service.ResolveForgottenTimer(ctx, id, ForgottenTimerResolution{Action: "ignore"})
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+
+		_, err = service.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+		_, err = service.ResolveForgottenTimer(ctx, id, ForgottenTimerResolution{Action: ForgottenTimerKeep})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not running")
+	})
+}
+
+func TestTaskService_PauseAndContinue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should continue the paused task as a linked segment", func(t *testing.T) {
+		service, repo := setupTaskServiceWithData(t, nil, nil)
+		defer repo.Close()
+
+		started, err := service.StartNewTaskWithOptions(ctx, "Write report", StartOptions{Tags: []string{"billable"}})
+		require.NoError(t, err)
+
+		paused, err := service.PauseTask(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, started.TimeEntry.ID, paused.TimeEntry.ID)
+		assert.NotNil(t, paused.TimeEntry.EndTime)
+		assert.True(t, paused.TimeEntry.Paused)
+
+		current, err := service.GetCurrentSession(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, current)
+
+		continued, err := service.ContinueTask(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, started.Task.ID, continued.Task.ID)
+		assert.NotEqual(t, started.TimeEntry.ID, continued.TimeEntry.ID)
+		assert.Nil(t, continued.TimeEntry.EndTime)
+		require.NotNil(t, continued.TimeEntry.ContinuesID)
+		assert.Equal(t, started.TimeEntry.ID, *continued.TimeEntry.ContinuesID)
+		assert.Equal(t, []string{"billable"}, continued.TimeEntry.Tags)
+
+		// The pause has been used up
+		dbEntry, err := repo.GetTimeEntry(ctx, started.TimeEntry.ID)
+		require.NoError(t, err)
+		assert.False(t, dbEntry.Paused)
+		_, err = service.ContinueTask(ctx)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+	})
+
+	t.Run("should continue the paused task after switching to another one", func(t *testing.T) {
+		service, repo := setupTaskServiceWithData(t, nil, nil)
+		defer repo.Close()
+
+		started, err := service.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		_, err = service.PauseTask(ctx)
+		require.NoError(t, err)
+		_, err = service.StartNewTask(ctx, "Phone call")
+		require.NoError(t, err)
+
+		continued, err := service.ContinueTask(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, started.Task.ID, continued.Task.ID)
+
+		running, err := repo.SearchTimeEntries(ctx, sqlite.SearchOptions{})
+		require.NoError(t, err)
+		require.Len(t, running, 1)
+		assert.Equal(t, continued.TimeEntry.ID, running[0].ID)
+	})
+
+	t.Run("should reject pausing when nothing is running", func(t *testing.T) {
+		service, repo := setupTaskServiceWithData(t, nil, nil)
+		defer repo.Close()
+
+		_, err := service.PauseTask(ctx)
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "no task is running")
+	})
+
+	t.Run("should reject continuing when nothing is paused", func(t *testing.T) {
+		service, repo := setupTaskServiceWithData(t, nil, nil)
+		defer repo.Close()
+
+		_, err := service.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		_, err = service.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+
+		_, err = service.ContinueTask(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no task is paused")
+	})
+}
+
 func TestTaskService_GetCurrentSession(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -587,6 +1535,141 @@ func TestTaskService_StopAllRunningTasks(t *testing.T) {
 	}
 }
 
+func TestTaskService_ArchiveTask(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (TaskService, SearchService, JournalService, sqlite.Repository) {
+		service, repo := setupTaskServiceWithData(t, nil, nil)
+		t.Cleanup(func() { repo.Close() })
+		return service, NewSearchService(repo, NewTimeService(repo), service), NewJournalService(repo), repo
+	}
+
+	t.Run("should hide archived tasks from searches while their time still counts", func(t *testing.T) {
+		service, search, _, _ := setup(t)
+
+		old, err := service.AddTimeEntry(ctx, "Old experiment", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		_, err = service.AddTimeEntry(ctx, "Write report", start.Add(2*time.Hour), start.Add(4*time.Hour))
+		require.NoError(t, err)
+
+		archived, err := service.ArchiveTask(ctx, old.Task.ID)
+		require.NoError(t, err)
+		assert.True(t, archived.IsArchived())
+
+		tasks, err := search.SearchTasks(ctx, SearchCriteria{})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "Write report", tasks[0].Task.TaskName)
+
+		tasks, err = search.SearchTasks(ctx, SearchCriteria{IncludeArchived: true})
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+
+		entries, err := search.SearchTimeEntries(ctx, SearchCriteria{TimeRange: &TimeRange{Start: start.Add(-time.Hour), End: start.Add(5 * time.Hour)}})
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("should reject archiving running or archived tasks", func(t *testing.T) {
+		service, _, _, _ := setup(t)
+
+		running, err := service.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		_, err = service.ArchiveTask(ctx, running.Task.ID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is running")
+
+		_, err = service.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+		_, err = service.ArchiveTask(ctx, running.Task.ID)
+		require.NoError(t, err)
+		_, err = service.ArchiveTask(ctx, running.Task.ID)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+
+		unarchived, err := service.UnarchiveTask(ctx, running.Task.ID)
+		require.NoError(t, err)
+		assert.False(t, unarchived.IsArchived())
+		_, err = service.UnarchiveTask(ctx, running.Task.ID)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+	})
+
+	t.Run("should bring archived tasks back when they are started or resumed", func(t *testing.T) {
+		service, _, _, repo := setup(t)
+
+		added, err := service.AddTimeEntry(ctx, "Write report", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		_, err = service.ArchiveTask(ctx, added.Task.ID)
+		require.NoError(t, err)
+
+		started, err := service.StartNewTask(ctx, "Write report")
+		require.NoError(t, err)
+		assert.Equal(t, added.Task.ID, started.Task.ID)
+		assert.False(t, started.Task.IsArchived())
+
+		_, err = service.StopAllRunningTasks(ctx)
+		require.NoError(t, err)
+		_, err = service.ArchiveTask(ctx, added.Task.ID)
+		require.NoError(t, err)
+		_, err = service.ResumeTask(ctx, added.Task.ID)
+		require.NoError(t, err)
+
+		dbTask, err := repo.GetTask(ctx, added.Task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, dbTask.ArchivedAt)
+	})
+
+	t.Run("should undo archiving", func(t *testing.T) {
+		service, _, journal, repo := setup(t)
+
+		added, err := service.AddTimeEntry(ctx, "Write report", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		_, err = service.ArchiveTask(ctx, added.Task.ID)
+		require.NoError(t, err)
+
+		undone, err := journal.UndoOperations(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, `Archived task "Write report"`, undone[0].Description)
+
+		dbTask, err := repo.GetTask(ctx, added.Task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, dbTask.ArchivedAt)
+	})
+
+	t.Run("should archive tasks not worked on since the cutoff", func(t *testing.T) {
+		service, _, journal, repo := setup(t)
+
+		old, err := service.AddTimeEntry(ctx, "Old experiment", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		recent, err := service.AddTimeEntry(ctx, "Write report", start.Add(48*time.Hour), start.Add(49*time.Hour))
+		require.NoError(t, err)
+		_, err = service.AddTimeEntry(ctx, "Long running", start.Add(2*time.Hour), start.Add(3*time.Hour))
+		require.NoError(t, err)
+		_, err = service.StartNewTask(ctx, "Long running")
+		require.NoError(t, err)
+		_, err = service.CreateTask(ctx, "Never worked on")
+		require.NoError(t, err)
+
+		archived, err := service.ArchiveInactiveTasks(ctx, start.Add(24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+		assert.Equal(t, old.Task.ID, archived[0].ID)
+
+		dbTask, err := repo.GetTask(ctx, recent.Task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, dbTask.ArchivedAt)
+
+		// Already archived tasks are not archived again, and the housekeeping is not journaled
+		archived, err = service.ArchiveInactiveTasks(ctx, start.Add(24*time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, archived)
+
+		operations, err := journal.ListOperations(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, OperationCreate, operations[0].Kind)
+	})
+}
+
 // Helper functions
 func setupTaskService(t *testing.T) TaskService {
 	repo, err := sqlite.New(":memory:")
@@ -605,7 +1688,7 @@ func setupTaskServiceWithData(t *testing.T, tasks []*domain.Task, entries []*dom
 	
 	// Create tasks
 	for _, task := range tasks {
-		dbTask := &sqlite.Task{TaskName: task.TaskName}
+		dbTask := &sqlite.Task{TaskName: task.TaskName, ProjectID: task.ProjectID}
 		err := repo.CreateTask(ctx, dbTask)
 		require.NoError(t, err)
 		task.ID = dbTask.ID // Update with actual ID
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"time-tracker/internal/errors"
+)
+
+// dateLayout is the ISO date format accepted in absolute time ranges
+const dateLayout = "2006-01-02"
+
+// relativeRangePattern matches relative ranges such as "30m", "3d" or "2mo"
+var relativeRangePattern = regexp.MustCompile(`^(\d+)(mo|m|h|d|w|y)$`)
+
+// ParseTimeRangeAt converts a time range expression into a concrete time range relative to now.
+//
+// Supported expressions:
+//   - relative: any N followed by m, h, d, w, mo or y ("30m", "3d", "2mo"), ending now
+//   - named: today, yesterday, this-week, last-week, this-month, last-month, this-year, last-year
+//   - ISO date: "2026-10-01" covers that whole day
+//   - absolute: "2026-10-01..2026-10-15" covers both days inclusively; an open end ("2026-10-01..") runs until now
+//
+// Days, weeks, months and years follow calendar boundaries in now's location; weeks start on Monday.
+func ParseTimeRangeAt(expr string, now time.Time) (*TimeRange, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	if expr == "" {
+		return nil, errors.NewValidationError("time range cannot be empty", nil)
+	}
+
+	if matches := relativeRangePattern.FindStringSubmatch(expr); matches != nil {
+		return parseRelativeRange(expr, matches[1], matches[2], now)
+	}
+
+	if timeRange := parseNamedRange(expr, now); timeRange != nil {
+		return timeRange, nil
+	}
+
+	if strings.Contains(expr, "..") {
+		return parseAbsoluteRange(expr, now)
+	}
+
+	if day, err := time.ParseInLocation(dateLayout, expr, now.Location()); err == nil {
+		return &TimeRange{Start: day, End: day.AddDate(0, 0, 1)}, nil
+	}
+
+	return nil, newInvalidTimeRangeError(expr, "expected e.g. 30m, 3d, 2mo, today, last-month, 2026-10-01 or 2026-10-01..2026-10-15")
+}
+
+// parseRelativeRange resolves "N<unit>" to the period ending now
+func parseRelativeRange(expr, number, unit string, now time.Time) (*TimeRange, error) {
+	value, err := strconv.Atoi(number)
+	if err != nil || value <= 0 {
+		return nil, newInvalidTimeRangeError(expr, "amount must be a positive number")
+	}
+
+	var start time.Time
+	switch unit {
+	case "m":
+		start = now.Add(-time.Duration(value) * time.Minute)
+	case "h":
+		start = now.Add(-time.Duration(value) * time.Hour)
+	case "d":
+		start = now.AddDate(0, 0, -value)
+	case "w":
+		start = now.AddDate(0, 0, -7*value)
+	case "mo":
+		start = now.AddDate(0, -value, 0)
+	case "y":
+		start = now.AddDate(-value, 0, 0)
+	}
+
+	return &TimeRange{Start: start, End: now}, nil
+}
+
+// parseNamedRange resolves named calendar periods, returning nil for unknown names.
+// Current periods ("today", "this-week") end now; previous periods cover the whole period.
+func parseNamedRange(expr string, now time.Time) *TimeRange {
+	today := startOfDay(now)
+
+	switch expr {
+	case "today":
+		return &TimeRange{Start: today, End: now}
+	case "yesterday":
+		return &TimeRange{Start: today.AddDate(0, 0, -1), End: today}
+	case "this-week":
+		return &TimeRange{Start: startOfWeek(now), End: now}
+	case "last-week":
+		thisWeek := startOfWeek(now)
+		return &TimeRange{Start: thisWeek.AddDate(0, 0, -7), End: thisWeek}
+	case "this-month":
+		return &TimeRange{Start: startOfMonth(now), End: now}
+	case "last-month":
+		thisMonth := startOfMonth(now)
+		return &TimeRange{Start: thisMonth.AddDate(0, -1, 0), End: thisMonth}
+	case "this-year":
+		return &TimeRange{Start: startOfYear(now), End: now}
+	case "last-year":
+		thisYear := startOfYear(now)
+		return &TimeRange{Start: thisYear.AddDate(-1, 0, 0), End: thisYear}
+	default:
+		return nil
+	}
+}
+
+// parseAbsoluteRange resolves "from..to" where both sides are ISO dates and the end date is inclusive
+func parseAbsoluteRange(expr string, now time.Time) (*TimeRange, error) {
+	parts := strings.SplitN(expr, "..", 2)
+
+	start, err := time.ParseInLocation(dateLayout, parts[0], now.Location())
+	if err != nil {
+		return nil, newInvalidTimeRangeError(expr, "start must be a date in YYYY-MM-DD format")
+	}
+
+	end := now
+	if parts[1] != "" {
+		endDay, err := time.ParseInLocation(dateLayout, parts[1], now.Location())
+		if err != nil {
+			return nil, newInvalidTimeRangeError(expr, "end must be a date in YYYY-MM-DD format")
+		}
+		end = endDay.AddDate(0, 0, 1)
+	}
+
+	if !end.After(start) {
+		return nil, newInvalidTimeRangeError(expr, "end date must not be before start date")
+	}
+
+	return &TimeRange{Start: start, End: end}, nil
+}
+
+// newInvalidTimeRangeError builds the validation error returned for unparseable time ranges
+func newInvalidTimeRangeError(expr, reason string) error {
+	return errors.NewValidationError(fmt.Sprintf("invalid time range %q: %s", expr, reason), nil).
+		WithContext("time_range", expr)
+}
+
+// startOfDay returns midnight at the start of t's day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns midnight at the start of the Monday of t's week
+func startOfWeek(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -daysSinceMonday)
+}
+
+// startOfMonth returns midnight on the first day of t's month
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// startOfYear returns midnight on January 1st of t's year
+func startOfYear(t time.Time) time.Time {
+	return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"testing"
+	"time"
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeRangeAt(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	// Friday 16 October 2026, 14:30
+	now := time.Date(2026, 10, 16, 14, 30, 0, 0, loc)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name          string
+		expr          string
+		expectedStart time.Time
+		expectedEnd   time.Time
+		expectError   string
+	}{
+		// Relative ranges
+		{name: "minutes", expr: "30m", expectedStart: now.Add(-30 * time.Minute), expectedEnd: now},
+		{name: "arbitrary minutes", expr: "45m", expectedStart: now.Add(-45 * time.Minute), expectedEnd: now},
+		{name: "hours", expr: "5h", expectedStart: now.Add(-5 * time.Hour), expectedEnd: now},
+		{name: "days", expr: "3d", expectedStart: time.Date(2026, 10, 13, 14, 30, 0, 0, loc), expectedEnd: now},
+		{name: "weeks", expr: "2w", expectedStart: time.Date(2026, 10, 2, 14, 30, 0, 0, loc), expectedEnd: now},
+		{name: "months follow the calendar", expr: "1mo", expectedStart: time.Date(2026, 9, 16, 14, 30, 0, 0, loc), expectedEnd: now},
+		{name: "several months", expr: "8mo", expectedStart: time.Date(2026, 2, 16, 14, 30, 0, 0, loc), expectedEnd: now},
+		{name: "years", expr: "1y", expectedStart: time.Date(2025, 10, 16, 14, 30, 0, 0, loc), expectedEnd: now},
+		{name: "case and whitespace insensitive", expr: " 2H ", expectedStart: now.Add(-2 * time.Hour), expectedEnd: now},
+
+		// Named ranges
+		{name: "today", expr: "today", expectedStart: date(2026, 10, 16), expectedEnd: now},
+		{name: "yesterday", expr: "yesterday", expectedStart: date(2026, 10, 15), expectedEnd: date(2026, 10, 16)},
+		{name: "this week starts on monday", expr: "this-week", expectedStart: date(2026, 10, 12), expectedEnd: now},
+		{name: "last week", expr: "last-week", expectedStart: date(2026, 10, 5), expectedEnd: date(2026, 10, 12)},
+		{name: "this month", expr: "this-month", expectedStart: date(2026, 10, 1), expectedEnd: now},
+		{name: "last month covers 30 days in september", expr: "last-month", expectedStart: date(2026, 9, 1), expectedEnd: date(2026, 10, 1)},
+		{name: "this year", expr: "this-year", expectedStart: date(2026, 1, 1), expectedEnd: now},
+		{name: "last year", expr: "last-year", expectedStart: date(2025, 1, 1), expectedEnd: date(2026, 1, 1)},
+
+		// Absolute ranges
+		{name: "iso date covers the whole day", expr: "2026-10-01", expectedStart: date(2026, 10, 1), expectedEnd: date(2026, 10, 2)},
+		{name: "date range is inclusive", expr: "2026-10-01..2026-10-15", expectedStart: date(2026, 10, 1), expectedEnd: date(2026, 10, 16)},
+		{name: "single day range", expr: "2026-10-01..2026-10-01", expectedStart: date(2026, 10, 1), expectedEnd: date(2026, 10, 2)},
+		{name: "open ended range runs until now", expr: "2026-10-01..", expectedStart: date(2026, 10, 1), expectedEnd: now},
+
+		// Errors
+		{name: "empty", expr: "", expectError: "empty"},
+		{name: "unknown unit", expr: "5x", expectError: "invalid time range"},
+		{name: "no unit", expr: "5", expectError: "invalid time range"},
+		{name: "zero amount", expr: "0d", expectError: "positive"},
+		{name: "negative amount", expr: "-5h", expectError: "invalid time range"},
+		{name: "unknown name", expr: "next-week", expectError: "invalid time range"},
+		{name: "invalid date", expr: "2026-13-01", expectError: "invalid time range"},
+		{name: "invalid range start", expr: "yesterday..2026-10-15", expectError: "start must be a date"},
+		{name: "invalid range end", expr: "2026-10-01..today", expectError: "end must be a date"},
+		{name: "reversed range", expr: "2026-10-15..2026-10-01", expectError: "must not be before"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseTimeRangeAt(tt.expr, now)
+
+			if tt.expectError != "" {
+				require.Error(t, err)
+				var appErr *errors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.True(t, appErr.IsType(errors.ErrorTypeValidation))
+				assert.Contains(t, err.Error(), tt.expectError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.expectedStart.Equal(result.Start), "start: expected %s, got %s", tt.expectedStart, result.Start)
+			assert.True(t, tt.expectedEnd.Equal(result.End), "end: expected %s, got %s", tt.expectedEnd, result.End)
+		})
+	}
+}
+
+func TestParseTimeRangeAt_MonthBoundaries(t *testing.T) {
+	// On 31 March "last month" is the whole of February, not the 30 days before
+	now := time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	result, err := ParseTimeRangeAt("last-month", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), result.Start)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), result.End)
+
+	// On Sunday the week still started on the previous Monday
+	sunday := time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC)
+	result, err = ParseTimeRangeAt("this-week", sunday)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), result.Start)
+}
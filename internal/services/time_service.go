@@ -26,47 +26,10 @@ func NewTimeService(repo sqlite.Repository) TimeService {
 	}
 }
 
-// ParseTimeRange converts time shorthand ("30m", "2h", "1d") to actual time range
+// ParseTimeRange converts a time range expression ("30m", "3d", "today", "last-month",
+// "2026-10-01..2026-10-15") to an actual time range ending at or before now
 func (t *timeServiceImpl) ParseTimeRange(timeStr string) (*TimeRange, error) {
-	if timeStr == "" {
-		return nil, errors.NewValidationError("time range cannot be empty", nil)
-	}
-
-	duration, err := t.parseTimeShorthand(timeStr)
-	if err != nil {
-		return nil, err
-	}
-
-	now := time.Now()
-	start := now.Add(-duration)
-	
-	return &TimeRange{
-		Start: start,
-		End:   now,
-	}, nil
-}
-
-// parseTimeShorthand converts shorthand time strings to durations
-// TODO: Extract this logic from CLI to make it more comprehensive
-func (t *timeServiceImpl) parseTimeShorthand(timeStr string) (time.Duration, error) {
-	switch timeStr {
-	case "30m":
-		return 30 * time.Minute, nil
-	case "1h":
-		return 1 * time.Hour, nil
-	case "2h":
-		return 2 * time.Hour, nil
-	case "1d":
-		return 24 * time.Hour, nil
-	case "1w":
-		return 7 * 24 * time.Hour, nil
-	case "1mo":
-		return 30 * 24 * time.Hour, nil
-	case "1y":
-		return 365 * 24 * time.Hour, nil
-	default:
-		return 0, errors.NewValidationError("invalid time format", nil)
-	}
+	return ParseTimeRangeAt(timeStr, time.Now())
 }
 
 // ValidateTimeEntry validates time entry parameters
@@ -128,6 +91,16 @@ func (t *timeServiceImpl) GetRunningEntries(ctx context.Context) ([]*domain.Time
 
 // StopRunningEntries stops all currently running time entries
 func (t *timeServiceImpl) StopRunningEntries(ctx context.Context) ([]*domain.TimeEntry, error) {
+	return t.StopRunningEntriesAt(ctx, time.Now())
+}
+
+// StopRunningEntriesAt stops all currently running time entries at the given time, which may not be
+// in the future nor before any of them started
+func (t *timeServiceImpl) StopRunningEntriesAt(ctx context.Context, at time.Time) ([]*domain.TimeEntry, error) {
+	if at.After(time.Now()) {
+		return nil, errors.NewValidationError("stop time cannot be in the future", nil)
+	}
+
 	// Get all running entries
 	searchOpts := sqlite.SearchOptions{}
 	runningEntries, err := t.repo.SearchTimeEntries(ctx, searchOpts)
@@ -135,13 +108,22 @@ func (t *timeServiceImpl) StopRunningEntries(ctx context.Context) ([]*domain.Tim
 		return nil, err
 	}
 
+	// Check every entry before stopping any
+	for _, entry := range runningEntries {
+		if entry.EndTime == nil && at.Before(entry.StartTime) {
+			return nil, errors.NewValidationError(
+				fmt.Sprintf("cannot stop entry %d at %s, before it started at %s",
+					entry.ID, at.Local().Format("2006-01-02 15:04:05"), entry.StartTime.Local().Format("2006-01-02 15:04:05")), nil,
+			).WithContext("entry_id", entry.ID)
+		}
+	}
+
 	// Stop each running entry
-	now := time.Now()
 	stoppedEntries := make([]*domain.TimeEntry, 0, len(runningEntries))
 	
 	for _, entry := range runningEntries {
 		if entry.EndTime == nil { // Confirm it's running
-			entry.EndTime = &now
+			entry.EndTime = &at
 			err := t.repo.UpdateTimeEntry(ctx, entry)
 			if err != nil {
 				return nil, err
@@ -157,21 +139,95 @@ func (t *timeServiceImpl) StopRunningEntries(ctx context.Context) ([]*domain.Tim
 
 // CreateTimeEntry creates a new running time entry for a task
 func (t *timeServiceImpl) CreateTimeEntry(ctx context.Context, taskID int64) (*domain.TimeEntry, error) {
-	now := time.Now()
-	
+	return t.CreateTimeEntryAt(ctx, taskID, time.Now())
+}
+
+// CreateTimeEntryAt creates a new time entry for a task running since start, which may not be in the future
+// nor before the end of an earlier entry
+func (t *timeServiceImpl) CreateTimeEntryAt(ctx context.Context, taskID int64, start time.Time) (*domain.TimeEntry, error) {
+	if start.After(time.Now()) {
+		return nil, errors.NewValidationError("start time cannot be in the future", nil)
+	}
+
 	// Validate the time entry
-	if err := t.ValidateTimeEntry(taskID, now, nil); err != nil {
+	if err := t.ValidateTimeEntry(taskID, start, nil); err != nil {
 		return nil, err
 	}
 
+	// The new entry may not begin before an earlier entry ends
+	previousEntries, err := t.FindOverlappingEntries(ctx, start, &start, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, previous := range previousEntries {
+		end := time.Now()
+		if previous.EndTime != nil {
+			end = *previous.EndTime
+		}
+		return nil, errors.NewValidationError(
+			fmt.Sprintf("start time %s is before entry %d ended at %s",
+				start.Local().Format("2006-01-02 15:04:05"), previous.ID, end.Local().Format("2006-01-02 15:04:05")), nil,
+		).WithContext("entry_id", previous.ID)
+	}
+
 	// Create database time entry
 	dbEntry := &sqlite.TimeEntry{
 		TaskID:    taskID,
-		StartTime: now,
+		StartTime: start,
 		EndTime:   nil, // Running task
 	}
 	
-	err := t.repo.CreateTimeEntry(ctx, dbEntry)
+	err = t.repo.CreateTimeEntry(ctx, dbEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain model
+	domainEntry := t.mapper.TimeEntry.FromDatabase(*dbEntry)
+	return &domainEntry, nil
+}
+
+// CreateCompletedTimeEntry creates a finished time entry for a task, rejecting overlaps with existing entries
+func (t *timeServiceImpl) CreateCompletedTimeEntry(ctx context.Context, taskID int64, start time.Time, end time.Time) (*domain.TimeEntry, error) {
+	// Validate the time entry
+	if err := t.ValidateTimeEntry(taskID, start, &end); err != nil {
+		return nil, err
+	}
+
+	// Reject entries that overlap recorded time
+	overlapping, err := t.FindOverlappingEntries(ctx, start, &end, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		return nil, newOverlapError(overlapping[0])
+	}
+
+	// Create database time entry
+	dbEntry := &sqlite.TimeEntry{
+		TaskID:    taskID,
+		StartTime: start,
+		EndTime:   &end,
+	}
+
+	err = t.repo.CreateTimeEntry(ctx, dbEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to domain model
+	domainEntry := t.mapper.TimeEntry.FromDatabase(*dbEntry)
+	return &domainEntry, nil
+}
+
+// GetTimeEntry retrieves a time entry by its ID
+func (t *timeServiceImpl) GetTimeEntry(ctx context.Context, id int64) (*domain.TimeEntry, error) {
+	// Validate time entry ID
+	if err := t.timeEntryValidator.ValidateTimeEntryID(id); err != nil {
+		return nil, err
+	}
+
+	dbEntry, err := t.repo.GetTimeEntry(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +237,77 @@ func (t *timeServiceImpl) CreateTimeEntry(ctx context.Context, taskID int64) (*d
 	return &domainEntry, nil
 }
 
+// UpdateTimeEntry saves changes to an existing time entry, rejecting overlaps with other entries
+func (t *timeServiceImpl) UpdateTimeEntry(ctx context.Context, entry *domain.TimeEntry) (*domain.TimeEntry, error) {
+	// Validate the updated time entry
+	if err := t.timeEntryValidator.ValidateTimeEntryForUpdate(entry.ID, entry.TaskID, entry.StartTime, entry.EndTime); err != nil {
+		return nil, err
+	}
+
+	// Reject changes that overlap other recorded time
+	overlapping, err := t.FindOverlappingEntries(ctx, entry.StartTime, entry.EndTime, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		return nil, newOverlapError(overlapping[0])
+	}
+
+	dbEntry := t.mapper.TimeEntry.ToDatabase(*entry)
+	if err := t.repo.UpdateTimeEntry(ctx, &dbEntry); err != nil {
+		return nil, err
+	}
+
+	// Convert to domain model
+	domainEntry := t.mapper.TimeEntry.FromDatabase(dbEntry)
+	return &domainEntry, nil
+}
+
+// FindOverlappingEntries returns entries that overlap the given period, ignoring excludeID.
+// A nil end means the period is still running. Running entries are treated as ending now.
+func (t *timeServiceImpl) FindOverlappingEntries(ctx context.Context, start time.Time, end *time.Time, excludeID int64) ([]*domain.TimeEntry, error) {
+	now := time.Now()
+	periodEnd := now
+	if end != nil {
+		periodEnd = *end
+	}
+
+	// Only entries starting before the period ends can overlap it
+	searchOpts := sqlite.SearchOptions{
+		EndTime: &periodEnd,
+	}
+	dbEntries, err := t.repo.SearchTimeEntries(ctx, searchOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	overlapping := make([]*domain.TimeEntry, 0)
+	for _, dbEntry := range dbEntries {
+		if dbEntry.ID == excludeID {
+			continue
+		}
+
+		entryEnd := now
+		if dbEntry.EndTime != nil {
+			entryEnd = *dbEntry.EndTime
+		}
+
+		if dbEntry.StartTime.Before(periodEnd) && entryEnd.After(start) {
+			domainEntry := t.mapper.TimeEntry.FromDatabase(*dbEntry)
+			overlapping = append(overlapping, &domainEntry)
+		}
+	}
+
+	return overlapping, nil
+}
+
+// newOverlapError builds a validation error describing the entry that was overlapped
+func newOverlapError(entry *domain.TimeEntry) error {
+	return errors.NewValidationError(
+		fmt.Sprintf("time entry overlaps with existing entry %d", entry.ID), nil,
+	).WithContext("entry_id", entry.ID)
+}
+
 // IsToday checks if a given time is within today's date range
 func (t *timeServiceImpl) IsToday(timeValue time.Time) bool {
 	now := time.Now()
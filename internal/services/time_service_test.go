@@ -44,6 +44,18 @@ func TestTimeService_ParseTimeRange(t *testing.T) {
 			expectedStart: 7 * 24 * time.Hour,
 			expectedEnd:   0,
 		},
+		{
+			name:          "should parse arbitrary day counts",
+			timeStr:       "3d",
+			expectedStart: 3 * 24 * time.Hour,
+			expectedEnd:   0,
+		},
+		{
+			name:          "should parse arbitrary minute counts",
+			timeStr:       "45m",
+			expectedStart: 45 * time.Minute,
+			expectedEnd:   0,
+		},
 		{
 			name:    "should return validation error for empty string",
 			timeStr: "",
@@ -320,6 +332,156 @@ func TestTimeService_CreateTimeEntry(t *testing.T) {
 	}
 }
 
+func TestTimeService_StopRunningEntriesAt(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("should stop running entries at the given time", func(t *testing.T) {
+		service, repo := setupTimeServiceWithData(t, nil, []*domain.TimeEntry{
+			{TaskID: 1, StartTime: now.Add(-1 * time.Hour), EndTime: nil},
+		})
+		defer repo.Close()
+
+		stopped, err := service.StopRunningEntriesAt(ctx, now.Add(-10*time.Minute))
+		require.NoError(t, err)
+		require.Len(t, stopped, 1)
+		assert.WithinDuration(t, now.Add(-10*time.Minute), *stopped[0].EndTime, time.Second)
+	})
+
+	t.Run("should reject a stop before the entry started", func(t *testing.T) {
+		service, repo := setupTimeServiceWithData(t, nil, []*domain.TimeEntry{
+			{TaskID: 1, StartTime: now.Add(-1 * time.Hour), EndTime: nil},
+		})
+		defer repo.Close()
+
+		_, err := service.StopRunningEntriesAt(ctx, now.Add(-2*time.Hour))
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "before it started")
+
+		running, err := service.GetRunningEntries(ctx)
+		require.NoError(t, err)
+		assert.Len(t, running, 1, "Nothing should be stopped when the stop time is rejected")
+	})
+
+	t.Run("should reject a stop in the future", func(t *testing.T) {
+		service, repo := setupTimeServiceWithData(t, nil, nil)
+		defer repo.Close()
+
+		_, err := service.StopRunningEntriesAt(ctx, now.Add(time.Hour))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be in the future")
+	})
+}
+
+func TestTimeService_CreateTimeEntryAt(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	tasks := []*domain.Task{{ID: 1, TaskName: "Test Task"}}
+
+	t.Run("should start the entry at the given time", func(t *testing.T) {
+		service, repo := setupTimeServiceWithData(t, tasks, []*domain.TimeEntry{
+			{TaskID: 1, StartTime: now.Add(-2 * time.Hour), EndTime: timePtr(now.Add(-1 * time.Hour))},
+		})
+		defer repo.Close()
+
+		entry, err := service.CreateTimeEntryAt(ctx, 1, now.Add(-1*time.Hour))
+		require.NoError(t, err)
+		assert.WithinDuration(t, now.Add(-1*time.Hour), entry.StartTime, time.Second)
+		assert.Nil(t, entry.EndTime)
+	})
+
+	t.Run("should reject a start before the previous entry ended", func(t *testing.T) {
+		service, repo := setupTimeServiceWithData(t, tasks, []*domain.TimeEntry{
+			{TaskID: 1, StartTime: now.Add(-2 * time.Hour), EndTime: timePtr(now.Add(-1 * time.Hour))},
+		})
+		defer repo.Close()
+
+		_, err := service.CreateTimeEntryAt(ctx, 1, now.Add(-90*time.Minute))
+		require.Error(t, err)
+		assert.True(t, errors.IsErrorType(err, errors.ErrorTypeValidation))
+		assert.Contains(t, err.Error(), "before entry 1 ended")
+	})
+
+	t.Run("should reject a start in the future", func(t *testing.T) {
+		service, repo := setupTimeServiceWithData(t, tasks, nil)
+		defer repo.Close()
+
+		_, err := service.CreateTimeEntryAt(ctx, 1, now.Add(time.Hour))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be in the future")
+	})
+}
+
+func TestTimeService_FindOverlappingEntries(t *testing.T) {
+	base := time.Now().Add(-6 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name            string
+		start           time.Time
+		end             *time.Time
+		excludeIndex    int // index into setupEntries to exclude, -1 for none
+		expectedIndexes []int
+	}{
+		{
+			name:            "should find entry overlapping start",
+			start:           base.Add(30 * time.Minute),
+			end:             timePtr(base.Add(90 * time.Minute)),
+			excludeIndex:    -1,
+			expectedIndexes: []int{0},
+		},
+		{
+			name:            "should not treat touching entries as overlapping",
+			start:           base.Add(time.Hour),
+			end:             timePtr(base.Add(2 * time.Hour)),
+			excludeIndex:    -1,
+			expectedIndexes: []int{},
+		},
+		{
+			name:            "should treat running entries as ending now",
+			start:           base.Add(4 * time.Hour),
+			end:             timePtr(base.Add(5 * time.Hour)),
+			excludeIndex:    -1,
+			expectedIndexes: []int{1},
+		},
+		{
+			name:            "should ignore excluded entry",
+			start:           base.Add(30 * time.Minute),
+			end:             timePtr(base.Add(90 * time.Minute)),
+			excludeIndex:    0,
+			expectedIndexes: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			entries := []*domain.TimeEntry{
+				{TaskID: 1, StartTime: base, EndTime: timePtr(base.Add(time.Hour))},
+				{TaskID: 1, StartTime: base.Add(3 * time.Hour), EndTime: nil},
+			}
+			service, repo := setupTimeServiceWithData(t, []*domain.Task{{TaskName: "Task"}}, entries)
+			defer repo.Close()
+			ctx := context.Background()
+
+			var excludeID int64
+			if tt.excludeIndex >= 0 {
+				excludeID = entries[tt.excludeIndex].ID
+			}
+
+			// Act
+			result, err := service.FindOverlappingEntries(ctx, tt.start, tt.end, excludeID)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, result, len(tt.expectedIndexes))
+			for i, index := range tt.expectedIndexes {
+				assert.Equal(t, entries[index].ID, result[i].ID)
+			}
+		})
+	}
+}
+
 func TestTimeService_IsToday(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,337 @@
+// Package timeparse reads the points in time and days typed on the command line, such as "14:05",
+// "yesterday 3pm", "last friday 09:30", "10 minutes ago" or "2026-10-12T14:00".
+package timeparse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"time-tracker/internal/errors"
+)
+
+// Reasons given when a value cannot be parsed
+const (
+	timeExpectation = "expected a time such as 14:05, 3pm, yesterday 17:00, last friday 09:30, 10 minutes ago or 2026-10-12T14:00"
+	dateExpectation = "expected a date such as 2026-10-01, today, yesterday, monday or last friday"
+)
+
+// Layouts of absolute times, tried before any natural-language expression
+var absoluteLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// Layouts of dates given by month and day, with or without a year
+var monthDayLayouts = []struct {
+	layout  string
+	hasYear bool
+}{
+	{"2006-01-02", true},
+	{"Jan 2 2006", true},
+	{"January 2 2006", true},
+	{"2 Jan 2006", true},
+	{"2 January 2006", true},
+	{"Jan 2", false},
+	{"January 2", false},
+	{"2 Jan", false},
+	{"2 January", false},
+}
+
+var (
+	clockPattern  = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+	offsetPattern = regexp.MustCompile(`^(\d+|an?)\s*(seconds?|secs?|s|minutes?|mins?|m|hours?|hrs?|h|days?|d|weeks?|wks?|w)\s*`)
+)
+
+// weekdays maps the full and abbreviated English day names to their weekday
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// Parser resolves time expressions relative to a reference time, reading wall-clock values in a timezone.
+// Expressions naming a weekday or a date without a year refer to the most recent such day, since times
+// given to a time tracker are almost always in the past; "next friday" and "tomorrow" look ahead.
+type Parser struct {
+	now      time.Time
+	location *time.Location
+}
+
+// NewParser creates a parser resolving relative expressions against now, with wall-clock values in location.
+// A nil location uses the system's local timezone.
+func NewParser(now time.Time, location *time.Location) *Parser {
+	if location == nil {
+		location = time.Local
+	}
+	return &Parser{now: now.In(location), location: location}
+}
+
+// Parse reads a point in time. A time of day without a day refers to today.
+func (p *Parser) Parse(value string) (time.Time, error) {
+	return p.ParseOn(value, p.now)
+}
+
+// ParseOn reads a point in time, with a time of day without a day referring to the date of day.
+// Relative expressions such as "10 minutes ago" or "yesterday 17:00" still count from now.
+func (p *Parser) ParseOn(value string, day time.Time) (time.Time, error) {
+	trimmed := strings.TrimSpace(value)
+	if t, ok := p.parseAbsolute(trimmed); ok {
+		return t, nil
+	}
+
+	text := normalize(trimmed)
+	if text == "now" {
+		return p.now, nil
+	}
+	if t, ok := p.parseOffset(text); ok {
+		return t, nil
+	}
+	if t, ok := p.parseDayAndClock(strings.Fields(text), p.startOfDay(day.In(p.location))); ok {
+		return t, nil
+	}
+	return time.Time{}, errors.NewInvalidInputError("time", value, timeExpectation)
+}
+
+// ParseDate reads a day, returning its midnight. Besides dates and day names it accepts offsets
+// such as "3 days ago", which give the day they fall on.
+func (p *Parser) ParseDate(value string) (time.Time, error) {
+	text := normalize(value)
+	if day, ok := p.parseDay(text); ok {
+		return day, nil
+	}
+	if t, ok := p.parseOffset(text); ok {
+		return p.startOfDay(t), nil
+	}
+	return time.Time{}, errors.NewInvalidInputError("date", value, dateExpectation)
+}
+
+// parseAbsolute reads RFC 3339 timestamps, which carry their own offset, and ISO dates with a time of day
+func (p *Parser) parseAbsolute(value string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	for _, layout := range absoluteLayouts {
+		if t, err := time.ParseInLocation(layout, value, p.location); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseOffset reads "10 minutes ago", "1h30m ago", "an hour ago" or "in 5 minutes" as an offset from now
+func (p *Parser) parseOffset(text string) (time.Time, bool) {
+	sign := -1
+	switch {
+	case strings.HasSuffix(text, " ago"):
+		text = strings.TrimSuffix(text, " ago")
+	case strings.HasPrefix(text, "in "):
+		text = strings.TrimPrefix(text, "in ")
+		sign = 1
+	default:
+		return time.Time{}, false
+	}
+
+	var days int
+	var duration time.Duration
+	rest := strings.TrimSpace(text)
+	if rest == "" {
+		return time.Time{}, false
+	}
+	for rest != "" {
+		match := offsetPattern.FindStringSubmatch(rest)
+		if match == nil {
+			return time.Time{}, false
+		}
+		rest = strings.TrimPrefix(rest[len(match[0]):], "and ")
+
+		amount := 1
+		if match[1] != "a" && match[1] != "an" {
+			n, err := strconv.Atoi(match[1])
+			if err != nil {
+				return time.Time{}, false
+			}
+			amount = n
+		}
+
+		switch unit := match[2]; {
+		case strings.HasPrefix(unit, "s"):
+			duration += time.Duration(amount) * time.Second
+		case strings.HasPrefix(unit, "m"):
+			duration += time.Duration(amount) * time.Minute
+		case strings.HasPrefix(unit, "h"):
+			duration += time.Duration(amount) * time.Hour
+		case strings.HasPrefix(unit, "d"):
+			days += amount
+		case strings.HasPrefix(unit, "w"):
+			days += 7 * amount
+		}
+	}
+
+	// Whole days follow the calendar, so "1 day ago" keeps the time of day across daylight saving changes
+	return p.now.AddDate(0, 0, sign*days).Add(time.Duration(sign) * duration), true
+}
+
+// parseDayAndClock reads a day followed or preceded by a time of day, "yesterday 3pm", "last friday at 09:30"
+// or "3pm yesterday", as well as a day alone, meaning its midnight, or a time of day alone, on defaultDay
+func (p *Parser) parseDayAndClock(words []string, defaultDay time.Time) (time.Time, bool) {
+	if len(words) == 0 {
+		return time.Time{}, false
+	}
+
+	for split := 0; split <= len(words); split++ {
+		// The day first, then the time of day
+		if t, ok := p.combine(words[:split], words[split:], defaultDay); ok {
+			return t, true
+		}
+		// The time of day first, then the day
+		if split > 0 && split < len(words) {
+			if t, ok := p.combine(words[split:], words[:split], defaultDay); ok {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// combine puts a day and a time of day together, either of which may be missing but not both
+func (p *Parser) combine(dayWords, clockWords []string, defaultDay time.Time) (time.Time, bool) {
+	if len(clockWords) > 0 && clockWords[0] == "at" {
+		clockWords = clockWords[1:]
+	}
+	if len(dayWords) == 0 && len(clockWords) == 0 {
+		return time.Time{}, false
+	}
+
+	day := defaultDay
+	if len(dayWords) > 0 {
+		parsed, ok := p.parseDay(strings.Join(dayWords, " "))
+		if !ok {
+			return time.Time{}, false
+		}
+		day = parsed
+	}
+
+	var hour, minute, second int
+	if len(clockWords) > 0 {
+		var ok bool
+		hour, minute, second, ok = parseClock(strings.Join(clockWords, ""))
+		if !ok {
+			return time.Time{}, false
+		}
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, p.location), true
+}
+
+// parseDay reads today, yesterday, tomorrow, a weekday optionally preceded by last or next, or a date,
+// returning the day's midnight
+func (p *Parser) parseDay(text string) (time.Time, bool) {
+	today := p.startOfDay(p.now)
+	switch text {
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	}
+
+	words := strings.Fields(text)
+	if len(words) == 1 || len(words) == 2 {
+		if weekday, ok := weekdays[words[len(words)-1]]; ok {
+			daysBack := (int(today.Weekday()) - int(weekday) + 7) % 7
+			switch {
+			case len(words) == 1:
+				return today.AddDate(0, 0, -daysBack), true
+			case words[0] == "last":
+				if daysBack == 0 {
+					daysBack = 7
+				}
+				return today.AddDate(0, 0, -daysBack), true
+			case words[0] == "next":
+				daysAhead := (int(weekday) - int(today.Weekday()) + 7) % 7
+				if daysAhead == 0 {
+					daysAhead = 7
+				}
+				return today.AddDate(0, 0, daysAhead), true
+			}
+		}
+	}
+
+	for _, candidate := range monthDayLayouts {
+		t, err := time.ParseInLocation(candidate.layout, text, p.location)
+		if err != nil {
+			continue
+		}
+		if candidate.hasYear {
+			return t, true
+		}
+		// Without a year, the most recent such date
+		day := time.Date(today.Year(), t.Month(), t.Day(), 0, 0, 0, 0, p.location)
+		if day.After(today) {
+			day = day.AddDate(-1, 0, 0)
+		}
+		return day, true
+	}
+	return time.Time{}, false
+}
+
+// parseClock reads a time of day, "14:05", "14:05:30", "3pm", "3:30pm", "noon" or "midnight".
+// Hours without minutes need am or pm, so a lone number is never taken for a time.
+func parseClock(text string) (hour, minute, second int, ok bool) {
+	switch text {
+	case "noon", "midday":
+		return 12, 0, 0, true
+	case "midnight":
+		return 0, 0, 0, true
+	}
+
+	match := clockPattern.FindStringSubmatch(strings.ReplaceAll(text, ".", ""))
+	if match == nil || (match[2] == "" && match[4] == "") {
+		return 0, 0, 0, false
+	}
+	hour, _ = strconv.Atoi(match[1])
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	if match[3] != "" {
+		second, _ = strconv.Atoi(match[3])
+	}
+	if minute > 59 || second > 59 {
+		return 0, 0, 0, false
+	}
+
+	switch match[4] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, false
+		}
+		hour %= 12
+		if match[4] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0, 0, false
+		}
+	}
+	return hour, minute, second, true
+}
+
+// startOfDay returns midnight of t's date in the parser's timezone
+func (p *Parser) startOfDay(t time.Time) time.Time {
+	t = t.In(p.location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, p.location)
+}
+
+// normalize lowercases text and collapses runs of whitespace, so expressions compare word by word
+func normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
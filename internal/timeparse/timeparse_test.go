@@ -0,0 +1,207 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+	"time-tracker/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Parse(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	// Friday 16 October 2026, 14:30
+	now := time.Date(2026, 10, 16, 14, 30, 0, 0, loc)
+	at := func(month time.Month, day, hour, minute, second int) time.Time {
+		return time.Date(2026, month, day, hour, minute, second, 0, loc)
+	}
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Time
+		expectError bool
+	}{
+		// Times of day, today
+		{name: "hours and minutes", value: "14:05", expected: at(10, 16, 14, 5, 0)},
+		{name: "with seconds", value: "14:05:30", expected: at(10, 16, 14, 5, 30)},
+		{name: "single-digit hour", value: "9:15", expected: at(10, 16, 9, 15, 0)},
+		{name: "midnight as 00:00", value: "00:00", expected: at(10, 16, 0, 0, 0)},
+		{name: "pm", value: "3pm", expected: at(10, 16, 15, 0, 0)},
+		{name: "am", value: "9am", expected: at(10, 16, 9, 0, 0)},
+		{name: "pm with minutes", value: "3:45pm", expected: at(10, 16, 15, 45, 0)},
+		{name: "spaced and dotted pm", value: "3 p.m.", expected: at(10, 16, 15, 0, 0)},
+		{name: "upper case", value: "3PM", expected: at(10, 16, 15, 0, 0)},
+		{name: "12am is midnight", value: "12am", expected: at(10, 16, 0, 0, 0)},
+		{name: "12pm is noon", value: "12pm", expected: at(10, 16, 12, 0, 0)},
+		{name: "noon", value: "noon", expected: at(10, 16, 12, 0, 0)},
+		{name: "midnight", value: "midnight", expected: at(10, 16, 0, 0, 0)},
+		{name: "at prefix", value: "at 9:30", expected: at(10, 16, 9, 30, 0)},
+		{name: "now", value: "now", expected: now},
+		{name: "surrounding whitespace", value: "  14:05  ", expected: at(10, 16, 14, 5, 0)},
+
+		// Days with a time of day
+		{name: "today with time", value: "today 08:00", expected: at(10, 16, 8, 0, 0)},
+		{name: "yesterday with time", value: "yesterday 17:00", expected: at(10, 15, 17, 0, 0)},
+		{name: "yesterday with pm", value: "yesterday 3pm", expected: at(10, 15, 15, 0, 0)},
+		{name: "yesterday at", value: "Yesterday at 3:30 pm", expected: at(10, 15, 15, 30, 0)},
+		{name: "time before day", value: "3pm yesterday", expected: at(10, 15, 15, 0, 0)},
+		{name: "tomorrow", value: "tomorrow 9am", expected: at(10, 17, 9, 0, 0)},
+		{name: "last friday on a friday is a week ago", value: "last friday 09:30", expected: at(10, 9, 9, 30, 0)},
+		{name: "last monday", value: "last monday 10:00", expected: at(10, 12, 10, 0, 0)},
+		{name: "bare weekday is the most recent", value: "monday 10:00", expected: at(10, 12, 10, 0, 0)},
+		{name: "bare weekday today", value: "friday 8am", expected: at(10, 16, 8, 0, 0)},
+		{name: "abbreviated weekday", value: "wed 11:00", expected: at(10, 14, 11, 0, 0)},
+		{name: "saturday is last week's", value: "saturday noon", expected: at(10, 10, 12, 0, 0)},
+		{name: "next weekday", value: "next monday 9am", expected: at(10, 19, 9, 0, 0)},
+		{name: "next friday on a friday", value: "next friday 9am", expected: at(10, 23, 9, 0, 0)},
+		{name: "day alone is midnight", value: "yesterday", expected: at(10, 15, 0, 0, 0)},
+		{name: "weekday alone is midnight", value: "last friday", expected: at(10, 9, 0, 0, 0)},
+
+		// Dates
+		{name: "ISO date and time", value: "2026-10-12 14:00", expected: at(10, 12, 14, 0, 0)},
+		{name: "ISO date and time with seconds", value: "2026-10-12 14:00:15", expected: at(10, 12, 14, 0, 15)},
+		{name: "ISO date with T", value: "2026-10-12T14:00", expected: at(10, 12, 14, 0, 0)},
+		{name: "ISO date with T and seconds", value: "2026-10-12T14:00:15", expected: at(10, 12, 14, 0, 15)},
+		{name: "ISO date with pm", value: "2026-10-12 2pm", expected: at(10, 12, 14, 0, 0)},
+		{name: "ISO date alone", value: "2026-10-12", expected: at(10, 12, 0, 0, 0)},
+		{name: "month and day", value: "Oct 12 14:00", expected: at(10, 12, 14, 0, 0)},
+		{name: "day and month", value: "12 october 9am", expected: at(10, 12, 9, 0, 0)},
+		{name: "month, day and year", value: "Oct 12 2025 14:00", expected: time.Date(2025, 10, 12, 14, 0, 0, 0, loc)},
+		{name: "date without year is the most recent", value: "Dec 24 18:00", expected: time.Date(2025, 12, 24, 18, 0, 0, 0, loc)},
+		{name: "RFC3339 keeps its offset", value: "2026-10-12T14:00:00Z", expected: time.Date(2026, 10, 12, 14, 0, 0, 0, time.UTC)},
+
+		// Offsets from now
+		{name: "minutes ago", value: "10 minutes ago", expected: now.Add(-10 * time.Minute)},
+		{name: "minute abbreviation", value: "10 min ago", expected: now.Add(-10 * time.Minute)},
+		{name: "compact duration", value: "10m ago", expected: now.Add(-10 * time.Minute)},
+		{name: "combined compact duration", value: "1h30m ago", expected: now.Add(-90 * time.Minute)},
+		{name: "combined words", value: "1 hour and 15 minutes ago", expected: now.Add(-75 * time.Minute)},
+		{name: "an hour ago", value: "an hour ago", expected: now.Add(-time.Hour)},
+		{name: "seconds ago", value: "30 seconds ago", expected: now.Add(-30 * time.Second)},
+		{name: "days ago keep the time of day", value: "2 days ago", expected: at(10, 14, 14, 30, 0)},
+		{name: "a week ago", value: "a week ago", expected: at(10, 9, 14, 30, 0)},
+		{name: "in the future", value: "in 5 minutes", expected: now.Add(5 * time.Minute)},
+
+		// Invalid values
+		{name: "empty", value: "", expectError: true},
+		{name: "lone number", value: "14", expectError: true},
+		{name: "hour out of range", value: "25:00", expectError: true},
+		{name: "minute out of range", value: "14:60", expectError: true},
+		{name: "pm hour out of range", value: "13pm", expectError: true},
+		{name: "unknown word", value: "quarter past nine", expectError: true},
+		{name: "months are ambiguous", value: "1 month ago", expectError: true},
+		{name: "ago without amount", value: "ago", expectError: true},
+		{name: "unknown day", value: "someday 9am", expectError: true},
+		{name: "two times", value: "9am 10am", expectError: true},
+		{name: "slashed date", value: "12/10/2026", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewParser(now, loc).Parse(tt.value)
+
+			if tt.expectError {
+				require.Error(t, err)
+				assert.True(t, errors.IsErrorType(err, errors.ErrorTypeInvalidInput))
+				assert.Contains(t, err.Error(), "expected a time such as")
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result), "Parse(%q) = %v, want %v", tt.value, result, tt.expected)
+		})
+	}
+}
+
+func TestParser_ParseOn(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	now := time.Date(2026, 10, 16, 14, 30, 0, 0, loc)
+	day := time.Date(2026, 10, 12, 9, 0, 0, 0, loc)
+	parser := NewParser(now, loc)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+	}{
+		{name: "time of day falls on the given day", value: "17:45", expected: time.Date(2026, 10, 12, 17, 45, 0, 0, loc)},
+		{name: "named days count from now", value: "yesterday 17:45", expected: time.Date(2026, 10, 15, 17, 45, 0, 0, loc)},
+		{name: "offsets count from now", value: "10 minutes ago", expected: now.Add(-10 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.ParseOn(tt.value, day)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result), "ParseOn(%q) = %v, want %v", tt.value, result, tt.expected)
+		})
+	}
+}
+
+func TestParser_ParseDate(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	now := time.Date(2026, 10, 16, 14, 30, 0, 0, loc)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Time
+		expectError bool
+	}{
+		{name: "today", value: "today", expected: date(2026, 10, 16)},
+		{name: "yesterday", value: "Yesterday", expected: date(2026, 10, 15)},
+		{name: "ISO date", value: "2026-10-01", expected: date(2026, 10, 1)},
+		{name: "weekday", value: "tuesday", expected: date(2026, 10, 13)},
+		{name: "last weekday", value: "last friday", expected: date(2026, 10, 9)},
+		{name: "next weekday", value: "next wednesday", expected: date(2026, 10, 21)},
+		{name: "month and day", value: "Sep 3", expected: date(2026, 9, 3)},
+		{name: "days ago", value: "3 days ago", expected: date(2026, 10, 13)},
+		{name: "weeks ago", value: "2 weeks ago", expected: date(2026, 10, 2)},
+		{name: "time of day is not a date", value: "14:05", expectError: true},
+		{name: "slashed date", value: "14/10/2026", expectError: true},
+		{name: "invalid day of month", value: "2026-02-30", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewParser(now, loc).ParseDate(tt.value)
+
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "expected a date such as")
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result), "ParseDate(%q) = %v, want %v", tt.value, result, tt.expected)
+		})
+	}
+}
+
+func TestParser_Location(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("reads wall-clock times in the parser's timezone", func(t *testing.T) {
+		tokyo := time.FixedZone("JST", 9*60*60)
+		result, err := NewParser(now, tokyo).Parse("09:00")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), result.UTC())
+	})
+
+	t.Run("takes today from the parser's timezone", func(t *testing.T) {
+		// Noon UTC is already the next day in Kiribati
+		kiribati := time.FixedZone("LINT", 14*60*60)
+		result, err := NewParser(now, kiribati).ParseDate("today")
+		require.NoError(t, err)
+		assert.Equal(t, 17, result.Day())
+	})
+
+	t.Run("defaults to the local timezone", func(t *testing.T) {
+		result, err := NewParser(now, nil).Parse("2026-10-12 14:00")
+		require.NoError(t, err)
+		assert.Equal(t, time.Local, result.Location())
+	})
+}